@@ -39,6 +39,14 @@ const (
 	Ignition Format = "ignition"
 )
 
+const (
+	// KubeadmInitForEtcdRestoreAnnotation is set by KubeadmControlPlane on the KubeadmConfig of the Machine which is
+	// used to restore etcd from a snapshot; when set, bootstrap data are generated using kubeadm init even
+	// if the control plane of the Cluster has already been initialized.
+	// The value of the annotation is the name of the EtcdSnapshot being restored.
+	KubeadmInitForEtcdRestoreAnnotation = "bootstrap.cluster.x-k8s.io/kubeadm-init-for-etcd-restore"
+)
+
 var (
 	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
 	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
@@ -78,6 +78,10 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dst.Spec.KubeadmConfigSpec)
+		dst.Spec.EtcdMaintenance = restored.Spec.EtcdMaintenance
+		dst.Spec.CertificateAuthorityRotation = restored.Spec.CertificateAuthorityRotation
+		dst.Spec.Remediation.TemplateRef = restored.Spec.Remediation.TemplateRef
+		dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
 	}
 
 	if src.Spec.RemediationStrategy != nil {
@@ -134,6 +138,9 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.Template.Spec.KubeadmConfigSpec, &dst.Spec.Template.Spec.KubeadmConfigSpec)
+		dst.Spec.Template.Spec.EtcdMaintenance = restored.Spec.Template.Spec.EtcdMaintenance
+		dst.Spec.Template.Spec.CertificateAuthorityRotation = restored.Spec.Template.Spec.CertificateAuthorityRotation
+		dst.Spec.Template.Spec.Remediation.TemplateRef = restored.Spec.Template.Spec.Remediation.TemplateRef
 	}
 
 	if src.Spec.Template.Spec.RemediationStrategy != nil {
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// EtcdBackupPolicyNameLabel is the label set on EtcdSnapshots created by an EtcdBackupPolicy.
+	EtcdBackupPolicyNameLabel = "controlplane.cluster.x-k8s.io/etcd-backup-policy-name"
+
+	// DefaultEtcdBackupPolicyMaxSnapshots is the default number of EtcdSnapshots retained by an EtcdBackupPolicy.
+	DefaultEtcdBackupPolicyMaxSnapshots = int32(5)
+)
+
+// EtcdBackupPolicy's LastSnapshotCompleted condition and corresponding reasons.
+const (
+	// EtcdBackupPolicyLastSnapshotCompletedCondition mirrors the Completed condition of the most recent EtcdSnapshot
+	// created by the EtcdBackupPolicy.
+	EtcdBackupPolicyLastSnapshotCompletedCondition = "LastSnapshotCompleted"
+
+	// EtcdBackupPolicyLastSnapshotCompletedReason surfaces when the most recent EtcdSnapshot is completed.
+	EtcdBackupPolicyLastSnapshotCompletedReason = "Completed"
+
+	// EtcdBackupPolicyLastSnapshotNotCompletedReason surfaces when the most recent EtcdSnapshot is not completed yet,
+	// or taking the snapshot failed.
+	EtcdBackupPolicyLastSnapshotNotCompletedReason = "NotCompleted"
+
+	// EtcdBackupPolicyNoSnapshotsReason surfaces when no EtcdSnapshot has been created yet.
+	EtcdBackupPolicyNoSnapshotsReason = "NoSnapshots"
+
+	// EtcdBackupPolicyLastSnapshotInternalErrorReason surfaces unexpected failures when reconciling EtcdSnapshots.
+	EtcdBackupPolicyLastSnapshotInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// EtcdBackupPolicySpec defines the desired state of EtcdBackupPolicy.
+type EtcdBackupPolicySpec struct {
+	// clusterName is the name of the Cluster this object belongs to.
+	// The control plane of the Cluster must be managed by a KubeadmControlPlane with local etcd.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// intervalSeconds is the interval between two consecutive etcd snapshots.
+	// +required
+	// +kubebuilder:validation:Minimum=60
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// maxSnapshots is the maximum number of completed EtcdSnapshots retained; when this number is exceeded,
+	// the oldest EtcdSnapshots are deleted.
+	// EtcdSnapshots which are not completed are deleted as soon as a newer EtcdSnapshot exists.
+	// Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSnapshots *int32 `json:"maxSnapshots,omitempty"`
+
+	// sink defines where etcd snapshots are stored.
+	// +required
+	Sink EtcdSnapshotSink `json:"sink,omitempty,omitzero"`
+}
+
+// EtcdBackupPolicyStatus defines the observed state of EtcdBackupPolicy.
+// +kubebuilder:validation:MinProperties=1
+type EtcdBackupPolicyStatus struct {
+	// conditions represents the observations of an EtcdBackupPolicy's current state.
+	// Known condition types are LastSnapshotCompleted, Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastSnapshotName is the name of the most recent EtcdSnapshot created by the EtcdBackupPolicy.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	LastSnapshotName string `json:"lastSnapshotName,omitempty"`
+
+	// lastSnapshotTime is the time the most recent EtcdSnapshot has been created.
+	// +optional
+	LastSnapshotTime metav1.Time `json:"lastSnapshotTime,omitempty,omitzero"`
+
+	// observedGeneration is the latest generation observed by the controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=etcdbackuppolicies,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="Interval",type="integer",JSONPath=".spec.intervalSeconds",description="Interval between snapshots in seconds"
+// +kubebuilder:printcolumn:name="Last Snapshot",type="string",JSONPath=".status.lastSnapshotName",description="Most recent EtcdSnapshot"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of EtcdBackupPolicy"
+
+// EtcdBackupPolicy is the Schema for the etcdbackuppolicies API.
+// An EtcdBackupPolicy periodically creates EtcdSnapshots for a Cluster, and deletes the oldest ones.
+type EtcdBackupPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of EtcdBackupPolicy.
+	// +required
+	Spec EtcdBackupPolicySpec `json:"spec,omitempty,omitzero"`
+	// status is the observed state of EtcdBackupPolicy.
+	// +optional
+	Status EtcdBackupPolicyStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (in *EtcdBackupPolicy) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (in *EtcdBackupPolicy) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdBackupPolicyList contains a list of EtcdBackupPolicy.
+type EtcdBackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard list's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#lists-and-simple-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// items is the list of EtcdBackupPolicies.
+	Items []EtcdBackupPolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &EtcdBackupPolicy{}, &EtcdBackupPolicyList{})
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// EtcdSnapshotFinalizer is the finalizer applied to EtcdSnapshot resources
+	// by its managing controller; it ensures snapshot data are deleted from the sink.
+	EtcdSnapshotFinalizer = "etcdsnapshot.controlplane.cluster.x-k8s.io"
+
+	// EtcdSnapshotNameLabel is the label set on objects storing the data of an EtcdSnapshot, e.g. Secrets.
+	// Note: The value of this label may be a hash if the EtcdSnapshot name does not fit the constraints for a label value.
+	EtcdSnapshotNameLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot-name"
+)
+
+// EtcdSnapshotSinkType defines the type of sink where etcd snapshots are stored.
+// +kubebuilder:validation:Enum=Secret;Directory
+type EtcdSnapshotSinkType string
+
+const (
+	// EtcdSnapshotSecretSinkType stores the compressed etcd snapshot in one or more Secrets in the namespace of the EtcdSnapshot.
+	// Note: Snapshots are split into multiple Secrets to stay below the size limit of a single object, but this sink
+	// is meant to be used only for small clusters; snapshots are stored in the same etcd of the management cluster.
+	EtcdSnapshotSecretSinkType EtcdSnapshotSinkType = "Secret"
+
+	// EtcdSnapshotDirectorySinkType stores the compressed etcd snapshot as a file in the directory configured
+	// with the --etcd-snapshot-directory flag of the KubeadmControlPlane controller, e.g. a mounted persistent volume.
+	EtcdSnapshotDirectorySinkType EtcdSnapshotSinkType = "Directory"
+)
+
+// EtcdSnapshotSink defines where etcd snapshots are stored.
+type EtcdSnapshotSink struct {
+	// type is the type of sink where etcd snapshots are stored.
+	// +required
+	Type EtcdSnapshotSinkType `json:"type,omitempty"`
+}
+
+// EtcdSnapshot's Completed condition and corresponding reasons.
+const (
+	// EtcdSnapshotCompletedCondition is true when the etcd snapshot has been taken and stored in the sink.
+	EtcdSnapshotCompletedCondition = "Completed"
+
+	// EtcdSnapshotCompletedReason surfaces when the etcd snapshot has been taken and stored in the sink.
+	EtcdSnapshotCompletedReason = "Completed"
+
+	// EtcdSnapshotWaitingForControlPlaneInitializedReason surfaces when the etcd snapshot cannot be taken
+	// because the control plane is not initialized yet.
+	EtcdSnapshotWaitingForControlPlaneInitializedReason = "WaitingForControlPlaneInitialized"
+
+	// EtcdSnapshotFailedReason surfaces when taking the etcd snapshot or storing it in the sink failed.
+	EtcdSnapshotFailedReason = "Failed"
+
+	// EtcdSnapshotInternalErrorReason surfaces unexpected failures when taking the etcd snapshot.
+	EtcdSnapshotInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// EtcdSnapshotSpec defines the desired state of EtcdSnapshot.
+type EtcdSnapshotSpec struct {
+	// clusterName is the name of the Cluster this object belongs to.
+	// The control plane of the Cluster must be managed by a KubeadmControlPlane with local etcd.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// sink defines where the etcd snapshot is stored.
+	// +required
+	Sink EtcdSnapshotSink `json:"sink,omitempty,omitzero"`
+}
+
+// EtcdSnapshotStatus defines the observed state of EtcdSnapshot.
+// +kubebuilder:validation:MinProperties=1
+type EtcdSnapshotStatus struct {
+	// conditions represents the observations of an EtcdSnapshot's current state.
+	// Known condition types are Completed, Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// location is the location of the etcd snapshot in the sink.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Location string `json:"location,omitempty"`
+
+	// sizeBytes is the size of the compressed etcd snapshot.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+
+	// sha256 is the hex encoded SHA-256 checksum of the compressed etcd snapshot.
+	// The checksum is used to verify the snapshot downloaded by the Machine restoring etcd.
+	// +optional
+	// +kubebuilder:validation:MinLength=64
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	SHA256 string `json:"sha256,omitempty"`
+
+	// revision is the revision of the etcd key-value store at the time the snapshot has been taken.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Revision *int64 `json:"revision,omitempty"`
+
+	// etcdVersion is the version of the etcd member that produced the snapshot.
+	// Note: The version is reported only by etcd >= v3.6.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+
+	// completionTime is the time the etcd snapshot has been stored in the sink.
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty,omitzero"`
+
+	// observedGeneration is the latest generation observed by the controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=etcdsnapshots,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=`.status.conditions[?(@.type=="Completed")].status`,description="Snapshot stored in the sink"
+// +kubebuilder:printcolumn:name="Sink",type="string",JSONPath=".spec.sink.type",description="Type of sink where the snapshot is stored"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".status.sizeBytes",description="Size of the compressed snapshot in bytes"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of EtcdSnapshot"
+
+// EtcdSnapshot is the Schema for the etcdsnapshots API.
+// An EtcdSnapshot takes a point-in-time snapshot of the etcd cluster hosted on the control plane
+// Machines of a KubeadmControlPlane, and stores it in a sink.
+type EtcdSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of EtcdSnapshot.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+	Spec EtcdSnapshotSpec `json:"spec,omitempty,omitzero"`
+	// status is the observed state of EtcdSnapshot.
+	// +optional
+	Status EtcdSnapshotStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (in *EtcdSnapshot) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (in *EtcdSnapshot) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdSnapshotList contains a list of EtcdSnapshot.
+type EtcdSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard list's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#lists-and-simple-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// items is the list of EtcdSnapshots.
+	Items []EtcdSnapshot `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &EtcdSnapshot{}, &EtcdSnapshotList{})
+}
@@ -26,13 +26,20 @@ import (
 )
 
 // KubeadmControlPlaneRolloutStrategyType defines the rollout strategies for a KubeadmControlPlane.
-// +kubebuilder:validation:Enum=RollingUpdate
+// +kubebuilder:validation:Enum=RollingUpdate;ScaleIn
 type KubeadmControlPlaneRolloutStrategyType string
 
 const (
 	// RollingUpdateStrategyType replaces the old control planes by new one using rolling update
 	// i.e. gradually scale up or down the old control planes and scale up or down the new one.
 	RollingUpdateStrategyType KubeadmControlPlaneRolloutStrategyType = "RollingUpdate"
+
+	// ScaleInStrategyType replaces the old control planes by new one by first deleting an old control plane,
+	// including its etcd member, and then creating the replacement; this strategy doesn't require spare capacity
+	// for an additional control plane Machine, but it requires at least 3 replicas.
+	// Before deleting a control plane Machine, KCP checks that the etcd cluster and the Kubernetes control plane
+	// components will remain operational after the deletion, same as it does before remediating a Machine.
+	ScaleInStrategyType KubeadmControlPlaneRolloutStrategyType = "ScaleIn"
 )
 
 const (
@@ -55,6 +62,7 @@ const (
 	// RemediationForAnnotation is used to link a new machine to the unhealthy machine it is replacing;
 	// please note that in case of retry, when also the remediating machine fails, the system keeps track of
 	// the first machine of the sequence only.
+	// When using external remediation (spec.remediation.templateRef), this annotation is set on the machine being remediated.
 	// NOTE: if something external to CAPI removes this annotation the system this can lead to
 	// failures in updating remediation retry (the counter restarts from zero).
 	RemediationForAnnotation = "controlplane.cluster.x-k8s.io/remediation-for"
@@ -65,9 +73,43 @@ const (
 	// ensure it runs last (thus ensuring that kubelet is still working while other pre-terminate hooks run).
 	PreTerminateHookCleanupAnnotation = clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/kcp-cleanup"
 
+	// RestoreEtcdSnapshotAnnotation can be set on a KubeadmControlPlane to restore etcd from an EtcdSnapshot;
+	// the value of the annotation is the name of an EtcdSnapshot in the same namespace of the KubeadmControlPlane.
+	// When the restore starts, all the control plane Machines are deleted and replaced by a new Machine
+	// initializing the control plane with the etcd data from the snapshot; the annotation is removed once
+	// the bootstrap data for this Machine have been generated.
+	// The restore requires RestoreEtcdSnapshotURLAnnotation to be set as well.
+	// NOTE: This is a disruptive operation, and it is meant to be used only to recover a control plane after quorum loss.
+	RestoreEtcdSnapshotAnnotation = "controlplane.cluster.x-k8s.io/restore-etcd-snapshot"
+
+	// RestoreEtcdSnapshotURLAnnotation is the URL from where the Machine restoring etcd downloads the compressed
+	// etcd snapshot, e.g. a pre-signed URL of an object storage (https://) or a path on the Machine (file://).
+	// The snapshot is never passed through the bootstrap data of the Machine, so its size is not limited; the
+	// data stored in the sink of the EtcdSnapshot must be made available at this URL before starting the restore,
+	// and the downloaded data are verified against the sha256 checksum recorded in the status of the EtcdSnapshot.
+	// Note: The Machine restoring etcd must provide either etcdutl or ctr, the containerd CLI.
+	// The annotation is removed together with RestoreEtcdSnapshotAnnotation.
+	RestoreEtcdSnapshotURLAnnotation = "controlplane.cluster.x-k8s.io/restore-etcd-snapshot-url"
+
+	// EtcdDefragmentationAnnotation is used to keep track of the last time each etcd member has been defragmented
+	// by KCP when spec.etcdMaintenance.defragmentation is set.
+	// NOTE: if something external to CAPI removes this annotation, KCP might defragment etcd members before
+	// spec.etcdMaintenance.defragmentation.minIntervalSeconds is expired.
+	EtcdDefragmentationAnnotation = "controlplane.cluster.x-k8s.io/etcd-defragmentation"
+
+	// CertificateAuthorityRotationAnnotation is used to keep track of the certificate authority rotation
+	// triggered by spec.certificateAuthorityRotation.after, including the current phase and when it started.
+	// NOTE: if something external to CAPI removes this annotation while a rotation is in progress, KCP
+	// starts the rotation again from the first phase.
+	CertificateAuthorityRotationAnnotation = "controlplane.cluster.x-k8s.io/certificate-authority-rotation"
+
 	// DefaultMinHealthyPeriodSeconds defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriodSeconds = int32(60 * 60)
+
+	// DefaultEtcdDefragmentationMinIntervalSeconds defines the default minimum period between two
+	// defragmentations of the same etcd member.
+	DefaultEtcdDefragmentationMinIntervalSeconds = int32(60 * 60)
 )
 
 // KubeadmControlPlane's Available condition and corresponding reasons.
@@ -319,6 +361,101 @@ const (
 	// the new machine to exists before removing the controlplane.cluster.x-k8s.io/remediation-in-progress annotation.
 	// This is part of a series of safeguards to ensure that operation are performed sequentially on control plane machines.
 	KubeadmControlPlaneMachineRemediationMachineDeletingReason = "MachineDeleting"
+
+	// KubeadmControlPlaneMachineRemediationWaitingForExternalRemediationReason surfaces when remediation of a control plane machine
+	// has been handed off to an external remediation controller by creating a remediation request from spec.remediation.templateRef.
+	KubeadmControlPlaneMachineRemediationWaitingForExternalRemediationReason = "WaitingForExternalRemediation"
+)
+
+// KubeadmControlPlane's EtcdSnapshotRestoring condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdSnapshotRestoringCondition surfaces details about an ongoing restore of etcd from an EtcdSnapshot.
+	// Note: This condition is set only after a restore is requested using the controlplane.cluster.x-k8s.io/restore-etcd-snapshot annotation.
+	KubeadmControlPlaneEtcdSnapshotRestoringCondition = "EtcdSnapshotRestoring"
+
+	// KubeadmControlPlaneEtcdSnapshotRestoringReason surfaces when a restore of etcd from an EtcdSnapshot is in progress.
+	KubeadmControlPlaneEtcdSnapshotRestoringReason = "Restoring"
+
+	// KubeadmControlPlaneEtcdSnapshotNotRestoringReason surfaces when no restore of etcd is in progress.
+	KubeadmControlPlaneEtcdSnapshotNotRestoringReason = "NotRestoring"
+
+	// KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason surfaces when a restore of etcd has been requested, but it
+	// cannot be started, e.g. because the EtcdSnapshot does not exist or it is not completed yet.
+	KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason = "RestoreBlocked"
+
+	// KubeadmControlPlaneEtcdSnapshotRestoringInternalErrorReason surfaces unexpected failures when restoring etcd from an EtcdSnapshot.
+	KubeadmControlPlaneEtcdSnapshotRestoringInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's EtcdDefragmenting condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdDefragmentingCondition surfaces details about ongoing defragmentation of etcd members.
+	// Note: This condition is set only if spec.etcdMaintenance.defragmentation is set.
+	KubeadmControlPlaneEtcdDefragmentingCondition = "EtcdDefragmenting"
+
+	// KubeadmControlPlaneEtcdDefragmentingReason surfaces when at least one etcd member is being defragmented or
+	// it is going to be defragmented.
+	KubeadmControlPlaneEtcdDefragmentingReason = "Defragmenting"
+
+	// KubeadmControlPlaneEtcdNotDefragmentingReason surfaces when no etcd member needs defragmentation.
+	KubeadmControlPlaneEtcdNotDefragmentingReason = "NotDefragmenting"
+
+	// KubeadmControlPlaneEtcdDefragmentationDeferredReason surfaces when defragmentation of etcd members must be deferred,
+	// e.g. because not all the etcd members are responsive.
+	KubeadmControlPlaneEtcdDefragmentationDeferredReason = "DefragmentationDeferred"
+
+	// KubeadmControlPlaneEtcdDefragmentingInternalErrorReason surfaces unexpected failures when defragmenting etcd members.
+	KubeadmControlPlaneEtcdDefragmentingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's CertificateAuthorityRotating condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneCertificateAuthorityRotatingCondition surfaces details about an ongoing rotation of the
+	// cluster certificate authorities.
+	// Note: This condition is set only if spec.certificateAuthorityRotation.after is set.
+	KubeadmControlPlaneCertificateAuthorityRotatingCondition = "CertificateAuthorityRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingReason surfaces when a rotation of the certificate authorities is in progress.
+	KubeadmControlPlaneCertificateAuthorityRotatingReason = "Rotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotationBlockedReason surfaces when a rotation of the certificate authorities
+	// is in progress, but it is waiting for Machines that KubeadmControlPlane cannot roll out, e.g. Machines controlled
+	// by MachinePools or standalone Machines; those Machines must be replaced by the user.
+	KubeadmControlPlaneCertificateAuthorityRotationBlockedReason = "RotationBlocked"
+
+	// KubeadmControlPlaneCertificateAuthorityNotRotatingReason surfaces when no rotation of the certificate authorities is in progress.
+	KubeadmControlPlaneCertificateAuthorityNotRotatingReason = "NotRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason surfaces unexpected failures when rotating
+	// the certificate authorities.
+	KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's CertificatesExpiring condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneCertificatesExpiringCondition is true if at least one of the certificates of the Cluster expires
+	// in less than 30 days or it is already expired.
+	// Certificates of the Cluster are the certificates stored in the cluster certificate Secrets (e.g. certificate authorities),
+	// the client certificates of the kubeconfig Secret, and the certificates of the control plane Machines.
+	KubeadmControlPlaneCertificatesExpiringCondition = clusterv1.CertificatesExpiringCondition
+
+	// KubeadmControlPlaneCertificatesExpiringReason surfaces when at least one of the certificates of the Cluster
+	// expires in less than 30 days.
+	KubeadmControlPlaneCertificatesExpiringReason = clusterv1.CertificatesExpiringReason
+
+	// KubeadmControlPlaneCertificatesExpiredReason surfaces when at least one of the certificates of the Cluster is expired.
+	KubeadmControlPlaneCertificatesExpiredReason = clusterv1.CertificatesExpiredReason
+
+	// KubeadmControlPlaneCertificatesNotExpiringReason surfaces when none of the certificates of the Cluster
+	// expires in less than 30 days.
+	KubeadmControlPlaneCertificatesNotExpiringReason = clusterv1.CertificatesNotExpiringReason
+
+	// KubeadmControlPlaneCertificatesExpiryUnknownReason surfaces when none of the certificates of the Cluster can be found,
+	// or when some of them cannot be parsed and none of the others is expiring.
+	KubeadmControlPlaneCertificatesExpiryUnknownReason = clusterv1.CertificatesExpiryUnknownReason
+
+	// KubeadmControlPlaneCertificatesExpiringInternalErrorReason surfaces unexpected failures when reading certificates.
+	KubeadmControlPlaneCertificatesExpiringInternalErrorReason = clusterv1.InternalErrorReason
 )
 
 // KubeadmControlPlane's Deleting condition and corresponding reasons.
@@ -477,6 +614,17 @@ type KubeadmControlPlaneSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcdMaintenance configures maintenance operations KCP performs on the etcd cluster
+	// hosted on control plane Machines.
+	// NOTE: etcdMaintenance cannot be set when using an external etcd.
+	// +optional
+	EtcdMaintenance KubeadmControlPlaneEtcdMaintenanceSpec `json:"etcdMaintenance,omitempty,omitzero"`
+
+	// certificateAuthorityRotation allows you to rotate the cluster certificate authorities and the
+	// service account key pair.
+	// +optional
+	CertificateAuthorityRotation KubeadmControlPlaneCertificateAuthorityRotationSpec `json:"certificateAuthorityRotation,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -601,8 +749,7 @@ type KubeadmControlPlaneRolloutBeforeSpec struct {
 // with new ones.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmControlPlaneRolloutStrategy struct {
-	// type of rollout. Currently the only supported strategy is
-	// "RollingUpdate".
+	// type of rollout. Allowed values are "RollingUpdate" and "ScaleIn".
 	// Default is RollingUpdate.
 	// +required
 	Type KubeadmControlPlaneRolloutStrategyType `json:"type,omitempty"`
@@ -671,6 +818,23 @@ type KubeadmControlPlaneRemediationSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	MinHealthyPeriodSeconds *int32 `json:"minHealthyPeriodSeconds,omitempty"`
+
+	// templateRef is a reference to a remediation template
+	// provided by an infrastructure provider.
+	//
+	// This field is completely optional, when filled, instead of deleting the unhealthy Machine, KCP
+	// creates a new object from the template referenced and hands off remediation of the Machine to
+	// a controller that lives outside of Cluster API, e.g. for rebooting or re-provisioning a bare-metal host.
+	// The new object has the same name of the Machine being remediated and it is owned by the Machine.
+	// KCP performs the same checks it performs before deleting an unhealthy Machine, including
+	// checks about etcd quorum and retry limits, before creating the new object. The new object
+	// is deleted by KCP once the Machine is healthy again.
+	//
+	// NOTE: KCP must have permissions to get, create and delete objects of the kind of the remediation request;
+	// permissions for groups other than infrastructure.cluster.x-k8s.io can be granted by using
+	// a ClusterRole with the kubeadm.controlplane.cluster.x-k8s.io/aggregate-to-manager label.
+	// +optional
+	TemplateRef clusterv1.MachineHealthCheckRemediationTemplateReference `json:"templateRef,omitempty,omitzero"`
 }
 
 // MachineNamingSpec allows changing the naming pattern used when creating Machines.
@@ -693,12 +857,73 @@ type MachineNamingSpec struct {
 	Template string `json:"template,omitempty"`
 }
 
+// KubeadmControlPlaneEtcdMaintenanceSpec configures maintenance operations on the etcd cluster
+// hosted on control plane Machines.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdMaintenanceSpec struct {
+	// defragmentation configures automatic defragmentation of etcd members.
+	// Etcd members are defragmented one at a time; if the etcd leader must be defragmented,
+	// leadership is moved to another member first.
+	// After an etcd member has been defragmented, NOSPACE alarms for this member are disarmed.
+	// If not set, etcd members are never defragmented by KCP.
+	// +optional
+	Defragmentation KubeadmControlPlaneEtcdDefragmentationSpec `json:"defragmentation,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdDefragmentationSpec configures automatic defragmentation of etcd members.
+// An etcd member is defragmented if its database size or fragmentation crosses one of the
+// thresholds, or if a NOSPACE alarm has been raised for it.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdDefragmentationSpec struct {
+	// dbSizeThresholdBytes is the size of the etcd member's backend database, in bytes, above
+	// which the member is defragmented.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DBSizeThresholdBytes *int64 `json:"dbSizeThresholdBytes,omitempty"`
+
+	// fragmentationThresholdPercent is the percentage of the etcd member's backend database not in use
+	// above which the member is defragmented.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	FragmentationThresholdPercent *int32 `json:"fragmentationThresholdPercent,omitempty"`
+
+	// minIntervalSeconds is the minimum period between two defragmentations of the same etcd member.
+	// This prevents the same member from being defragmented again and again e.g. when its database size
+	// is above dbSizeThresholdBytes also after defragmentation.
+	//
+	// If not set, this value is defaulted to 1h.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinIntervalSeconds *int32 `json:"minIntervalSeconds,omitempty"`
+}
+
+// KubeadmControlPlaneCertificateAuthorityRotationSpec allows you to rotate the cluster certificate authorities
+// and the service account key pair.
+// The rotation is performed in three phases: first new certificate authorities are added to the trust bundles
+// while the old ones are still used for signing, then signing is moved to the new certificate authorities,
+// and finally the old certificate authorities are removed from the trust bundles.
+// In each phase the kubeconfig Secret is regenerated and all the control plane Machines and the Machines
+// controlled by MachineDeployments of the Cluster are rolled out; the next phase starts only after all
+// the Machines of the Cluster have been replaced.
+// NOTE: Certificate authorities not generated by Cluster API, e.g. provided by users or used by an external etcd,
+// are not rotated. Machines not controlled by MachineDeployments, e.g. MachinePools, must be replaced by users.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneCertificateAuthorityRotationSpec struct {
+	// after is a field to indicate a rotation of the certificate authorities should be performed
+	// after the specified time; a new rotation is performed every time this field is set to a later time.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the rotation after March 9, 2026, at 9 am UTC use "2026-03-09T09:00:00Z".
+	// +optional
+	After metav1.Time `json:"after,omitempty,omitzero"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmControlPlaneStatus struct {
 	// conditions represents the observations of a KubeadmControlPlane's current state.
 	// Known condition types are Available, CertificatesAvailable, EtcdClusterAvailable, MachinesReady, MachinesUpToDate,
-	// ScalingUp, ScalingDown, Remediating, Deleting, Paused.
+	// ScalingUp, ScalingDown, Remediating, EtcdSnapshotRestoring, EtcdDefragmenting, CertificateAuthorityRotating, CertificatesExpiring, Deleting, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -753,6 +978,12 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation LastRemediationStatus `json:"lastRemediation,omitempty,omitzero"`
 
+	// certificatesExpiryDate is the earliest expiry date of the certificates of the Cluster, i.e. the certificates
+	// stored in the cluster certificate Secrets, the client certificates of the kubeconfig Secret, and the certificates
+	// of the control plane Machines.
+	// +optional
+	CertificatesExpiryDate metav1.Time `json:"certificatesExpiryDate,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *KubeadmControlPlaneDeprecatedStatus `json:"deprecated,omitempty"`
@@ -111,6 +111,17 @@ type KubeadmControlPlaneTemplateResourceSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcdMaintenance configures maintenance operations KCP performs on the etcd cluster
+	// hosted on control plane Machines.
+	// NOTE: etcdMaintenance cannot be set when using an external etcd.
+	// +optional
+	EtcdMaintenance KubeadmControlPlaneEtcdMaintenanceSpec `json:"etcdMaintenance,omitempty,omitzero"`
+
+	// certificateAuthorityRotation allows you to rotate the cluster certificate authorities and the
+	// service account key pair.
+	// +optional
+	CertificateAuthorityRotation KubeadmControlPlaneCertificateAuthorityRotationSpec `json:"certificateAuthorityRotation,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -27,6 +27,232 @@ import (
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupPolicy) DeepCopyInto(out *EtcdBackupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPolicy.
+func (in *EtcdBackupPolicy) DeepCopy() *EtcdBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdBackupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupPolicyList) DeepCopyInto(out *EtcdBackupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdBackupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPolicyList.
+func (in *EtcdBackupPolicyList) DeepCopy() *EtcdBackupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdBackupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupPolicySpec) DeepCopyInto(out *EtcdBackupPolicySpec) {
+	*out = *in
+	if in.MaxSnapshots != nil {
+		in, out := &in.MaxSnapshots, &out.MaxSnapshots
+		*out = new(int32)
+		**out = **in
+	}
+	out.Sink = in.Sink
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPolicySpec.
+func (in *EtcdBackupPolicySpec) DeepCopy() *EtcdBackupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupPolicyStatus) DeepCopyInto(out *EtcdBackupPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastSnapshotTime.DeepCopyInto(&out.LastSnapshotTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPolicyStatus.
+func (in *EtcdBackupPolicyStatus) DeepCopy() *EtcdBackupPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshot) DeepCopyInto(out *EtcdSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshot.
+func (in *EtcdSnapshot) DeepCopy() *EtcdSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotList) DeepCopyInto(out *EtcdSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotList.
+func (in *EtcdSnapshotList) DeepCopy() *EtcdSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotSink) DeepCopyInto(out *EtcdSnapshotSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotSink.
+func (in *EtcdSnapshotSink) DeepCopy() *EtcdSnapshotSink {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotSpec) DeepCopyInto(out *EtcdSnapshotSpec) {
+	*out = *in
+	out.Sink = in.Sink
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotSpec.
+func (in *EtcdSnapshotSpec) DeepCopy() *EtcdSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotStatus) DeepCopyInto(out *EtcdSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SizeBytes != nil {
+		in, out := &in.SizeBytes, &out.SizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(int64)
+		**out = **in
+	}
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotStatus.
+func (in *EtcdSnapshotStatus) DeepCopy() *EtcdSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlane) DeepCopyInto(out *KubeadmControlPlane) {
 	*out = *in
@@ -54,6 +280,22 @@ func (in *KubeadmControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationSpec) DeepCopyInto(out *KubeadmControlPlaneCertificateAuthorityRotationSpec) {
+	*out = *in
+	in.After.DeepCopyInto(&out.After)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneCertificateAuthorityRotationSpec.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationSpec) DeepCopy() *KubeadmControlPlaneCertificateAuthorityRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneCertificateAuthorityRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopyInto(out *KubeadmControlPlaneDeprecatedStatus) {
 	*out = *in
@@ -74,6 +316,52 @@ func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopy() *KubeadmControlPlaneDe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdDefragmentationSpec) {
+	*out = *in
+	if in.DBSizeThresholdBytes != nil {
+		in, out := &in.DBSizeThresholdBytes, &out.DBSizeThresholdBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FragmentationThresholdPercent != nil {
+		in, out := &in.FragmentationThresholdPercent, &out.FragmentationThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinIntervalSeconds != nil {
+		in, out := &in.MinIntervalSeconds, &out.MinIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdDefragmentationSpec.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopy() *KubeadmControlPlaneEtcdDefragmentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdDefragmentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdMaintenanceSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdMaintenanceSpec) {
+	*out = *in
+	in.Defragmentation.DeepCopyInto(&out.Defragmentation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdMaintenanceSpec.
+func (in *KubeadmControlPlaneEtcdMaintenanceSpec) DeepCopy() *KubeadmControlPlaneEtcdMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneInitializationStatus) DeepCopyInto(out *KubeadmControlPlaneInitializationStatus) {
 	*out = *in
@@ -218,6 +506,7 @@ func (in *KubeadmControlPlaneRemediationSpec) DeepCopyInto(out *KubeadmControlPl
 		*out = new(int32)
 		**out = **in
 	}
+	out.TemplateRef = in.TemplateRef
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneRemediationSpec.
@@ -312,6 +601,8 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.EtcdMaintenance.DeepCopyInto(&out.EtcdMaintenance)
+	in.CertificateAuthorityRotation.DeepCopyInto(&out.CertificateAuthorityRotation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -356,6 +647,7 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 		**out = **in
 	}
 	in.LastRemediation.DeepCopyInto(&out.LastRemediation)
+	in.CertificatesExpiryDate.DeepCopyInto(&out.CertificatesExpiryDate)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(KubeadmControlPlaneDeprecatedStatus)
@@ -524,6 +816,8 @@ func (in *KubeadmControlPlaneTemplateResourceSpec) DeepCopyInto(out *KubeadmCont
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.EtcdMaintenance.DeepCopyInto(&out.EtcdMaintenance)
+	in.CertificateAuthorityRotation.DeepCopyInto(&out.CertificateAuthorityRotation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneTemplateResourceSpec.
@@ -84,6 +84,10 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 	if !reflect.DeepEqual(initialization, clusterv1.ClusterInitializationStatus{}) {
 		dst.Status.Initialization = initialization
 	}
+
+	if ok {
+		dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
+	}
 	return nil
 }
 
@@ -482,6 +486,15 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover intent for bool values converted to *bool.
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.Paused, ok, restored.Spec.Paused, &dst.Spec.Paused)
 
+	if ok {
+		dst.Spec.Rollout.Strategy.BlueGreen = restored.Spec.Rollout.Strategy.BlueGreen
+		dst.Spec.Rollout.Strategy.Canary = restored.Spec.Rollout.Strategy.Canary
+		dst.Status.Canary = restored.Status.Canary
+		dst.Spec.Rollout.ProgressDeadlineSeconds = restored.Spec.Rollout.ProgressDeadlineSeconds
+		dst.Spec.Rollout.OnProgressDeadlineExceeded = restored.Spec.Rollout.OnProgressDeadlineExceeded
+		dst.Status.Rollout = restored.Status.Rollout
+	}
+
 	return nil
 }
 
@@ -873,7 +886,7 @@ func Convert_v1beta1_MachineDeploymentClass_To_v1beta2_MachineDeploymentClass(in
 		return err
 	}
 	if in.Strategy != nil {
-		out.Rollout.Strategy.Type = clusterv1.MachineDeploymentRolloutStrategyType(in.Strategy.Type)
+		out.Rollout.Strategy.Type = clusterv1.MachineDeploymentTopologyRolloutStrategyType(in.Strategy.Type)
 		if in.Strategy.RollingUpdate != nil {
 			out.Rollout.Strategy.RollingUpdate.MaxUnavailable = in.Strategy.RollingUpdate.MaxUnavailable
 			out.Rollout.Strategy.RollingUpdate.MaxSurge = in.Strategy.RollingUpdate.MaxSurge
@@ -1014,7 +1027,7 @@ func Convert_v1beta1_MachineDeploymentTopology_To_v1beta2_MachineDeploymentTopol
 		}
 	}
 	if in.Strategy != nil {
-		out.Rollout.Strategy.Type = clusterv1.MachineDeploymentRolloutStrategyType(in.Strategy.Type)
+		out.Rollout.Strategy.Type = clusterv1.MachineDeploymentTopologyRolloutStrategyType(in.Strategy.Type)
 		if in.Strategy.RollingUpdate != nil {
 			out.Rollout.Strategy.RollingUpdate.MaxUnavailable = in.Strategy.RollingUpdate.MaxUnavailable
 			out.Rollout.Strategy.RollingUpdate.MaxSurge = in.Strategy.RollingUpdate.MaxSurge
@@ -2222,7 +2235,8 @@ func Convert_v1beta2_ClusterNetwork_To_v1beta1_ClusterNetwork(in *clusterv1.Clus
 }
 
 func Convert_v1beta1_MachineDeploymentSpec_To_v1beta2_MachineDeploymentSpec(in *MachineDeploymentSpec, out *clusterv1.MachineDeploymentSpec, s apimachineryconversion.Scope) error {
-	// NOTE: v1beta2 MachineDeploymentSpec does not have ProgressDeadlineSeconds anymore. But it's fine to just lose this field it was never used.
+	// NOTE: v1beta1 ProgressDeadlineSeconds is intentionally not converted to v1beta2 spec.rollout.progressDeadlineSeconds,
+	// because it was never used and it was defaulted to 600s; converting it would enable progress deadlines on existing MachineDeployments.
 	if err := autoConvert_v1beta1_MachineDeploymentSpec_To_v1beta2_MachineDeploymentSpec(in, out, s); err != nil {
 		return err
 	}
@@ -1654,6 +1654,7 @@ func autoConvert_v1beta2_ClusterStatus_To_v1beta1_ClusterStatus(in *v1beta2.Clus
 	// WARNING: in.Initialization requires manual conversion: does not exist in peer-type
 	// WARNING: in.ControlPlane requires manual conversion: does not exist in peer-type
 	// WARNING: in.Workers requires manual conversion: does not exist in peer-type
+	// WARNING: in.CertificatesExpiryDate requires manual conversion: does not exist in peer-type
 	// WARNING: in.FailureDomains requires manual conversion: inconvertible types ([]sigs.k8s.io/cluster-api/api/core/v1beta2.FailureDomain vs sigs.k8s.io/cluster-api/api/core/v1beta1.FailureDomains)
 	out.Phase = in.Phase
 	out.ObservedGeneration = in.ObservedGeneration
@@ -2412,6 +2413,8 @@ func autoConvert_v1beta2_MachineDeploymentStatus_To_v1beta1_MachineDeploymentSta
 	}
 	// WARNING: in.UpToDateReplicas requires manual conversion: does not exist in peer-type
 	out.Phase = in.Phase
+	// WARNING: in.Canary requires manual conversion: does not exist in peer-type
+	// WARNING: in.Rollout requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -440,6 +440,33 @@ const (
 	ClusterRemediatingInternalErrorReason = InternalErrorReason
 )
 
+// Cluster's CertificatesExpiring condition and corresponding reasons.
+const (
+	// ClusterCertificatesExpiringCondition is true if at least one of the certificates of the Cluster expires
+	// in less than 30 days or it is already expired.
+	// Certificates of the Cluster are the certificates stored in the cluster certificate Secrets (e.g. certificate authorities),
+	// the client certificates of the kubeconfig Secret, and the Machine certificates reported in Machine's status.certificatesExpiryDate.
+	ClusterCertificatesExpiringCondition = CertificatesExpiringCondition
+
+	// ClusterCertificatesExpiringReason surfaces when at least one of the certificates of the Cluster
+	// expires in less than 30 days.
+	ClusterCertificatesExpiringReason = CertificatesExpiringReason
+
+	// ClusterCertificatesExpiredReason surfaces when at least one of the certificates of the Cluster is expired.
+	ClusterCertificatesExpiredReason = CertificatesExpiredReason
+
+	// ClusterCertificatesNotExpiringReason surfaces when none of the certificates of the Cluster
+	// expires in less than 30 days.
+	ClusterCertificatesNotExpiringReason = CertificatesNotExpiringReason
+
+	// ClusterCertificatesExpiryUnknownReason surfaces when none of the certificates of the Cluster can be found,
+	// or when some of them cannot be parsed and none of the others is expiring.
+	ClusterCertificatesExpiryUnknownReason = CertificatesExpiryUnknownReason
+
+	// ClusterCertificatesExpiringInternalErrorReason surfaces unexpected failures when reading certificates.
+	ClusterCertificatesExpiringInternalErrorReason = InternalErrorReason
+)
+
 // Cluster's Deleting condition and corresponding reasons.
 const (
 	// ClusterDeletingCondition surfaces details about ongoing deletion of the cluster.
@@ -1171,6 +1198,20 @@ type MachineDeploymentTopologyRolloutSpec struct {
 	Strategy MachineDeploymentTopologyRolloutStrategy `json:"strategy,omitempty,omitzero"`
 }
 
+// MachineDeploymentTopologyRolloutStrategyType defines the type of rollout strategies for MachineDeployments
+// in a Cluster topology and in a ClusterClass.
+// Note: The BlueGreen and Canary rollout strategies of MachineDeployments are not supported in the topology.
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+type MachineDeploymentTopologyRolloutStrategyType string
+
+const (
+	// RollingUpdateMachineDeploymentTopologyStrategyType sets the RollingUpdate rollout strategy on the MachineDeployment.
+	RollingUpdateMachineDeploymentTopologyStrategyType MachineDeploymentTopologyRolloutStrategyType = "RollingUpdate"
+
+	// OnDeleteMachineDeploymentTopologyStrategyType sets the OnDelete rollout strategy on the MachineDeployment.
+	OnDeleteMachineDeploymentTopologyStrategyType MachineDeploymentTopologyRolloutStrategyType = "OnDelete"
+)
+
 // MachineDeploymentTopologyRolloutStrategy describes how to replace existing machines
 // with new ones.
 // +kubebuilder:validation:MinProperties=1
@@ -1178,7 +1219,7 @@ type MachineDeploymentTopologyRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
 	// +required
-	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
+	Type MachineDeploymentTopologyRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
 	// type = RollingUpdate.
@@ -1428,7 +1469,7 @@ func (n NetworkRanges) String() string {
 type ClusterStatus struct {
 	// conditions represents the observations of a Cluster's current state.
 	// Known condition types are Available, InfrastructureReady, ControlPlaneInitialized, ControlPlaneAvailable, WorkersAvailable, MachinesReady
-	// MachinesUpToDate, RemoteConnectionProbe, ScalingUp, ScalingDown, Remediating, Deleting, Paused.
+	// MachinesUpToDate, RemoteConnectionProbe, ScalingUp, ScalingDown, Remediating, CertificatesExpiring, Deleting, Paused.
 	// Additionally, a TopologyReconciled condition will be added in case the Cluster is referencing a ClusterClass / defining a managed Topology.
 	// +optional
 	// +listType=map
@@ -1449,6 +1490,12 @@ type ClusterStatus struct {
 	// +optional
 	Workers *WorkersStatus `json:"workers,omitempty"`
 
+	// certificatesExpiryDate is the earliest expiry date of the certificates of the Cluster, i.e. the certificates
+	// stored in the cluster certificate Secrets, the client certificates of the kubeconfig Secret, and the Machine
+	// certificates reported in Machine's status.certificatesExpiryDate.
+	// +optional
+	CertificatesExpiryDate metav1.Time `json:"certificatesExpiryDate,omitempty,omitzero"`
+
 	// failureDomains is a slice of failure domain objects synced from the infrastructure provider.
 	// +optional
 	// +listType=map
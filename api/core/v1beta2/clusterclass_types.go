@@ -722,7 +722,7 @@ type MachineDeploymentClassRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
 	// +required
-	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
+	Type MachineDeploymentTopologyRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
 	// type = RollingUpdate.
@@ -90,6 +90,12 @@ const (
 	// Please use object specific variants of this condition which provides more details for each context where
 	// the same condition type exists.
 	PausedCondition = "Paused"
+
+	// CertificatesExpiringCondition reports if certificates are expiring soon or they are already expired.
+	// Note: This condition type is defined to ensure consistent naming of conditions across objects.
+	// Please use object specific variants of this condition which provides more details for each context where
+	// the same condition type exists.
+	CertificatesExpiringCondition = "CertificatesExpiring"
 )
 
 // Reasons that are used across different objects.
@@ -212,4 +218,17 @@ const (
 
 	// NotProvisionedReason documents an object or a piece of infrastructure is not provisioned.
 	NotProvisionedReason = "NotProvisioned"
+
+	// CertificatesExpiringReason surfaces when at least one certificate is expiring soon.
+	CertificatesExpiringReason = "CertificatesExpiring"
+
+	// CertificatesExpiredReason surfaces when at least one certificate is expired.
+	CertificatesExpiredReason = "CertificatesExpired"
+
+	// CertificatesNotExpiringReason surfaces when none of the certificates is expiring soon.
+	CertificatesNotExpiringReason = "CertificatesNotExpiring"
+
+	// CertificatesExpiryUnknownReason surfaces when it is not possible to determine certificates expiry,
+	// e.g. because certificates do not exist yet or they cannot be parsed.
+	CertificatesExpiryUnknownReason = "CertificatesExpiryUnknown"
 )
@@ -376,6 +376,10 @@ const (
 	// MachineDeletingInternalErrorReason surfaces unexpected failures when deleting a Machine.
 	MachineDeletingInternalErrorReason = InternalErrorReason
 
+	// MachineDeletingWaitingForBeforeMachineDeleteHookReason surfaces when the Machine deletion
+	// waits for the BeforeMachineDelete Runtime SDK hook to unblock deletion.
+	MachineDeletingWaitingForBeforeMachineDeleteHookReason = "WaitingForBeforeMachineDeleteHook"
+
 	// MachineDeletingWaitingForPreDrainHookReason surfaces when the Machine deletion
 	// waits for pre-drain hooks to complete. I.e. it waits until there are no annotations
 	// with the `pre-drain.delete.hook.machine.cluster.x-k8s.io` prefix on the Machine anymore.
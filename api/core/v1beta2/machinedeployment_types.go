@@ -32,7 +32,7 @@ const (
 )
 
 // MachineDeploymentRolloutStrategyType defines the type of MachineDeployment rollout strategies.
-// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete;BlueGreen;Canary
 type MachineDeploymentRolloutStrategyType string
 
 const (
@@ -43,6 +43,14 @@ const (
 	// OnDeleteMachineDeploymentStrategyType replaces old MachineSets when the deletion of the associated machines are completed.
 	OnDeleteMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "OnDelete"
 
+	// BlueGreenMachineDeploymentStrategyType replaces the old MachineSets by bringing up a full new MachineSet first,
+	// and then scaling down all the old MachineSets at once when the new one is available.
+	BlueGreenMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "BlueGreen"
+
+	// CanaryMachineDeploymentStrategyType replaces the old MachineSets in steps, moving an increasing number
+	// of replicas to the new MachineSet; each step is completed only when its gates pass.
+	CanaryMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "Canary"
+
 	// RevisionAnnotation is the revision annotation of a machine deployment's machine sets which records its rollout sequence.
 	RevisionAnnotation = "machinedeployment.clusters.x-k8s.io/revision"
 
@@ -56,6 +64,32 @@ const (
 	// proportions in case the deployment has surge replicas.
 	MaxReplicasAnnotation = "machinedeployment.clusters.x-k8s.io/max-replicas"
 
+	// BlueGreenPromoteAnnotation can be set on a MachineDeployment using the BlueGreen rollout strategy with
+	// manual promotion; the value of the annotation must be the name of the new MachineSet to be promoted.
+	BlueGreenPromoteAnnotation = "machinedeployment.clusters.x-k8s.io/blue-green-promote"
+
+	// BlueGreenStartedAnnotation is set on the new MachineSet of a MachineDeployment using the BlueGreen rollout strategy
+	// and it records the time when the blue/green rollout started; it is removed when the rollout is rolled back, so
+	// a retry of the rollout starts from scratch.
+	// Note: This annotation is managed by the MachineDeployment controller.
+	BlueGreenStartedAnnotation = "machinedeployment.clusters.x-k8s.io/blue-green-started"
+
+	// BlueGreenAvailableAnnotation is set on the new MachineSet of a MachineDeployment using the BlueGreen rollout strategy
+	// and it records the time when all the Machines of the new MachineSet became available.
+	// Note: This annotation is managed by the MachineDeployment controller.
+	BlueGreenAvailableAnnotation = "machinedeployment.clusters.x-k8s.io/blue-green-available"
+
+	// BlueGreenRolledBackAnnotation is set on the new MachineSet of a MachineDeployment using the BlueGreen rollout strategy
+	// when the new MachineSet did not become available in time, and it records the time of the rollback.
+	// While this annotation is set, the new MachineSet is kept at zero replicas and the old MachineSets keep serving;
+	// remove the annotation to retry the rollout.
+	BlueGreenRolledBackAnnotation = "machinedeployment.clusters.x-k8s.io/blue-green-rolled-back"
+
+	// CanaryStepAnnotation is set on the new MachineSet of a MachineDeployment using the Canary rollout strategy
+	// and it records the index of the canary step in progress, starting from 0.
+	// Note: This annotation is managed by the MachineDeployment controller.
+	CanaryStepAnnotation = "machinedeployment.clusters.x-k8s.io/canary-step"
+
 	// MachineDeploymentUniqueLabel is used to uniquely identify the Machines of a MachineSet.
 	// The MachineDeployment controller will set this label on a MachineSet when it is created.
 	// The label is also applied to the Machines of the MachineSet and used in the MachineSet selector.
@@ -160,6 +194,24 @@ const (
 	MachineDeploymentRollingOutInternalErrorReason = InternalErrorReason
 )
 
+// MachineDeployment's RolloutFailed condition and corresponding reasons.
+const (
+	// MachineDeploymentRolloutFailedCondition is true if the rollout of the new MachineSet did not make progress
+	// within spec.rollout.progressDeadlineSeconds.
+	MachineDeploymentRolloutFailedCondition = "RolloutFailed"
+
+	// MachineDeploymentRolloutProgressDeadlineExceededReason surfaces when the rollout of the new MachineSet did not
+	// make progress within spec.rollout.progressDeadlineSeconds.
+	MachineDeploymentRolloutProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+	// MachineDeploymentRolloutRolledBackReason surfaces when the MachineDeployment has been rolled back to the previous
+	// MachineSet revision after the rollout of the new MachineSet did not make progress within spec.rollout.progressDeadlineSeconds.
+	MachineDeploymentRolloutRolledBackReason = "RolledBack"
+
+	// MachineDeploymentRolloutNotFailedReason surfaces when the rollout of the new MachineSet is making progress or it is completed.
+	MachineDeploymentRolloutNotFailedReason = "NotFailed"
+)
+
 // MachineDeployment's ScalingUp condition and corresponding reasons.
 const (
 	// MachineDeploymentScalingUpCondition is true if actual replicas < desired replicas.
@@ -309,13 +361,45 @@ type MachineDeploymentRolloutSpec struct {
 	// strategy specifies how to roll out control plane Machines.
 	// +optional
 	Strategy MachineDeploymentRolloutStrategy `json:"strategy,omitempty,omitzero"`
+
+	// progressDeadlineSeconds is the maximum time in seconds for a rollout to make progress before it
+	// is considered to be failed. The rollout makes progress when a Machine of the new MachineSet becomes available.
+	// When the deadline is exceeded, the RolloutFailed condition is set to true and the action defined
+	// in onProgressDeadlineExceeded is taken.
+	// If not set, rollouts never fail.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// onProgressDeadlineExceeded defines the action to take when the rollout does not make progress
+	// within progressDeadlineSeconds.
+	// If set to Fail, the rollout is only marked as failed, and it is retried forever.
+	// If set to Rollback, the MachineDeployment's spec.template.spec is reverted to the one of the previous
+	// MachineSet revision; rollback is not supported for MachineDeployments managed by a ClusterClass.
+	// Defaults to Fail.
+	// +optional
+	OnProgressDeadlineExceeded MachineDeploymentProgressDeadlineExceededAction `json:"onProgressDeadlineExceeded,omitempty"`
 }
 
+// MachineDeploymentProgressDeadlineExceededAction defines the action to take when a MachineDeployment rollout
+// does not make progress within the progress deadline.
+// +kubebuilder:validation:Enum=Fail;Rollback
+type MachineDeploymentProgressDeadlineExceededAction string
+
+const (
+	// FailMachineDeploymentProgressDeadlineExceededAction marks the rollout as failed.
+	FailMachineDeploymentProgressDeadlineExceededAction MachineDeploymentProgressDeadlineExceededAction = "Fail"
+
+	// RollbackMachineDeploymentProgressDeadlineExceededAction marks the rollout as failed and rolls back
+	// the MachineDeployment to the previous MachineSet revision.
+	RollbackMachineDeploymentProgressDeadlineExceededAction MachineDeploymentProgressDeadlineExceededAction = "Rollback"
+)
+
 // MachineDeploymentRolloutStrategy describes how to replace existing machines
 // with new ones.
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentRolloutStrategy struct {
-	// type of rollout. Allowed values are RollingUpdate and OnDelete.
+	// type of rollout. Allowed values are RollingUpdate, OnDelete, BlueGreen and Canary.
 	// Default is RollingUpdate.
 	// +required
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
@@ -324,6 +408,148 @@ type MachineDeploymentRolloutStrategy struct {
 	// type = RollingUpdate.
 	// +optional
 	RollingUpdate MachineDeploymentRolloutStrategyRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+
+	// blueGreen is the blue/green config params. Present only if
+	// type = BlueGreen.
+	// +optional
+	BlueGreen MachineDeploymentRolloutStrategyBlueGreen `json:"blueGreen,omitempty,omitzero"`
+
+	// canary is the canary config params. Present only if
+	// type = Canary.
+	// +optional
+	Canary MachineDeploymentRolloutStrategyCanary `json:"canary,omitempty,omitzero"`
+}
+
+// MachineDeploymentRolloutStrategyCanary is used to control the desired behavior of canary rollouts.
+// With canary rollouts, replicas are moved from the old MachineSets to the new MachineSet in steps; in each step
+// the new MachineSet is scaled up to the number of replicas defined by the step, and old MachineSets are scaled down
+// once the new replicas are available. The rollout proceeds to the next step only when all the gates of the current step pass.
+// After the last step, all the remaining replicas are moved to the new MachineSet.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStrategyCanary struct {
+	// steps defines the canary steps.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	Steps []MachineDeploymentCanaryStep `json:"steps,omitempty"`
+}
+
+// MachineDeploymentCanaryStep defines a step of a canary rollout.
+type MachineDeploymentCanaryStep struct {
+	// replicas is the number of replicas of the new MachineSet at the end of this step.
+	// Value can be an absolute number (ex: 1) or a percentage of desired machines (ex: 25%).
+	// Absolute number is calculated from percentage by rounding up.
+	// +required
+	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+
+	// gates defines the checks that must pass before proceeding to the next step.
+	// If not set, the rollout proceeds to the next step as soon as the replicas of the new MachineSet are available.
+	// +optional
+	Gates MachineDeploymentCanaryStepGates `json:"gates,omitempty,omitzero"`
+}
+
+// MachineDeploymentCanaryStepGates defines the checks that must pass before proceeding to the next canary step.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentCanaryStepGates struct {
+	// machineConditions defines conditions that must be set with the given status on all the Machines of the new MachineSet.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	MachineConditions []MachineDeploymentCanaryMachineConditionGate `json:"machineConditions,omitempty"`
+
+	// nodeLabels defines labels that must be set with the given value on the Nodes of all the Machines of the new MachineSet.
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	NodeLabels []MachineDeploymentCanaryNodeLabelGate `json:"nodeLabels,omitempty"`
+
+	// extensionHandlers defines the names of the runtime extension handlers implementing the AnalyzeMachineDeploymentCanaryStep hook
+	// that must return a successful response without requesting a retry.
+	// Note: This field can be used only if the RuntimeSDK feature flag is enabled.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=512
+	ExtensionHandlers []string `json:"extensionHandlers,omitempty"`
+}
+
+// MachineDeploymentCanaryMachineConditionGate defines a condition that must be set on Machines.
+type MachineDeploymentCanaryMachineConditionGate struct {
+	// type of the condition.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	Type string `json:"type,omitempty"`
+
+	// status of the condition. Defaults to True.
+	// +optional
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+}
+
+// MachineDeploymentCanaryNodeLabelGate defines a label that must be set on Nodes.
+type MachineDeploymentCanaryNodeLabelGate struct {
+	// key of the label.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	Key string `json:"key,omitempty"`
+
+	// value of the label. If not set, the gate passes when the label is set, no matter of its value.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	Value string `json:"value,omitempty"`
+}
+
+// MachineDeploymentBlueGreenPromotionType defines how the new MachineSet is promoted during a blue/green rollout.
+// +kubebuilder:validation:Enum=Automatic;Manual
+type MachineDeploymentBlueGreenPromotionType string
+
+const (
+	// AutomaticMachineDeploymentBlueGreenPromotionType promotes the new MachineSet as soon as all its Machines
+	// are available and the soak period is completed.
+	AutomaticMachineDeploymentBlueGreenPromotionType MachineDeploymentBlueGreenPromotionType = "Automatic"
+
+	// ManualMachineDeploymentBlueGreenPromotionType promotes the new MachineSet only after all its Machines
+	// are available, the soak period is completed and the MachineDeployment is annotated with
+	// machinedeployment.clusters.x-k8s.io/blue-green-promote set to the name of the new MachineSet.
+	ManualMachineDeploymentBlueGreenPromotionType MachineDeploymentBlueGreenPromotionType = "Manual"
+)
+
+// MachineDeploymentRolloutStrategyBlueGreen is used to control the desired behavior of blue/green rollouts.
+// With blue/green rollouts, the new MachineSet is scaled up to the desired number of replicas while the old
+// MachineSets keep serving; once all the new Machines are available and the new MachineSet is promoted, all the old
+// MachineSets are scaled down at once, and their Machines are cordoned, drained and deleted.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStrategyBlueGreen struct {
+	// soakSeconds is the number of seconds all the Machines of the new MachineSet must be continuously
+	// available before the new MachineSet is promoted.
+	// Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SoakSeconds *int32 `json:"soakSeconds,omitempty"`
+
+	// promotion defines how the new MachineSet is promoted. Allowed values are Automatic and Manual.
+	// With Manual promotion, the new MachineSet is promoted only after the MachineDeployment is annotated with
+	// machinedeployment.clusters.x-k8s.io/blue-green-promote set to the name of the new MachineSet.
+	// Defaults to Automatic.
+	// +optional
+	Promotion MachineDeploymentBlueGreenPromotionType `json:"promotion,omitempty"`
+
+	// rollbackTimeoutSeconds is the maximum number of seconds the new MachineSet can take to have all
+	// its Machines available. If the timeout expires, the rollout is rolled back by scaling down the new MachineSet
+	// to zero while the old MachineSets keep serving.
+	// If not set, the rollout is never rolled back automatically.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RollbackTimeoutSeconds *int32 `json:"rollbackTimeoutSeconds,omitempty"`
 }
 
 // MachineDeploymentRolloutStrategyRollingUpdate is used to control the desired behavior of rolling update.
@@ -423,7 +649,7 @@ type MachineDeploymentDeletionSpec struct {
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentStatus struct {
 	// conditions represents the observations of a MachineDeployment's current state.
-	// Known condition types are Available, MachinesReady, MachinesUpToDate, ScalingUp, ScalingDown, Remediating, Deleting, Paused.
+	// Known condition types are Available, MachinesReady, MachinesUpToDate, RollingOut, RolloutFailed, ScalingUp, ScalingDown, Remediating, Deleting, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -465,11 +691,57 @@ type MachineDeploymentStatus struct {
 	// +kubebuilder:validation:Enum=ScalingUp;ScalingDown;Running;Failed;Unknown
 	Phase string `json:"phase,omitempty"`
 
+	// canary reports the progress of a canary rollout.
+	// It is set only while a rollout using the Canary strategy is in progress.
+	// +optional
+	Canary MachineDeploymentCanaryStatus `json:"canary,omitempty,omitzero"`
+
+	// rollout reports the progress of the current rollout.
+	// It is set only when spec.rollout.progressDeadlineSeconds is set.
+	// +optional
+	Rollout MachineDeploymentRolloutStatus `json:"rollout,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *MachineDeploymentDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// MachineDeploymentCanaryStatus reports the progress of a canary rollout.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentCanaryStatus struct {
+	// currentStep is the canary step in progress, starting from 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// totalSteps is the total number of canary steps, including the final step moving all the remaining replicas
+	// to the new MachineSet when not explicitly defined.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TotalSteps int32 `json:"totalSteps,omitempty"`
+
+	// message describes what the canary step in progress is waiting for, if anything.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Message string `json:"message,omitempty"`
+}
+
+// MachineDeploymentRolloutStatus reports the progress of a MachineDeployment rollout.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStatus struct {
+	// machineSetName is the name of the new MachineSet being rolled out.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	MachineSetName string `json:"machineSetName,omitempty"`
+
+	// lastProgressTime is the last time the rollout of the new MachineSet made progress, i.e. when the MachineSet
+	// became the new MachineSet or when one of its Machines became available.
+	// +optional
+	LastProgressTime metav1.Time `json:"lastProgressTime,omitempty,omitzero"`
+}
+
 // MachineDeploymentDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type MachineDeploymentDeprecatedStatus struct {
@@ -658,6 +658,7 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(WorkersStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	in.CertificatesExpiryDate.DeepCopyInto(&out.CertificatesExpiryDate)
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make([]FailureDomain, len(*in))
@@ -1565,6 +1566,102 @@ func (in *MachineDeployment) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCanaryMachineConditionGate) DeepCopyInto(out *MachineDeploymentCanaryMachineConditionGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCanaryMachineConditionGate.
+func (in *MachineDeploymentCanaryMachineConditionGate) DeepCopy() *MachineDeploymentCanaryMachineConditionGate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCanaryMachineConditionGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCanaryNodeLabelGate) DeepCopyInto(out *MachineDeploymentCanaryNodeLabelGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCanaryNodeLabelGate.
+func (in *MachineDeploymentCanaryNodeLabelGate) DeepCopy() *MachineDeploymentCanaryNodeLabelGate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCanaryNodeLabelGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCanaryStatus) DeepCopyInto(out *MachineDeploymentCanaryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCanaryStatus.
+func (in *MachineDeploymentCanaryStatus) DeepCopy() *MachineDeploymentCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCanaryStep) DeepCopyInto(out *MachineDeploymentCanaryStep) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	in.Gates.DeepCopyInto(&out.Gates)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCanaryStep.
+func (in *MachineDeploymentCanaryStep) DeepCopy() *MachineDeploymentCanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCanaryStepGates) DeepCopyInto(out *MachineDeploymentCanaryStepGates) {
+	*out = *in
+	if in.MachineConditions != nil {
+		in, out := &in.MachineConditions, &out.MachineConditions
+		*out = make([]MachineDeploymentCanaryMachineConditionGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make([]MachineDeploymentCanaryNodeLabelGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtensionHandlers != nil {
+		in, out := &in.ExtensionHandlers, &out.ExtensionHandlers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCanaryStepGates.
+func (in *MachineDeploymentCanaryStepGates) DeepCopy() *MachineDeploymentCanaryStepGates {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCanaryStepGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentClass) DeepCopyInto(out *MachineDeploymentClass) {
 	*out = *in
@@ -1921,6 +2018,11 @@ func (in *MachineDeploymentRolloutSpec) DeepCopyInto(out *MachineDeploymentRollo
 	*out = *in
 	in.After.DeepCopyInto(&out.After)
 	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutSpec.
@@ -1933,10 +2035,28 @@ func (in *MachineDeploymentRolloutSpec) DeepCopy() *MachineDeploymentRolloutSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStatus) DeepCopyInto(out *MachineDeploymentRolloutStatus) {
+	*out = *in
+	in.LastProgressTime.DeepCopyInto(&out.LastProgressTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStatus.
+func (in *MachineDeploymentRolloutStatus) DeepCopy() *MachineDeploymentRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentRolloutStrategy) DeepCopyInto(out *MachineDeploymentRolloutStrategy) {
 	*out = *in
 	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+	in.BlueGreen.DeepCopyInto(&out.BlueGreen)
+	in.Canary.DeepCopyInto(&out.Canary)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategy.
@@ -1949,6 +2069,53 @@ func (in *MachineDeploymentRolloutStrategy) DeepCopy() *MachineDeploymentRollout
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStrategyBlueGreen) DeepCopyInto(out *MachineDeploymentRolloutStrategyBlueGreen) {
+	*out = *in
+	if in.SoakSeconds != nil {
+		in, out := &in.SoakSeconds, &out.SoakSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackTimeoutSeconds != nil {
+		in, out := &in.RollbackTimeoutSeconds, &out.RollbackTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategyBlueGreen.
+func (in *MachineDeploymentRolloutStrategyBlueGreen) DeepCopy() *MachineDeploymentRolloutStrategyBlueGreen {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStrategyBlueGreen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStrategyCanary) DeepCopyInto(out *MachineDeploymentRolloutStrategyCanary) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]MachineDeploymentCanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategyCanary.
+func (in *MachineDeploymentRolloutStrategyCanary) DeepCopy() *MachineDeploymentRolloutStrategyCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStrategyCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentRolloutStrategyRollingUpdate) DeepCopyInto(out *MachineDeploymentRolloutStrategyRollingUpdate) {
 	*out = *in
@@ -2035,6 +2202,8 @@ func (in *MachineDeploymentStatus) DeepCopyInto(out *MachineDeploymentStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	out.Canary = in.Canary
+	in.Rollout.DeepCopyInto(&out.Rollout)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(MachineDeploymentDeprecatedStatus)
@@ -86,6 +86,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeletionSpec":                                      schema_cluster_api_api_core_v1beta2_MachineDeletionSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeletionStatus":                                    schema_cluster_api_api_core_v1beta2_MachineDeletionStatus(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeployment":                                        schema_cluster_api_api_core_v1beta2_MachineDeployment(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryMachineConditionGate":              schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryMachineConditionGate(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryNodeLabelGate":                     schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryNodeLabelGate(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStatus":                            schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStatus(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStep":                              schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStep(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStepGates":                         schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStepGates(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentClass":                                   schema_cluster_api_api_core_v1beta2_MachineDeploymentClass(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentClassBootstrapTemplate":                  schema_cluster_api_api_core_v1beta2_MachineDeploymentClassBootstrapTemplate(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentClassHealthCheck":                        schema_cluster_api_api_core_v1beta2_MachineDeploymentClassHealthCheck(ref),
@@ -103,7 +108,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentList":                                    schema_cluster_api_api_core_v1beta2_MachineDeploymentList(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRemediationSpec":                         schema_cluster_api_api_core_v1beta2_MachineDeploymentRemediationSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutSpec":                             schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutSpec(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStatus":                           schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStatus(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategy":                         schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyBlueGreen":                schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyBlueGreen(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary":                   schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanary(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate":            schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyRollingUpdate(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentSpec":                                    schema_cluster_api_api_core_v1beta2_MachineDeploymentSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentStatus":                                  schema_cluster_api_api_core_v1beta2_MachineDeploymentStatus(ref),
@@ -1301,7 +1309,7 @@ func schema_cluster_api_api_core_v1beta2_ClusterStatus(ref common.ReferenceCallb
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "conditions represents the observations of a Cluster's current state. Known condition types are Available, InfrastructureReady, ControlPlaneInitialized, ControlPlaneAvailable, WorkersAvailable, MachinesReady MachinesUpToDate, RemoteConnectionProbe, ScalingUp, ScalingDown, Remediating, Deleting, Paused. Additionally, a TopologyReconciled condition will be added in case the Cluster is referencing a ClusterClass / defining a managed Topology.",
+							Description: "conditions represents the observations of a Cluster's current state. Known condition types are Available, InfrastructureReady, ControlPlaneInitialized, ControlPlaneAvailable, WorkersAvailable, MachinesReady MachinesUpToDate, RemoteConnectionProbe, ScalingUp, ScalingDown, Remediating, CertificatesExpiring, Deleting, Paused. Additionally, a TopologyReconciled condition will be added in case the Cluster is referencing a ClusterClass / defining a managed Topology.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1332,6 +1340,12 @@ func schema_cluster_api_api_core_v1beta2_ClusterStatus(ref common.ReferenceCallb
 							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.WorkersStatus"),
 						},
 					},
+					"certificatesExpiryDate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "certificatesExpiryDate is the earliest expiry date of the certificates of the Cluster, i.e. the certificates stored in the cluster certificate Secrets, the client certificates of the kubeconfig Secret, and the Machine certificates reported in Machine's status.certificatesExpiryDate.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"failureDomains": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -1378,7 +1392,7 @@ func schema_cluster_api_api_core_v1beta2_ClusterStatus(ref common.ReferenceCallb
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterControlPlaneStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterDeprecatedStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterInitializationStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.FailureDomain", "sigs.k8s.io/cluster-api/api/core/v1beta2.WorkersStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterControlPlaneStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterDeprecatedStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.ClusterInitializationStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.FailureDomain", "sigs.k8s.io/cluster-api/api/core/v1beta2.WorkersStatus"},
 	}
 }
 
@@ -2913,6 +2927,204 @@ func schema_cluster_api_api_core_v1beta2_MachineDeployment(ref common.ReferenceC
 	}
 }
 
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryMachineConditionGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentCanaryMachineConditionGate defines a condition that must be set on Machines.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "type of the condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "status of the condition. Defaults to True.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type"},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryNodeLabelGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentCanaryNodeLabelGate defines a label that must be set on Nodes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "key of the label.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "value of the label. If not set, the gate passes when the label is set, no matter of its value.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentCanaryStatus reports the progress of a canary rollout.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"currentStep": {
+						SchemaProps: spec.SchemaProps{
+							Description: "currentStep is the canary step in progress, starting from 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"totalSteps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "totalSteps is the total number of canary steps, including the final step moving all the remaining replicas to the new MachineSet when not explicitly defined.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "message describes what the canary step in progress is waiting for, if anything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStep(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentCanaryStep defines a step of a canary rollout.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "replicas is the number of replicas of the new MachineSet at the end of this step. Value can be an absolute number (ex: 1) or a percentage of desired machines (ex: 25%). Absolute number is calculated from percentage by rounding up.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"gates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "gates defines the checks that must pass before proceeding to the next step. If not set, the rollout proceeds to the next step as soon as the replicas of the new MachineSet are available.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStepGates"),
+						},
+					},
+				},
+				Required: []string{"replicas"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStepGates"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentCanaryStepGates(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentCanaryStepGates defines the checks that must pass before proceeding to the next canary step.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"machineConditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "machineConditions defines conditions that must be set with the given status on all the Machines of the new MachineSet.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryMachineConditionGate"),
+									},
+								},
+							},
+						},
+					},
+					"nodeLabels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"key",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "nodeLabels defines labels that must be set with the given value on the Nodes of all the Machines of the new MachineSet.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryNodeLabelGate"),
+									},
+								},
+							},
+						},
+					},
+					"extensionHandlers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "extensionHandlers defines the names of the runtime extension handlers implementing the AnalyzeMachineDeploymentCanaryStep hook that must return a successful response without requesting a retry. Note: This field can be used only if the RuntimeSDK feature flag is enabled.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryMachineConditionGate", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryNodeLabelGate"},
+	}
+}
+
 func schema_cluster_api_api_core_v1beta2_MachineDeploymentClass(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3515,6 +3727,20 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutSpec(ref common
 							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategy"),
 						},
 					},
+					"progressDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "progressDeadlineSeconds is the maximum time in seconds for a rollout to make progress before it is considered to be failed. The rollout makes progress when a Machine of the new MachineSet becomes available. When the deadline is exceeded, the RolloutFailed condition is set to true and the action defined in onProgressDeadlineExceeded is taken. If not set, rollouts never fail.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"onProgressDeadlineExceeded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "onProgressDeadlineExceeded defines the action to take when the rollout does not make progress within progressDeadlineSeconds. If set to Fail, the rollout is only marked as failed, and it is retried forever. If set to Rollback, the MachineDeployment's spec.template.spec is reverted to the one of the previous MachineSet revision; rollback is not supported for MachineDeployments managed by a ClusterClass. Defaults to Fail.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -3523,6 +3749,34 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutSpec(ref common
 	}
 }
 
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentRolloutStatus reports the progress of a MachineDeployment rollout.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"machineSetName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "machineSetName is the name of the new MachineSet being rolled out.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastProgressTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastProgressTime is the last time the rollout of the new MachineSet made progress, i.e. when the MachineSet became the new MachineSet or when one of its Machines became available.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3532,7 +3786,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref co
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate.",
+							Description: "type of rollout. Allowed values are RollingUpdate, OnDelete, BlueGreen and Canary. Default is RollingUpdate.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -3544,12 +3798,95 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref co
 							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"),
 						},
 					},
+					"blueGreen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "blueGreen is the blue/green config params. Present only if type = BlueGreen.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyBlueGreen"),
+						},
+					},
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "canary is the canary config params. Present only if type = Canary.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary"),
+						},
+					},
 				},
 				Required: []string{"type"},
 			},
 		},
 		Dependencies: []string{
-			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"},
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyBlueGreen", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyBlueGreen(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentRolloutStrategyBlueGreen is used to control the desired behavior of blue/green rollouts. With blue/green rollouts, the new MachineSet is scaled up to the desired number of replicas while the old MachineSets keep serving; once all the new Machines are available and the new MachineSet is promoted, all the old MachineSets are scaled down at once, and their Machines are cordoned, drained and deleted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"soakSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "soakSeconds is the number of seconds all the Machines of the new MachineSet must be continuously available before the new MachineSet is promoted. Defaults to 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"promotion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "promotion defines how the new MachineSet is promoted. Allowed values are Automatic and Manual. With Manual promotion, the new MachineSet is promoted only after the MachineDeployment is annotated with machinedeployment.clusters.x-k8s.io/blue-green-promote set to the name of the new MachineSet. Defaults to Automatic.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rollbackTimeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "rollbackTimeoutSeconds is the maximum number of seconds the new MachineSet can take to have all its Machines available. If the timeout expires, the rollout is rolled back by scaling down the new MachineSet to zero while the old MachineSets keep serving. If not set, the rollout is never rolled back automatically.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentRolloutStrategyCanary is used to control the desired behavior of canary rollouts. With canary rollouts, replicas are moved from the old MachineSets to the new MachineSet in steps; in each step the new MachineSet is scaled up to the number of replicas defined by the step, and old MachineSets are scaled down once the new replicas are available. The rollout proceeds to the next step only when all the gates of the current step pass. After the last step, all the remaining replicas are moved to the new MachineSet.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"steps": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "steps defines the canary steps.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStep"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"steps"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStep"},
 	}
 }
 
@@ -3676,7 +4013,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentStatus(ref common.Refe
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "conditions represents the observations of a MachineDeployment's current state. Known condition types are Available, MachinesReady, MachinesUpToDate, ScalingUp, ScalingDown, Remediating, Deleting, Paused.",
+							Description: "conditions represents the observations of a MachineDeployment's current state. Known condition types are Available, MachinesReady, MachinesUpToDate, RollingOut, RolloutFailed, ScalingUp, ScalingDown, Remediating, Deleting, Paused.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -3737,6 +4074,20 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentStatus(ref common.Refe
 							Format:      "",
 						},
 					},
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "canary reports the progress of a canary rollout. It is set only while a rollout using the Canary strategy is in progress.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStatus"),
+						},
+					},
+					"rollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "rollout reports the progress of the current rollout. It is set only when spec.rollout.progressDeadlineSeconds is set.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStatus"),
+						},
+					},
 					"deprecated": {
 						SchemaProps: spec.SchemaProps{
 							Description: "deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.",
@@ -3747,7 +4098,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentStatus(ref common.Refe
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentDeprecatedStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentCanaryStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentDeprecatedStatus", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStatus"},
 	}
 }
 
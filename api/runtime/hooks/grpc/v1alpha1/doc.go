@@ -0,0 +1,20 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the protobuf messages used to call Runtime Hooks via gRPC.
+// Messages mirror the corresponding types in sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1.
+// Note: hooks.pb.go is generated from hooks.proto, see the generate-go-protobuf target in the Makefile.
+package v1alpha1
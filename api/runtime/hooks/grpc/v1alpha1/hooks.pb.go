@@ -0,0 +1,744 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: hooks.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Variable mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 Variable.
+type Variable struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the variable.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// value of the variable, JSON encoded.
+	Value         []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variable) Reset() {
+	*x = Variable{}
+	mi := &file_hooks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variable) ProtoMessage() {}
+
+func (x *Variable) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variable.ProtoReflect.Descriptor instead.
+func (*Variable) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Variable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Variable) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// HolderReference mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 HolderReference.
+type HolderReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// api_version of the referenced object.
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// kind of the referenced object.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// namespace of the referenced object.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// name of the referenced object.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// field_path is the path to the field of the object which references the template.
+	FieldPath     string `protobuf:"bytes,5,opt,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HolderReference) Reset() {
+	*x = HolderReference{}
+	mi := &file_hooks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolderReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolderReference) ProtoMessage() {}
+
+func (x *HolderReference) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolderReference.ProtoReflect.Descriptor instead.
+func (*HolderReference) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HolderReference) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *HolderReference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *HolderReference) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HolderReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HolderReference) GetFieldPath() string {
+	if x != nil {
+		return x.FieldPath
+	}
+	return ""
+}
+
+// GeneratePatchesRequest mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 GeneratePatchesRequest.
+type GeneratePatchesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// settings defines key value pairs to be passed to the call.
+	Settings map[string]string `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// variables are global variables for all templates.
+	Variables []*Variable `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	// items is the list of templates to generate patches for.
+	Items         []*GeneratePatchesRequestItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePatchesRequest) Reset() {
+	*x = GeneratePatchesRequest{}
+	mi := &file_hooks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePatchesRequest) ProtoMessage() {}
+
+func (x *GeneratePatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePatchesRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePatchesRequest) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeneratePatchesRequest) GetSettings() map[string]string {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *GeneratePatchesRequest) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *GeneratePatchesRequest) GetItems() []*GeneratePatchesRequestItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// GeneratePatchesRequestItem mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 GeneratePatchesRequestItem.
+type GeneratePatchesRequestItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uid is used to correlate the patch with the template.
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// holder_reference is a reference to the object where the template is used.
+	HolderReference *HolderReference `protobuf:"bytes,2,opt,name=holder_reference,json=holderReference,proto3" json:"holder_reference,omitempty"`
+	// object contains the template as a JSON encoded Kubernetes object.
+	Object []byte `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	// variables are variables specific for the current template.
+	Variables     []*Variable `protobuf:"bytes,4,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePatchesRequestItem) Reset() {
+	*x = GeneratePatchesRequestItem{}
+	mi := &file_hooks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePatchesRequestItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePatchesRequestItem) ProtoMessage() {}
+
+func (x *GeneratePatchesRequestItem) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePatchesRequestItem.ProtoReflect.Descriptor instead.
+func (*GeneratePatchesRequestItem) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GeneratePatchesRequestItem) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *GeneratePatchesRequestItem) GetHolderReference() *HolderReference {
+	if x != nil {
+		return x.HolderReference
+	}
+	return nil
+}
+
+func (x *GeneratePatchesRequestItem) GetObject() []byte {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *GeneratePatchesRequestItem) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+// GeneratePatchesResponse mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 GeneratePatchesResponse.
+type GeneratePatchesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status of the call. One of "Success" or "Failure".
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// message is a human-readable description of the status of the call.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// items is the list of generated patches.
+	Items         []*GeneratePatchesResponseItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePatchesResponse) Reset() {
+	*x = GeneratePatchesResponse{}
+	mi := &file_hooks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePatchesResponse) ProtoMessage() {}
+
+func (x *GeneratePatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePatchesResponse.ProtoReflect.Descriptor instead.
+func (*GeneratePatchesResponse) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GeneratePatchesResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GeneratePatchesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GeneratePatchesResponse) GetItems() []*GeneratePatchesResponseItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// GeneratePatchesResponseItem mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 GeneratePatchesResponseItem.
+type GeneratePatchesResponseItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uid identifies the corresponding template in the request on which the patch should be applied.
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// patch_type defines the type of the patch. One of "JSONPatch" or "JSONMergePatch".
+	PatchType string `protobuf:"bytes,2,opt,name=patch_type,json=patchType,proto3" json:"patch_type,omitempty"`
+	// patch contains the patch which should be applied to the template.
+	Patch         []byte `protobuf:"bytes,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePatchesResponseItem) Reset() {
+	*x = GeneratePatchesResponseItem{}
+	mi := &file_hooks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePatchesResponseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePatchesResponseItem) ProtoMessage() {}
+
+func (x *GeneratePatchesResponseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePatchesResponseItem.ProtoReflect.Descriptor instead.
+func (*GeneratePatchesResponseItem) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GeneratePatchesResponseItem) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *GeneratePatchesResponseItem) GetPatchType() string {
+	if x != nil {
+		return x.PatchType
+	}
+	return ""
+}
+
+func (x *GeneratePatchesResponseItem) GetPatch() []byte {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+// ValidateTopologyRequest mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 ValidateTopologyRequest.
+type ValidateTopologyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// settings defines key value pairs to be passed to the call.
+	Settings map[string]string `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// variables are global variables for all templates.
+	Variables []*Variable `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	// items is the list of templates to validate.
+	Items         []*ValidateTopologyRequestItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTopologyRequest) Reset() {
+	*x = ValidateTopologyRequest{}
+	mi := &file_hooks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTopologyRequest) ProtoMessage() {}
+
+func (x *ValidateTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTopologyRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidateTopologyRequest) GetSettings() map[string]string {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *ValidateTopologyRequest) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *ValidateTopologyRequest) GetItems() []*ValidateTopologyRequestItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ValidateTopologyRequestItem mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 ValidateTopologyRequestItem.
+type ValidateTopologyRequestItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// holder_reference is a reference to the object where the template is used.
+	HolderReference *HolderReference `protobuf:"bytes,1,opt,name=holder_reference,json=holderReference,proto3" json:"holder_reference,omitempty"`
+	// object contains the template as a JSON encoded Kubernetes object.
+	Object []byte `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// variables are variables specific for the current template.
+	Variables     []*Variable `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTopologyRequestItem) Reset() {
+	*x = ValidateTopologyRequestItem{}
+	mi := &file_hooks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTopologyRequestItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTopologyRequestItem) ProtoMessage() {}
+
+func (x *ValidateTopologyRequestItem) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTopologyRequestItem.ProtoReflect.Descriptor instead.
+func (*ValidateTopologyRequestItem) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValidateTopologyRequestItem) GetHolderReference() *HolderReference {
+	if x != nil {
+		return x.HolderReference
+	}
+	return nil
+}
+
+func (x *ValidateTopologyRequestItem) GetObject() []byte {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ValidateTopologyRequestItem) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+// ValidateTopologyResponse mirrors hooks.runtime.cluster.x-k8s.io/v1alpha1 ValidateTopologyResponse.
+type ValidateTopologyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status of the call. One of "Success" or "Failure".
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// message is a human-readable description of the status of the call.
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTopologyResponse) Reset() {
+	*x = ValidateTopologyResponse{}
+	mi := &file_hooks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTopologyResponse) ProtoMessage() {}
+
+func (x *ValidateTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hooks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTopologyResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_hooks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidateTopologyResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ValidateTopologyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_hooks_proto protoreflect.FileDescriptor
+
+const file_hooks_proto_rawDesc = "" +
+	"\n" +
+	"\vhooks.proto\x12'cluster_x_k8s_io.runtime.hooks.v1alpha1\"4\n" +
+	"\bVariable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"\x97\x01\n" +
+	"\x0fHolderReference\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"field_path\x18\x05 \x01(\tR\tfieldPath\"\xec\x02\n" +
+	"\x16GeneratePatchesRequest\x12i\n" +
+	"\bsettings\x18\x01 \x03(\v2M.cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.SettingsEntryR\bsettings\x12O\n" +
+	"\tvariables\x18\x02 \x03(\v21.cluster_x_k8s_io.runtime.hooks.v1alpha1.VariableR\tvariables\x12Y\n" +
+	"\x05items\x18\x03 \x03(\v2C.cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequestItemR\x05items\x1a;\n" +
+	"\rSettingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfc\x01\n" +
+	"\x1aGeneratePatchesRequestItem\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12c\n" +
+	"\x10holder_reference\x18\x02 \x01(\v28.cluster_x_k8s_io.runtime.hooks.v1alpha1.HolderReferenceR\x0fholderReference\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\fR\x06object\x12O\n" +
+	"\tvariables\x18\x04 \x03(\v21.cluster_x_k8s_io.runtime.hooks.v1alpha1.VariableR\tvariables\"\xa7\x01\n" +
+	"\x17GeneratePatchesResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12Z\n" +
+	"\x05items\x18\x03 \x03(\v2D.cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesResponseItemR\x05items\"d\n" +
+	"\x1bGeneratePatchesResponseItem\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x1d\n" +
+	"\n" +
+	"patch_type\x18\x02 \x01(\tR\tpatchType\x12\x14\n" +
+	"\x05patch\x18\x03 \x01(\fR\x05patch\"\xef\x02\n" +
+	"\x17ValidateTopologyRequest\x12j\n" +
+	"\bsettings\x18\x01 \x03(\v2N.cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.SettingsEntryR\bsettings\x12O\n" +
+	"\tvariables\x18\x02 \x03(\v21.cluster_x_k8s_io.runtime.hooks.v1alpha1.VariableR\tvariables\x12Z\n" +
+	"\x05items\x18\x03 \x03(\v2D.cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequestItemR\x05items\x1a;\n" +
+	"\rSettingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xeb\x01\n" +
+	"\x1bValidateTopologyRequestItem\x12c\n" +
+	"\x10holder_reference\x18\x01 \x01(\v28.cluster_x_k8s_io.runtime.hooks.v1alpha1.HolderReferenceR\x0fholderReference\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\fR\x06object\x12O\n" +
+	"\tvariables\x18\x03 \x03(\v21.cluster_x_k8s_io.runtime.hooks.v1alpha1.VariableR\tvariables\"L\n" +
+	"\x18ValidateTopologyResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessageB9Z7sigs.k8s.io/cluster-api/api/runtime/hooks/grpc/v1alpha1b\x06proto3"
+
+var (
+	file_hooks_proto_rawDescOnce sync.Once
+	file_hooks_proto_rawDescData []byte
+)
+
+func file_hooks_proto_rawDescGZIP() []byte {
+	file_hooks_proto_rawDescOnce.Do(func() {
+		file_hooks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hooks_proto_rawDesc), len(file_hooks_proto_rawDesc)))
+	})
+	return file_hooks_proto_rawDescData
+}
+
+var file_hooks_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hooks_proto_goTypes = []any{
+	(*Variable)(nil),                    // 0: cluster_x_k8s_io.runtime.hooks.v1alpha1.Variable
+	(*HolderReference)(nil),             // 1: cluster_x_k8s_io.runtime.hooks.v1alpha1.HolderReference
+	(*GeneratePatchesRequest)(nil),      // 2: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest
+	(*GeneratePatchesRequestItem)(nil),  // 3: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequestItem
+	(*GeneratePatchesResponse)(nil),     // 4: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesResponse
+	(*GeneratePatchesResponseItem)(nil), // 5: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesResponseItem
+	(*ValidateTopologyRequest)(nil),     // 6: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest
+	(*ValidateTopologyRequestItem)(nil), // 7: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequestItem
+	(*ValidateTopologyResponse)(nil),    // 8: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyResponse
+	nil,                                 // 9: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.SettingsEntry
+	nil,                                 // 10: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.SettingsEntry
+}
+var file_hooks_proto_depIdxs = []int32{
+	9,  // 0: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.settings:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.SettingsEntry
+	0,  // 1: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.variables:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.Variable
+	3,  // 2: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequest.items:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequestItem
+	1,  // 3: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequestItem.holder_reference:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.HolderReference
+	0,  // 4: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesRequestItem.variables:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.Variable
+	5,  // 5: cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesResponse.items:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.GeneratePatchesResponseItem
+	10, // 6: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.settings:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.SettingsEntry
+	0,  // 7: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.variables:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.Variable
+	7,  // 8: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequest.items:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequestItem
+	1,  // 9: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequestItem.holder_reference:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.HolderReference
+	0,  // 10: cluster_x_k8s_io.runtime.hooks.v1alpha1.ValidateTopologyRequestItem.variables:type_name -> cluster_x_k8s_io.runtime.hooks.v1alpha1.Variable
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_hooks_proto_init() }
+func file_hooks_proto_init() {
+	if File_hooks_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hooks_proto_rawDesc), len(file_hooks_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_hooks_proto_goTypes,
+		DependencyIndexes: file_hooks_proto_depIdxs,
+		MessageInfos:      file_hooks_proto_msgTypes,
+	}.Build()
+	File_hooks_proto = out.File
+	file_hooks_proto_goTypes = nil
+	file_hooks_proto_depIdxs = nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// AnalyzeMachineDeploymentCanaryStepRequest is the request of the AnalyzeMachineDeploymentCanaryStep hook.
+// +kubebuilder:object:root=true
+type AnalyzeMachineDeploymentCanaryStepRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machineDeployment is the MachineDeployment being rolled out.
+	// +required
+	MachineDeployment clusterv1.MachineDeployment `json:"machineDeployment,omitempty,omitzero"`
+
+	// machineSet is the new MachineSet of the MachineDeployment.
+	// +required
+	MachineSet clusterv1.MachineSet `json:"machineSet,omitempty,omitzero"`
+
+	// step is the canary step being analyzed, starting from 1.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	Step int32 `json:"step,omitempty"`
+}
+
+var _ RetryResponseObject = &AnalyzeMachineDeploymentCanaryStepResponse{}
+
+// AnalyzeMachineDeploymentCanaryStepResponse is the response of the AnalyzeMachineDeploymentCanaryStep hook.
+// The result of the analysis is determined by the CommonRetryResponse fields:
+// - Status=Success + RetryAfterSeconds > 0: analysis is in progress, the rollout must not proceed to the next step
+// - Status=Success + RetryAfterSeconds = 0: analysis completed successfully, the rollout can proceed to the next step
+// - Status=Failure: analysis failed
+// +kubebuilder:object:root=true
+type AnalyzeMachineDeploymentCanaryStepResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// AnalyzeMachineDeploymentCanaryStep is the hook that will be called to determine if a MachineDeployment
+// using the Canary rollout strategy can proceed to the next canary step.
+func AnalyzeMachineDeploymentCanaryStep(*AnalyzeMachineDeploymentCanaryStepRequest, *AnalyzeMachineDeploymentCanaryStepResponse) {
+}
+
+func init() {
+	catalogBuilder.RegisterHook(AnalyzeMachineDeploymentCanaryStep, &runtimecatalog.HookMeta{
+		Tags:    []string{"MachineDeployment Rollout Hooks"},
+		Summary: "Cluster API Runtime will call this hook to determine if a canary rollout can proceed to the next step",
+		Description: "Cluster API Runtime will call this hook when all the replicas of the new MachineSet for the current canary step " +
+			"are available, and only for the extension handlers listed in the gates of the step.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The hook will be called repeatedly until it returns a successful response without requesting a retry\n" +
+			"- This hook must be idempotent - it can be called multiple times for the same step\n",
+	})
+}
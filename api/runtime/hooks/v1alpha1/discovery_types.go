@@ -46,6 +46,12 @@ type DiscoveryResponse struct {
 	// +listMapKey=name
 	// +optional
 	Handlers []ExtensionHandler `json:"handlers,omitempty"`
+
+	// transports defines the transports supported by an Extension to call ExtensionHandlers.
+	// HTTPS is always supported; this is defaulted to [HTTPS] if left undefined.
+	// +listType=set
+	// +optional
+	Transports []Transport `json:"transports,omitempty"`
 }
 
 // ExtensionHandler represents the discovery information for an extension handler which includes
@@ -96,6 +102,18 @@ const (
 	FailurePolicyFail FailurePolicy = "Fail"
 )
 
+// Transport specifies the transport used to call ExtensionHandlers.
+type Transport string
+
+const (
+	// TransportHTTPS means that ExtensionHandlers are called by sending JSON requests via HTTPS.
+	TransportHTTPS Transport = "HTTPS"
+
+	// TransportGRPC means that ExtensionHandlers of Runtime Hooks with a protobuf definition are called by sending
+	// protobuf requests via gRPC, while all the other ExtensionHandlers are called via HTTPS.
+	TransportGRPC Transport = "GRPC"
+)
+
 // Discovery represents the discovery hook.
 func Discovery(*DiscoveryRequest, *DiscoveryResponse) {}
 
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// BeforeMachineCreateRequest is the request of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineCreateResponse{}
+
+// BeforeMachineCreateResponse is the response of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineCreate is the hook that will be called after a Machine is created and before
+// the provisioning of the Machine starts.
+func BeforeMachineCreate(*BeforeMachineCreateRequest, *BeforeMachineCreateResponse) {}
+
+// AfterMachineReadyRequest is the request of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine"`
+}
+
+var _ ResponseObject = &AfterMachineReadyResponse{}
+
+// AfterMachineReadyResponse is the response of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterMachineReady is the hook that will be called after the Node hosted on a Machine is ready for the first time.
+func AfterMachineReady(*AfterMachineReadyRequest, *AfterMachineReadyResponse) {}
+
+// BeforeMachineDeleteRequest is the request of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeleteResponse{}
+
+// BeforeMachineDeleteResponse is the response of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDelete is the hook that will be called after delete is issued on a Machine
+// and before the Node hosted on the Machine is drained.
+func BeforeMachineDelete(*BeforeMachineDeleteRequest, *BeforeMachineDeleteResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(BeforeMachineCreate, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a Machine is provisioned",
+		Description: "Cluster API Runtime will call this hook after a Machine is created and before the Machine's " +
+			"BootstrapConfig and InfrastructureMachine are provisioned.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called for all the Machines, including Machines controlled by MachineSets and control plane providers\n" +
+			"- This hook will not be called for Machines existing before the RuntimeSDK feature gate was enabled\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the Machine is provisioned, e.g. registering the Machine in an external inventory",
+	})
+
+	catalogBuilder.RegisterHook(AfterMachineReady, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after the Node hosted on a Machine is ready for the first time",
+		Description: "Cluster API Runtime will call this hook after the Node hosted on a Machine reports the Ready condition " +
+			"for the first time.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines for which BeforeMachineCreate was called\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a non-blocking hook; it will be called until all the Runtime Extensions return a successful response",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDelete, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a Machine is deleted",
+		Description: "Cluster API Runtime will call this hook after the Machine deletion has been triggered, " +
+			"and immediately before the Node hosted on the Machine is drained.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called before the pre-drain and pre-terminate delete hooks defined via annotations\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the Machine is drained and deleted, e.g. evacuating storage",
+	})
+}
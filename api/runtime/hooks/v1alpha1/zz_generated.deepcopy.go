@@ -188,6 +188,58 @@ func (in *AfterControlPlaneUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineReadyRequest) DeepCopyInto(out *AfterMachineReadyRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineReadyRequest.
+func (in *AfterMachineReadyRequest) DeepCopy() *AfterMachineReadyRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineReadyRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineReadyRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineReadyResponse) DeepCopyInto(out *AfterMachineReadyResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineReadyResponse.
+func (in *AfterMachineReadyResponse) DeepCopy() *AfterMachineReadyResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineReadyResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineReadyResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AfterWorkersUpgradeRequest) DeepCopyInto(out *AfterWorkersUpgradeRequest) {
 	*out = *in
@@ -249,6 +301,58 @@ func (in *AfterWorkersUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalyzeMachineDeploymentCanaryStepRequest) DeepCopyInto(out *AnalyzeMachineDeploymentCanaryStepRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.MachineDeployment.DeepCopyInto(&out.MachineDeployment)
+	in.MachineSet.DeepCopyInto(&out.MachineSet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyzeMachineDeploymentCanaryStepRequest.
+func (in *AnalyzeMachineDeploymentCanaryStepRequest) DeepCopy() *AnalyzeMachineDeploymentCanaryStepRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalyzeMachineDeploymentCanaryStepRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnalyzeMachineDeploymentCanaryStepRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalyzeMachineDeploymentCanaryStepResponse) DeepCopyInto(out *AnalyzeMachineDeploymentCanaryStepResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyzeMachineDeploymentCanaryStepResponse.
+func (in *AnalyzeMachineDeploymentCanaryStepResponse) DeepCopy() *AnalyzeMachineDeploymentCanaryStepResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalyzeMachineDeploymentCanaryStepResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnalyzeMachineDeploymentCanaryStepResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeforeClusterCreateRequest) DeepCopyInto(out *BeforeClusterCreateRequest) {
 	*out = *in
@@ -473,6 +577,110 @@ func (in *BeforeControlPlaneUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineCreateRequest) DeepCopyInto(out *BeforeMachineCreateRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineCreateRequest.
+func (in *BeforeMachineCreateRequest) DeepCopy() *BeforeMachineCreateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineCreateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineCreateRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineCreateResponse) DeepCopyInto(out *BeforeMachineCreateResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineCreateResponse.
+func (in *BeforeMachineCreateResponse) DeepCopy() *BeforeMachineCreateResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineCreateResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineCreateResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeleteRequest) DeepCopyInto(out *BeforeMachineDeleteRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeleteRequest.
+func (in *BeforeMachineDeleteRequest) DeepCopy() *BeforeMachineDeleteRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeleteRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeleteRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeleteResponse) DeepCopyInto(out *BeforeMachineDeleteResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeleteResponse.
+func (in *BeforeMachineDeleteResponse) DeepCopy() *BeforeMachineDeleteResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeleteResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeleteResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeforeWorkersUpgradeRequest) DeepCopyInto(out *BeforeWorkersUpgradeRequest) {
 	*out = *in
@@ -1013,6 +1221,11 @@ func (in *DiscoveryResponse) DeepCopyInto(out *DiscoveryResponse) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Transports != nil {
+		in, out := &in.Transports, &out.Transports
+		*out = make([]Transport, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryResponse.
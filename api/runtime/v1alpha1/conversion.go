@@ -26,12 +26,27 @@ import (
 
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
 
 func (src *ExtensionConfig) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*runtimev1.ExtensionConfig)
 
-	return Convert_v1alpha1_ExtensionConfig_To_v1beta2_ExtensionConfig(src, dst, nil)
+	if err := Convert_v1alpha1_ExtensionConfig_To_v1beta2_ExtensionConfig(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &runtimev1.ExtensionConfig{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.ClientConfig.Transport = restored.Spec.ClientConfig.Transport
+	dst.Spec.CircuitBreaker = restored.Spec.CircuitBreaker
+	dst.Spec.MaxInFlightRequests = restored.Spec.MaxInFlightRequests
+	dst.Status.Transport = restored.Status.Transport
+
+	return nil
 }
 
 func (dst *ExtensionConfig) ConvertFrom(srcRaw conversion.Hub) error {
@@ -48,7 +63,9 @@ func (dst *ExtensionConfig) ConvertFrom(srcRaw conversion.Hub) error {
 		}
 		dst.Status.Handlers[i] = h
 	}
-	return nil
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalDataUnsafeNoCopy(src, dst)
 }
 
 func Convert_v1beta2_ExtensionConfigStatus_To_v1alpha1_ExtensionConfigStatus(in *runtimev1.ExtensionConfigStatus, out *ExtensionConfigStatus, s apimachineryconversion.Scope) error {
@@ -137,6 +154,10 @@ func Convert_v1beta2_ExtensionHandler_To_v1alpha1_ExtensionHandler(in *runtimev1
 	return nil
 }
 
+func Convert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec(in *runtimev1.ExtensionConfigSpec, out *ExtensionConfigSpec, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec(in, out, s)
+}
+
 func Convert_v1alpha1_ClientConfig_To_v1beta2_ClientConfig(in *ClientConfig, out *runtimev1.ClientConfig, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1alpha1_ClientConfig_To_v1beta2_ClientConfig(in, out, s); err != nil {
 		return err
@@ -146,6 +146,7 @@ func autoConvert_v1beta2_ClientConfig_To_v1alpha1_ClientConfig(in *v1beta2.Clien
 	}
 	// WARNING: in.Service requires manual conversion: inconvertible types (sigs.k8s.io/cluster-api/api/runtime/v1beta2.ServiceReference vs *sigs.k8s.io/cluster-api/api/runtime/v1alpha1.ServiceReference)
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	// WARNING: in.Transport requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -243,14 +244,11 @@ func autoConvert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec(in
 	}
 	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.Settings = *(*map[string]string)(unsafe.Pointer(&in.Settings))
+	// WARNING: in.CircuitBreaker requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxInFlightRequests requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec is an autogenerated conversion function.
-func Convert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec(in *v1beta2.ExtensionConfigSpec, out *ExtensionConfigSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_ExtensionConfigSpec_To_v1alpha1_ExtensionConfigSpec(in, out, s)
-}
-
 func autoConvert_v1alpha1_ExtensionConfigStatus_To_v1beta2_ExtensionConfigStatus(in *ExtensionConfigStatus, out *v1beta2.ExtensionConfigStatus, s conversion.Scope) error {
 	if in.Handlers != nil {
 		in, out := &in.Handlers, &out.Handlers
@@ -301,6 +299,7 @@ func autoConvert_v1beta2_ExtensionConfigStatus_To_v1alpha1_ExtensionConfigStatus
 	} else {
 		out.Handlers = nil
 	}
+	// WARNING: in.Transport requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -41,6 +41,45 @@ type ExtensionConfigSpec struct {
 	// Note: Settings can be overridden on the ClusterClass.
 	// +optional
 	Settings map[string]string `json:"settings,omitempty"`
+
+	// circuitBreaker configures a circuit breaker for calls to the ExtensionHandlers of the Extension server.
+	// While the circuit breaker is open, calls to the ExtensionHandlers fail immediately and the failurePolicy
+	// of the ExtensionHandlers applies.
+	// If not set, calls to the ExtensionHandlers are never prevented.
+	// +optional
+	CircuitBreaker CircuitBreaker `json:"circuitBreaker,omitempty,omitzero"`
+
+	// maxInFlightRequests is the maximum number of concurrent calls to the ExtensionHandlers of the Extension server.
+	// Calls exceeding the limit wait for a previous call to complete, up to the timeout of the ExtensionHandler;
+	// if the timeout expires, the call fails and the failurePolicy of the ExtensionHandler applies.
+	// The time spent waiting counts against the timeout of the call.
+	// If not set, the number of concurrent calls is not limited.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlightRequests int32 `json:"maxInFlightRequests,omitempty"`
+}
+
+// CircuitBreaker configures a circuit breaker for calls to the ExtensionHandlers of an Extension server.
+// +kubebuilder:validation:MinProperties=1
+type CircuitBreaker struct {
+	// failureThreshold is the number of consecutive failed calls after which the circuit breaker opens.
+	// Failed calls are calls which could not be completed, e.g. because of a timeout or a connection error;
+	// responses with status Failure are not considered failed calls.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// halfOpenAfterSeconds is the time after which an open circuit breaker lets a single probe call through.
+	// If the probe call succeeds the circuit breaker closes, otherwise it opens again.
+	// Defaults to 30 if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	HalfOpenAfterSeconds int32 `json:"halfOpenAfterSeconds,omitempty"`
+}
+
+// IsDefined returns true if the CircuitBreaker is set.
+func (c *CircuitBreaker) IsDefined() bool {
+	return !reflect.DeepEqual(c, &CircuitBreaker{})
 }
 
 // ClientConfig contains the information to make a client
@@ -81,6 +120,14 @@ type ClientConfig struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=51200
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// transport is the preferred transport to be used to call the ExtensionHandlers of the Extension server.
+	// GRPC is used only if the Extension server reports support for it in the Discovery response, otherwise HTTPS is used.
+	// Note: GRPC is used only for ExtensionHandlers of Runtime Hooks with a protobuf definition, i.e. GeneratePatches
+	// and ValidateTopology; all the other ExtensionHandlers, including Discovery, are always called via HTTPS.
+	// Defaults to HTTPS if not set.
+	// +optional
+	Transport Transport `json:"transport,omitempty"`
 }
 
 // ServiceReference holds a reference to a Kubernetes Service of an Extension server.
@@ -120,7 +167,7 @@ func (r *ServiceReference) IsDefined() bool {
 // +kubebuilder:validation:MinProperties=1
 type ExtensionConfigStatus struct {
 	// conditions represents the observations of a ExtensionConfig's current state.
-	// Known condition types are Discovered, Paused.
+	// Known condition types are Discovered, CircuitBreakerClosed, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -134,6 +181,11 @@ type ExtensionConfigStatus struct {
 	// +kubebuilder:validation:MaxItems=512
 	Handlers []ExtensionHandler `json:"handlers,omitempty"`
 
+	// transport is the transport used to call the ExtensionHandlers of the Extension server,
+	// as negotiated during discovery.
+	// +optional
+	Transport Transport `json:"transport,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *ExtensionConfigDeprecatedStatus `json:"deprecated,omitempty"`
@@ -215,6 +267,24 @@ const (
 	FailurePolicyFail FailurePolicy = "Fail"
 )
 
+// Transport specifies the transport used to call the ExtensionHandlers of an Extension server.
+// +kubebuilder:validation:Enum=HTTPS;GRPC
+type Transport string
+
+const (
+	// TransportHTTPS means that ExtensionHandlers are called by sending JSON requests via HTTPS.
+	TransportHTTPS Transport = "HTTPS"
+
+	// TransportGRPC means that ExtensionHandlers of Runtime Hooks with a protobuf definition are called by sending
+	// protobuf requests via gRPC, while all the other ExtensionHandlers are called via HTTPS.
+	TransportGRPC Transport = "GRPC"
+
+	// TransportInProcess means that ExtensionHandlers of an in-process Runtime Extension are called directly,
+	// without sending requests over the network.
+	// Note: This value is only reported in recorded calls and traces; it cannot be set on ExtensionConfigs.
+	TransportInProcess Transport = "InProcess"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=extensionconfigs,shortName=ext,scope=Cluster,categories=cluster-api
 // +kubebuilder:subresource:status
@@ -299,6 +369,29 @@ const (
 	ExtensionConfigNotDiscoveredReason = "NotDiscovered"
 )
 
+// ExtensionConfig's CircuitBreakerClosed conditions and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// ExtensionConfigCircuitBreakerClosedCondition is true if calls to the ExtensionHandlers of the runtime extension
+	// are not prevented by the circuit breaker.
+	ExtensionConfigCircuitBreakerClosedCondition = "CircuitBreakerClosed"
+
+	// ExtensionConfigCircuitBreakerClosedReason surfaces that the circuit breaker is closed, i.e. calls to the
+	// ExtensionHandlers of the runtime extension are not prevented.
+	ExtensionConfigCircuitBreakerClosedReason = "Closed"
+
+	// ExtensionConfigCircuitBreakerOpenReason surfaces that the circuit breaker is open, i.e. calls to the
+	// ExtensionHandlers of the runtime extension fail immediately.
+	ExtensionConfigCircuitBreakerOpenReason = "Open"
+
+	// ExtensionConfigCircuitBreakerHalfOpenReason surfaces that the circuit breaker is half-open, i.e. a single
+	// probe call to the ExtensionHandlers of the runtime extension is allowed, while other calls fail immediately.
+	ExtensionConfigCircuitBreakerHalfOpenReason = "HalfOpen"
+
+	// ExtensionConfigCircuitBreakerNotConfiguredReason surfaces that no circuit breaker is configured, i.e. calls to the
+	// ExtensionHandlers of the runtime extension are never prevented.
+	ExtensionConfigCircuitBreakerNotConfiguredReason = "NotConfigured"
+)
+
 const (
 	// RuntimeExtensionDiscoveredV1Beta1Condition is a condition set on an ExtensionConfig object once it has been discovered by the Runtime SDK client.
 	RuntimeExtensionDiscoveredV1Beta1Condition clusterv1.ConditionType = "Discovered"
@@ -26,6 +26,21 @@ import (
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
+func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
 	*out = *in
@@ -142,6 +157,7 @@ func (in *ExtensionConfigSpec) DeepCopyInto(out *ExtensionConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	out.CircuitBreaker = in.CircuitBreaker
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionConfigSpec.
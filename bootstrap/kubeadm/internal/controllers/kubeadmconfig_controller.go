@@ -347,6 +347,12 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 		return r.handleClusterNotInitialized(ctx, scope)
 	}
 
+	// When the control plane provider is restoring etcd from a snapshot, the control plane is initialized again
+	// with kubeadm init even if the Cluster has been already initialized.
+	if _, ok := config.Annotations[bootstrapv1.KubeadmInitForEtcdRestoreAnnotation]; ok && configOwner.IsControlPlaneMachine() {
+		return r.handleClusterNotInitialized(ctx, scope)
+	}
+
 	// Every other case it's a join scenario
 	// Nb. in this case ClusterConfiguration and InitConfiguration should not be defined by users, but in case of misconfigurations, CABPK simply ignore them
 
@@ -543,6 +543,64 @@ func TestKubeadmConfigReconciler_Reconcile_GenerateCloudConfigData(t *testing.T)
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestKubeadmConfigReconciler_Reconcile_GenerateInitDataForEtcdRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	configName := "control-plane-restore-cfg"
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "validhost", Port: 6443}
+	cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+
+	controlPlaneRestoreMachine := newControlPlaneMachine(cluster, "control-plane-restore-machine")
+	controlPlaneRestoreConfig := newControlPlaneInitKubeadmConfig(controlPlaneRestoreMachine.Namespace, configName)
+	controlPlaneRestoreConfig.Annotations = map[string]string{
+		bootstrapv1.KubeadmInitForEtcdRestoreAnnotation: "snapshot",
+	}
+
+	addKubeadmConfigToMachine(controlPlaneRestoreConfig, controlPlaneRestoreMachine)
+
+	objects := []client.Object{
+		cluster,
+		controlPlaneRestoreMachine,
+		controlPlaneRestoreConfig,
+	}
+	objects = append(objects, createSecrets(t, cluster, controlPlaneRestoreConfig)...)
+
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}).Build()
+
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+	}
+
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      configName,
+		},
+	}
+	result, err := k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
+
+	cfg, err := getKubeadmConfig(myclient, configName, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ptr.Deref(cfg.Status.Initialization.DataSecretCreated, false)).To(BeTrue())
+
+	// Expect bootstrap data for kubeadm init, even if the control plane is already initialized.
+	s := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: configName}, s)).To(Succeed())
+	g.Expect(string(s.Data["value"])).To(ContainSubstring("kubeadm init"))
+
+	// Expect no bootstrap token to be created for kubeadm join.
+	l := &corev1.SecretList{}
+	g.Expect(myclient.List(ctx, l, client.InNamespace(metav1.NamespaceSystem))).To(Succeed())
+	g.Expect(l.Items).To(BeEmpty())
+}
+
 // If a control plane has no JoinConfiguration, then we will create a default and no error will occur.
 func TestKubeadmConfigReconciler_Reconcile_ErrorIfJoiningControlPlaneHasInvalidConfiguration(t *testing.T) {
 	g := NewWithT(t)
@@ -31,6 +31,10 @@ const (
 	// ClusterctlCoreLabelCertManagerValue define the value for ClusterctlCoreLabel to be used for cert-manager objects.
 	ClusterctlCoreLabelCertManagerValue = "cert-manager"
 
+	// ClusterctlCoreLabelRollbackSnapshotValue define the value for ClusterctlCoreLabel to be used for the snapshots
+	// of the providers taken before an upgrade, which are used to roll back the upgrade.
+	ClusterctlCoreLabelRollbackSnapshotValue = "rollback-snapshot"
+
 	// ClusterctlMoveLabel can be set on CRDs that providers wish to move but that are not part of a Cluster.
 	ClusterctlMoveLabel = "clusterctl.cluster.x-k8s.io/move"
 
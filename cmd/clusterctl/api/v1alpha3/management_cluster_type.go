@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ManagementCluster defines the desired state of a management cluster, i.e. the list of providers
+// to be installed, and it is used by clusterctl apply.
+type ManagementCluster struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// providers is the list of providers that should be installed in the management cluster.
+	// Providers installed in the management cluster but not included in this list are deleted.
+	// +optional
+	Providers []ManagementClusterProvider `json:"providers,omitempty"`
+
+	// variables defines values for the variables used in the provider components YAML;
+	// values defined here take precedence over values from environment variables and from the clusterctl config file.
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// ManagementClusterProvider defines the desired state of a provider in the management cluster.
+type ManagementClusterProvider struct {
+	// name of the provider, e.g. `cluster-api`, `kubeadm` or `aws`.
+	// The name must match a provider defined in the clusterctl configuration.
+	Name string `json:"name"`
+
+	// type of the provider.
+	// See ProviderType for a list of supported values.
+	Type ProviderType `json:"type"`
+
+	// version of the provider, e.g. `v1.10.0`.
+	// If empty, the latest release is used when installing the provider, while an already installed
+	// provider is left on its current version.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// namespace where the provider should be installed.
+	// If empty, the provider's default namespace is used when installing the provider.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// featureGates to be set on the provider's controllers, e.g. `ClusterTopology: true`.
+	// Feature gates not listed here are left to the default value of the provider components YAML.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &ManagementCluster{})
+}
@@ -52,6 +52,16 @@ type ReleaseSeries struct {
 	// The value is an API Version, e.g. `v1alpha3`.
 	// +optional
 	Contract string `json:"contract,omitempty"`
+
+	// minKubernetesVersion is the oldest Kubernetes minor version of workload clusters supported by this series,
+	// e.g. `v1.32`; if empty, no lower bound is enforced when upgrading to this series.
+	// +optional
+	MinKubernetesVersion string `json:"minKubernetesVersion,omitempty"`
+
+	// maxKubernetesVersion is the newest Kubernetes minor version of workload clusters supported by this series,
+	// e.g. `v1.35`; if empty, no upper bound is enforced when upgrading to this series.
+	// +optional
+	MaxKubernetesVersion string `json:"maxKubernetesVersion,omitempty"`
 }
 
 func (rs ReleaseSeries) newer(release ReleaseSeries) bool {
@@ -19,14 +19,20 @@ package alpha
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/compare"
 )
 
 // getMachineDeployment retrieves the MachineDeployment object corresponding to the name and namespace specified.
@@ -73,3 +79,101 @@ func patchMachineDeployment(ctx context.Context, proxy cluster.Proxy, name, name
 	}
 	return nil
 }
+
+// getMachineSetsForDeployment returns a list of MachineSets associated with a MachineDeployment.
+func getMachineSetsForDeployment(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment) ([]*clusterv1.MachineSet, error) {
+	log := logf.Log
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&md.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get label selector from MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := c.List(ctx, machineSets, client.InNamespace(md.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineSets for MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+
+	filtered := make([]*clusterv1.MachineSet, 0, len(machineSets.Items))
+	for idx := range machineSets.Items {
+		ms := &machineSets.Items[idx]
+
+		// Skip this MachineSet if its controller ref is not pointing to this MachineDeployment.
+		if !metav1.IsControlledBy(ms, md) {
+			log.V(5).Info("Skipping MachineSet, controller ref does not match MachineDeployment", "MachineSet", ms.Name)
+			continue
+		}
+		// Skip this MachineSet unless the selector matches.
+		// Note: If a MachineDeployment with an empty selector creeps in, it should match nothing, not everything.
+		if selector.Empty() || !selector.Matches(labels.Set(ms.Labels)) {
+			log.V(5).Info("Skipping MachineSet, label mismatch", "MachineSet", ms.Name)
+			continue
+		}
+		filtered = append(filtered, ms)
+	}
+	return filtered, nil
+}
+
+// getMachineDeploymentRevisions returns the revisions of a MachineDeployment sorted by revision number.
+// Note: MachineSets without a valid revision annotation are ignored.
+func getMachineDeploymentRevisions(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment) ([]RolloutRevision, error) {
+	msList, err := getMachineSetsForDeployment(ctx, proxy, md)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]RolloutRevision, 0, len(msList))
+	for _, ms := range msList {
+		v, err := mdutil.Revision(ms)
+		if err != nil || v == 0 {
+			continue
+		}
+		revisions = append(revisions, RolloutRevision{Revision: v, MachineSet: ms})
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+
+	for i := 1; i < len(revisions); i++ {
+		diff, err := machineSetTemplateDiff(revisions[i-1].MachineSet, revisions[i].MachineSet)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute template diff for MachineDeployment %s/%s revision %d", md.Namespace, md.Name, revisions[i].Revision)
+		}
+		revisions[i].TemplateDiff = diff
+	}
+	return revisions, nil
+}
+
+// machineSetTemplateDiff returns the diff between the Machine templates of two MachineSets, excluding the label used to identify MachineSets.
+func machineSetTemplateDiff(from, to *clusterv1.MachineSet) (string, error) {
+	fromTemplate := from.Spec.Template.DeepCopy()
+	delete(fromTemplate.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	toTemplate := to.Spec.Template.DeepCopy()
+	delete(toTemplate.Labels, clusterv1.MachineDeploymentUniqueLabel)
+
+	_, diff, err := compare.Diff(fromTemplate, toTemplate)
+	return diff, err
+}
+
+// findMachineDeploymentRevision finds the MachineSet for a specific revision; if toRevision is 0,
+// the MachineSet for the revision before the latest one is returned.
+func findMachineDeploymentRevision(toRevision int64, revisions []RolloutRevision) (*clusterv1.MachineSet, error) {
+	if toRevision > 0 {
+		for _, r := range revisions {
+			if r.Revision == toRevision {
+				return r.MachineSet, nil
+			}
+		}
+		return nil, errors.Errorf("unable to find specified MachineDeployment revision: %v", toRevision)
+	}
+
+	// Note: revisions are sorted by revision number.
+	if len(revisions) < 2 {
+		return nil, errors.New("no rollout history found for MachineDeployment")
+	}
+	return revisions[len(revisions)-2].MachineSet, nil
+}
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 )
 
@@ -36,11 +37,43 @@ var validResourceTypes = []string{
 	KubeadmControlPlane,
 }
 
+var validHistoryResourceTypes = []string{
+	MachineDeployment,
+}
+
 // Rollout defines the behavior of a rollout implementation.
 type Rollout interface {
 	ObjectRestarter(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectPauser(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectResumer(context.Context, cluster.Proxy, corev1.ObjectReference) error
+	ObjectRollbacker(context.Context, cluster.Proxy, corev1.ObjectReference, int64) error
+	ObjectHistory(context.Context, cluster.Proxy, corev1.ObjectReference) ([]RolloutRevision, error)
+	ObjectStatus(context.Context, cluster.Proxy, corev1.ObjectReference) (*RolloutStatus, error)
+}
+
+// RolloutRevision is a revision in the rollout history of a cluster-api resource.
+type RolloutRevision struct {
+	// Revision is the revision number.
+	Revision int64
+
+	// MachineSet is the MachineSet corresponding to the revision.
+	MachineSet *clusterv1.MachineSet
+
+	// TemplateDiff is the diff between the Machine template of the previous revision and the Machine template of this revision.
+	// Note: TemplateDiff is empty for the oldest revision.
+	TemplateDiff string
+}
+
+// RolloutStatus is the status of the rollout of a cluster-api resource.
+type RolloutStatus struct {
+	// Done is true when the rollout is completed.
+	Done bool
+
+	// Failed is true when the rollout failed.
+	Failed bool
+
+	// Message describes the current status of the rollout.
+	Message string
 }
 
 var _ Rollout = &rollout{}
@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// ObjectHistory returns the rollout history of the specified cluster-api resource, sorted by revision.
+// Note: Only revisions corresponding to existing MachineSets are returned; MachineSets scaled down to zero
+// are deleted when a rollout completes, and thus they are not part of the history anymore.
+func (r *rollout) ObjectHistory(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) ([]RolloutRevision, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		return getMachineDeploymentRevisions(ctx, proxy, deployment)
+	default:
+		return nil, errors.Errorf("Invalid resource type %q, valid values are %v", ref.Kind, validHistoryResourceTypes)
+	}
+}
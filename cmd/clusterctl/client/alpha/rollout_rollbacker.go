@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// ObjectRollbacker will issue a rollback on the specified cluster-api resource.
+func (r *rollout) ObjectRollbacker(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference, toRevision int64) error {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		if ptr.Deref(deployment.Spec.Paused, false) {
+			return errors.Errorf("can't rollback paused MachineDeployment (run rollout resume first): %v/%v", ref.Kind, ref.Name)
+		}
+		if _, ok := deployment.Labels[clusterv1.ClusterTopologyOwnedLabel]; ok {
+			return errors.Errorf("can't rollback MachineDeployment managed by a ClusterClass (change the Cluster topology instead): %v/%v", ref.Kind, ref.Name)
+		}
+		if err := rollbackMachineDeployment(ctx, proxy, deployment, toRevision); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("Invalid resource type %q, valid values are %v", ref.Kind, validHistoryResourceTypes)
+	}
+	return nil
+}
+
+// rollbackMachineDeployment will rollback to a previous MachineSet revision used by this MachineDeployment.
+func rollbackMachineDeployment(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment, toRevision int64) error {
+	log := logf.Log
+
+	if toRevision < 0 {
+		return errors.Errorf("revision number cannot be negative: %v", toRevision)
+	}
+
+	revisions, err := getMachineDeploymentRevisions(ctx, proxy, md)
+	if err != nil {
+		return err
+	}
+	log.V(7).Info("Found MachineDeployment revisions", "count", len(revisions))
+
+	msForRevision, err := findMachineDeploymentRevision(toRevision, revisions)
+	if err != nil {
+		return err
+	}
+	if msForRevision == revisions[len(revisions)-1].MachineSet {
+		log.Info("Skipping rollback, the MachineDeployment is already at the requested revision", "MachineSet", msForRevision.Name)
+		return nil
+	}
+	log.V(7).Info("Found MachineSet for revision", "MachineSet", msForRevision.Name)
+
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	patchHelper, err := patch.NewHelper(md, c)
+	if err != nil {
+		return err
+	}
+
+	// Copy the template of the MachineSet into the MachineDeployment, excluding the label used to identify MachineSets.
+	revMSTemplate := *msForRevision.Spec.Template.DeepCopy()
+	delete(revMSTemplate.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	md.Spec.Template = revMSTemplate
+
+	if err := patchHelper.Patch(ctx, md); err != nil {
+		return errors.Wrapf(err, "failed while patching MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectRollbacker(t *testing.T) {
+	labels := map[string]string{
+		clusterv1.ClusterNameLabel:           "test",
+		clusterv1.MachineDeploymentNameLabel: "test-md-0",
+	}
+	currentVersion := "v1.19.3"
+	rollbackVersion := "v1.19.1"
+	deployment := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: "cluster.x-k8s.io/v1beta2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: "default",
+			UID:       "md-uid",
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: "test",
+			},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "test",
+					Version:     currentVersion,
+					InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+						APIGroup: clusterv1.GroupVersionInfrastructure.Group,
+						Kind:     "InfrastructureMachineTemplate",
+						Name:     "md-template",
+					},
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("data-secret-name"),
+					},
+				},
+			},
+		},
+	}
+	machineSet := func(name, revision, version string) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "MachineSet",
+				APIVersion: "cluster.x-k8s.io/v1beta2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+				},
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:             "test",
+					clusterv1.MachineDeploymentUniqueLabel: name,
+				},
+				Annotations: map[string]string{
+					clusterv1.RevisionAnnotation: revision,
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				ClusterName: "test",
+				Template:    *deployment.Spec.Template.DeepCopy(),
+			},
+		}
+		ms.Spec.Template.Spec.Version = version
+		ms.Spec.Template.Labels = map[string]string{
+			clusterv1.ClusterNameLabel:             "test",
+			clusterv1.MachineDeploymentNameLabel:   "test-md-0",
+			clusterv1.MachineDeploymentUniqueLabel: name,
+		}
+		return ms
+	}
+
+	type fields struct {
+		objs       []client.Object
+		ref        corev1.ObjectReference
+		toRevision int64
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantErr     bool
+		wantVersion string
+	}{
+		{
+			name: "machinedeployment should rollback to the previous revision",
+			fields: fields{
+				objs: []client.Object{
+					deployment,
+					machineSet("ms-rev-1", "1", "v1.18.0"),
+					machineSet("ms-rev-2", "2", rollbackVersion),
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+			},
+			wantErr:     false,
+			wantVersion: rollbackVersion,
+		},
+		{
+			name: "machinedeployment should rollback to the specified revision",
+			fields: fields{
+				objs: []client.Object{
+					deployment,
+					machineSet("ms-rev-1", "1", "v1.18.0"),
+					machineSet("ms-rev-2", "2", rollbackVersion),
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+				toRevision: 1,
+			},
+			wantErr:     false,
+			wantVersion: "v1.18.0",
+		},
+		{
+			name: "machinedeployment should not change when rolling back to the latest revision",
+			fields: fields{
+				objs: []client.Object{
+					deployment,
+					machineSet("ms-rev-2", "2", rollbackVersion),
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+				toRevision: 3,
+			},
+			wantErr:     false,
+			wantVersion: currentVersion,
+		},
+		{
+			name: "rollback should fail if the revision does not exist",
+			fields: fields{
+				objs: []client.Object{
+					deployment,
+					machineSet("ms-rev-2", "2", rollbackVersion),
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+				toRevision: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollback should fail if there is no previous revision",
+			fields: fields{
+				objs: []client.Object{
+					deployment,
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollback should fail for a paused machinedeployment",
+			fields: fields{
+				objs: []client.Object{
+					func() client.Object {
+						md := deployment.DeepCopy()
+						md.Spec.Paused = ptr.To(true)
+						return md
+					}(),
+					machineSet("ms-rev-2", "2", rollbackVersion),
+					machineSet("ms-rev-3", "3", currentVersion),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollback should fail for unsupported resource types",
+			fields: fields{
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "kcp",
+					Namespace: "default",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.fields.objs...)
+			err := r.ObjectRollbacker(context.Background(), proxy, tt.fields.ref, tt.fields.toRevision)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cl, err := proxy.NewClient(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+			md := &clusterv1.MachineDeployment{}
+			g.Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(deployment), md)).To(Succeed())
+			g.Expect(md.Spec.Template.Spec.Version).To(Equal(tt.wantVersion))
+			g.Expect(md.Spec.Template.Labels).ToNot(HaveKey(clusterv1.MachineDeploymentUniqueLabel))
+		})
+	}
+}
+
+func Test_ObjectHistory(t *testing.T) {
+	g := NewWithT(t)
+
+	deployment := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: "cluster.x-k8s.io/v1beta2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: "default",
+			UID:       "md-uid",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+		},
+	}
+	machineSet := func(name, revision string, owned bool) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "MachineSet",
+				APIVersion: "cluster.x-k8s.io/v1beta2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+				Annotations: map[string]string{
+					clusterv1.RevisionAnnotation: revision,
+				},
+			},
+		}
+		if owned {
+			ms.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+			}
+		}
+		return ms
+	}
+
+	withVersion := func(ms *clusterv1.MachineSet, version string) *clusterv1.MachineSet {
+		ms.Spec.Template.Spec.Version = version
+		ms.Spec.Template.Labels = map[string]string{
+			clusterv1.MachineDeploymentUniqueLabel: ms.Name,
+		}
+		return ms
+	}
+
+	proxy := test.NewFakeProxy().WithObjs(
+		deployment,
+		withVersion(machineSet("ms-rev-10", "10", true), "v1.31.0"),
+		withVersion(machineSet("ms-rev-2", "2", true), "v1.30.0"),
+		machineSet("ms-invalid-rev", "foo", true),
+		machineSet("ms-not-owned", "3", false),
+	)
+	r := newRolloutClient()
+	revisions, err := r.ObjectHistory(context.Background(), proxy, corev1.ObjectReference{
+		Kind:      MachineDeployment,
+		Name:      "test-md-0",
+		Namespace: "default",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revisions).To(HaveLen(2))
+	g.Expect(revisions[0].Revision).To(Equal(int64(2)))
+	g.Expect(revisions[0].MachineSet.Name).To(Equal("ms-rev-2"))
+	g.Expect(revisions[1].Revision).To(Equal(int64(10)))
+	g.Expect(revisions[1].MachineSet.Name).To(Equal("ms-rev-10"))
+	g.Expect(revisions[0].TemplateDiff).To(BeEmpty())
+	g.Expect(revisions[1].TemplateDiff).To(MatchRegexp(`-\s+Version:\s+"v1.30.0"`))
+	g.Expect(revisions[1].TemplateDiff).To(MatchRegexp(`\+\s+Version:\s+"v1.31.0"`))
+	g.Expect(revisions[1].TemplateDiff).ToNot(ContainSubstring(clusterv1.MachineDeploymentUniqueLabel))
+
+	_, err = r.ObjectHistory(context.Background(), proxy, corev1.ObjectReference{
+		Kind:      KubeadmControlPlane,
+		Name:      "kcp",
+		Namespace: "default",
+	})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ObjectStatus returns the status of the rollout of the specified cluster-api resource.
+func (r *rollout) ObjectStatus(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) (*RolloutStatus, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		return machineDeploymentRolloutStatus(deployment), nil
+	case KubeadmControlPlane:
+		kcp, err := getKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || kcp == nil {
+			return nil, errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		return kubeadmControlPlaneRolloutStatus(kcp), nil
+	default:
+		return nil, errors.Errorf("Invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
+	}
+}
+
+// machineDeploymentRolloutStatus computes the status of the rollout of a MachineDeployment.
+func machineDeploymentRolloutStatus(md *clusterv1.MachineDeployment) *RolloutStatus {
+	if md.Generation > md.Status.ObservedGeneration {
+		return &RolloutStatus{Message: "Waiting for MachineDeployment spec update to be observed..."}
+	}
+
+	if c := conditions.Get(md, clusterv1.MachineDeploymentRolloutFailedCondition); c != nil && c.Status == metav1.ConditionTrue {
+		return &RolloutStatus{Failed: true, Message: fmt.Sprintf("MachineDeployment %q rollout failed: %s", md.Name, c.Message)}
+	}
+
+	return replicasRolloutStatus("MachineDeployment", md.Name, "machines", ptr.Deref(md.Spec.Replicas, 0), md.Status.Replicas, md.Status.UpToDateReplicas, md.Status.AvailableReplicas)
+}
+
+// kubeadmControlPlaneRolloutStatus computes the status of the rollout of a KubeadmControlPlane.
+// Note: KubeadmControlPlane does not surface rollout failures, so the rollout status is never failed.
+func kubeadmControlPlaneRolloutStatus(kcp *controlplanev1.KubeadmControlPlane) *RolloutStatus {
+	if kcp.Generation > kcp.Status.ObservedGeneration {
+		return &RolloutStatus{Message: "Waiting for KubeadmControlPlane spec update to be observed..."}
+	}
+
+	return replicasRolloutStatus("KubeadmControlPlane", kcp.Name, "control plane machines", ptr.Deref(kcp.Spec.Replicas, 0), kcp.Status.Replicas, kcp.Status.UpToDateReplicas, kcp.Status.AvailableReplicas)
+}
+
+// replicasRolloutStatus computes the status of a rollout from replica counters, mirroring the semantic of kubectl rollout status.
+func replicasRolloutStatus(kind, name, machines string, desiredReplicas int32, replicas, upToDateReplicas, availableReplicas *int32) *RolloutStatus {
+	// Note: Replica counters are not set until the controller computes them for the first time.
+	if replicas == nil || upToDateReplicas == nil || availableReplicas == nil {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q replica counters to be reported...", kind, name)}
+	}
+
+	if *upToDateReplicas < desiredReplicas {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d out of %d new %s have been updated...", kind, name, *upToDateReplicas, desiredReplicas, machines)}
+	}
+	if *replicas > *upToDateReplicas {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d old %s are pending termination...", kind, name, *replicas-*upToDateReplicas, machines)}
+	}
+	if *availableReplicas < *upToDateReplicas {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for %s %q rollout to finish: %d of %d updated %s are available...", kind, name, *availableReplicas, *upToDateReplicas, machines)}
+	}
+	return &RolloutStatus{Done: true, Message: fmt.Sprintf("%s %q successfully rolled out", kind, name)}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectStatus(t *testing.T) {
+	md := func(generation, observedGeneration int64, replicas int32, statusReplicas, upToDateReplicas, availableReplicas *int32, conditions ...metav1.Condition) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "md-1",
+				Generation: generation,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: ptr.To(replicas),
+			},
+			Status: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				Replicas:           statusReplicas,
+				UpToDateReplicas:   upToDateReplicas,
+				AvailableReplicas:  availableReplicas,
+				Conditions:         conditions,
+			},
+		}
+	}
+	kcp := func(generation, observedGeneration int64, replicas int32, statusReplicas, upToDateReplicas, availableReplicas *int32) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "KubeadmControlPlane",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "kcp",
+				Generation: generation,
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Replicas: ptr.To(replicas),
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				ObservedGeneration: observedGeneration,
+				Replicas:           statusReplicas,
+				UpToDateReplicas:   upToDateReplicas,
+				AvailableReplicas:  availableReplicas,
+			},
+		}
+	}
+	mdRef := corev1.ObjectReference{
+		Kind:      MachineDeployment,
+		Name:      "md-1",
+		Namespace: "default",
+	}
+	kcpRef := corev1.ObjectReference{
+		Kind:      KubeadmControlPlane,
+		Name:      "kcp",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name        string
+		obj         client.Object
+		ref         corev1.ObjectReference
+		wantErr     bool
+		wantStatus  *RolloutStatus
+		wantMessage string
+	}{
+		{
+			name:        "machinedeployment spec update not yet observed",
+			obj:         md(2, 1, 3, ptr.To[int32](3), ptr.To[int32](3), ptr.To[int32](3)),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "spec update to be observed",
+		},
+		{
+			name:        "machinedeployment replica counters not yet reported",
+			obj:         md(1, 1, 3, nil, nil, nil),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "replica counters",
+		},
+		{
+			name:        "machinedeployment with machines not yet updated",
+			obj:         md(1, 1, 3, ptr.To[int32](4), ptr.To[int32](1), ptr.To[int32](3)),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "1 out of 3 new machines have been updated",
+		},
+		{
+			name:        "machinedeployment with old machines pending termination",
+			obj:         md(1, 1, 3, ptr.To[int32](4), ptr.To[int32](3), ptr.To[int32](3)),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "1 old machines are pending termination",
+		},
+		{
+			name:        "machinedeployment with updated machines not yet available",
+			obj:         md(1, 1, 3, ptr.To[int32](3), ptr.To[int32](3), ptr.To[int32](2)),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "2 of 3 updated machines are available",
+		},
+		{
+			name:        "machinedeployment rollout completed",
+			obj:         md(1, 1, 3, ptr.To[int32](3), ptr.To[int32](3), ptr.To[int32](3)),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{Done: true},
+			wantMessage: "successfully rolled out",
+		},
+		{
+			name: "machinedeployment rollout failed",
+			obj: md(1, 1, 3, ptr.To[int32](4), ptr.To[int32](1), ptr.To[int32](3), metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRolloutFailedCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentRolloutProgressDeadlineExceededReason,
+				Message: "MachineSet ms-1 did not make progress in 10m0s",
+			}),
+			ref:         mdRef,
+			wantStatus:  &RolloutStatus{Failed: true},
+			wantMessage: "did not make progress",
+		},
+		{
+			name:        "kubeadmcontrolplane with machines not yet updated",
+			obj:         kcp(1, 1, 3, ptr.To[int32](4), ptr.To[int32](1), ptr.To[int32](3)),
+			ref:         kcpRef,
+			wantStatus:  &RolloutStatus{},
+			wantMessage: "1 out of 3 new control plane machines have been updated",
+		},
+		{
+			name:        "kubeadmcontrolplane rollout completed",
+			obj:         kcp(1, 1, 3, ptr.To[int32](3), ptr.To[int32](3), ptr.To[int32](3)),
+			ref:         kcpRef,
+			wantStatus:  &RolloutStatus{Done: true},
+			wantMessage: "successfully rolled out",
+		},
+		{
+			name:    "unknown resource type",
+			obj:     md(1, 1, 3, nil, nil, nil),
+			ref:     corev1.ObjectReference{Kind: "foo", Name: "md-1", Namespace: "default"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.obj)
+			status, err := r.ObjectStatus(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status.Done).To(Equal(tt.wantStatus.Done))
+			g.Expect(status.Failed).To(Equal(tt.wantStatus.Failed))
+			g.Expect(status.Message).To(ContainSubstring(tt.wantMessage))
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// ApplyManagementClusterOptions carries the options supported by ApplyManagementCluster.
+type ApplyManagementClusterOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// ManagementCluster defines the desired state of the management cluster.
+	ManagementCluster clusterctlv1.ManagementCluster
+
+	// DryRun instructs apply to only compute the changes required to reach the desired state, without applying them.
+	DryRun bool
+
+	// WaitProviders instructs apply to wait till the providers are successfully installed or upgraded.
+	WaitProviders bool
+
+	// WaitProviderTimeout sets the timeout per provider install or upgrade.
+	WaitProviderTimeout time.Duration
+
+	// Force instructs apply to proceed with upgrades even if there are workload Clusters
+	// not compatible with the target versions of the providers.
+	Force bool
+}
+
+// ManagementClusterPlan defines the changes required to bring a management cluster to the desired state.
+type ManagementClusterPlan struct {
+	// Install lists the providers to be installed; an empty version means the latest release available.
+	Install []clusterctlv1.Provider
+
+	// Upgrade lists the providers to be upgraded, or to be re-installed with different feature gates.
+	Upgrade []cluster.UpgradeItem
+
+	// Delete lists the providers to be deleted.
+	Delete []clusterctlv1.Provider
+}
+
+// IsEmpty returns true if the management cluster is already in the desired state.
+func (p ManagementClusterPlan) IsEmpty() bool {
+	return len(p.Install) == 0 && len(p.Upgrade) == 0 && len(p.Delete) == 0
+}
+
+// ApplyManagementCluster brings a management cluster to the desired state by installing, upgrading
+// or deleting providers as required.
+func (c *clusterctlClient) ApplyManagementCluster(ctx context.Context, options ApplyManagementClusterOptions) (ManagementClusterPlan, error) {
+	log := logf.Log
+
+	if err := validateManagementCluster(options.ManagementCluster); err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	// Default WaitProviderTimeout as we cannot rely on defaulting in the CLI
+	// when clusterctl is used as a library.
+	if options.WaitProviderTimeout.Nanoseconds() == 0 {
+		options.WaitProviderTimeout = time.Duration(5*60) * time.Second
+	}
+
+	// Variables defined in the management cluster spec take precedence over other sources.
+	for key, value := range options.ManagementCluster.Variables {
+		c.configClient.Variables().Set(key, value)
+	}
+
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	// Ensure the custom resource definitions required by clusterctl are in place.
+	if err := clusterClient.ProviderInventory().EnsureCustomResourceDefinitions(ctx); err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	// Ensure this command only runs against empty management clusters or management clusters with the current Cluster API contract;
+	// temporarily we also allow upgrading from v1beta1 to allow transition to the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx, cluster.AllowCAPINotInstalled{}, cluster.AllowCAPIContract{Contract: clusterv1beta1.GroupVersion.Version}); err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	installedProviders, err := clusterClient.ProviderInventory().List(ctx)
+	if err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	plan, err := planManagementCluster(ctx, clusterClient, options.ManagementCluster, installedProviders)
+	if err != nil {
+		return ManagementClusterPlan{}, err
+	}
+
+	if options.DryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+
+	// Get the components for the providers to be installed before changing the management cluster,
+	// so errors in the spec, e.g. unknown providers or versions, are surfaced as early as possible.
+	installer := clusterClient.ProviderInstaller()
+	for _, provider := range plan.Install {
+		featureGates := desiredProviderFor(options.ManagementCluster, provider).FeatureGates
+		addOptions := addToInstallerOptions{
+			installer:       installer,
+			targetNamespace: provider.Namespace,
+			featureGates:    featureGates,
+			providerList:    installedProviders,
+		}
+		name := provider.ProviderName
+		if provider.Version != "" {
+			name += ":" + provider.Version
+		}
+		if err := c.addToInstaller(ctx, addOptions, provider.GetProviderType(), name); err != nil {
+			return plan, err
+		}
+	}
+
+	upgradeOptions := cluster.UpgradeOptions{
+		WaitProviders:       options.WaitProviders,
+		WaitProviderTimeout: options.WaitProviderTimeout,
+		Force:               options.Force,
+	}
+
+	// Before changing the management cluster, run all the checks on the management cluster resulting by the plan,
+	// so a plan failing validation does not leave the management cluster in a partially applied state.
+	// NOTE: providers to be deleted are not considered, so they do not block upgrades to a new contract version.
+	if len(plan.Upgrade) > 0 {
+		if err := clusterClient.ProviderUpgrader().ValidateCustomPlan(ctx, upgradeOptions, plan.Delete, plan.Upgrade...); err != nil {
+			return plan, err
+		}
+	}
+	if len(plan.Install) > 0 {
+		if err := installer.ValidatePlanned(ctx, plan.Delete, plan.Upgrade); err != nil {
+			return plan, err
+		}
+	}
+
+	// Delete the providers not included in the spec.
+	for _, provider := range plan.Delete {
+		log.Info("Deleting", "Provider", klog.KObj(&provider), "providerVersion", provider.Version)
+		if err := clusterClient.ProviderComponents().Delete(ctx, cluster.DeleteOptions{Provider: provider}); err != nil {
+			return plan, err
+		}
+	}
+
+	if len(plan.Upgrade) > 0 {
+		// Ensures the latest version of cert-manager, as in upgrade apply.
+		if err := clusterClient.CertManager().EnsureLatestVersion(ctx); err != nil {
+			return plan, err
+		}
+
+		if err := clusterClient.ProviderUpgrader().ApplyCustomPlan(ctx, upgradeOptions, plan.Upgrade...); err != nil {
+			return plan, err
+		}
+	}
+
+	if len(plan.Install) > 0 {
+		// Before installing the providers, ensure the cert-manager Webhook is in place.
+		if err := clusterClient.CertManager().EnsureInstalled(ctx); err != nil {
+			return plan, err
+		}
+
+		installOptions := cluster.InstallOptions{
+			WaitProviders:       options.WaitProviders,
+			WaitProviderTimeout: options.WaitProviderTimeout,
+		}
+		if _, err := installer.Install(ctx, installOptions); err != nil {
+			return plan, err
+		}
+	}
+
+	return plan, nil
+}
+
+// planManagementCluster computes the changes required to bring a management cluster from the installed providers to the desired state.
+func planManagementCluster(ctx context.Context, clusterClient cluster.Client, managementCluster clusterctlv1.ManagementCluster, installedProviders *clusterctlv1.ProviderList) (ManagementClusterPlan, error) {
+	plan := ManagementClusterPlan{}
+
+	matched := sets.Set[string]{}
+	for _, desired := range managementCluster.Providers {
+		var installed *clusterctlv1.Provider
+		for i := range installedProviders.Items {
+			if installedProviders.Items[i].ProviderName == desired.Name && installedProviders.Items[i].GetProviderType() == desired.Type {
+				installed = &installedProviders.Items[i]
+				break
+			}
+		}
+
+		if installed == nil {
+			plan.Install = append(plan.Install, clusterctlv1.Provider{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: desired.Namespace,
+					Name:      clusterctlv1.ManifestLabel(desired.Name, desired.Type),
+				},
+				ProviderName: desired.Name,
+				Type:         string(desired.Type),
+				Version:      desired.Version,
+			})
+			continue
+		}
+		matched.Insert(installed.InstanceName())
+
+		if desired.Namespace != "" && desired.Namespace != installed.Namespace {
+			return ManagementClusterPlan{}, errors.Errorf("unable to move the provider %s to the %s namespace: changing the namespace of an installed provider is not supported", installed.InstanceName(), desired.Namespace)
+		}
+
+		nextVersion := ""
+		if desired.Version != "" && desired.Version != installed.Version {
+			desiredVersion, err := version.ParseSemantic(desired.Version)
+			if err != nil {
+				return ManagementClusterPlan{}, errors.Wrapf(err, "failed to parse version for the %s provider", installed.InstanceName())
+			}
+			currentVersion, err := version.ParseSemantic(installed.Version)
+			if err != nil {
+				return ManagementClusterPlan{}, errors.Wrapf(err, "failed to parse current version for the %s provider", installed.InstanceName())
+			}
+			if desiredVersion.LessThan(currentVersion) {
+				return ManagementClusterPlan{}, errors.Errorf("unable to downgrade the provider %s from version %s to version %s: downgrades are not supported, use clusterctl upgrade rollback instead", installed.InstanceName(), installed.Version, desired.Version)
+			}
+			nextVersion = desired.Version
+		}
+
+		// Providers with feature gates different from the desired state are re-installed, using the current version if not upgraded.
+		if nextVersion == "" && len(desired.FeatureGates) > 0 {
+			currentFeatureGates, err := clusterClient.ProviderComponents().GetFeatureGates(ctx, *installed)
+			if err != nil {
+				return ManagementClusterPlan{}, err
+			}
+			for name, enabled := range desired.FeatureGates {
+				if current, ok := currentFeatureGates[name]; !ok || current != enabled {
+					nextVersion = installed.Version
+					break
+				}
+			}
+		}
+
+		if nextVersion != "" {
+			plan.Upgrade = append(plan.Upgrade, cluster.UpgradeItem{
+				Provider:     *installed,
+				NextVersion:  nextVersion,
+				FeatureGates: desired.FeatureGates,
+			})
+		}
+	}
+
+	for _, installed := range installedProviders.Items {
+		if !matched.Has(installed.InstanceName()) {
+			plan.Delete = append(plan.Delete, installed)
+		}
+	}
+
+	return plan, nil
+}
+
+// validateManagementCluster checks the management cluster spec is consistent before computing changes.
+func validateManagementCluster(managementCluster clusterctlv1.ManagementCluster) error {
+	providerTypes := sets.New(
+		clusterctlv1.CoreProviderType,
+		clusterctlv1.BootstrapProviderType,
+		clusterctlv1.ControlPlaneProviderType,
+		clusterctlv1.InfrastructureProviderType,
+		clusterctlv1.IPAMProviderType,
+		clusterctlv1.RuntimeExtensionProviderType,
+		clusterctlv1.AddonProviderType,
+	)
+
+	coreProviders := 0
+	providers := sets.Set[string]{}
+	for _, provider := range managementCluster.Providers {
+		if provider.Name == "" {
+			return errors.New("invalid management cluster spec: providers must have a name")
+		}
+		if !providerTypes.Has(provider.Type) {
+			return errors.Errorf("invalid management cluster spec: invalid type %q for the provider %s", provider.Type, provider.Name)
+		}
+		manifestLabel := clusterctlv1.ManifestLabel(provider.Name, provider.Type)
+		if providers.Has(manifestLabel) {
+			return errors.Errorf("invalid management cluster spec: the provider %s is defined more than once", manifestLabel)
+		}
+		providers.Insert(manifestLabel)
+		if provider.Version != "" {
+			if _, err := version.ParseSemantic(provider.Version); err != nil {
+				return errors.Wrapf(err, "invalid management cluster spec: invalid version for the provider %s", manifestLabel)
+			}
+		}
+		if provider.Type == clusterctlv1.CoreProviderType {
+			coreProviders++
+		}
+	}
+
+	if coreProviders != 1 {
+		return errors.Errorf("invalid management cluster spec: there must be one core provider, found %d", coreProviders)
+	}
+	return nil
+}
+
+// desiredProviderFor returns the provider in the management cluster spec matching the given provider.
+func desiredProviderFor(managementCluster clusterctlv1.ManagementCluster, provider clusterctlv1.Provider) clusterctlv1.ManagementClusterProvider {
+	for _, desired := range managementCluster.Providers {
+		if desired.Name == provider.ProviderName && desired.Type == provider.GetProviderType() {
+			return desired
+		}
+	}
+	return clusterctlv1.ManagementClusterProvider{}
+}
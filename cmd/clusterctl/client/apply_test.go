@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_clusterctlClient_ApplyManagementCluster_DryRun(t *testing.T) {
+	// core v1.0.0, infra v2.0.0, infra-compatible v2.0.0
+	managerDeployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "infra-system",
+			Name:      "infra-controller-manager",
+			Labels: map[string]string{
+				clusterctlv1.ClusterctlLabel: "",
+				clusterv1.ProviderNameLabel:  "infrastructure-infra",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Args: []string{"--feature-gates=MachinePool=true"}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		providers []clusterctlv1.ManagementClusterProvider
+		wantPlan  ManagementClusterPlan
+		wantErr   string
+	}{
+		{
+			name: "no changes",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Version: "v1.0.0"},
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, FeatureGates: map[string]bool{"MachinePool": true}},
+				{Name: "infra-compatible", Type: clusterctlv1.InfrastructureProviderType, Namespace: "infra-compatible-system"},
+			},
+			wantPlan: ManagementClusterPlan{},
+		},
+		{
+			name: "install, upgrade and delete providers",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Version: "v1.0.1"},
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v2.0.0"},
+				{Name: "kubeadm", Type: clusterctlv1.BootstrapProviderType, Namespace: "kubeadm-system"},
+			},
+			wantPlan: ManagementClusterPlan{
+				Install: []clusterctlv1.Provider{
+					{
+						ObjectMeta:   metav1.ObjectMeta{Namespace: "kubeadm-system", Name: "bootstrap-kubeadm"},
+						ProviderName: "kubeadm",
+						Type:         string(clusterctlv1.BootstrapProviderType),
+					},
+				},
+				Upgrade: []cluster.UpgradeItem{
+					{
+						Provider:    fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system"),
+						NextVersion: "v1.0.1",
+					},
+				},
+				Delete: []clusterctlv1.Provider{
+					fakeProvider("infra-compatible", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-compatible-system"),
+				},
+			},
+		},
+		{
+			name: "re-install providers with different feature gates",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType},
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, FeatureGates: map[string]bool{"MachinePool": false}},
+				{Name: "infra-compatible", Type: clusterctlv1.InfrastructureProviderType},
+			},
+			wantPlan: ManagementClusterPlan{
+				Upgrade: []cluster.UpgradeItem{
+					{
+						Provider:     fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-system"),
+						NextVersion:  "v2.0.0",
+						FeatureGates: map[string]bool{"MachinePool": false},
+					},
+				},
+			},
+		},
+		{
+			name: "fails for downgrades",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Version: "v0.9.0"},
+			},
+			wantErr: "downgrades are not supported",
+		},
+		{
+			name: "fails when changing the namespace of a provider",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Namespace: "capi-system"},
+			},
+			wantErr: "changing the namespace of an installed provider is not supported",
+		},
+		{
+			name: "fails without a core provider",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType},
+			},
+			wantErr: "there must be one core provider, found 0",
+		},
+		{
+			name: "fails for duplicated providers",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType},
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType},
+				{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v2.0.1"},
+			},
+			wantErr: "the provider infrastructure-infra is defined more than once",
+		},
+		{
+			name: "fails for invalid provider types",
+			providers: []clusterctlv1.ManagementClusterProvider{
+				{Name: "cluster-api", Type: clusterctlv1.CoreProviderType},
+				{Name: "infra", Type: "Infra"},
+			},
+			wantErr: `invalid type "Infra"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			fc := fakeClientForUpgrade()
+			kubeconfig := Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+			fc.clusters[cluster.Kubeconfig(kubeconfig)].Proxy().(*test.FakeProxy).WithObjs(managerDeployment)
+
+			plan, err := fc.ApplyManagementCluster(ctx, ApplyManagementClusterOptions{
+				Kubeconfig:        kubeconfig,
+				ManagementCluster: clusterctlv1.ManagementCluster{Providers: tt.providers},
+				DryRun:            true,
+			})
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for i := range plan.Upgrade {
+				plan.Upgrade[i].ResourceVersion = ""
+			}
+			for i := range plan.Delete {
+				plan.Delete[i].ResourceVersion = ""
+			}
+			g.Expect(plan).To(BeComparableTo(tt.wantPlan))
+		})
+	}
+}
+
+func Test_clusterctlClient_ApplyManagementCluster(t *testing.T) {
+	t.Run("upgrade and delete providers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		fc := fakeClientForUpgrade() // core v1.0.0, infra v2.0.0, infra-compatible v2.0.0
+		kubeconfig := Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+
+		_, err := fc.ApplyManagementCluster(ctx, ApplyManagementClusterOptions{
+			Kubeconfig: kubeconfig,
+			ManagementCluster: clusterctlv1.ManagementCluster{
+				Providers: []clusterctlv1.ManagementClusterProvider{
+					{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Version: "v1.0.1"},
+					{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v2.0.1"},
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		c, err := fc.clusters[cluster.Kubeconfig(kubeconfig)].Proxy().NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		gotProviders := &clusterctlv1.ProviderList{}
+		g.Expect(c.List(ctx, gotProviders)).To(Succeed())
+		sort.Slice(gotProviders.Items, func(i, j int) bool {
+			return gotProviders.Items[i].Name < gotProviders.Items[j].Name
+		})
+
+		g.Expect(gotProviders.Items).To(HaveLen(2))
+		g.Expect(gotProviders.Items[0].InstanceName()).To(Equal("cluster-api-system/cluster-api"))
+		g.Expect(gotProviders.Items[0].Version).To(Equal("v1.0.1"))
+		g.Expect(gotProviders.Items[1].InstanceName()).To(Equal("infra-system/infrastructure-infra"))
+		g.Expect(gotProviders.Items[1].Version).To(Equal("v2.0.1"))
+	})
+
+	t.Run("does not change the management cluster if the plan fails validation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		fc := fakeClientForUpgrade() // core v1.0.0, infra v2.0.0, infra-compatible v2.0.0
+		kubeconfig := Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+
+		// v2.1.0 of the infra provider does not match any release series.
+		_, err := fc.ApplyManagementCluster(ctx, ApplyManagementClusterOptions{
+			Kubeconfig: kubeconfig,
+			ManagementCluster: clusterctlv1.ManagementCluster{
+				Providers: []clusterctlv1.ManagementClusterProvider{
+					{Name: "cluster-api", Type: clusterctlv1.CoreProviderType, Version: "v1.0.1"},
+					{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v2.1.0"},
+				},
+			},
+		})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("does not match any release series"))
+
+		c, err := fc.clusters[cluster.Kubeconfig(kubeconfig)].Proxy().NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// The provider not included in the spec is not deleted, and no provider is upgraded.
+		gotProviders := &clusterctlv1.ProviderList{}
+		g.Expect(c.List(ctx, gotProviders)).To(Succeed())
+		g.Expect(gotProviders.Items).To(HaveLen(3))
+		for _, provider := range gotProviders.Items {
+			g.Expect(provider.Version).To(Equal(map[string]string{
+				"cluster-api":      "v1.0.0",
+				"infra":            "v2.0.0",
+				"infra-compatible": "v2.0.0",
+			}[provider.ProviderName]))
+		}
+	})
+
+	t.Run("install providers with variables and feature gates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx := context.Background()
+
+		fc := fakeEmptyCluster()
+		kubeconfig := Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+
+		plan, err := fc.ApplyManagementCluster(ctx, ApplyManagementClusterOptions{
+			Kubeconfig: kubeconfig,
+			ManagementCluster: clusterctlv1.ManagementCluster{
+				Providers: []clusterctlv1.ManagementClusterProvider{
+					{Name: "cluster-api", Type: clusterctlv1.CoreProviderType},
+					{Name: "infra", Type: clusterctlv1.InfrastructureProviderType, Version: "v3.0.0", FeatureGates: map[string]bool{"MachinePool": true}},
+				},
+				Variables: map[string]string{"SOME_VARIABLE": "from-spec"},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(plan.Install).To(HaveLen(2))
+
+		c, err := fc.clusters[cluster.Kubeconfig(kubeconfig)].Proxy().NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		gotProviders := &clusterctlv1.ProviderList{}
+		g.Expect(c.List(ctx, gotProviders)).To(Succeed())
+		g.Expect(gotProviders.Items).To(HaveLen(2))
+
+		deployment := &appsv1.Deployment{}
+		g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "ns4", Name: "capa-controller-manager"}, deployment)).To(Succeed())
+		g.Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ConsistOf("--feature-gates=MachinePool=true"))
+		g.Expect(deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName).To(Equal("from-spec"))
+	})
+}
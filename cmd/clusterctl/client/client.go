@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/validation"
 	"sigs.k8s.io/cluster-api/internal/contract"
 )
 
@@ -47,9 +48,16 @@ type Client interface {
 	// InitImages returns the list of images required for executing the init command.
 	InitImages(ctx context.Context, options InitOptions) ([]string, error)
 
+	// Mirror downloads provider releases from the provider repositories, so they can be used in disconnected environments.
+	Mirror(ctx context.Context, options MirrorOptions) (*MirrorResult, error)
+
 	// GetClusterTemplate returns a workload cluster template.
 	GetClusterTemplate(ctx context.Context, options GetClusterTemplateOptions) (Template, error)
 
+	// ValidateTemplate validates the objects of a workload cluster template using the Cluster API webhooks logic,
+	// without requiring a management cluster.
+	ValidateTemplate(ctx context.Context, options ValidateTemplateOptions) ([]validation.Issue, error)
+
 	// GetKubeconfig returns the kubeconfig of the workload cluster.
 	GetKubeconfig(ctx context.Context, options GetKubeconfigOptions) (string, error)
 
@@ -68,6 +76,12 @@ type Client interface {
 	// ApplyUpgrade executes an upgrade plan.
 	ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error
 
+	// RollbackUpgrade restores providers to the version they had before the last upgrade.
+	RollbackUpgrade(ctx context.Context, options RollbackUpgradeOptions) error
+
+	// ApplyManagementCluster installs, upgrades or deletes providers to bring a management cluster to the desired state.
+	ApplyManagementCluster(ctx context.Context, options ApplyManagementClusterOptions) (ManagementClusterPlan, error)
+
 	// ProcessYAML provides a direct way to process a yaml and inspect its
 	// variables.
 	ProcessYAML(ctx context.Context, options ProcessYAMLOptions) (YamlPrinter, error)
@@ -75,6 +89,9 @@ type Client interface {
 	// DescribeCluster returns the object tree representing the status of a Cluster API cluster.
 	DescribeCluster(ctx context.Context, options DescribeClusterOptions) (*tree.ObjectTree, error)
 
+	// DescribeClusters returns a summary of the status of all the Cluster API clusters matching the given options.
+	DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error)
+
 	// AlphaClient is an Interface for alpha features in clusterctl
 	AlphaClient
 }
@@ -87,6 +104,14 @@ type AlphaClient interface {
 	RolloutPause(ctx context.Context, options RolloutPauseOptions) error
 	// RolloutResume provides rollout resume of paused cluster-api resources
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
+	// RolloutUndo provides rollout rollback of cluster-api resources
+	RolloutUndo(ctx context.Context, options RolloutUndoOptions) error
+	// RolloutHistory provides the rollout history of a cluster-api resource
+	RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]alpha.RolloutRevision, error)
+	// RolloutStatus provides the rollout status of a cluster-api resource
+	RolloutStatus(ctx context.Context, options RolloutStatusOptions) (*alpha.RolloutStatus, error)
+	// RuntimeReplay re-sends a recorded request to a runtime extension.
+	RuntimeReplay(ctx context.Context, options RuntimeReplayOptions) (*RuntimeReplayResult, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
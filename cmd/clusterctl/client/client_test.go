@@ -31,10 +31,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/validation"
 	yaml "sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
@@ -105,6 +107,10 @@ func (f fakeClient) InitImages(ctx context.Context, options InitOptions) ([]stri
 	return f.internalClient.InitImages(ctx, options)
 }
 
+func (f fakeClient) Mirror(ctx context.Context, options MirrorOptions) (*MirrorResult, error) {
+	return f.internalClient.Mirror(ctx, options)
+}
+
 func (f fakeClient) Delete(ctx context.Context, options DeleteOptions) error {
 	return f.internalClient.Delete(ctx, options)
 }
@@ -125,6 +131,18 @@ func (f fakeClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOption
 	return f.internalClient.ApplyUpgrade(ctx, options)
 }
 
+func (f fakeClient) RollbackUpgrade(ctx context.Context, options RollbackUpgradeOptions) error {
+	return f.internalClient.RollbackUpgrade(ctx, options)
+}
+
+func (f fakeClient) ValidateTemplate(ctx context.Context, options ValidateTemplateOptions) ([]validation.Issue, error) {
+	return f.internalClient.ValidateTemplate(ctx, options)
+}
+
+func (f fakeClient) ApplyManagementCluster(ctx context.Context, options ApplyManagementClusterOptions) (ManagementClusterPlan, error) {
+	return f.internalClient.ApplyManagementCluster(ctx, options)
+}
+
 func (f fakeClient) ProcessYAML(ctx context.Context, options ProcessYAMLOptions) (YamlPrinter, error) {
 	return f.internalClient.ProcessYAML(ctx, options)
 }
@@ -137,6 +155,10 @@ func (f fakeClient) DescribeCluster(ctx context.Context, options DescribeCluster
 	return f.internalClient.DescribeCluster(ctx, options)
 }
 
+func (f fakeClient) DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error) {
+	return f.internalClient.DescribeClusters(ctx, options)
+}
+
 func (f fakeClient) RolloutPause(ctx context.Context, options RolloutPauseOptions) error {
 	return f.internalClient.RolloutPause(ctx, options)
 }
@@ -145,6 +167,22 @@ func (f fakeClient) RolloutResume(ctx context.Context, options RolloutResumeOpti
 	return f.internalClient.RolloutResume(ctx, options)
 }
 
+func (f fakeClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions) error {
+	return f.internalClient.RolloutUndo(ctx, options)
+}
+
+func (f fakeClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]alpha.RolloutRevision, error) {
+	return f.internalClient.RolloutHistory(ctx, options)
+}
+
+func (f fakeClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) (*alpha.RolloutStatus, error) {
+	return f.internalClient.RolloutStatus(ctx, options)
+}
+
+func (f fakeClient) RuntimeReplay(ctx context.Context, options RuntimeReplayOptions) (*RuntimeReplayResult, error) {
+	return f.internalClient.RuntimeReplay(ctx, options)
+}
+
 // newFakeClient returns a clusterctl client that allows to execute tests on a set of fake config, fake repositories and fake clusters.
 // you can use WithCluster and WithRepository to prepare for the test case.
 func newFakeClient(ctx context.Context, configClient config.Client) *fakeClient {
@@ -496,14 +534,18 @@ type fakeTemplateClient struct {
 	processor             yaml.Processor
 }
 
-func (f *fakeTemplateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
+func (f *fakeTemplateClient) Raw(ctx context.Context, flavor string) ([]byte, error) {
 	name := "cluster-template"
 	if flavor != "" {
 		name = fmt.Sprintf("%s-%s", name, flavor)
 	}
 	name = fmt.Sprintf("%s.yaml", name)
 
-	content, err := f.fakeRepository.GetFile(ctx, f.version, name)
+	return f.fakeRepository.GetFile(ctx, f.version, name)
+}
+
+func (f *fakeTemplateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
+	content, err := f.Raw(ctx, flavor)
 	if err != nil {
 		return nil, err
 	}
@@ -524,9 +566,13 @@ type fakeClusterClassClient struct {
 	processor             yaml.Processor
 }
 
-func (f *fakeClusterClassClient) Get(ctx context.Context, class, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
+func (f *fakeClusterClassClient) Raw(ctx context.Context, class string) ([]byte, error) {
 	name := fmt.Sprintf("clusterclass-%s.yaml", class)
-	content, err := f.fakeRepository.GetFile(ctx, f.version, name)
+	return f.fakeRepository.GetFile(ctx, f.version, name)
+}
+
+func (f *fakeClusterClassClient) Get(ctx context.Context, class, targetNamespace string, skipTemplateProcess bool) (repository.Template, error) {
+	content, err := f.Raw(ctx, class)
 	if err != nil {
 		return nil, err
 	}
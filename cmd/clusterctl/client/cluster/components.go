@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -68,6 +69,9 @@ type ComponentsClient interface {
 
 	// ValidateNoObjectsExist checks if custom resources of the custom resource definitions exist and returns an error if so.
 	ValidateNoObjectsExist(ctx context.Context, provider clusterctlv1.Provider) error
+
+	// GetFeatureGates returns the feature gates set in the --feature-gates argument of the provider's controller.
+	GetFeatureGates(ctx context.Context, provider clusterctlv1.Provider) (map[string]bool, error)
 }
 
 // providerComponents implements ComponentsClient.
@@ -234,6 +238,14 @@ func (p *providerComponents) Delete(ctx context.Context, options DeleteOptions)
 		}
 	}
 
+	// If the provider is deleted together with its inventory entry, delete also the snapshot taken
+	// during the last upgrade, if any, because it is not possible to roll back anymore.
+	if !options.SkipInventory && !namespacesToDelete.Has(options.Provider.Namespace) {
+		if err := deleteProviderSnapshot(ctx, cs, options.Provider); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
 	return kerrors.NewAggregate(errList)
 }
 
@@ -317,3 +329,28 @@ func newComponentsClient(proxy Proxy) *providerComponents {
 		proxy: proxy,
 	}
 }
+
+func (p *providerComponents) GetFeatureGates(ctx context.Context, provider clusterctlv1.Provider) (map[string]bool, error) {
+	proxyClient, err := p.proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabel: "",
+		clusterv1.ProviderNameLabel:  provider.ManifestLabel(),
+	}
+
+	deployments := &unstructured.UnstructuredList{}
+	deployments.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DeploymentList"))
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		return proxyClient.List(ctx, deployments, client.InNamespace(provider.Namespace), client.MatchingLabels(labels))
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Deployments for provider %s", provider.InstanceName())
+	}
+
+	for i := range deployments.Items {
+		deployments.Items[i].SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	}
+	return util.InspectFeatureGates(deployments.Items)
+}
@@ -234,6 +234,16 @@ func storageVersionForCRD(crd *apiextensionsv1.CustomResourceDefinition) (string
 	return "", errors.Errorf("could not find storage version for CRD %q", crd.Name)
 }
 
+// versionForCRD returns the version with the given name for a given CRD, if any.
+func versionForCRD(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
 // newCRDMigrationBackoff creates a new API Machinery backoff parameter set suitable for use with crd migration operations.
 // Clusterctl upgrades cert-manager right before doing CRD migration. This may lead to rollout of new certificates.
 // The time between new certificate creation + injection into objects (CRD, Webhooks) and the new secrets getting propagated
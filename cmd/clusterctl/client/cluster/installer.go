@@ -62,6 +62,11 @@ type ProviderInstaller interface {
 	//   otherwise a warning is logged.
 	Validate(context.Context) error
 
+	// ValidatePlanned performs the same checks of Validate on the management cluster resulting by deleting
+	// providersToDelete and by upgrading providersToUpgrade, so the installation can be validated before
+	// changing the management cluster.
+	ValidatePlanned(ctx context.Context, providersToDelete []clusterctlv1.Provider, providersToUpgrade []UpgradeItem) error
+
 	// Images returns the list of images required for installing the providers ready in the install queue.
 	Images() []string
 }
@@ -180,7 +185,41 @@ func (i *providerInstaller) Validate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return i.validate(ctx, providerList)
+}
+
+func (i *providerInstaller) ValidatePlanned(ctx context.Context, providersToDelete []clusterctlv1.Provider, providersToUpgrade []UpgradeItem) error {
+	// Get the list of providers currently in the cluster.
+	currentProviderList, err := i.providerInventory.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Simulate the management cluster after deleting and upgrading providers.
+	deleted := sets.Set[string]{}
+	for _, provider := range providersToDelete {
+		deleted.Insert(provider.InstanceName())
+	}
+	nextVersions := map[string]string{}
+	for _, upgradeItem := range providersToUpgrade {
+		nextVersions[upgradeItem.InstanceName()] = upgradeItem.NextVersion
+	}
+	providerList := &clusterctlv1.ProviderList{}
+	for _, provider := range currentProviderList.Items {
+		if deleted.Has(provider.InstanceName()) {
+			continue
+		}
+		if nextVersion, ok := nextVersions[provider.InstanceName()]; ok && nextVersion != "" {
+			provider.Version = nextVersion
+		}
+		providerList.Items = append(providerList.Items, provider)
+	}
+	return i.validate(ctx, providerList)
+}
 
+// validate performs the checks described in Validate on the management cluster with the given providers.
+func (i *providerInstaller) validate(ctx context.Context, providerList *clusterctlv1.ProviderList) error {
+	var err error
 	// Starts simulating what will be the resulting management cluster by adding to the list the providers in the installQueue.
 	// During this operation following checks are performed:
 	// - There must be only one instance of the same provider
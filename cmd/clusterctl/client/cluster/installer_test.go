@@ -142,8 +142,10 @@ func Test_providerInstaller_Validate(t *testing.T) {
 	}
 
 	type fields struct {
-		proxy        Proxy
-		installQueue []repository.Components
+		proxy              Proxy
+		installQueue       []repository.Components
+		providersToDelete  []clusterctlv1.Provider
+		providersToUpgrade []UpgradeItem
 	}
 	tests := []struct {
 		name    string
@@ -286,6 +288,35 @@ func Test_providerInstaller_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "install infra1/current contract on a cluster with core/previous contract (not supported) that is going to be upgraded to core/current contract",
+			fields: fields{
+				proxy: test.NewFakeProxy().
+					WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v0.9.0", "cluster-api-system"),
+				installQueue: []repository.Components{
+					newFakeComponents("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra1-system"),
+				},
+				providersToUpgrade: []UpgradeItem{
+					{Provider: fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v0.9.0", "cluster-api-system"), NextVersion: "v1.0.0"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "install infra1/current contract on a cluster with an instance of infra1 that is going to be deleted",
+			fields: fields{
+				proxy: test.NewFakeProxy().
+					WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system").
+					WithProviderInventory("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "n1"),
+				installQueue: []repository.Components{
+					newFakeComponents("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "n2"),
+				},
+				providersToDelete: []clusterctlv1.Provider{
+					fakeProvider("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "n1"),
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -308,7 +339,12 @@ func Test_providerInstaller_Validate(t *testing.T) {
 				getCompatibleContractVersions: getCompatibleContractVersions,
 			}
 
-			err := i.Validate(ctx)
+			var err error
+			if tt.fields.providersToDelete != nil || tt.fields.providersToUpgrade != nil {
+				err = i.ValidatePlanned(ctx, tt.fields.providersToDelete, tt.fields.providersToUpgrade)
+			} else {
+				err = i.Validate(ctx)
+			}
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
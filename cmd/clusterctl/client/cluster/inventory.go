@@ -450,9 +450,13 @@ func (p *inventoryClient) CheckSingleProviderInstance(ctx context.Context) error
 	if err != nil {
 		return err
 	}
+	return checkSingleProviderInstance(providers.Items)
+}
 
+// checkSingleProviderInstance returns an error if there are multiple instances of the same provider in a list of providers.
+func checkSingleProviderInstance(providers []clusterctlv1.Provider) error {
 	providerGroups := make(map[string][]string)
-	for _, p := range providers.Items {
+	for _, p := range providers {
 		namespacedName := types.NamespacedName{Namespace: p.Namespace, Name: p.Name}.String()
 		if providers, ok := providerGroups[p.ManifestLabel()]; ok {
 			providerGroups[p.ManifestLabel()] = append(providers, namespacedName)
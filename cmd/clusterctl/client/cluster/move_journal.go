@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// moveJournalPhase defines the phase of a move operation recorded in a moveJournal.
+type moveJournalPhase string
+
+const (
+	// moveJournalPhaseCreate is the phase where objects are created in the target management cluster.
+	// A move operation failed in this phase can be resumed or rolled back.
+	moveJournalPhaseCreate = moveJournalPhase("Create")
+
+	// moveJournalPhaseDelete is the phase where objects are deleted from the source management cluster.
+	// A move operation failed in this phase can only be resumed.
+	moveJournalPhaseDelete = moveJournalPhase("Delete")
+)
+
+// moveJournal records the progress of a move operation, so a move operation failed halfway can be resumed or rolled back.
+// The journal records the moveSequence computed when the move operation started, together with the number of moveGroups
+// already created in the target management cluster and deleted from the source management cluster.
+// Nb. All the methods of moveJournal are no-op on a nil moveJournal, which is used when no journal is recorded (e.g. dry run).
+type moveJournal struct {
+	// path of the file where the journal is stored.
+	path string
+
+	// objects maps the nodes in the moveSequence to the corresponding entries in the journal.
+	objects map[*node]*moveJournalObject
+
+	// Phase of the move operation.
+	Phase moveJournalPhase `json:"phase"`
+
+	// Groups of objects in the moveSequence, in the same order they are created in the target management cluster.
+	Groups [][]*moveJournalObject `json:"groups"`
+
+	// CreatedGroups is the number of groups already created in the target management cluster.
+	CreatedGroups int `json:"createdGroups"`
+
+	// DeletedGroups is the number of groups already deleted from the source management cluster, starting from the last one.
+	DeletedGroups int `json:"deletedGroups"`
+}
+
+// moveJournalObject records an object in the moveSequence.
+type moveJournalObject struct {
+	// Identity of the object in the source management cluster.
+	Identity corev1.ObjectReference `json:"identity"`
+
+	// Owners of the object, identified by the UID in the source management cluster.
+	Owners []moveJournalOwner `json:"owners,omitempty"`
+
+	// IsGlobal is true if the object is a global resource (no namespace).
+	IsGlobal bool `json:"isGlobal,omitempty"`
+
+	// IsGlobalHierarchy is true if the object is part of a hierarchy of a global resource.
+	IsGlobalHierarchy bool `json:"isGlobalHierarchy,omitempty"`
+
+	// ShouldNotDelete is true if the object should not be deleted from the source management cluster.
+	ShouldNotDelete bool `json:"shouldNotDelete,omitempty"`
+
+	// NewUID is the UID of the object in the target management cluster.
+	NewUID types.UID `json:"newUID,omitempty"`
+
+	// Created is true if the object has been created in the target management cluster by the move operation;
+	// it is false for objects which already existed in the target management cluster.
+	Created bool `json:"created,omitempty"`
+}
+
+// moveJournalOwner records an OwnerReference of an object in the moveSequence.
+type moveJournalOwner struct {
+	// UID of the owner in the source management cluster.
+	UID types.UID `json:"uid"`
+
+	Controller         *bool `json:"controller,omitempty"`
+	BlockOwnerDeletion *bool `json:"blockOwnerDeletion,omitempty"`
+}
+
+// newMoveJournal returns a moveJournal to be stored in the given path.
+// An error is returned if the journal of a previous move operation already exists in the same path.
+func newMoveJournal(path string) (*moveJournal, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("found the journal of a previous move operation at %s: resume or roll back the previous move operation before starting a new one", path)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to check the move journal at %s", path)
+	}
+
+	return &moveJournal{
+		path:    path,
+		objects: map[*node]*moveJournalObject{},
+		Phase:   moveJournalPhaseCreate,
+	}, nil
+}
+
+// readMoveJournal reads the journal of a previous move operation stored in the given path.
+func readMoveJournal(path string) (*moveJournal, error) {
+	b, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("the journal of a previous move operation does not exist at %s", path)
+		}
+		return nil, errors.Wrapf(err, "failed to read the move journal at %s", path)
+	}
+
+	j := &moveJournal{
+		path:    path,
+		objects: map[*node]*moveJournalObject{},
+	}
+	if err := yaml.Unmarshal(b, j); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the move journal at %s", path)
+	}
+	return j, nil
+}
+
+// start records the moveSequence of a move operation in the journal.
+func (j *moveJournal) start(moveSequence *moveSequence) error {
+	if j == nil {
+		return nil
+	}
+
+	j.Groups = make([][]*moveJournalObject, 0, len(moveSequence.groups))
+	for _, group := range moveSequence.groups {
+		journalGroup := make([]*moveJournalObject, 0, len(group))
+		for _, n := range group {
+			o := &moveJournalObject{
+				Identity:          n.identity,
+				IsGlobal:          n.isGlobal,
+				IsGlobalHierarchy: n.isGlobalHierarchy,
+				ShouldNotDelete:   n.shouldNotDelete,
+			}
+			for owner, attributes := range n.owners {
+				o.Owners = append(o.Owners, moveJournalOwner{
+					UID:                owner.identity.UID,
+					Controller:         attributes.Controller,
+					BlockOwnerDeletion: attributes.BlockOwnerDeletion,
+				})
+			}
+			j.objects[n] = o
+			journalGroup = append(journalGroup, o)
+		}
+		j.Groups = append(j.Groups, journalGroup)
+	}
+	return j.save()
+}
+
+// getMoveSequence rebuilds the moveSequence recorded in the journal.
+func (j *moveJournal) getMoveSequence() (*moveSequence, error) {
+	moveSequence := &moveSequence{
+		groups:   []moveGroup{},
+		nodesMap: make(map[*node]empty),
+	}
+
+	uidToNode := map[types.UID]*node{}
+	for _, journalGroup := range j.Groups {
+		group := moveGroup{}
+		for _, o := range journalGroup {
+			n := &node{
+				identity:          o.Identity,
+				owners:            make(map[*node]ownerReferenceAttributes),
+				softOwners:        make(map[*node]empty),
+				tenant:            make(map[*node]empty),
+				isGlobal:          o.IsGlobal,
+				isGlobalHierarchy: o.IsGlobalHierarchy,
+				shouldNotDelete:   o.ShouldNotDelete,
+				newUID:            o.NewUID,
+			}
+			// Owners are always in a previous group of the moveSequence.
+			for _, owner := range o.Owners {
+				ownerNode, ok := uidToNode[owner.UID]
+				if !ok {
+					return nil, errors.Errorf("invalid move journal: owner with UID %s of %s %s/%s not found", owner.UID, o.Identity.Kind, o.Identity.Namespace, o.Identity.Name)
+				}
+				n.addOwner(ownerNode, ownerReferenceAttributes{
+					Controller:         owner.Controller,
+					BlockOwnerDeletion: owner.BlockOwnerDeletion,
+				})
+			}
+			uidToNode[o.Identity.UID] = n
+			j.objects[n] = o
+			group = append(group, n)
+		}
+		moveSequence.addGroup(group)
+	}
+	return moveSequence, nil
+}
+
+// isDeleting returns true if the move operation already started deleting objects from the source management cluster.
+func (j *moveJournal) isDeleting() bool {
+	if j == nil {
+		return false
+	}
+	return j.Phase == moveJournalPhaseDelete
+}
+
+// createdGroups returns the number of groups already created in the target management cluster.
+func (j *moveJournal) createdGroups() int {
+	if j == nil {
+		return 0
+	}
+	return j.CreatedGroups
+}
+
+// deletedGroups returns the number of groups already deleted from the source management cluster.
+func (j *moveJournal) deletedGroups() int {
+	if j == nil {
+		return 0
+	}
+	return j.DeletedGroups
+}
+
+// isCreated returns true if the object corresponding to the node has been created in the target management cluster by the move operation.
+func (j *moveJournal) isCreated(n *node) bool {
+	if j == nil {
+		return false
+	}
+	o, ok := j.objects[n]
+	return ok && o.Created
+}
+
+// recordTargetObject records the UID of the object corresponding to the node in the target management cluster,
+// and if the object has been created by the move operation.
+func (j *moveJournal) recordTargetObject(n *node, created bool) error {
+	if j == nil {
+		return nil
+	}
+	o, ok := j.objects[n]
+	if !ok {
+		return nil
+	}
+	o.NewUID = n.newUID
+	// Nb. An object created by a previous attempt is reported as already existing when retrying, so the created flag is never reset here.
+	o.Created = o.Created || created
+	return j.save()
+}
+
+// recordTargetObjectDeleted records the object corresponding to the node has been deleted from the target management cluster.
+func (j *moveJournal) recordTargetObjectDeleted(n *node) error {
+	if j == nil {
+		return nil
+	}
+	o, ok := j.objects[n]
+	if !ok {
+		return nil
+	}
+	o.NewUID = ""
+	o.Created = false
+	return j.save()
+}
+
+// completeCreateGroup records a group has been created in the target management cluster.
+func (j *moveJournal) completeCreateGroup() error {
+	if j == nil {
+		return nil
+	}
+	j.CreatedGroups++
+	return j.save()
+}
+
+// startDelete records the move operation is starting to delete objects from the source management cluster.
+func (j *moveJournal) startDelete() error {
+	if j == nil {
+		return nil
+	}
+	j.Phase = moveJournalPhaseDelete
+	return j.save()
+}
+
+// completeDeleteGroup records a group has been deleted from the source management cluster.
+func (j *moveJournal) completeDeleteGroup() error {
+	if j == nil {
+		return nil
+	}
+	j.DeletedGroups++
+	return j.save()
+}
+
+// remove deletes the journal once the move operation is completed or rolled back.
+func (j *moveJournal) remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete the move journal at %s", j.path)
+	}
+	return nil
+}
+
+func (j *moveJournal) save() error {
+	b, err := yaml.Marshal(j)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the move journal")
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create the directory for the move journal at %s", j.path)
+	}
+	if err := os.WriteFile(j.path, b, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the move journal at %s", j.path)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_moveJournal_getMoveSequence(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &node{
+		identity:   corev1.ObjectReference{APIVersion: "cluster.x-k8s.io/v1beta2", Kind: "Cluster", Namespace: "ns1", Name: "cluster1", UID: "cluster1"},
+		owners:     map[*node]ownerReferenceAttributes{},
+		softOwners: map[*node]empty{},
+		newUID:     "new-cluster1",
+	}
+	secret := &node{
+		identity:        corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "cluster1-kubeconfig", UID: "secret1"},
+		owners:          map[*node]ownerReferenceAttributes{cluster: {Controller: ptr.To(true)}},
+		softOwners:      map[*node]empty{},
+		shouldNotDelete: true,
+	}
+	moveSequence := &moveSequence{
+		groups:   []moveGroup{},
+		nodesMap: map[*node]empty{},
+	}
+	moveSequence.addGroup(moveGroup{cluster})
+	moveSequence.addGroup(moveGroup{secret})
+
+	journalFile := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal, err := newMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal.start(moveSequence)).To(Succeed())
+	g.Expect(journal.recordTargetObject(cluster, true)).To(Succeed())
+	g.Expect(journal.completeCreateGroup()).To(Succeed())
+
+	// Read the journal and rebuild the move sequence.
+	got, err := readMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got.isDeleting()).To(BeFalse())
+	g.Expect(got.createdGroups()).To(Equal(1))
+	g.Expect(got.deletedGroups()).To(Equal(0))
+
+	gotMoveSequence, err := got.getMoveSequence()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gotMoveSequence.groups).To(HaveLen(2))
+	g.Expect(gotMoveSequence.getClusters()).To(HaveLen(1))
+
+	gotCluster := gotMoveSequence.getGroup(0)[0]
+	g.Expect(gotCluster.identity).To(Equal(cluster.identity))
+	g.Expect(gotCluster.newUID).To(BeEquivalentTo("new-cluster1"))
+	g.Expect(got.isCreated(gotCluster)).To(BeTrue())
+
+	gotSecret := gotMoveSequence.getGroup(1)[0]
+	g.Expect(gotSecret.identity).To(Equal(secret.identity))
+	g.Expect(gotSecret.shouldNotDelete).To(BeTrue())
+	g.Expect(gotSecret.owners).To(HaveLen(1))
+	g.Expect(gotSecret.owners).To(HaveKeyWithValue(gotCluster, ownerReferenceAttributes{Controller: ptr.To(true)}))
+	g.Expect(got.isCreated(gotSecret)).To(BeFalse())
+
+	// An object already existing in the target cluster does not reset the created flag recorded by a previous attempt.
+	g.Expect(got.recordTargetObject(gotCluster, false)).To(Succeed())
+	g.Expect(got.isCreated(gotCluster)).To(BeTrue())
+
+	g.Expect(got.startDelete()).To(Succeed())
+	g.Expect(got.isDeleting()).To(BeTrue())
+}
+
+func Test_readMoveJournal_notFound(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := readMoveJournal(filepath.Join(t.TempDir(), "move-journal.yaml"))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -50,13 +52,53 @@ import (
 // ResourceMutatorFunc holds the type for mutators to be applied on resources during a move operation.
 type ResourceMutatorFunc func(u *unstructured.Unstructured) error
 
+// ClusterSelector restricts a move operation to a subset of the Clusters existing in the namespace being moved.
+// Clusters must match both Names and Labels, if set; an empty ClusterSelector selects all the Clusters.
+type ClusterSelector struct {
+	// Names of the Clusters to be moved.
+	Names []string
+
+	// Labels selects the Clusters to be moved by label.
+	Labels labels.Selector
+}
+
+// IsEmpty returns true if the ClusterSelector selects all the Clusters.
+func (s ClusterSelector) IsEmpty() bool {
+	return len(s.Names) == 0 && (s.Labels == nil || s.Labels.Empty())
+}
+
+// matches returns true if the Cluster with the given name and labels is selected.
+func (s ClusterSelector) matches(name string, clusterLabels map[string]string) bool {
+	if len(s.Names) > 0 && !slices.Contains(s.Names, name) {
+		return false
+	}
+	if s.Labels != nil && !s.Labels.Matches(labels.Set(clusterLabels)) {
+		return false
+	}
+	return true
+}
+
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
 type ObjectMover interface {
-	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
-	Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error
-
-	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory.
-	ToDirectory(ctx context.Context, namespace string, directory string) error
+	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster;
+	// if the clusterSelector is not empty, only the objects related to the selected Clusters are moved.
+	// If journalFile is not empty, the progress of the move operation is recorded in a journal, so a move operation
+	// failed halfway can be resumed or rolled back; the journal is deleted when the move operation completes.
+	Move(ctx context.Context, namespace string, clusterSelector ClusterSelector, toCluster Client, dryRun bool, journalFile string, mutators ...ResourceMutatorFunc) error
+
+	// ResumeMove resumes a failed move operation recorded in journalFile.
+	// The same mutators used for the failed move operation must be used.
+	ResumeMove(ctx context.Context, toCluster Client, journalFile string, mutators ...ResourceMutatorFunc) error
+
+	// RollbackMove rolls back a failed move operation recorded in journalFile, by deleting the objects created in
+	// the target management cluster and resuming the Clusters and the ClusterClasses in the source management cluster.
+	// A move operation can be rolled back only if it has not yet started deleting objects from the source management cluster.
+	// The same mutators used for the failed move operation must be used.
+	RollbackMove(ctx context.Context, toCluster Client, journalFile string, mutators ...ResourceMutatorFunc) error
+
+	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory;
+	// if the clusterSelector is not empty, only the objects related to the selected Clusters are written.
+	ToDirectory(ctx context.Context, namespace string, clusterSelector ClusterSelector, directory string) error
 
 	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
 	FromDirectory(ctx context.Context, toCluster Client, directory string) error
@@ -67,12 +109,15 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+
+	// journal records the progress of the move operation, if any.
+	journal *moveJournal
 }
 
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
-func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error {
+func (o *objectMover) Move(ctx context.Context, namespace string, clusterSelector ClusterSelector, toCluster Client, dryRun bool, journalFile string, mutators ...ResourceMutatorFunc) error {
 	log := logf.Log
 	log.Info("Performing move...")
 	o.dryRun = dryRun
@@ -89,7 +134,16 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 		}
 	}
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	// Records the progress of the move operation, if required; nb. a dry run does not record any journal.
+	if journalFile != "" && !o.dryRun {
+		journal, err := newMoveJournal(journalFile)
+		if err != nil {
+			return err
+		}
+		o.journal = journal
+	}
+
+	objectGraph, err := o.getObjectGraph(ctx, namespace, clusterSelector)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -103,11 +157,55 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 	return o.move(ctx, objectGraph, proxy, mutators...)
 }
 
-func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directory string) error {
+func (o *objectMover) ResumeMove(ctx context.Context, toCluster Client, journalFile string, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.Info("Resuming move...")
+
+	journal, err := readMoveJournal(journalFile)
+	if err != nil {
+		return err
+	}
+	o.journal = journal
+
+	// checks that all the required providers in place in the target cluster.
+	if err := o.checkTargetProviders(ctx, toCluster.ProviderInventory()); err != nil {
+		return errors.Wrap(err, "failed to check providers in target cluster")
+	}
+
+	moveSequence, err := journal.getMoveSequence()
+	if err != nil {
+		return err
+	}
+
+	return o.runMove(ctx, moveSequence, toCluster.Proxy(), mutators...)
+}
+
+func (o *objectMover) RollbackMove(ctx context.Context, toCluster Client, journalFile string, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.Info("Rolling back move...")
+
+	journal, err := readMoveJournal(journalFile)
+	if err != nil {
+		return err
+	}
+	if journal.isDeleting() {
+		return errors.Errorf("the move operation recorded at %s cannot be rolled back because it already started deleting objects from the source cluster; resume it instead", journalFile)
+	}
+	o.journal = journal
+
+	moveSequence, err := journal.getMoveSequence()
+	if err != nil {
+		return err
+	}
+
+	return o.rollback(ctx, moveSequence, toCluster.Proxy(), mutators...)
+}
+
+func (o *objectMover) ToDirectory(ctx context.Context, namespace string, clusterSelector ClusterSelector, directory string) error {
 	log := logf.Log
 	log.Info("Moving to directory...")
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	objectGraph, err := o.getObjectGraph(ctx, namespace, clusterSelector)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -186,7 +284,7 @@ func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, erro
 	return objs, nil
 }
 
-func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*objectGraph, error) {
+func (o *objectMover) getObjectGraph(ctx context.Context, namespace string, clusterSelector ClusterSelector) (*objectGraph, error) {
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
 	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
@@ -202,6 +300,11 @@ func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*ob
 		return nil, errors.Wrap(err, "failed to discover the object graph")
 	}
 
+	// If required, restrict the object graph to the objects related to the selected Clusters.
+	if err := objectGraph.filterClusters(clusterSelector); err != nil {
+		return nil, errors.Wrap(err, "failed to select Clusters to move")
+	}
+
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/toDirectory operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving/backing up are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
@@ -352,34 +455,6 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
-	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
-	log.V(1).Info("Pausing the source cluster")
-	if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
-		return err
-	}
-
-	log.V(1).Info("Pausing the source ClusterClasses")
-	if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, o.dryRun); err != nil {
-		return errors.Wrap(err, "error pausing ClusterClasses")
-	}
-
-	log.Info("Waiting for all resources to be ready to move")
-	// exponential backoff configuration which returns durations for a total time of ~2m.
-	// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
-	waitForMoveUnblockedBackoff := wait.Backoff{
-		Duration: 5 * time.Second,
-		Factor:   1.5,
-		Steps:    10,
-		Jitter:   0.1,
-	}
-	if err := waitReadyForMove(ctx, o.fromProxy, graph.getMoveNodes(), o.dryRun, waitForMoveUnblockedBackoff); err != nil {
-		return errors.Wrap(err, "error waiting for resources to be ready to move")
-	}
-
-	// Nb. DO NOT call ensureNamespaces at this point because:
-	// - namespace will be ensured to exist before creating the resource.
-	// - If it's done here, we might create a namespace that can end up unused on target cluster (due to mutators).
-
 	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
 	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
 	// - All the Clusters should be moved first (group 1, processed in parallel)
@@ -387,10 +462,63 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	// - then all the MachineSets, then all the Machines, etc.
 	moveSequence := getMoveSequence(graph)
 
-	// Create all objects group by group, ensuring all the ownerReferences are re-created.
-	log.Info("Creating objects in the target cluster")
-	for groupIndex := range len(moveSequence.groups) {
-		if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+	// Records the move sequence in the journal, so a move operation failed halfway can be resumed or rolled back.
+	if err := o.journal.start(moveSequence); err != nil {
+		return err
+	}
+
+	return o.runMove(ctx, moveSequence, toProxy, mutators...)
+}
+
+// runMove moves the objects in the moveSequence to a target management cluster, skipping the moveGroups already
+// processed by a previous attempt of the same move operation, if any.
+func (o *objectMover) runMove(ctx context.Context, moveSequence *moveSequence, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+
+	clusters := moveSequence.getClusters()
+	clusterClasses := moveSequence.getClusterClasses()
+
+	if !o.journal.isDeleting() {
+		// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
+		log.V(1).Info("Pausing the source cluster")
+		if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
+			return err
+		}
+
+		log.V(1).Info("Pausing the source ClusterClasses")
+		if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, o.dryRun); err != nil {
+			return errors.Wrap(err, "error pausing ClusterClasses")
+		}
+
+		log.Info("Waiting for all resources to be ready to move")
+		// exponential backoff configuration which returns durations for a total time of ~2m.
+		// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
+		waitForMoveUnblockedBackoff := wait.Backoff{
+			Duration: 5 * time.Second,
+			Factor:   1.5,
+			Steps:    10,
+			Jitter:   0.1,
+		}
+		if err := waitReadyForMove(ctx, o.fromProxy, moveSequence.getNodes(), o.dryRun, waitForMoveUnblockedBackoff); err != nil {
+			return errors.Wrap(err, "error waiting for resources to be ready to move")
+		}
+
+		// Nb. DO NOT call ensureNamespaces at this point because:
+		// - namespace will be ensured to exist before creating the resource.
+		// - If it's done here, we might create a namespace that can end up unused on target cluster (due to mutators).
+
+		// Create all objects group by group, ensuring all the ownerReferences are re-created.
+		log.Info("Creating objects in the target cluster")
+		for groupIndex := o.journal.createdGroups(); groupIndex < len(moveSequence.groups); groupIndex++ {
+			if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+				return err
+			}
+			if err := o.journal.completeCreateGroup(); err != nil {
+				return err
+			}
+		}
+
+		if err := o.journal.startDelete(); err != nil {
 			return err
 		}
 	}
@@ -401,10 +529,25 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Delete all objects group by group in reverse order.
 	log.Info("Deleting objects from the source cluster")
-	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
+	for groupIndex := len(moveSequence.groups) - 1 - o.journal.deletedGroups(); groupIndex >= 0; groupIndex-- {
 		if err := o.deleteGroup(ctx, moveSequence.getGroup(groupIndex)); err != nil {
 			return err
 		}
+		if err := o.journal.completeDeleteGroup(); err != nil {
+			return err
+		}
+	}
+
+	// Resume the ClusterClasses kept in the source management cluster because they are still used by other Clusters.
+	keptClusterClasses := []*node{}
+	for _, clusterClass := range clusterClasses {
+		if clusterClass.shouldNotDelete {
+			keptClusterClasses = append(keptClusterClasses, clusterClass)
+		}
+	}
+	log.V(1).Info("Resuming the ClusterClasses kept in the source cluster")
+	if err := setClusterClassPause(ctx, o.fromProxy, keptClusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses kept in the source cluster")
 	}
 
 	// Resume the ClusterClasses in the target management cluster, so the controllers start reconciling it.
@@ -415,7 +558,41 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...); err != nil {
+		return err
+	}
+
+	// The move operation is completed, so the journal is not required anymore.
+	return o.journal.remove()
+}
+
+// rollback rolls back a failed move operation, by deleting the objects created in the target management cluster and
+// by resuming the Clusters and the ClusterClasses in the source management cluster.
+func (o *objectMover) rollback(ctx context.Context, moveSequence *moveSequence, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+
+	// Delete all objects created in the target cluster group by group in reverse order.
+	log.Info("Deleting objects from the target cluster")
+	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
+		if err := o.deleteTargetGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+			return err
+		}
+	}
+
+	// Resume the ClusterClasses in the source management cluster, so the controllers start reconciling it again.
+	log.V(1).Info("Resuming the source ClusterClasses")
+	if err := setClusterClassPause(ctx, o.fromProxy, moveSequence.getClusterClasses(), false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+
+	// Reset the pause field on the Cluster object in the source management cluster, so the controllers start reconciling it again.
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, moveSequence.getClusters(), false, o.dryRun); err != nil {
+		return err
+	}
+
+	// The move operation is rolled back, so the journal is not required anymore.
+	return o.journal.remove()
 }
 
 func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string) error {
@@ -537,6 +714,37 @@ func (s *moveSequence) hasNode(n *node) bool {
 	return ok
 }
 
+// getNodes returns all the nodes in the move sequence.
+func (s *moveSequence) getNodes() []*node {
+	nodes := []*node{}
+	for _, group := range s.groups {
+		nodes = append(nodes, group...)
+	}
+	return nodes
+}
+
+// getClusters returns the list of Clusters in the move sequence.
+func (s *moveSequence) getClusters() []*node {
+	clusters := []*node{}
+	for _, n := range s.getNodes() {
+		if n.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+			clusters = append(clusters, n)
+		}
+	}
+	return clusters
+}
+
+// getClusterClasses returns the list of ClusterClasses in the move sequence.
+func (s *moveSequence) getClusterClasses() []*node {
+	clusterClasses := []*node{}
+	for _, n := range s.getNodes() {
+		if n.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind() {
+			clusterClasses = append(clusterClasses, n)
+		}
+	}
+	return clusterClasses
+}
+
 func (s *moveSequence) getGroup(i int) moveGroup {
 	return s.groups[i]
 }
@@ -1057,11 +1265,13 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 		existingNamespaces.Insert(obj.GetNamespace())
 	}
 	oldManagedFields := obj.GetManagedFields()
+	created := true
 	if err := cTo.Create(ctx, obj); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			return errors.Wrapf(err, "error creating %q %s/%s",
 				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
+		created = false
 
 		// If the object already exists, try to update it if it is node a global object / something belonging to a global object hierarchy (e.g. a secrets owned by a global identity object).
 		if nodeToCreate.isGlobal || nodeToCreate.isGlobalHierarchy {
@@ -1091,6 +1301,11 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 	// Stores the newUID assigned to the newly created object.
 	nodeToCreate.newUID = obj.GetUID()
 
+	// Records the object in the journal, so a failed move can be resumed or rolled back.
+	if err := o.journal.recordTargetObject(nodeToCreate, created); err != nil {
+		return err
+	}
+
 	if err := patchTopologyManagedFields(ctx, oldManagedFields, obj, cTo); err != nil {
 		return errors.Wrap(err, "error patching the managed fields")
 	}
@@ -1270,44 +1485,106 @@ func (o *objectMover) deleteSourceObject(ctx context.Context, nodeToDelete *node
 		return nil
 	}
 
-	cFrom, err := o.fromProxy.NewClient(ctx)
-	if err != nil {
-		return err
-	}
-
 	// Get the source object
 	sourceObj := &unstructured.Unstructured{}
 	sourceObj.SetAPIVersion(nodeToDelete.identity.APIVersion)
 	sourceObj.SetKind(nodeToDelete.identity.Kind)
-	sourceObjKey := client.ObjectKey{
-		Namespace: nodeToDelete.identity.Namespace,
-		Name:      nodeToDelete.identity.Name,
+	sourceObj.SetName(nodeToDelete.identity.Name)
+	sourceObj.SetNamespace(nodeToDelete.identity.Namespace)
+
+	return forceDeleteObject(ctx, o.fromProxy, sourceObj)
+}
+
+// deleteTargetGroup deletes all the Kubernetes objects created by a failed move operation in the target management cluster
+// corresponding to the object graph nodes in a moveGroup.
+func (o *objectMover) deleteTargetGroup(ctx context.Context, group moveGroup, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	deleteTargetObjectBackoff := newWriteBackoff()
+	errList := []error{}
+	for i := range group {
+		nodeToDelete := group[i]
+
+		// Objects which already existed in the target management cluster before the move operation are preserved.
+		if !o.journal.isCreated(nodeToDelete) {
+			continue
+		}
+
+		// Delete the Kubernetes object corresponding to the current node.
+		// Nb. The operation is wrapped in a retry loop to make rollback more resilient to unexpected conditions.
+		err := retryWithExponentialBackoff(ctx, deleteTargetObjectBackoff, func(ctx context.Context) error {
+			return o.deleteTargetObject(ctx, nodeToDelete, toProxy, mutators...)
+		})
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+
+		if err := o.journal.recordTargetObjectDeleted(nodeToDelete); err != nil {
+			errList = append(errList, err)
+		}
 	}
 
-	if err := cFrom.Get(ctx, sourceObjKey, sourceObj); err != nil {
+	return kerrors.NewAggregate(errList)
+}
+
+// deleteTargetObject deletes the Kubernetes object corresponding to the node from the target management cluster, taking care of removing all the finalizers so
+// the objects gets immediately deleted (force delete).
+func (o *objectMover) deleteTargetObject(ctx context.Context, nodeToDelete *node, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.V(1).Info("Deleting from target", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
+
+	if o.dryRun {
+		return nil
+	}
+
+	// Get the target object; since the object has been already created in the target cluster, the ONLY affect that mutators can have
+	// here is on namespace of the resource.
+	targetObj := &unstructured.Unstructured{}
+	targetObj.SetAPIVersion(nodeToDelete.identity.APIVersion)
+	targetObj.SetKind(nodeToDelete.identity.Kind)
+	targetObj.SetName(nodeToDelete.identity.Name)
+	targetObj.SetNamespace(nodeToDelete.identity.Namespace)
+
+	targetObj, err := applyMutators(targetObj, mutators...)
+	if err != nil {
+		return err
+	}
+
+	return forceDeleteObject(ctx, toProxy, targetObj)
+}
+
+// forceDeleteObject deletes a Kubernetes object, taking care of removing all the finalizers so the objects gets immediately deleted.
+func forceDeleteObject(ctx context.Context, proxy Proxy, obj *unstructured.Unstructured) error {
+	log := logf.Log
+
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
 		if apierrors.IsNotFound(err) {
 			// If the object is already deleted, move on.
-			log.V(5).Info("Object already deleted, skipping delete for", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
+			log.V(5).Info("Object already deleted, skipping delete for", obj.GetKind(), obj.GetName(), "Namespace", obj.GetNamespace())
 			return nil
 		}
 		return errors.Wrapf(err, "error reading %q %s/%s",
-			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	if err := cFrom.Patch(ctx, sourceObj, addDeleteForMoveAnnotationPatch); err != nil {
+	if err := c.Patch(ctx, obj, addDeleteForMoveAnnotationPatch); err != nil {
 		return errors.Wrapf(err, "error adding delete-for-move annotation from %q %s/%s",
-			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	if err := cFrom.Delete(ctx, sourceObj); err != nil {
+	if err := c.Delete(ctx, obj); err != nil {
 		return errors.Wrapf(err, "error deleting %q %s/%s",
-			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	if len(sourceObj.GetFinalizers()) > 0 {
-		if err := cFrom.Patch(ctx, sourceObj, removeFinalizersPatch); err != nil {
+	if len(obj.GetFinalizers()) > 0 {
+		if err := c.Patch(ctx, obj, removeFinalizersPatch); err != nil {
 			return errors.Wrapf(err, "error removing finalizers from %q %s/%s",
-				sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
 	}
 	return nil
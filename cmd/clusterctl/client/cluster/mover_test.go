@@ -1369,6 +1369,272 @@ func Test_objectMover_move(t *testing.T) {
 	}
 }
 
+func Test_objectMover_move_selectedClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := []client.Object{}
+	objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class1").Objs()...)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(deduplicateObjects(objs))
+
+	// Get all the types to be considered for discovery
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+	// trigger discovery the content of the source cluster, and then select cluster1 only
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+	g.Expect(graph.filterClusters(ClusterSelector{Names: []string{"cluster1"}})).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	// Run move
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	csTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the selected cluster is moved.
+	g.Expect(apierrors.IsNotFound(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{}))).To(BeTrue())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{})).To(Succeed())
+
+	// the cluster not selected is kept in the source cluster.
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster2"}, &clusterv1.Cluster{})).To(Succeed())
+	g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster2"}, &clusterv1.Cluster{}))).To(BeTrue())
+
+	// the ClusterClass shared by the two clusters is copied, but not deleted from the source cluster, where it is resumed.
+	classFrom := &clusterv1.ClusterClass{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, classFrom)).To(Succeed())
+	g.Expect(classFrom.Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, &clusterv1.ClusterClass{})).To(Succeed())
+}
+
+func Test_objectMover_move_journal(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "cluster1").Objs()
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(objs)
+
+	// Get all the types to be considered for discovery
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	journalFile := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal, err := newMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Run move
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	// the journal is deleted once the move operation is completed.
+	_, err = os.Stat(journalFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	// a new move operation can't start if the journal of a previous move operation exists.
+	g.Expect(os.WriteFile(journalFile, []byte("phase: Create"), 0600)).To(Succeed())
+	_, err = newMoveJournal(journalFile)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func Test_objectMover_runMove_resume(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "cluster1").Objs()
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(objs)
+
+	// Get all the types to be considered for discovery
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	// Simulate a move operation failed after creating the first group in the target cluster.
+	moveSequence := getMoveSequence(graph)
+	g.Expect(len(moveSequence.groups)).To(BeNumerically(">", 1))
+
+	journalFile := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal, err := newMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal.start(moveSequence)).To(Succeed())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(setClusterPause(ctx, graph.proxy, moveSequence.getClusters(), true, false)).To(Succeed())
+	g.Expect(mover.createGroup(ctx, moveSequence.getGroup(0), toProxy)).To(Succeed())
+	g.Expect(journal.completeCreateGroup()).To(Succeed())
+
+	// Resume the move operation from the journal.
+	resumedJournal, err := readMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resumedJournal.createdGroups()).To(Equal(1))
+
+	resumedMoveSequence, err := resumedJournal.getMoveSequence()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resumedMoveSequence.groups).To(HaveLen(len(moveSequence.groups)))
+
+	resumedMover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   resumedJournal,
+	}
+	g.Expect(resumedMover.runMove(ctx, resumedMoveSequence, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	csTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the cluster is moved and resumed in the target cluster.
+	g.Expect(apierrors.IsNotFound(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{}))).To(BeTrue())
+	clusterTo := &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, clusterTo)).To(Succeed())
+	g.Expect(ptr.Deref(clusterTo.Spec.Paused, false)).To(BeFalse())
+
+	// the owner references of the objects created after resuming point to the objects created before the failure.
+	infraClusterTo := &unstructured.Unstructured{}
+	infraClusterTo.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	infraClusterTo.SetKind("GenericInfrastructureCluster")
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, infraClusterTo)).To(Succeed())
+	g.Expect(infraClusterTo.GetOwnerReferences()).To(HaveLen(1))
+	g.Expect(infraClusterTo.GetOwnerReferences()[0].UID).To(Equal(clusterTo.UID))
+
+	// the journal is deleted once the move operation is completed.
+	_, err = os.Stat(journalFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func Test_objectMover_rollback(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := []client.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster2").Objs()...)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(objs)
+
+	// Get all the types to be considered for discovery
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+	// gets a fakeProxy to a cluster with all the required CRDs, where cluster2 already exists.
+	toProxy := getFakeProxyWithCRDs()
+	csTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(csTo.Create(ctx, &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster2"}})).To(Succeed())
+
+	// Simulate a move operation failed after creating all the objects in the target cluster.
+	moveSequence := getMoveSequence(graph)
+
+	journalFile := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal, err := newMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal.start(moveSequence)).To(Succeed())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(setClusterPause(ctx, graph.proxy, moveSequence.getClusters(), true, false)).To(Succeed())
+	for i := range moveSequence.groups {
+		g.Expect(mover.createGroup(ctx, moveSequence.getGroup(i), toProxy)).To(Succeed())
+		g.Expect(journal.completeCreateGroup()).To(Succeed())
+	}
+
+	// Roll back the move operation from the journal.
+	rollbackJournal, err := readMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rollbackMoveSequence, err := rollbackJournal.getMoveSequence()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rollbackMover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   rollbackJournal,
+	}
+	g.Expect(rollbackMover.rollback(ctx, rollbackMoveSequence, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the objects created by the move operation are deleted from the target cluster.
+	g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster1"}, &clusterv1.Cluster{}))).To(BeTrue())
+	for _, o := range objs {
+		if o.GetName() == "cluster2" && o.GetObjectKind().GroupVersionKind().Kind == clusterv1.ClusterKind {
+			continue
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(o.GetObjectKind().GroupVersionKind())
+		g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKeyFromObject(o), u))).To(BeTrue(), "%s %s should be deleted", u.GetKind(), o.GetName())
+	}
+
+	// the objects already existing in the target cluster are preserved.
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "cluster2"}, &clusterv1.Cluster{})).To(Succeed())
+
+	// the clusters are resumed in the source cluster.
+	for _, name := range []string{"cluster1", "cluster2"} {
+		clusterFrom := &clusterv1.Cluster{}
+		g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, clusterFrom)).To(Succeed())
+		g.Expect(ptr.Deref(clusterFrom.Spec.Paused, false)).To(BeFalse())
+	}
+
+	// the journal is deleted once the move operation is rolled back.
+	_, err = os.Stat(journalFile)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func Test_objectMover_RollbackMove_deleting(t *testing.T) {
+	g := NewWithT(t)
+
+	journalFile := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal, err := newMoveJournal(journalFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal.start(&moveSequence{groups: []moveGroup{}, nodesMap: map[*node]empty{}})).To(Succeed())
+	g.Expect(journal.startDelete()).To(Succeed())
+
+	// a move operation which already started deleting objects from the source cluster can't be rolled back.
+	mover := objectMover{}
+	err = mover.RollbackMove(context.Background(), nil, journalFile)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("resume it instead"))
+}
+
 func Test_objectMover_move_with_Mutator(t *testing.T) {
 	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
 	// we use same mutator function for all tests and validate outcome based on input.
@@ -46,6 +46,7 @@ import (
 const clusterTopologyNameKey = "cluster.spec.topology.class"
 const clusterTopologyNamespaceKey = "cluster.spec.topology.classNamespace"
 const clusterResourceSetBindingClusterNameKey = "clusterresourcesetbinding.spec.clustername"
+const clusterLabelsKey = "cluster.metadata.labels"
 
 type empty struct{}
 
@@ -154,10 +155,11 @@ func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) erro
 		if err := localScheme.Convert(obj, cluster, nil); err != nil {
 			return errors.Wrapf(err, "failed to convert object %s to Cluster", n.identityStr())
 		}
+		if n.additionalInfo == nil {
+			n.additionalInfo = map[string]interface{}{}
+		}
+		n.additionalInfo[clusterLabelsKey] = cluster.GetLabels()
 		if cluster.Spec.Topology.IsDefined() {
-			if n.additionalInfo == nil {
-				n.additionalInfo = map[string]interface{}{}
-			}
 			n.additionalInfo[clusterTopologyNameKey] = cluster.GetClassKey().Name
 			n.additionalInfo[clusterTopologyNamespaceKey] = cluster.GetClassKey().Namespace
 		}
@@ -778,6 +780,113 @@ func (o *objectGraph) setTenantHierarchy(node, tenant *node, isGlobalHierarchy b
 	}
 }
 
+// filterClusters restricts the object graph to the objects related to the Clusters selected by the clusterSelector, i.e.
+// the objects in the hierarchy of the selected Clusters and the shared objects they depend on, like ClusterClasses,
+// ClusterResourceSets and their templates/resources.
+// Shared objects which are related also to Clusters not being moved are marked as should not delete, so they are copied
+// to the target management cluster without being deleted from the source management cluster.
+// NOTE: Objects labeled for force move which are not related to any Cluster (e.g. global identities) are copied but not deleted,
+// because Clusters might depend on them without an explicit OwnerReference.
+func (o *objectGraph) filterClusters(clusterSelector ClusterSelector) error {
+	if clusterSelector.IsEmpty() {
+		return nil
+	}
+
+	log := logf.Log
+
+	selectedClusters := map[*node]bool{}
+	selectedCount := 0
+	foundNames := sets.Set[string]{}
+	for _, cluster := range o.getClusters() {
+		clusterLabels, _ := cluster.additionalInfo[clusterLabelsKey].(map[string]string)
+		selectedClusters[cluster] = clusterSelector.matches(cluster.identity.Name, clusterLabels)
+		if selectedClusters[cluster] {
+			log.V(1).Info("Selecting Cluster for move", "Cluster", klog.KRef(cluster.identity.Namespace, cluster.identity.Name))
+			selectedCount++
+		}
+		foundNames.Insert(cluster.identity.Name)
+	}
+	for _, name := range clusterSelector.Names {
+		if !foundNames.Has(name) {
+			return errors.Errorf("Cluster %q not found", name)
+		}
+	}
+	if selectedCount == 0 {
+		return errors.New("no Clusters matching the selection criteria")
+	}
+
+	// clusterTenants returns if a node belongs to selected and to not selected Clusters.
+	clusterTenants := func(n *node) (selected, notSelected bool) {
+		for tenant := range n.tenant {
+			isSelected, isCluster := selectedClusters[tenant]
+			if !isCluster {
+				continue
+			}
+			selected = selected || isSelected
+			notSelected = notSelected || !isSelected
+		}
+		return selected, notSelected
+	}
+
+	// Identify the shared objects related to selected and to not selected Clusters, e.g. the ClusterClass used by a Cluster
+	// is a tenant of all the objects in the Cluster hierarchy.
+	sharedTenantsOfSelected := map[*node]empty{}
+	sharedTenantsOfNotSelected := map[*node]empty{}
+	for _, n := range o.getMoveNodes() {
+		selected, notSelected := clusterTenants(n)
+		for tenant := range n.tenant {
+			if _, isCluster := selectedClusters[tenant]; isCluster {
+				continue
+			}
+			if selected {
+				sharedTenantsOfSelected[tenant] = empty{}
+			}
+			if notSelected {
+				sharedTenantsOfNotSelected[tenant] = empty{}
+			}
+		}
+	}
+
+	for _, n := range o.getMoveNodes() {
+		selected, notSelected := clusterTenants(n)
+
+		// If the node belongs to Clusters, move it only if it belongs to a selected Cluster;
+		// don't delete it if it belongs also to a not selected Cluster.
+		if selected || notSelected {
+			if !selected {
+				delete(o.uidToNode, n.identity.UID)
+				continue
+			}
+			n.shouldNotDelete = n.shouldNotDelete || notSelected
+			continue
+		}
+
+		// Otherwise the node is a shared object; move it only if it is related to a selected Cluster;
+		// don't delete it if it is related also to a not selected Cluster.
+		relatedToSelected, relatedToNotSelected, isClusterClassOrClusterResourceSetHierarchy := false, false, false
+		for tenant := range n.tenant {
+			_, ok := sharedTenantsOfSelected[tenant]
+			relatedToSelected = relatedToSelected || ok
+			_, ok = sharedTenantsOfNotSelected[tenant]
+			relatedToNotSelected = relatedToNotSelected || ok
+			tenantGK := tenant.identity.GroupVersionKind().GroupKind()
+			isClusterClassOrClusterResourceSetHierarchy = isClusterClassOrClusterResourceSetHierarchy ||
+				tenantGK == clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind() ||
+				tenantGK == addonsv1.GroupVersion.WithKind("ClusterResourceSet").GroupKind()
+		}
+		switch {
+		case relatedToSelected:
+			n.shouldNotDelete = n.shouldNotDelete || relatedToNotSelected
+		case relatedToNotSelected || isClusterClassOrClusterResourceSetHierarchy:
+			delete(o.uidToNode, n.identity.UID)
+		default:
+			n.shouldNotDelete = true
+		}
+	}
+
+	return nil
+}
+
 // checkVirtualNode logs if nodes are still virtual.
 func (o *objectGraph) checkVirtualNode() {
 	log := logf.Log
@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -2774,3 +2775,199 @@ func deduplicateObjects(objs []client.Object) []client.Object {
 	}
 	return res
 }
+
+func Test_objectGraph_filterClusters(t *testing.T) {
+	type args struct {
+		objs            []client.Object
+		clusterSelector ClusterSelector
+	}
+	tests := []struct {
+		name                string
+		args                args
+		wantMoveNodes       []string
+		wantShouldNotDelete []string
+		wantErr             bool
+	}{
+		{
+			name: "An empty selector selects all the Clusters",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").Objs()...)
+					return objs
+				}(),
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster2",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster2",
+				"/v1, Kind=Secret, ns1/cluster2-ca",
+				"/v1, Kind=Secret, ns1/cluster2-kubeconfig",
+			},
+		},
+		{
+			name: "Select a Cluster by name",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").Objs()...)
+					return objs
+				}(),
+				clusterSelector: ClusterSelector{Names: []string{"cluster1"}},
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+			},
+		},
+		{
+			name: "Select a Cluster by label",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithLabels(map[string]string{"env": "prod"}).Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithLabels(map[string]string{"env": "dev"}).Objs()...)
+					return objs
+				}(),
+				clusterSelector: ClusterSelector{Labels: labels.SelectorFromSet(labels.Set{"env": "prod"})},
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+			},
+		},
+		{
+			name: "A ClusterClass used by selected and not selected Clusters is copied but not deleted",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class1").Objs()...)
+					return deduplicateObjects(objs)
+				}(),
+				clusterSelector: ClusterSelector{Names: []string{"cluster1"}},
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=ClusterClass, ns1/class1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				clusterv1.GroupVersionControlPlane.String() + ", Kind=GenericControlPlaneTemplate, ns1/class1",
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+			},
+			wantShouldNotDelete: []string{
+				clusterv1.GroupVersion.String() + ", Kind=ClusterClass, ns1/class1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				clusterv1.GroupVersionControlPlane.String() + ", Kind=GenericControlPlaneTemplate, ns1/class1",
+			},
+		},
+		{
+			name: "A ClusterClass used only by selected Clusters is moved, a ClusterClass used only by not selected Clusters is not",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class2").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class2").Objs()...)
+					return deduplicateObjects(objs)
+				}(),
+				clusterSelector: ClusterSelector{Names: []string{"cluster1"}},
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=ClusterClass, ns1/class1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				clusterv1.GroupVersionControlPlane.String() + ", Kind=GenericControlPlaneTemplate, ns1/class1",
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+			},
+		},
+		{
+			name: "ClusterResourceSets not applied to selected Clusters are not moved, objects not linked to any Cluster are copied but not deleted",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+					cluster2 := test.NewFakeCluster("ns1", "cluster2").Objs()
+					objs = append(objs, cluster2...)
+					objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+						WithSecret("resource-s1").
+						ApplyToCluster(test.SelectClusterObj(cluster2, "ns1", "cluster2")).
+						Objs()...)
+					objs = append(objs, test.NewFakeExternalObject("ns1", "externalObject1").Objs()...)
+					return objs
+				}(),
+				clusterSelector: ClusterSelector{Names: []string{"cluster1"}},
+			},
+			wantMoveNodes: []string{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1",
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1",
+				"/v1, Kind=Secret, ns1/cluster1-ca",
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig",
+				"external.cluster.x-k8s.io/v1beta2, Kind=GenericExternalObject, ns1/externalObject1",
+			},
+			wantShouldNotDelete: []string{
+				"external.cluster.x-k8s.io/v1beta2, Kind=GenericExternalObject, ns1/externalObject1",
+			},
+		},
+		{
+			name: "Return an error if a Cluster selected by name does not exist",
+			args: args{
+				objs:            test.NewFakeCluster("ns1", "cluster1").Objs(),
+				clusterSelector: ClusterSelector{Names: []string{"cluster1", "cluster2"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Return an error if no Clusters are selected",
+			args: args{
+				objs:            test.NewFakeCluster("ns1", "cluster1").Objs(),
+				clusterSelector: ClusterSelector{Labels: labels.SelectorFromSet(labels.Set{"env": "prod"})},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			graph := getObjectGraphWithObjs(tt.args.objs)
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+			g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+			err := graph.filterClusters(tt.args.clusterSelector)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotMoveNodes := []string{}
+			gotShouldNotDelete := []string{}
+			for _, n := range graph.getMoveNodes() {
+				gotMoveNodes = append(gotMoveNodes, string(n.identity.UID))
+				if n.shouldNotDelete {
+					gotShouldNotDelete = append(gotShouldNotDelete, string(n.identity.UID))
+				}
+			}
+			g.Expect(gotMoveNodes).To(ConsistOf(tt.wantMoveNodes))
+			g.Expect(gotShouldNotDelete).To(ConsistOf(tt.wantShouldNotDelete))
+		})
+	}
+}
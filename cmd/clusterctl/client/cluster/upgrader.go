@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -49,18 +50,39 @@ type ProviderUpgrader interface {
 
 	// ApplyCustomPlan plan executes an upgrade using the UpgradeItems provided by the user.
 	ApplyCustomPlan(ctx context.Context, opts UpgradeOptions, providersToUpgrade ...UpgradeItem) error
+
+	// ValidateCustomPlan performs the same checks of ApplyCustomPlan without changing the management cluster;
+	// providersToDelete are not considered by the checks, because they are going to be deleted before the upgrade.
+	ValidateCustomPlan(ctx context.Context, opts UpgradeOptions, providersToDelete []clusterctlv1.Provider, providersToUpgrade ...UpgradeItem) error
+
+	// CheckCompatibility checks if the workload Clusters in the management cluster are compatible with the target
+	// versions of the providers in an UpgradePlan.
+	CheckCompatibility(ctx context.Context, upgradePlan UpgradePlan) ([]ClusterCompatibility, error)
+
+	// Rollback restores the providers to the version they had before the last upgrade, using the snapshots
+	// taken by ApplyPlan and ApplyCustomPlan. If no provider is specified, all the providers with a snapshot taken by the
+	// last upgrade are rolled back.
+	Rollback(ctx context.Context, providers ...clusterctlv1.Provider) error
 }
 
 // UpgradePlan defines a list of possible upgrade targets for a management cluster.
 type UpgradePlan struct {
 	Contract  string
 	Providers []UpgradeItem
+
+	// Clusters reports the compatibility of the workload Clusters with the target versions of the providers.
+	// NOTE: this is computed only for upgrade plans that can be applied by the current version of clusterctl.
+	Clusters []ClusterCompatibility
 }
 
 // UpgradeOptions defines the options used to upgrade installation.
 type UpgradeOptions struct {
 	WaitProviders       bool
 	WaitProviderTimeout time.Duration
+
+	// Force instructs the upgrade to proceed even if there are workload Clusters not compatible with
+	// the target versions of the providers.
+	Force bool
 }
 
 // isPartialUpgrade returns true if at least one upgradeItem in the plan does not have a target version.
@@ -77,6 +99,8 @@ func (u *UpgradePlan) isPartialUpgrade() bool {
 type UpgradeItem struct {
 	clusterctlv1.Provider
 	NextVersion string
+	// FeatureGates to be set on the provider's controller when installing the next version.
+	FeatureGates map[string]bool
 }
 
 // UpgradeRef returns a string identifying the upgrade item; this string is derived by the provider.
@@ -191,9 +215,14 @@ func (u *providerUpgrader) ApplyCustomPlan(ctx context.Context, opts UpgradeOpti
 	log := logf.Log
 	log.Info("Performing upgrade...")
 
+	providerList, err := u.providerInventory.List(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Create a custom upgrade plan from the upgrade items, taking care of ensuring all the providers in a management
 	// cluster are consistent with the contract version of the core provider (or compatible ones).
-	upgradePlan, err := u.createCustomPlan(ctx, upgradeItems)
+	upgradePlan, err := u.createCustomPlan(ctx, providerList.Items, upgradeItems)
 	if err != nil {
 		return err
 	}
@@ -202,6 +231,31 @@ func (u *providerUpgrader) ApplyCustomPlan(ctx context.Context, opts UpgradeOpti
 	return u.doUpgrade(ctx, upgradePlan, opts)
 }
 
+func (u *providerUpgrader) ValidateCustomPlan(ctx context.Context, opts UpgradeOptions, providersToDelete []clusterctlv1.Provider, upgradeItems ...UpgradeItem) error {
+	providerList, err := u.providerInventory.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Simulate the management cluster after deleting providersToDelete.
+	deleted := sets.Set[string]{}
+	for _, provider := range providersToDelete {
+		deleted.Insert(provider.InstanceName())
+	}
+	providers := []clusterctlv1.Provider{}
+	for _, provider := range providerList.Items {
+		if !deleted.Has(provider.InstanceName()) {
+			providers = append(providers, provider)
+		}
+	}
+
+	upgradePlan, err := u.createCustomPlan(ctx, providers, upgradeItems)
+	if err != nil {
+		return err
+	}
+	return u.validateUpgrade(ctx, upgradePlan, opts, providers)
+}
+
 // getUpgradePlan returns the upgrade plan for a specific set of providers/contract
 // NB. this function is used both for upgrade plan and upgrade apply.
 func (u *providerUpgrader) getUpgradePlan(ctx context.Context, providers []clusterctlv1.Provider, contract string) (*UpgradePlan, error) {
@@ -233,17 +287,14 @@ func (u *providerUpgrader) getUpgradePlan(ctx context.Context, providers []clust
 
 // createCustomPlan creates a custom upgrade plan from a set of upgrade items, taking care of ensuring all the providers
 // in a management cluster are consistent with the contract version of the core provider (or compatible ones).
-func (u *providerUpgrader) createCustomPlan(ctx context.Context, upgradeItems []UpgradeItem) (*UpgradePlan, error) {
+func (u *providerUpgrader) createCustomPlan(ctx context.Context, providers []clusterctlv1.Provider, upgradeItems []UpgradeItem) (*UpgradePlan, error) {
 	// Gets the contract version of the core provider.
 	// The this is required to ensure all the providers in a management cluster are consistent with the contract supported by the core provider.
 	// e.g if the core provider is v1beta1, all the provider should be v1beta1 as well.
 
 	// The target contract is derived from the current version of the core provider, or, if the core provider is included in the upgrade list,
 	// from its target version.
-	providerList, err := u.providerInventory.List(ctx)
-	if err != nil {
-		return nil, err
-	}
+	providerList := &clusterctlv1.ProviderList{Items: providers}
 	coreProviders := providerList.FilterCore()
 	if len(coreProviders) != 1 {
 		return nil, errors.Errorf("invalid management cluster: there must be one core provider, found %d", len(coreProviders))
@@ -360,6 +411,7 @@ func (u *providerUpgrader) getUpgradeComponents(ctx context.Context, provider Up
 	options := repository.ComponentsOptions{
 		Version:         provider.NextVersion,
 		TargetNamespace: provider.Namespace,
+		FeatureGates:    provider.FeatureGates,
 	}
 	components, err := providerRepository.Components().Get(ctx, options)
 	if err != nil {
@@ -369,39 +421,13 @@ func (u *providerUpgrader) getUpgradeComponents(ctx context.Context, provider Up
 }
 
 func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePlan, opts UpgradeOptions) error {
-	// Check for multiple instances of the same provider (not supported).
-	if err := u.providerInventory.CheckSingleProviderInstance(ctx); err != nil {
+	providerList, err := u.providerInventory.List(ctx)
+	if err != nil {
 		return err
 	}
 
-	// Block unsupported skip upgrades for Core, Kubeadm Bootstrap, Kubeadm ControlPlane.
-	// NOTE: in future we might consider extending the clusterctl contract to support enforcing of skip upgrade
-	// rules for out of tree providers.
-	minVersionSkew := semver.MustParse("1.10.0")
-	for _, upgradeItem := range upgradePlan.Providers {
-		if upgradeItem.Type != string(clusterctlv1.CoreProviderType) &&
-			(upgradeItem.Type != string(clusterctlv1.BootstrapProviderType) || upgradeItem.ProviderName != config.KubeadmBootstrapProviderName) &&
-			(upgradeItem.Type != string(clusterctlv1.ControlPlaneProviderType) || upgradeItem.ProviderName != config.KubeadmControlPlaneProviderName) {
-			continue
-		}
-
-		currentVersion, err := semver.ParseTolerant(upgradeItem.Version)
-		if err != nil {
-			return errors.Wrapf(err, "failed to parse current version for %s provider", upgradeItem.InstanceName())
-		}
-
-		if currentVersion.LT(minVersionSkew) {
-			continue
-		}
-
-		nextVersion, err := semver.ParseTolerant(upgradeItem.NextVersion)
-		if err != nil {
-			return errors.Wrapf(err, "failed to parse next version for %s provider", upgradeItem.InstanceName())
-		}
-
-		if nextVersion.Minor > currentVersion.Minor+3 {
-			return errors.Errorf("upgrade for %s provider can't skip more than 3 versions", upgradeItem.InstanceName())
-		}
+	if err := u.validateUpgrade(ctx, upgradePlan, opts, providerList.Items); err != nil {
+		return err
 	}
 
 	// Ensure Providers are updated in the following order: Core, Bootstrap, ControlPlane, Infrastructure.
@@ -410,6 +436,19 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		return providers[a].GetProviderType().Order() < providers[b].GetProviderType().Order()
 	})
 
+	// Take a snapshot of all the providers, so it is possible to roll back the upgrade.
+	providersToSnapshot := []clusterctlv1.Provider{}
+	for _, upgradeItem := range providers {
+		// If there is not a specified next version, skip it (we are already up-to-date).
+		if upgradeItem.NextVersion == "" {
+			continue
+		}
+		providersToSnapshot = append(providersToSnapshot, upgradeItem.Provider)
+	}
+	if err := u.snapshotProviders(ctx, providersToSnapshot...); err != nil {
+		return err
+	}
+
 	// Scale down all providers.
 	// This is done to ensure all Pods of all "old" provider Deployments have been deleted.
 	// Otherwise it can happen that a provider Pod survives the upgrade because we create
@@ -463,7 +502,77 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		}
 	}
 
-	return waitForProvidersReady(ctx, InstallOptions(opts), installQueue, u.proxy)
+	return waitForProvidersReady(ctx, InstallOptions{WaitProviders: opts.WaitProviders, WaitProviderTimeout: opts.WaitProviderTimeout}, installQueue, u.proxy)
+}
+
+// validateUpgrade checks an upgrade plan can be applied to a management cluster with the given providers.
+func (u *providerUpgrader) validateUpgrade(ctx context.Context, upgradePlan *UpgradePlan, opts UpgradeOptions, providers []clusterctlv1.Provider) error {
+	// Check for multiple instances of the same provider (not supported).
+	if err := checkSingleProviderInstance(providers); err != nil {
+		return err
+	}
+
+	// Block unsupported skip upgrades for Core, Kubeadm Bootstrap, Kubeadm ControlPlane.
+	// NOTE: in future we might consider extending the clusterctl contract to support enforcing of skip upgrade
+	// rules for out of tree providers.
+	minVersionSkew := semver.MustParse("1.10.0")
+	for _, upgradeItem := range upgradePlan.Providers {
+		if upgradeItem.Type != string(clusterctlv1.CoreProviderType) &&
+			(upgradeItem.Type != string(clusterctlv1.BootstrapProviderType) || upgradeItem.ProviderName != config.KubeadmBootstrapProviderName) &&
+			(upgradeItem.Type != string(clusterctlv1.ControlPlaneProviderType) || upgradeItem.ProviderName != config.KubeadmControlPlaneProviderName) {
+			continue
+		}
+
+		currentVersion, err := semver.ParseTolerant(upgradeItem.Version)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse current version for %s provider", upgradeItem.InstanceName())
+		}
+
+		if currentVersion.LT(minVersionSkew) {
+			continue
+		}
+
+		nextVersion, err := semver.ParseTolerant(upgradeItem.NextVersion)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse next version for %s provider", upgradeItem.InstanceName())
+		}
+
+		if nextVersion.Minor > currentVersion.Minor+3 {
+			return errors.Errorf("upgrade for %s provider can't skip more than 3 versions", upgradeItem.InstanceName())
+		}
+	}
+
+	// Block upgrades breaking workload Clusters, unless the upgrade is forced.
+	if !opts.Force {
+		if err := u.checkClustersCompatibility(ctx, upgradePlan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkClustersCompatibility returns an error if there are workload Clusters not compatible with the target versions of the providers in the upgrade plan.
+func (u *providerUpgrader) checkClustersCompatibility(ctx context.Context, upgradePlan *UpgradePlan) error {
+	compatibility, err := u.CheckCompatibility(ctx, *upgradePlan)
+	if err != nil {
+		return err
+	}
+
+	log := logf.Log
+	issues := []string{}
+	for _, cluster := range compatibility {
+		for _, issue := range cluster.Issues {
+			if issue.Severity != CompatibilityIssueError {
+				log.Info(fmt.Sprintf("Warning: Cluster %s/%s: %s", cluster.Namespace, cluster.Name, issue.Message))
+				continue
+			}
+			issues = append(issues, fmt.Sprintf("Cluster %s/%s: %s", cluster.Namespace, cluster.Name, issue.Message))
+		}
+	}
+	if len(issues) > 0 {
+		return errors.Errorf("unable to perform upgrade: the following workload Clusters are not compatible with the target provider versions:\n- %s\nPlease address these issues or use --force to skip this check", strings.Join(issues, "\n- "))
+	}
+	return nil
 }
 
 func (u *providerUpgrader) scaleDownProvider(ctx context.Context, provider clusterctlv1.Provider) error {
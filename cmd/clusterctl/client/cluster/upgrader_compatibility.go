@@ -0,0 +1,472 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+)
+
+// CompatibilityIssueSeverity defines the severity of a CompatibilityIssue.
+type CompatibilityIssueSeverity string
+
+const (
+	// CompatibilityIssueError identifies issues blocking the upgrade, unless the upgrade is forced.
+	CompatibilityIssueError CompatibilityIssueSeverity = "Error"
+
+	// CompatibilityIssueWarning identifies issues that do not block the upgrade, but that should be addressed
+	// before the next upgrade, e.g. usage of deprecated API versions.
+	CompatibilityIssueWarning CompatibilityIssueSeverity = "Warning"
+)
+
+// CompatibilityIssue defines an issue preventing a Cluster to work with the target version of a provider.
+type CompatibilityIssue struct {
+	// Severity of the issue.
+	Severity CompatibilityIssueSeverity
+
+	// Provider is the instance name of the provider the issue refers to, if any.
+	Provider string
+
+	// Message describes the issue.
+	Message string
+}
+
+// ClusterCompatibility reports the compatibility of a workload Cluster with the target versions of the providers in an UpgradePlan.
+type ClusterCompatibility struct {
+	// Namespace of the Cluster.
+	Namespace string
+
+	// Name of the Cluster.
+	Name string
+
+	// ClusterClass used by the Cluster, if any, in the form namespace/name.
+	ClusterClass string
+
+	// KubernetesVersion of the Cluster, if known.
+	KubernetesVersion string
+
+	// Issues detected for the Cluster.
+	Issues []CompatibilityIssue
+}
+
+// IsCompatible returns true if there are no issues blocking the upgrade for the Cluster.
+func (c ClusterCompatibility) IsCompatible() bool {
+	for _, issue := range c.Issues {
+		if issue.Severity == CompatibilityIssueError {
+			return false
+		}
+	}
+	return true
+}
+
+// upgradeTarget holds the information about the target version of a provider required for checking Cluster compatibility.
+type upgradeTarget struct {
+	// provider is the instance name of the provider.
+	provider string
+
+	// providerType is the type of the provider.
+	providerType clusterctlv1.ProviderType
+
+	// version is the target version of the provider.
+	version string
+
+	// releaseSeries is the release series of the target version, as defined in the provider metadata.
+	releaseSeries *clusterctlv1.ReleaseSeries
+
+	// crds are the CRDs in the components YAML of the target version, by GroupKind.
+	crds map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition
+}
+
+func (u *providerUpgrader) CheckCompatibility(ctx context.Context, upgradePlan UpgradePlan) ([]ClusterCompatibility, error) {
+	targets, err := u.getUpgradeTargets(ctx, upgradePlan)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no provider is going to change, all the Clusters are compatible.
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	c, err := u.proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		return c.List(ctx, clusterList)
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	ret := make([]ClusterCompatibility, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		compatibility, err := checkClusterCompatibility(ctx, c, &clusterList.Items[i], targets)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *compatibility)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// getUpgradeTargets returns the upgradeTarget for each provider in the upgrade plan with a target version.
+func (u *providerUpgrader) getUpgradeTargets(ctx context.Context, upgradePlan UpgradePlan) ([]upgradeTarget, error) {
+	targets := []upgradeTarget{}
+	for _, upgradeItem := range upgradePlan.Providers {
+		// If there is not a specified next version, skip it (the provider is not going to change).
+		if upgradeItem.NextVersion == "" {
+			continue
+		}
+
+		nextVersion, err := version.ParseSemantic(upgradeItem.NextVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse next version for the %s provider", upgradeItem.InstanceName())
+		}
+
+		upgradeInfo, err := u.getUpgradeInfo(ctx, upgradeItem.Provider)
+		if err != nil {
+			return nil, err
+		}
+
+		releaseSeries := upgradeInfo.metadata.GetReleaseSeriesForVersion(nextVersion)
+		if releaseSeries == nil {
+			return nil, errors.Errorf("invalid target version: version %s for the provider %s does not match any release series", upgradeItem.NextVersion, upgradeItem.InstanceName())
+		}
+		for _, v := range []string{releaseSeries.MinKubernetesVersion, releaseSeries.MaxKubernetesVersion} {
+			if v == "" {
+				continue
+			}
+			if _, err := semver.ParseTolerant(v); err != nil {
+				return nil, errors.Wrapf(err, "invalid provider metadata: failed to parse Kubernetes version %q for the release series %d.%d of the provider %s", v, releaseSeries.Major, releaseSeries.Minor, upgradeItem.InstanceName())
+			}
+		}
+
+		components, err := u.getUpgradeComponents(ctx, upgradeItem)
+		if err != nil {
+			return nil, err
+		}
+
+		crds := map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition{}
+		for _, obj := range components.Objs() {
+			if obj.GetKind() != "CustomResourceDefinition" {
+				continue
+			}
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			if err := scheme.Scheme.Convert(&obj, crd, nil); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert CRD %q", obj.GetName())
+			}
+			crds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd
+		}
+
+		targets = append(targets, upgradeTarget{
+			provider:      upgradeItem.InstanceName(),
+			providerType:  upgradeItem.GetProviderType(),
+			version:       upgradeItem.NextVersion,
+			releaseSeries: releaseSeries,
+			crds:          crds,
+		})
+	}
+	return targets, nil
+}
+
+// checkClusterCompatibility checks if a Cluster is compatible with the target versions of the providers it references.
+// The core provider is referenced by all the Clusters; other providers are referenced by a Cluster if one of the
+// infrastructure, control plane or bootstrap config objects used by the Cluster is of a kind defined by the provider.
+func checkClusterCompatibility(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, targets []upgradeTarget) (*ClusterCompatibility, error) {
+	compatibility := &ClusterCompatibility{
+		Namespace:         cluster.Namespace,
+		Name:              cluster.Name,
+		KubernetesVersion: getClusterKubernetesVersion(ctx, c, cluster),
+	}
+
+	referencedKinds, err := getClusterReferencedKinds(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterClass *clusterv1.ClusterClass
+	if cluster.Spec.Topology.IsDefined() {
+		clusterClassKey := cluster.GetClassKey()
+		compatibility.ClusterClass = clusterClassKey.String()
+
+		clusterClass = &clusterv1.ClusterClass{}
+		clusterClassNotFound := false
+		if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+			err := c.Get(ctx, clusterClassKey, clusterClass)
+			if apierrors.IsNotFound(err) {
+				clusterClassNotFound = true
+				return nil
+			}
+			return err
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to get ClusterClass %s", clusterClassKey)
+		}
+		if clusterClassNotFound {
+			clusterClass = nil
+			compatibility.Issues = append(compatibility.Issues, CompatibilityIssue{
+				Severity: CompatibilityIssueWarning,
+				Message:  fmt.Sprintf("ClusterClass %s not found, unable to check ClusterClass templates", clusterClassKey),
+			})
+		}
+	}
+	if clusterClass != nil {
+		for _, ref := range clusterClassTemplateRefs(clusterClass) {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil {
+				continue
+			}
+			referencedKinds.Insert(schema.GroupKind{Group: gv.Group, Kind: ref.Kind})
+		}
+	}
+
+	for _, target := range targets {
+		if !target.isReferenced(referencedKinds) {
+			continue
+		}
+
+		if compatibility.KubernetesVersion != "" {
+			compatibility.Issues = append(compatibility.Issues, target.checkKubernetesVersion(compatibility.KubernetesVersion)...)
+		}
+
+		if clusterClass != nil {
+			clusterClassKey := client.ObjectKeyFromObject(clusterClass)
+			for _, ref := range clusterClassTemplateRefs(clusterClass) {
+				compatibility.Issues = append(compatibility.Issues, target.checkAPIVersion(fmt.Sprintf("ClusterClass %s", clusterClassKey), ref.Kind, ref.Name, ref.APIVersion)...)
+			}
+		}
+
+		// For Clusters not using a ClusterClass, check the API versions of the objects referenced by the Cluster;
+		// for Clusters using a ClusterClass, the topology controller recreates those objects from the ClusterClass templates.
+		if !cluster.Spec.Topology.IsDefined() {
+			for _, ref := range []clusterv1.ContractVersionedObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+				compatibility.Issues = append(compatibility.Issues, target.checkContractVersionedRef(ctx, c, cluster, ref)...)
+			}
+		}
+	}
+	return compatibility, nil
+}
+
+// getClusterReferencedKinds returns the kinds of the infrastructure, control plane and bootstrap config objects
+// referenced by a Cluster and by its Machines.
+func getClusterReferencedKinds(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) (sets.Set[schema.GroupKind], error) {
+	kinds := sets.New[schema.GroupKind]()
+	for _, ref := range []clusterv1.ContractVersionedObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+		if ref.IsDefined() {
+			kinds.Insert(ref.GroupKind())
+		}
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		return c.List(ctx, machineList, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name})
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines for Cluster %s", klog.KObj(cluster))
+	}
+	for _, machine := range machineList.Items {
+		for _, ref := range []clusterv1.ContractVersionedObjectReference{machine.Spec.InfrastructureRef, machine.Spec.Bootstrap.ConfigRef} {
+			if ref.IsDefined() {
+				kinds.Insert(ref.GroupKind())
+			}
+		}
+	}
+	return kinds, nil
+}
+
+// getClusterKubernetesVersion returns the Kubernetes version of a Cluster; for Clusters using a managed topology
+// the version defined in the topology is used, otherwise the version defined in the control plane.
+// Nb. errors are ignored, because the Kubernetes version could be not known, e.g. the control plane is not yet created.
+func getClusterKubernetesVersion(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) string {
+	if cluster.Spec.Topology.IsDefined() {
+		return cluster.Spec.Topology.Version
+	}
+	if !cluster.Spec.ControlPlaneRef.IsDefined() {
+		return ""
+	}
+	controlPlane, err := external.GetObjectFromContractVersionedRef(ctx, c, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		return ""
+	}
+	v, err := contract.ControlPlane().Version().Get(controlPlane)
+	if err != nil || v == nil {
+		return ""
+	}
+	return *v
+}
+
+// clusterClassTemplateRefs returns all the template references in a ClusterClass.
+func clusterClassTemplateRefs(clusterClass *clusterv1.ClusterClass) []clusterv1.ClusterClassTemplateReference {
+	refs := []clusterv1.ClusterClassTemplateReference{
+		clusterClass.Spec.Infrastructure.TemplateRef,
+		clusterClass.Spec.ControlPlane.TemplateRef,
+		clusterClass.Spec.ControlPlane.MachineInfrastructure.TemplateRef,
+	}
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		refs = append(refs, md.Bootstrap.TemplateRef, md.Infrastructure.TemplateRef)
+	}
+	for _, mp := range clusterClass.Spec.Workers.MachinePools {
+		refs = append(refs, mp.Bootstrap.TemplateRef, mp.Infrastructure.TemplateRef)
+	}
+
+	ret := []clusterv1.ClusterClassTemplateReference{}
+	for _, ref := range refs {
+		if ref.APIVersion == "" || ref.Kind == "" {
+			continue
+		}
+		ret = append(ret, ref)
+	}
+	return ret
+}
+
+// checkKubernetesVersion checks if a Kubernetes version is in the range supported by the target release series.
+// NOTE: only major and minor are considered, because release series define supported Kubernetes minor versions.
+func (t *upgradeTarget) checkKubernetesVersion(kubernetesVersion string) []CompatibilityIssue {
+	if t.releaseSeries.MinKubernetesVersion == "" && t.releaseSeries.MaxKubernetesVersion == "" {
+		return nil
+	}
+
+	v, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return []CompatibilityIssue{{
+			Severity: CompatibilityIssueWarning,
+			Provider: t.provider,
+			Message:  fmt.Sprintf("unable to parse Kubernetes version %q, unable to check compatibility with %s %s", kubernetesVersion, t.provider, t.version),
+		}}
+	}
+	v = semver.Version{Major: v.Major, Minor: v.Minor}
+
+	// Nb. min and max versions are validated in getUpgradeTargets.
+	if t.releaseSeries.MinKubernetesVersion != "" {
+		minVersion, _ := semver.ParseTolerant(t.releaseSeries.MinKubernetesVersion)
+		if v.LT(semver.Version{Major: minVersion.Major, Minor: minVersion.Minor}) {
+			return []CompatibilityIssue{{
+				Severity: CompatibilityIssueError,
+				Provider: t.provider,
+				Message:  fmt.Sprintf("Kubernetes version %s is older than %s, the oldest version supported by %s %s", kubernetesVersion, t.releaseSeries.MinKubernetesVersion, t.provider, t.version),
+			}}
+		}
+	}
+	if t.releaseSeries.MaxKubernetesVersion != "" {
+		maxVersion, _ := semver.ParseTolerant(t.releaseSeries.MaxKubernetesVersion)
+		if v.GT(semver.Version{Major: maxVersion.Major, Minor: maxVersion.Minor}) {
+			return []CompatibilityIssue{{
+				Severity: CompatibilityIssueError,
+				Provider: t.provider,
+				Message:  fmt.Sprintf("Kubernetes version %s is newer than %s, the newest version supported by %s %s", kubernetesVersion, t.releaseSeries.MaxKubernetesVersion, t.provider, t.version),
+			}}
+		}
+	}
+	return nil
+}
+
+// isReferenced returns true if the provider is referenced by a Cluster using the given kinds.
+// NOTE: the core provider is referenced by all the Clusters.
+func (t *upgradeTarget) isReferenced(referencedKinds sets.Set[schema.GroupKind]) bool {
+	if t.providerType == clusterctlv1.CoreProviderType {
+		return true
+	}
+	for gk := range referencedKinds {
+		if _, ok := t.crds[gk]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkContractVersionedRef checks if the API version currently used for an object referenced by a Cluster
+// is served by the CRDs of the target version.
+// NOTE: the API version is resolved using the contract labels of the CRD installed in the cluster.
+func (t *upgradeTarget) checkContractVersionedRef(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, ref clusterv1.ContractVersionedObjectReference) []CompatibilityIssue {
+	if !ref.IsDefined() {
+		return nil
+	}
+
+	// Skip objects not belonging to this provider.
+	if _, ok := t.crds[ref.GroupKind()]; !ok {
+		return nil
+	}
+
+	apiVersion, err := contract.GetAPIVersion(ctx, c, ref.GroupKind())
+	if err != nil {
+		return []CompatibilityIssue{{
+			Severity: CompatibilityIssueWarning,
+			Provider: t.provider,
+			Message:  fmt.Sprintf("unable to determine the API version of %s %s referenced by Cluster %s, unable to check compatibility with %s %s", ref.Kind, ref.Name, klog.KObj(cluster), t.provider, t.version),
+		}}
+	}
+	return t.checkAPIVersion(fmt.Sprintf("Cluster %s", klog.KObj(cluster)), ref.Kind, ref.Name, apiVersion)
+}
+
+// checkAPIVersion checks if the API version of an object referenced by a Cluster or a ClusterClass is served by the CRDs of the target version.
+func (t *upgradeTarget) checkAPIVersion(referencedBy, kind, name, apiVersion string) []CompatibilityIssue {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil
+	}
+
+	// Skip objects not belonging to this provider.
+	crd, ok := t.crds[schema.GroupKind{Group: gv.Group, Kind: kind}]
+	if !ok {
+		return nil
+	}
+
+	crdVersion := versionForCRD(crd, gv.Version)
+	if crdVersion == nil || !crdVersion.Served {
+		return []CompatibilityIssue{{
+			Severity: CompatibilityIssueError,
+			Provider: t.provider,
+			Message:  fmt.Sprintf("%s references %s %s using API version %s, which is not served by %s %s", referencedBy, kind, name, apiVersion, t.provider, t.version),
+		}}
+	}
+	if crdVersion.Deprecated {
+		message := fmt.Sprintf("%s references %s %s using API version %s, which is deprecated in %s %s", referencedBy, kind, name, apiVersion, t.provider, t.version)
+		if crdVersion.DeprecationWarning != nil {
+			message = fmt.Sprintf("%s: %s", message, *crdVersion.DeprecationWarning)
+		}
+		return []CompatibilityIssue{{
+			Severity: CompatibilityIssueWarning,
+			Provider: t.provider,
+			Message:  message,
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,469 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/contract"
+)
+
+// compatibilityTestComponents returns components YAML defining a FooClusterTemplate CRD, serving v1beta2 and the deprecated v1beta1.
+func compatibilityTestComponents() []byte {
+	return []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: fooclustertemplates.infrastructure.cluster.x-k8s.io
+spec:
+  group: infrastructure.cluster.x-k8s.io
+  names:
+    kind: FooClusterTemplate
+    listKind: FooClusterTemplateList
+    plural: fooclustertemplates
+    singular: fooclustertemplate
+  scope: Namespaced
+  versions:
+  - name: v1beta2
+    served: true
+    storage: true
+  - name: v1beta1
+    served: true
+    storage: false
+    deprecated: true
+    deprecationWarning: v1beta1 is deprecated, use v1beta2
+`)
+}
+
+func compatibilityTestClusterClass(name, infrastructureAPIVersion string) *clusterv1.ClusterClass {
+	return &clusterv1.ClusterClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "ClusterClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      name,
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.InfrastructureClass{
+				TemplateRef: clusterv1.ClusterClassTemplateReference{
+					APIVersion: infrastructureAPIVersion,
+					Kind:       "FooClusterTemplate",
+					Name:       name,
+				},
+			},
+			ControlPlane: clusterv1.ControlPlaneClass{
+				TemplateRef: clusterv1.ClusterClassTemplateReference{
+					APIVersion: "controlplane.cluster.x-k8s.io/v1alpha1",
+					Kind:       "BarControlPlaneTemplate",
+					Name:       name,
+				},
+			},
+		},
+	}
+}
+
+func compatibilityTestCluster(name, clusterClass, kubernetesVersion string) *clusterv1.Cluster {
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      name,
+		},
+	}
+	if clusterClass != "" {
+		cluster.Spec.Topology = clusterv1.Topology{
+			ClassRef: clusterv1.ClusterClassRef{Name: clusterClass},
+			Version:  kubernetesVersion,
+		}
+	}
+	return cluster
+}
+
+func compatibilityTestUpgrader(g *WithT, objs ...client.Object) *providerUpgrader {
+	ctx := context.Background()
+
+	reader := test.NewFakeReader().
+		WithProvider("cluster-api", clusterctlv1.CoreProviderType, "https://somewhere.com").
+		WithProvider("infra", clusterctlv1.InfrastructureProviderType, "https://somewhere.com")
+	repositories := map[string]repository.Repository{
+		"cluster-api": repository.NewMemoryRepository().
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v1.1.0").
+			WithVersions("v1.0.0", "v1.1.0").
+			WithFile("v1.1.0", "components.yaml", []byte("")).
+			WithMetadata("v1.1.0", &clusterctlv1.Metadata{
+				ReleaseSeries: []clusterctlv1.ReleaseSeries{
+					{Major: 1, Minor: 0, Contract: currentContractVersion},
+					{Major: 1, Minor: 1, Contract: currentContractVersion, MinKubernetesVersion: "v1.30", MaxKubernetesVersion: "v1.32"},
+				},
+			}),
+		"infrastructure-infra": repository.NewMemoryRepository().
+			WithPaths("root", "components.yaml").
+			WithDefaultVersion("v2.1.0").
+			WithVersions("v2.0.0", "v2.1.0").
+			WithFile("v2.1.0", "components.yaml", compatibilityTestComponents()).
+			WithMetadata("v2.1.0", &clusterctlv1.Metadata{
+				ReleaseSeries: []clusterctlv1.ReleaseSeries{
+					{Major: 2, Minor: 0, Contract: currentContractVersion},
+					{Major: 2, Minor: 1, Contract: currentContractVersion},
+				},
+			}),
+	}
+	proxy := test.NewFakeProxy().
+		WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system").
+		WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-system").
+		WithObjs(objs...)
+
+	configClient, err := config.New(ctx, "", config.InjectReader(reader))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return &providerUpgrader{
+		configClient: configClient,
+		proxy:        proxy,
+		repositoryClientFactory: func(ctx context.Context, provider config.Provider, configClient config.Client, _ ...repository.Option) (repository.Client, error) {
+			return repository.New(ctx, provider, configClient, repository.InjectRepository(repositories[provider.ManifestLabel()]))
+		},
+		providerInventory:             newInventoryClient(proxy, nil, currentContractVersion),
+		currentContractVersion:        currentContractVersion,
+		getCompatibleContractVersions: getCompatibleContractVersions,
+	}
+}
+
+func Test_providerUpgrader_CheckCompatibility(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	u := compatibilityTestUpgrader(g,
+		compatibilityTestClusterClass("cc-v1beta2", "infrastructure.cluster.x-k8s.io/v1beta2"),
+		compatibilityTestClusterClass("cc-v1beta1", "infrastructure.cluster.x-k8s.io/v1beta1"),
+		compatibilityTestClusterClass("cc-v1alpha4", "infrastructure.cluster.x-k8s.io/v1alpha4"),
+		compatibilityTestCluster("compatible", "cc-v1beta2", "v1.31.2"),
+		compatibilityTestCluster("deprecated-api", "cc-v1beta1", "v1.32.0"),
+		compatibilityTestCluster("dropped-api", "cc-v1alpha4", "v1.31.0"),
+		compatibilityTestCluster("old-kubernetes", "cc-v1beta2", "v1.29.5"),
+		compatibilityTestCluster("new-kubernetes", "cc-v1beta2", "v1.33.0"),
+		compatibilityTestCluster("missing-clusterclass", "cc-missing", "v1.31.0"),
+		compatibilityTestCluster("no-topology", "", ""),
+	)
+
+	plan := UpgradePlan{
+		Contract: currentContractVersion,
+		Providers: []UpgradeItem{
+			{Provider: fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system"), NextVersion: "v1.1.0"},
+			{Provider: fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-system"), NextVersion: "v2.1.0"},
+		},
+	}
+
+	got, err := u.CheckCompatibility(ctx, plan)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	byName := map[string]ClusterCompatibility{}
+	for _, c := range got {
+		byName[c.Name] = c
+	}
+	g.Expect(byName).To(HaveLen(7))
+
+	g.Expect(byName["compatible"].IsCompatible()).To(BeTrue())
+	g.Expect(byName["compatible"].Issues).To(BeEmpty())
+	g.Expect(byName["compatible"].ClusterClass).To(Equal("ns1/cc-v1beta2"))
+	g.Expect(byName["compatible"].KubernetesVersion).To(Equal("v1.31.2"))
+
+	g.Expect(byName["deprecated-api"].IsCompatible()).To(BeTrue())
+	g.Expect(byName["deprecated-api"].Issues).To(HaveLen(1))
+	g.Expect(byName["deprecated-api"].Issues[0].Severity).To(Equal(CompatibilityIssueWarning))
+	g.Expect(byName["deprecated-api"].Issues[0].Provider).To(Equal("infra-system/infrastructure-infra"))
+	g.Expect(byName["deprecated-api"].Issues[0].Message).To(ContainSubstring("v1beta1 is deprecated, use v1beta2"))
+
+	g.Expect(byName["dropped-api"].IsCompatible()).To(BeFalse())
+	g.Expect(byName["dropped-api"].Issues).To(HaveLen(1))
+	g.Expect(byName["dropped-api"].Issues[0].Message).To(ContainSubstring("infrastructure.cluster.x-k8s.io/v1alpha4, which is not served by infra-system/infrastructure-infra v2.1.0"))
+
+	g.Expect(byName["old-kubernetes"].IsCompatible()).To(BeFalse())
+	g.Expect(byName["old-kubernetes"].Issues).To(HaveLen(1))
+	g.Expect(byName["old-kubernetes"].Issues[0].Provider).To(Equal("cluster-api-system/cluster-api"))
+	g.Expect(byName["old-kubernetes"].Issues[0].Message).To(ContainSubstring("older than v1.30"))
+
+	g.Expect(byName["new-kubernetes"].IsCompatible()).To(BeFalse())
+	g.Expect(byName["new-kubernetes"].Issues[0].Message).To(ContainSubstring("newer than v1.32"))
+
+	g.Expect(byName["missing-clusterclass"].IsCompatible()).To(BeTrue())
+	g.Expect(byName["missing-clusterclass"].Issues).To(HaveLen(1))
+	g.Expect(byName["missing-clusterclass"].Issues[0].Severity).To(Equal(CompatibilityIssueWarning))
+
+	g.Expect(byName["no-topology"].IsCompatible()).To(BeTrue())
+	g.Expect(byName["no-topology"].Issues).To(BeEmpty())
+	g.Expect(byName["no-topology"].KubernetesVersion).To(BeEmpty())
+
+	// Clusters are sorted by namespace and name.
+	g.Expect(got[0].Name).To(Equal("compatible"))
+	g.Expect(got[len(got)-1].Name).To(Equal("old-kubernetes"))
+}
+
+func Test_providerUpgrader_CheckCompatibility_noChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	u := compatibilityTestUpgrader(g, compatibilityTestCluster("old-kubernetes", "", "v1.29.5"))
+
+	got, err := u.CheckCompatibility(context.Background(), UpgradePlan{
+		Contract: currentContractVersion,
+		Providers: []UpgradeItem{
+			{Provider: fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system")},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeEmpty())
+}
+
+func Test_providerUpgrader_ApplyPlan_blocksIncompatibleClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	u := compatibilityTestUpgrader(g,
+		compatibilityTestClusterClass("cc-v1alpha4", "infrastructure.cluster.x-k8s.io/v1alpha4"),
+		compatibilityTestCluster("dropped-api", "cc-v1alpha4", "v1.31.0"),
+	)
+
+	err := u.ApplyPlan(context.Background(), UpgradeOptions{}, currentContractVersion)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Cluster %s/%s", "ns1", "dropped-api")))
+	g.Expect(err.Error()).To(ContainSubstring("--force"))
+}
+
+func Test_checkClusterCompatibility(t *testing.T) {
+	crd := func(group, kind string, contractVersion string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+				Kind:       "CustomResourceDefinition",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: contract.CalculateCRDName(group, kind),
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: kind},
+			},
+		}
+		if contractVersion != "" {
+			crd.Labels = map[string]string{fmt.Sprintf("%s/%s", clusterv1.GroupVersion.Group, currentContractVersion): contractVersion}
+		}
+		for _, v := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+		}
+		return crd
+	}
+
+	targets := []upgradeTarget{
+		{
+			provider:      "cluster-api-system/cluster-api",
+			providerType:  clusterctlv1.CoreProviderType,
+			version:       "v1.1.0",
+			releaseSeries: &clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MinKubernetesVersion: "v1.30", MaxKubernetesVersion: "v1.32"},
+		},
+		{
+			provider:      "infra-system/infrastructure-infra",
+			providerType:  clusterctlv1.InfrastructureProviderType,
+			version:       "v2.1.0",
+			releaseSeries: &clusterctlv1.ReleaseSeries{Major: 2, Minor: 1, MinKubernetesVersion: "v1.31"},
+			crds: map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition{
+				{Group: "infrastructure.cluster.x-k8s.io", Kind: "FooClusterTemplate"}: crd("infrastructure.cluster.x-k8s.io", "FooClusterTemplate", "", "v1beta2"),
+				{Group: "infrastructure.cluster.x-k8s.io", Kind: "FooCluster"}:         crd("infrastructure.cluster.x-k8s.io", "FooCluster", "", "v1beta2"),
+			},
+		},
+		{
+			provider:      "bootstrap-system/bootstrap-bar",
+			providerType:  clusterctlv1.BootstrapProviderType,
+			version:       "v3.1.0",
+			releaseSeries: &clusterctlv1.ReleaseSeries{Major: 3, Minor: 1, MaxKubernetesVersion: "v1.30"},
+			crds: map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition{
+				{Group: "bootstrap.cluster.x-k8s.io", Kind: "BarConfig"}: crd("bootstrap.cluster.x-k8s.io", "BarConfig", "", "v1beta2"),
+			},
+		},
+	}
+
+	clusterWithInfrastructureRef := compatibilityTestCluster("c", "", "")
+	clusterWithInfrastructureRef.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{
+		APIGroup: "infrastructure.cluster.x-k8s.io",
+		Kind:     "FooCluster",
+		Name:     "c",
+	}
+
+	machineWithBootstrapConfigRef := &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "m1",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "c"},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "c",
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: "bootstrap.cluster.x-k8s.io",
+					Kind:     "BarConfig",
+					Name:     "m1",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		cluster        *clusterv1.Cluster
+		objs           []client.Object
+		wantIssues     []string
+		wantCompatible bool
+	}{
+		{
+			name:           "providers not referenced by the Cluster are not checked",
+			cluster:        compatibilityTestCluster("c", "cc-v1beta2", "v1.31.0"),
+			objs:           []client.Object{compatibilityTestClusterClass("cc-v1beta2", "infrastructure.cluster.x-k8s.io/v1beta2")},
+			wantCompatible: true,
+		},
+		{
+			name:    "providers referenced by Machines of the Cluster are checked",
+			cluster: compatibilityTestCluster("c", "cc-v1beta2", "v1.31.0"),
+			objs: []client.Object{
+				compatibilityTestClusterClass("cc-v1beta2", "infrastructure.cluster.x-k8s.io/v1beta2"),
+				machineWithBootstrapConfigRef,
+			},
+			wantIssues:     []string{"Kubernetes version v1.31.0 is newer than v1.30, the newest version supported by bootstrap-system/bootstrap-bar v3.1.0"},
+			wantCompatible: false,
+		},
+		{
+			name:    "providers referenced by the ClusterClass are checked",
+			cluster: compatibilityTestCluster("c", "cc-v1beta2", "v1.30.0"),
+			objs: []client.Object{
+				compatibilityTestClusterClass("cc-v1beta2", "infrastructure.cluster.x-k8s.io/v1beta2"),
+			},
+			wantIssues:     []string{"Kubernetes version v1.30.0 is older than v1.31, the oldest version supported by infra-system/infrastructure-infra v2.1.0"},
+			wantCompatible: false,
+		},
+		{
+			name:    "API version of the infrastructureRef of a Cluster not using a ClusterClass is served",
+			cluster: clusterWithInfrastructureRef,
+			objs: []client.Object{
+				crd("infrastructure.cluster.x-k8s.io", "FooCluster", "v1beta2", "v1beta2"),
+			},
+			wantCompatible: true,
+		},
+		{
+			name:    "API version of the infrastructureRef of a Cluster not using a ClusterClass is dropped",
+			cluster: clusterWithInfrastructureRef,
+			objs: []client.Object{
+				crd("infrastructure.cluster.x-k8s.io", "FooCluster", "v1alpha4", "v1alpha4"),
+			},
+			wantIssues:     []string{"Cluster ns1/c references FooCluster c using API version infrastructure.cluster.x-k8s.io/v1alpha4, which is not served by infra-system/infrastructure-infra v2.1.0"},
+			wantCompatible: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			c, err := test.NewFakeProxy().WithObjs(tt.objs...).NewClient(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := checkClusterCompatibility(ctx, c, tt.cluster, targets)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			messages := []string{}
+			for _, issue := range got.Issues {
+				messages = append(messages, issue.Message)
+			}
+			g.Expect(messages).To(ConsistOf(tt.wantIssues))
+			g.Expect(got.IsCompatible()).To(Equal(tt.wantCompatible))
+		})
+	}
+}
+
+func Test_upgradeTarget_checkKubernetesVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		releaseSeries     clusterctlv1.ReleaseSeries
+		kubernetesVersion string
+		wantSeverity      CompatibilityIssueSeverity
+	}{
+		{
+			name:              "no issues if the release series does not define a Kubernetes version range",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1},
+			kubernetesVersion: "v1.20.0",
+		},
+		{
+			name:              "no issues if the Kubernetes version is in range, ignoring patch",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MinKubernetesVersion: "v1.30.3", MaxKubernetesVersion: "v1.32"},
+			kubernetesVersion: "v1.30.0",
+		},
+		{
+			name:              "no issues if the Kubernetes version is a pre-release in range",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MinKubernetesVersion: "v1.30", MaxKubernetesVersion: "v1.32"},
+			kubernetesVersion: "v1.32.0-rc.1",
+		},
+		{
+			name:              "error if the Kubernetes version is older than the min version",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MinKubernetesVersion: "v1.30"},
+			kubernetesVersion: "v1.29.9",
+			wantSeverity:      CompatibilityIssueError,
+		},
+		{
+			name:              "error if the Kubernetes version is newer than the max version",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MaxKubernetesVersion: "v1.32"},
+			kubernetesVersion: "v1.33.0",
+			wantSeverity:      CompatibilityIssueError,
+		},
+		{
+			name:              "warning if the Kubernetes version can't be parsed",
+			releaseSeries:     clusterctlv1.ReleaseSeries{Major: 1, Minor: 1, MaxKubernetesVersion: "v1.32"},
+			kubernetesVersion: "foo",
+			wantSeverity:      CompatibilityIssueWarning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			target := &upgradeTarget{provider: "cluster-api", version: "v1.1.0", releaseSeries: &tt.releaseSeries}
+			got := target.checkKubernetesVersion(tt.kubernetesVersion)
+			if tt.wantSeverity == "" {
+				g.Expect(got).To(BeEmpty())
+				return
+			}
+			g.Expect(got).To(HaveLen(1))
+			g.Expect(got[0].Severity).To(Equal(tt.wantSeverity))
+		})
+	}
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	// rollbackSnapshotSecretNamePrefix is the prefix of the name of the Secrets storing provider snapshots.
+	rollbackSnapshotSecretNamePrefix = "clusterctl-rollback-"
+
+	// rollbackSnapshotDataKey is the key of the Secret data storing the provider snapshot.
+	rollbackSnapshotDataKey = "snapshot.json.gz"
+
+	// rollbackSnapshotUpgradeIDLabel is the label identifying the upgrade that took a provider snapshot.
+	// Upgrade IDs are derived from the time the upgrade started, so the latest upgrade has the greatest upgrade ID.
+	rollbackSnapshotUpgradeIDLabel = "clusterctl.cluster.x-k8s.io/upgrade-id"
+)
+
+// newUpgradeID returns the ID of an upgrade started at the given time.
+func newUpgradeID(now time.Time) string {
+	return now.UTC().Format("20060102T150405.000000Z")
+}
+
+// providerSnapshot is a snapshot of a provider taken before an upgrade, which allows to roll back the upgrade.
+type providerSnapshot struct {
+	// Provider is the inventory entry of the provider before the upgrade.
+	Provider clusterctlv1.Provider `json:"provider"`
+
+	// Objects are the provider components before the upgrade, including CRDs but not the provider namespace.
+	Objects []unstructured.Unstructured `json:"objects"`
+}
+
+// rollbackSnapshotSecretName returns the name of the Secret storing the snapshot for a provider.
+func rollbackSnapshotSecretName(provider clusterctlv1.Provider) string {
+	return rollbackSnapshotSecretNamePrefix + provider.ManifestLabel()
+}
+
+// snapshotProviders takes a snapshot of the components and of the inventory entry of the providers before an upgrade;
+// all the snapshots are tagged with the same upgrade ID, so it is possible to roll back the latest upgrade only.
+// NOTE: all the snapshots are computed before saving any of them, so an invalid snapshot, e.g. a snapshot exceeding
+// the maximum size of a Secret, does not leave behind snapshots for a subset of the providers.
+func (u *providerUpgrader) snapshotProviders(ctx context.Context, providers ...clusterctlv1.Provider) error {
+	if len(providers) == 0 {
+		return nil
+	}
+
+	c, err := u.proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	upgradeID := newUpgradeID(time.Now())
+	secrets := []*corev1.Secret{}
+	for _, provider := range providers {
+		secret, err := u.snapshotProvider(ctx, c, provider, upgradeID)
+		if err != nil {
+			return err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	for _, secret := range secrets {
+		if err := saveProviderSnapshot(ctx, c, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotProvider returns the Secret storing a snapshot of the components and of the inventory entry of a provider.
+// NOTE: if a snapshot already exists for the current version of the provider, it is preserved; this prevents
+// a retry of a failed upgrade from overriding the snapshot with the components left behind by the failure.
+func (u *providerUpgrader) snapshotProvider(ctx context.Context, c client.Client, provider clusterctlv1.Provider, upgradeID string) (*corev1.Secret, error) {
+	log := logf.Log
+
+	// Gets the inventory entry of the provider, because the provider in custom upgrade plans is derived by user input.
+	providerList, err := u.providerInventory.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var current *clusterctlv1.Provider
+	for i := range providerList.Items {
+		if providerList.Items[i].InstanceName() == provider.InstanceName() {
+			current = &providerList.Items[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, errors.Errorf("unable to take a snapshot of the provider %s: the provider is not part of the management cluster", provider.InstanceName())
+	}
+
+	existing, err := getProviderSnapshot(ctx, c, *current)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Provider.Version == current.Version {
+		log.V(1).Info("Preserving existing snapshot", "Provider", klog.KObj(current), "providerVersion", current.Version)
+		return encodeProviderSnapshot(existing, upgradeID)
+	}
+
+	log.Info("Taking snapshot", "Provider", klog.KObj(current), "providerVersion", current.Version)
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabel: "",
+		clusterv1.ProviderNameLabel:  current.ManifestLabel(),
+	}
+	resources, err := u.proxy.ListResources(ctx, labels, current.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &providerSnapshot{
+		Provider: *current,
+	}
+	snapshot.Provider.ObjectMeta = metav1.ObjectMeta{
+		Namespace:   current.Namespace,
+		Name:        current.Name,
+		Labels:      current.Labels,
+		Annotations: current.Annotations,
+	}
+	instanceNamespacePrefix := fmt.Sprintf("%s-", current.Namespace)
+	for _, obj := range resources {
+		// Skip the provider namespace, which is not deleted during upgrades, and the inventory entry, which is stored separately.
+		if obj.GetKind() == namespaceKind || obj.GroupVersionKind().GroupKind().String() == providerGroupKind {
+			continue
+		}
+
+		// Skip cluster resources not belonging to the provider instance, consistently with providerComponents.Delete.
+		isCRD := obj.GetKind() == customResourceDefinitionKind
+		isWebhook := obj.GetKind() == validatingWebhookConfigurationKind || obj.GetKind() == mutatingWebhookConfigurationKind
+		if util.IsClusterResource(obj.GetKind()) && !isCRD && !isWebhook && !strings.HasPrefix(obj.GetName(), instanceNamespacePrefix) {
+			continue
+		}
+
+		snapshot.Objects = append(snapshot.Objects, sanitizeSnapshotObject(obj))
+	}
+
+	// Ensure CRDs are restored first.
+	sort.SliceStable(snapshot.Objects, func(i, j int) bool {
+		return snapshot.Objects[i].GetKind() == customResourceDefinitionKind && snapshot.Objects[j].GetKind() != customResourceDefinitionKind
+	})
+
+	return encodeProviderSnapshot(snapshot, upgradeID)
+}
+
+// sanitizeSnapshotObject drops from an object the fields that are set by the API server and that should not be
+// set when the object is restored.
+func sanitizeSnapshotObject(obj unstructured.Unstructured) unstructured.Unstructured {
+	obj = *obj.DeepCopy()
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if obj.GetKind() == "Service" {
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+	}
+	return obj
+}
+
+// encodeProviderSnapshot returns the Secret storing a provider snapshot in the provider namespace.
+// NOTE: The Secret does not have the clusterctl label, so it is not deleted together with the provider components during upgrades.
+func encodeProviderSnapshot(snapshot *providerSnapshot, upgradeID string) (*corev1.Secret, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal snapshot for provider %s", snapshot.Provider.InstanceName())
+	}
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		return nil, errors.Wrapf(err, "failed to compress snapshot for provider %s", snapshot.Provider.InstanceName())
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to compress snapshot for provider %s", snapshot.Provider.InstanceName())
+	}
+
+	if compressed.Len() > corev1.MaxSecretSize {
+		return nil, errors.Errorf("unable to take a snapshot of the provider %s: the compressed snapshot is %d bytes, which exceeds the maximum size of a Secret (%d bytes)",
+			snapshot.Provider.InstanceName(), compressed.Len(), corev1.MaxSecretSize)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: snapshot.Provider.Namespace,
+			Name:      rollbackSnapshotSecretName(snapshot.Provider),
+			Labels: map[string]string{
+				clusterctlv1.ClusterctlCoreLabel: clusterctlv1.ClusterctlCoreLabelRollbackSnapshotValue,
+				clusterv1.ProviderNameLabel:      snapshot.Provider.ManifestLabel(),
+				rollbackSnapshotUpgradeIDLabel:   upgradeID,
+			},
+		},
+		Data: map[string][]byte{
+			rollbackSnapshotDataKey: compressed.Bytes(),
+		},
+	}, nil
+}
+
+// saveProviderSnapshot creates or updates the Secret storing a provider snapshot.
+func saveProviderSnapshot(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	providerName := secret.Labels[clusterv1.ProviderNameLabel]
+	return retryWithExponentialBackoff(ctx, newWriteBackoff(), func(ctx context.Context) error {
+		current := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(secret), current); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to get snapshot for provider %s/%s", secret.Namespace, providerName)
+			}
+			if err := c.Create(ctx, secret.DeepCopy()); err != nil {
+				return errors.Wrapf(err, "failed to create snapshot for provider %s/%s", secret.Namespace, providerName)
+			}
+			return nil
+		}
+		current.Labels = secret.Labels
+		current.Data = secret.Data
+		if err := c.Update(ctx, current); err != nil {
+			return errors.Wrapf(err, "failed to update snapshot for provider %s/%s", secret.Namespace, providerName)
+		}
+		return nil
+	})
+}
+
+// getProviderSnapshot returns the snapshot for a provider, or nil if there is no snapshot.
+func getProviderSnapshot(ctx context.Context, c client.Client, provider clusterctlv1.Provider) (*providerSnapshot, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: provider.Namespace, Name: rollbackSnapshotSecretName(provider)}
+	notFound := false
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		err := c.Get(ctx, key, secret)
+		if apierrors.IsNotFound(err) {
+			notFound = true
+			return nil
+		}
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get snapshot for provider %s", provider.InstanceName())
+	}
+	if notFound {
+		return nil, nil
+	}
+	return decodeProviderSnapshot(secret)
+}
+
+// decodeProviderSnapshot decodes the provider snapshot stored in a Secret.
+func decodeProviderSnapshot(secret *corev1.Secret) (*providerSnapshot, error) {
+	r, err := gzip.NewReader(bytes.NewReader(secret.Data[rollbackSnapshotDataKey]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decompress snapshot %s", klog.KObj(secret))
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decompress snapshot %s", klog.KObj(secret))
+	}
+
+	snapshot := &providerSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal snapshot %s", klog.KObj(secret))
+	}
+	return snapshot, nil
+}
+
+// deleteProviderSnapshot deletes the snapshot for a provider, if any.
+func deleteProviderSnapshot(ctx context.Context, c client.Client, provider clusterctlv1.Provider) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: provider.Namespace,
+			Name:      rollbackSnapshotSecretName(provider),
+		},
+	}
+	return retryWithExponentialBackoff(ctx, newWriteBackoff(), func(ctx context.Context) error {
+		if err := c.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete snapshot for provider %s", provider.InstanceName())
+		}
+		return nil
+	})
+}
+
+func (u *providerUpgrader) Rollback(ctx context.Context, providers ...clusterctlv1.Provider) error {
+	log := logf.Log
+
+	c, err := u.proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Gets the snapshots for the providers to roll back; if no provider is specified, the snapshots taken by the latest upgrade are considered.
+	snapshots := []*providerSnapshot{}
+	if len(providers) == 0 {
+		secretList := &corev1.SecretList{}
+		if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+			return c.List(ctx, secretList, client.MatchingLabels{clusterctlv1.ClusterctlCoreLabel: clusterctlv1.ClusterctlCoreLabelRollbackSnapshotValue})
+		}); err != nil {
+			return errors.Wrap(err, "failed to list snapshots")
+		}
+		latestUpgradeID := ""
+		for i := range secretList.Items {
+			if upgradeID := secretList.Items[i].Labels[rollbackSnapshotUpgradeIDLabel]; upgradeID > latestUpgradeID {
+				latestUpgradeID = upgradeID
+			}
+		}
+		if len(secretList.Items) > 0 {
+			log.Info("Rolling back the latest upgrade", "upgradeID", latestUpgradeID)
+		}
+		for i := range secretList.Items {
+			if secretList.Items[i].Labels[rollbackSnapshotUpgradeIDLabel] != latestUpgradeID {
+				continue
+			}
+			snapshot, err := decodeProviderSnapshot(&secretList.Items[i])
+			if err != nil {
+				return err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		if len(snapshots) == 0 {
+			return errors.New("unable to perform rollback: there are no snapshots of providers taken by a previous upgrade")
+		}
+	} else {
+		for _, provider := range providers {
+			snapshot, err := getProviderSnapshot(ctx, c, provider)
+			if err != nil {
+				return err
+			}
+			if snapshot == nil {
+				return errors.Errorf("unable to perform rollback: there is no snapshot for the provider %s taken by a previous upgrade", provider.InstanceName())
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	// Before changing anything, checks all the providers can be rolled back.
+	for _, snapshot := range snapshots {
+		if err := checkRollbackCRDs(ctx, c, snapshot); err != nil {
+			return err
+		}
+	}
+
+	// Ensure Providers are rolled back in the following order: Core, Bootstrap, ControlPlane, Infrastructure.
+	sort.Slice(snapshots, func(a, b int) bool {
+		return snapshots[a].Provider.GetProviderType().Order() < snapshots[b].Provider.GetProviderType().Order()
+	})
+
+	// Scale down all providers, for the same reasons described in doUpgrade.
+	for _, snapshot := range snapshots {
+		if err := u.scaleDownProvider(ctx, snapshot.Provider); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		log.Info("Rolling back", "Provider", klog.KObj(&snapshot.Provider), "providerVersion", snapshot.Provider.Version)
+
+		// Delete the provider, preserving CRD, namespace and the inventory.
+		if err := u.providerComponents.Delete(ctx, DeleteOptions{
+			Provider:         snapshot.Provider,
+			IncludeNamespace: false,
+			IncludeCRDs:      false,
+			SkipInventory:    true,
+		}); err != nil {
+			return err
+		}
+
+		// Restore the provider components and the inventory entry from the snapshot.
+		if err := u.providerComponents.Create(ctx, snapshot.Objects); err != nil {
+			return err
+		}
+		if err := u.providerInventory.Create(ctx, snapshot.Provider); err != nil {
+			return err
+		}
+
+		// Delete the snapshot, which is now consumed.
+		if err := deleteProviderSnapshot(ctx, c, snapshot.Provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRollbackCRDs returns an error if the CRDs in a snapshot can't be restored, because objects are stored
+// in API versions not defined in the CRDs in the snapshot, e.g. because the CRD migration performed during
+// the upgrade migrated objects to a new storage version.
+func checkRollbackCRDs(ctx context.Context, c client.Client, snapshot *providerSnapshot) error {
+	for _, obj := range snapshot.Objects {
+		if obj.GetKind() != customResourceDefinitionKind {
+			continue
+		}
+
+		previousCRD := &apiextensionsv1.CustomResourceDefinition{}
+		if err := scheme.Scheme.Convert(&obj, previousCRD, nil); err != nil {
+			return errors.Wrapf(err, "failed to convert CRD %q", obj.GetName())
+		}
+
+		currentCRD := &apiextensionsv1.CustomResourceDefinition{}
+		crdNotFound := false
+		if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+			err := c.Get(ctx, client.ObjectKeyFromObject(previousCRD), currentCRD)
+			if apierrors.IsNotFound(err) {
+				crdNotFound = true
+				return nil
+			}
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "failed to get CRD %q", previousCRD.Name)
+		}
+		if crdNotFound {
+			continue
+		}
+
+		missingVersions := sets.Set[string]{}
+		for _, storedVersion := range currentCRD.Status.StoredVersions {
+			if versionForCRD(previousCRD, storedVersion) == nil {
+				missingVersions.Insert(storedVersion)
+			}
+		}
+		if missingVersions.Len() > 0 {
+			return errors.Errorf("unable to roll back the provider %s to version %s: objects of CRD %q are stored in versions %s, which are not defined in version %s of the provider",
+				snapshot.Provider.InstanceName(), snapshot.Provider.Version, currentCRD.Name, strings.Join(sets.List(missingVersions), ", "), snapshot.Provider.Version)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+var rollbackTestLabels = map[string]string{
+	clusterctlv1.ClusterctlLabel: "",
+	clusterv1.ProviderNameLabel:  "infrastructure-infra",
+}
+
+func rollbackTestObjs(image string, crdVersions ...string) []client.Object {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "fooclusters.infrastructure.cluster.x-k8s.io", Labels: rollbackTestLabels},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "infrastructure.cluster.x-k8s.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "FooCluster", ListKind: "FooClusterList", Plural: "fooclusters"},
+			Scope: apiextensionsv1.NamespaceScoped,
+		},
+	}
+	for i, v := range crdVersions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true, Storage: i == len(crdVersions)-1})
+	}
+	crd.Status.StoredVersions = crdVersions
+
+	return []client.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "infra-system", Labels: rollbackTestLabels},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "infra-system", Name: "infra-controller-manager", Labels: rollbackTestLabels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "manager", Image: image}},
+					},
+				},
+			},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: "infra-system-infra-manager-role", Labels: rollbackTestLabels},
+		},
+		crd,
+	}
+}
+
+func rollbackTestUpgrader(objs ...client.Object) (*providerUpgrader, *test.FakeProxy) {
+	proxy := test.NewFakeProxy().
+		WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system").
+		WithObjs(objs...)
+
+	return &providerUpgrader{
+		proxy:              proxy,
+		providerInventory:  newInventoryClient(proxy, nil, currentContractVersion),
+		providerComponents: newComponentsClient(proxy),
+	}, proxy
+}
+
+func Test_providerUpgrader_snapshotProvider(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	u, proxy := rollbackTestUpgrader(rollbackTestObjs("infra:v1.0.0", "v1beta1")...)
+	provider := fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system")
+
+	g.Expect(u.snapshotProviders(ctx, provider)).To(Succeed())
+
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	snapshot, err := getProviderSnapshot(ctx, c, provider)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshot).ToNot(BeNil())
+	g.Expect(snapshot.Provider.Version).To(Equal("v1.0.0"))
+	g.Expect(snapshot.Provider.ResourceVersion).To(BeEmpty())
+
+	kinds := []string{}
+	for _, obj := range snapshot.Objects {
+		kinds = append(kinds, obj.GetKind())
+		g.Expect(obj.GetResourceVersion()).To(BeEmpty())
+		g.Expect(obj.Object).ToNot(HaveKey("status"))
+	}
+	// CRDs are restored first; the namespace and the inventory entry are not part of the snapshot.
+	g.Expect(kinds).To(HaveLen(3))
+	g.Expect(kinds[0]).To(Equal("CustomResourceDefinition"))
+	g.Expect(kinds).To(ConsistOf("CustomResourceDefinition", "Deployment", "ClusterRole"))
+
+	// The snapshot Secret should not be selected when listing the provider components.
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "clusterctl-rollback-infrastructure-infra"}, secret)).To(Succeed())
+	g.Expect(secret.Labels).ToNot(HaveKey(clusterctlv1.ClusterctlLabel))
+
+	// A new snapshot for the same version of the provider does not override the existing one.
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "infra-controller-manager"}, deployment)).To(Succeed())
+	deployment.Spec.Template.Spec.Containers[0].Image = "infra:broken"
+	g.Expect(c.Update(ctx, deployment)).To(Succeed())
+
+	g.Expect(u.snapshotProviders(ctx, provider)).To(Succeed())
+	snapshot, err = getProviderSnapshot(ctx, c, provider)
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, obj := range snapshot.Objects {
+		if obj.GetKind() == "Deployment" {
+			g.Expect(obj.Object).To(HaveKeyWithValue("spec", HaveKeyWithValue("template", HaveKeyWithValue("spec", HaveKeyWithValue("containers", ContainElement(HaveKeyWithValue("image", "infra:v1.0.0")))))))
+		}
+	}
+}
+
+func Test_providerUpgrader_Rollback(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	u, proxy := rollbackTestUpgrader(rollbackTestObjs("infra:v1.0.0", "v1beta1")...)
+	provider := fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system")
+	g.Expect(u.snapshotProviders(ctx, provider)).To(Succeed())
+
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// A snapshot taken by a previous upgrade of another provider is not rolled back.
+	// NOTE: the snapshot can't be decoded, so the rollback fails if the snapshot is considered.
+	previousSnapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "bootstrap-system",
+			Name:      "clusterctl-rollback-bootstrap-foo",
+			Labels: map[string]string{
+				clusterctlv1.ClusterctlCoreLabel: clusterctlv1.ClusterctlCoreLabelRollbackSnapshotValue,
+				clusterv1.ProviderNameLabel:      "bootstrap-foo",
+				rollbackSnapshotUpgradeIDLabel:   newUpgradeID(time.Now().Add(-24 * time.Hour)),
+			},
+		},
+		Data: map[string][]byte{rollbackSnapshotDataKey: []byte("invalid")},
+	}
+	g.Expect(c.Create(ctx, previousSnapshot)).To(Succeed())
+
+	// Simulate an upgrade to v2.0.0, adding a new API version to the CRD without migrating objects.
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "infra-controller-manager"}, deployment)).To(Succeed())
+	deployment.Spec.Template.Spec.Containers[0].Image = "infra:v2.0.0"
+	g.Expect(c.Update(ctx, deployment)).To(Succeed())
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "fooclusters.infrastructure.cluster.x-k8s.io"}, crd)).To(Succeed())
+	crd.Spec.Versions[0].Storage = false
+	crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta2", Served: true, Storage: true})
+	g.Expect(c.Update(ctx, crd)).To(Succeed())
+
+	inventoryProvider := &clusterctlv1.Provider{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "infrastructure-infra"}, inventoryProvider)).To(Succeed())
+	inventoryProvider.Version = "v2.0.0"
+	g.Expect(c.Update(ctx, inventoryProvider)).To(Succeed())
+
+	g.Expect(u.Rollback(ctx)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "infra-controller-manager"}, deployment)).To(Succeed())
+	g.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("infra:v1.0.0"))
+	g.Expect(deployment.Spec.Replicas).To(Equal(ptr.To[int32](1)))
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "fooclusters.infrastructure.cluster.x-k8s.io"}, crd)).To(Succeed())
+	g.Expect(crd.Spec.Versions).To(HaveLen(1))
+	g.Expect(crd.Spec.Versions[0].Name).To(Equal("v1beta1"))
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "infrastructure-infra"}, inventoryProvider)).To(Succeed())
+	g.Expect(inventoryProvider.Version).To(Equal("v1.0.0"))
+
+	// The snapshot is consumed by the rollback.
+	err = c.Get(ctx, client.ObjectKey{Namespace: "infra-system", Name: "clusterctl-rollback-infrastructure-infra"}, &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The snapshot taken by the previous upgrade is preserved.
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(previousSnapshot), &corev1.Secret{})).To(Succeed())
+}
+
+func Test_encodeProviderSnapshot_tooBig(t *testing.T) {
+	g := NewWithT(t)
+
+	// Random data can't be compressed, so the snapshot exceeds the maximum size of a Secret.
+	data := make([]byte, corev1.MaxSecretSize)
+	_, err := rand.Read(data)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	snapshot := &providerSnapshot{
+		Provider: fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system"),
+		Objects: []unstructured.Unstructured{{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data":       map[string]interface{}{"foo": base64.StdEncoding.EncodeToString(data)},
+		}}},
+	}
+	_, err = encodeProviderSnapshot(snapshot, newUpgradeID(time.Now()))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("exceeds the maximum size of a Secret"))
+}
+
+func Test_providerUpgrader_Rollback_refusesMigratedCRDs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	u, proxy := rollbackTestUpgrader(rollbackTestObjs("infra:v1.0.0", "v1beta1")...)
+	provider := fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system")
+	g.Expect(u.snapshotProviders(ctx, provider)).To(Succeed())
+
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Simulate an upgrade to v2.0.0 dropping v1beta1 from the CRD after migrating objects to v1beta2.
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "fooclusters.infrastructure.cluster.x-k8s.io"}, crd)).To(Succeed())
+	crd.Spec.Versions = []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1beta2", Served: true, Storage: true}}
+	g.Expect(c.Update(ctx, crd)).To(Succeed())
+	crd.Status.StoredVersions = []string{"v1beta2"}
+	g.Expect(c.Status().Update(ctx, crd)).To(Succeed())
+
+	err = u.Rollback(ctx, provider)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("stored in versions v1beta2"))
+
+	// Nothing is changed, and the snapshot is preserved.
+	_, err = getProviderSnapshot(ctx, c, provider)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "fooclusters.infrastructure.cluster.x-k8s.io"}, crd)).To(Succeed())
+	g.Expect(crd.Spec.Versions[0].Name).To(Equal("v1beta2"))
+}
+
+func Test_providerUpgrader_Rollback_noSnapshot(t *testing.T) {
+	g := NewWithT(t)
+
+	u, _ := rollbackTestUpgrader()
+	err := u.Rollback(context.Background(), fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("there is no snapshot for the provider"))
+}
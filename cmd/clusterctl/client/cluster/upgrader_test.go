@@ -998,7 +998,10 @@ func Test_providerUpgrader_createCustomPlan(t *testing.T) {
 				currentContractVersion:        currentContractVersion,
 				getCompatibleContractVersions: getCompatibleContractVersions,
 			}
-			got, err := u.createCustomPlan(ctx, tt.args.providersToUpgrade)
+			providerList, err := u.providerInventory.List(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := u.createCustomPlan(ctx, providerList.Items, tt.args.providersToUpgrade)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
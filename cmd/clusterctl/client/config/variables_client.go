@@ -21,6 +21,9 @@ const (
 	GitHubTokenVariable = "github-token"
 	// GitLabAccessTokenVariable defines a variable hosting the GitLab access token. This can be used with Personal and Project access tokens.
 	GitLabAccessTokenVariable = "gitlab-access-token"
+	// OCIDockerConfigVariable defines a variable hosting the path of the docker config file with the credentials for OCI registries.
+	// If not set, the docker config file in $DOCKER_CONFIG or in $HOME/.docker is used.
+	OCIDockerConfigVariable = "oci-docker-config"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
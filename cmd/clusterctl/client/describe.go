@@ -19,6 +19,9 @@ package client
 import (
 	"context"
 
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 )
 
@@ -104,3 +107,70 @@ func (c *clusterctlClient) DescribeCluster(ctx context.Context, options Describe
 		V1Beta1:                 options.V1Beta1,
 	})
 }
+
+// DescribeClustersOptions carries the options supported by DescribeClusters.
+type DescribeClustersOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the workload clusters are located. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// AllNamespaces instructs DescribeClusters to consider workload clusters in all the namespaces.
+	AllNamespaces bool
+
+	// LabelSelector is a label query for selecting the workload clusters to describe, e.g. env=prod.
+	LabelSelector string
+
+	// SortBy defines how to sort workload clusters, e.g. name, class, version, age or available.
+	// If unspecified, workload clusters are sorted by namespace and name.
+	SortBy string
+}
+
+// DescribeClusters returns a summary of the status of all the Cluster API clusters matching the given options.
+func (c *clusterctlClient) DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error) {
+	selector := labels.Everything()
+	if options.LabelSelector != "" {
+		var err error
+		selector, err = labels.Parse(options.LabelSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+		}
+	}
+
+	// gets access to the management cluster
+	cluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := cluster.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.AllNamespaces {
+		options.Namespace = ""
+	} else if options.Namespace == "" {
+		currentNamespace, err := cluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	// Fetch the Cluster client.
+	client, err := cluster.Proxy().NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets the summary of the status of the selected Cluster API clusters.
+	return tree.DiscoverClusters(ctx, client, tree.DiscoverClustersOptions{
+		Namespace:     options.Namespace,
+		LabelSelector: selector,
+		SortBy:        options.SortBy,
+	})
+}
@@ -300,6 +300,7 @@ type addToInstallerOptions struct {
 	installer           cluster.ProviderInstaller
 	targetNamespace     string
 	skipTemplateProcess bool
+	featureGates        map[string]bool
 	providerList        *clusterctlv1.ProviderList
 }
 
@@ -316,6 +317,7 @@ func (c *clusterctlClient) addToInstaller(ctx context.Context, options addToInst
 		componentsOptions := repository.ComponentsOptions{
 			TargetNamespace:     options.targetNamespace,
 			SkipTemplateProcess: options.skipTemplateProcess,
+			FeatureGates:        options.featureGates,
 		}
 		components, err := c.getComponentsByName(ctx, provider, providerType, componentsOptions)
 		if err != nil {
@@ -0,0 +1,417 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/util/container"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const (
+	// mirrorMetadataFile is the name of the file with the provider's metadata in a mirrored release.
+	mirrorMetadataFile = "metadata.yaml"
+
+	// mirrorCertManagerName is the name of the folder where cert-manager releases are mirrored.
+	mirrorCertManagerName = "cert-manager"
+)
+
+// MirrorOptions carries the options supported by Mirror.
+type MirrorOptions struct {
+	// CoreProvider version (e.g. cluster-api:v1.1.5) to mirror. If unspecified, the
+	// cluster-api core provider's latest release is used.
+	CoreProvider string
+
+	// BootstrapProviders and versions (e.g. kubeadm:v1.1.5) to mirror.
+	// If unspecified, the kubeadm bootstrap provider's latest release is used.
+	BootstrapProviders []string
+
+	// InfrastructureProviders and versions (e.g. aws:v0.5.0) to mirror.
+	InfrastructureProviders []string
+
+	// ControlPlaneProviders and versions (e.g. kubeadm:v1.1.5) to mirror.
+	// If unspecified, the kubeadm control plane provider latest release is used.
+	ControlPlaneProviders []string
+
+	// IPAMProviders and versions (e.g. infoblox:v0.0.1) to mirror.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders and versions (e.g. test:v0.0.1) to mirror.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders and versions (e.g. helm:v0.1.0) to mirror.
+	AddonProviders []string
+
+	// Flavors of the cluster templates to mirror for each infrastructure provider, in addition to the default cluster template (if any).
+	Flavors []string
+
+	// ClusterClasses to mirror for each infrastructure provider.
+	ClusterClasses []string
+
+	// SkipCertManager instructs Mirror to not mirror the cert-manager release.
+	SkipCertManager bool
+
+	// ImageRepository sets the container registry to pull images from in the mirrored components YAML
+	// e.g. registry.example.com/cluster-api. If empty, only the image overrides defined in the clusterctl config are applied.
+	ImageRepository string
+
+	// Directory where the mirrored releases are stored, using the layout of clusterctl local repositories,
+	// i.e. {directory}/{provider-label}/{version}/{file}.
+	// A clusterctl configuration file with the providers and the cert-manager pointing to the mirrored releases
+	// and a file with the list of images are also stored in the directory.
+	Directory string
+
+	// Archive is the tarball where the mirrored releases are stored, using the OCI image layout; each release is stored as
+	// an OCI artifact with reference name {provider-label}:{version}.
+	Archive string
+}
+
+// MirrorResult describes the result of Mirror.
+type MirrorResult struct {
+	// Providers lists all the mirrored provider releases, including the cert-manager release.
+	Providers []MirroredProvider
+
+	// Images lists all the images required by the mirrored provider releases.
+	Images []MirroredImage
+}
+
+// MirroredProvider describes a mirrored provider release.
+type MirroredProvider struct {
+	// Name of the provider.
+	Name string
+
+	// Type of the provider; empty for cert-manager.
+	Type clusterctlv1.ProviderType
+
+	// Version of the release.
+	Version string
+
+	// ManifestLabel of the provider, which is used as the folder name of the release in the mirror.
+	ManifestLabel string
+
+	// ComponentsFile is the name of the file with the provider components.
+	ComponentsFile string
+
+	// Files lists all the files of the mirrored release.
+	Files []string
+}
+
+// MirroredImage describes an image required by mirrored providers.
+type MirroredImage struct {
+	// Source is the image referenced by the provider repository.
+	Source string
+
+	// Target is the image referenced by the mirrored components YAML, after applying image overrides.
+	Target string
+}
+
+// Mirror downloads provider releases, including components, metadata and templates, from the provider repositories
+// so they can be used in disconnected environments.
+func (c *clusterctlClient) Mirror(ctx context.Context, options MirrorOptions) (*MirrorResult, error) {
+	log := logf.Log
+
+	if (options.Directory == "") == (options.Archive == "") {
+		return nil, errors.New("exactly one of directory and archive must be set")
+	}
+
+	// If not explicitly set, mirror the same providers that are installed by default on the first run of init.
+	if options.CoreProvider == "" {
+		options.CoreProvider = config.ClusterAPIProviderName
+	}
+	if len(options.BootstrapProviders) == 0 {
+		options.BootstrapProviders = append(options.BootstrapProviders, config.KubeadmBootstrapProviderName)
+	}
+	if len(options.ControlPlaneProviders) == 0 {
+		options.ControlPlaneProviders = append(options.ControlPlaneProviders, config.KubeadmControlPlaneProviderName)
+	}
+
+	providers := []struct {
+		providerType clusterctlv1.ProviderType
+		names        []string
+	}{
+		{clusterctlv1.CoreProviderType, []string{options.CoreProvider}},
+		{clusterctlv1.BootstrapProviderType, options.BootstrapProviders},
+		{clusterctlv1.ControlPlaneProviderType, options.ControlPlaneProviders},
+		{clusterctlv1.InfrastructureProviderType, options.InfrastructureProviders},
+		{clusterctlv1.IPAMProviderType, options.IPAMProviders},
+		{clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders},
+		{clusterctlv1.AddonProviderType, options.AddonProviders},
+	}
+
+	var writer mirrorWriter
+	if options.Directory != "" {
+		writer = newMirrorDirectoryWriter(options.Directory)
+	} else {
+		writer = newMirrorArchiveWriter(options.Archive)
+	}
+
+	result := &MirrorResult{}
+	images := map[string]string{}
+	for _, p := range providers {
+		for _, provider := range p.names {
+			// It is possible to opt-out from mirroring of bootstrap/control-plane providers using '-' as a provider name (NoopProvider).
+			if provider == NoopProvider {
+				if p.providerType == clusterctlv1.CoreProviderType {
+					return nil, errors.New("the '-' value can not be used for the core provider")
+				}
+				continue
+			}
+
+			log.Info("Mirroring", "provider", provider, "type", p.providerType)
+			mirrored, err := c.mirrorProvider(ctx, writer, provider, p.providerType, options, images)
+			if err != nil {
+				return nil, err
+			}
+			result.Providers = append(result.Providers, *mirrored)
+		}
+	}
+
+	if !options.SkipCertManager {
+		log.Info("Mirroring", "provider", mirrorCertManagerName)
+		mirrored, err := c.mirrorCertManager(ctx, writer, options, images)
+		if err != nil {
+			return nil, err
+		}
+		result.Providers = append(result.Providers, *mirrored)
+	}
+
+	for source, target := range images {
+		result.Images = append(result.Images, MirroredImage{Source: source, Target: target})
+	}
+	sort.Slice(result.Images, func(i, j int) bool {
+		return result.Images[i].Source < result.Images[j].Source
+	})
+
+	if err := writer.Close(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mirrorProvider mirrors a provider release, given the provider name in the form name[:version].
+func (c *clusterctlClient) mirrorProvider(ctx context.Context, writer mirrorWriter, provider string, providerType clusterctlv1.ProviderType, options MirrorOptions, images map[string]string) (*MirroredProvider, error) {
+	log := logf.Log
+
+	name, version, err := parseProviderName(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfig, err := c.configClient.Providers().Get(name, providerType)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryClient, err := c.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: providerConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// If the request does not target a specific version, mirror the default repository version, e.g. latest.
+	if version == "" {
+		version = repositoryClient.DefaultVersion()
+	}
+
+	componentsFile, err := componentsFileName(providerConfig.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	mirrored := &MirroredProvider{
+		Name:           providerConfig.Name(),
+		Type:           providerConfig.Type(),
+		Version:        version,
+		ManifestLabel:  providerConfig.ManifestLabel(),
+		ComponentsFile: componentsFile,
+	}
+	write := func(file string, content []byte) error {
+		mirrored.Files = append(mirrored.Files, file)
+		return writer.WriteFile(mirrored.ManifestLabel, version, file, content)
+	}
+
+	// Mirror the provider components, applying image overrides.
+	components, err := repositoryClient.Components().Raw(ctx, repository.ComponentsOptions{Version: version})
+	if err != nil {
+		return nil, err
+	}
+	components, err = c.mirrorImages(components, providerConfig.ManifestLabel(), options.ImageRepository, images)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to mirror images for provider %q", providerConfig.ManifestLabel())
+	}
+	if err := write(componentsFile, components); err != nil {
+		return nil, err
+	}
+
+	// Mirror the provider metadata.
+	// NOTE: Metadata are serialized from the parsed object, because for some providers metadata are embedded in clusterctl.
+	metadata, err := repositoryClient.Metadata(version).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metadata.APIVersion = clusterctlv1.GroupVersion.String()
+	metadata.Kind = "Metadata"
+	metadataContent, err := yaml.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal metadata for provider %q", providerConfig.ManifestLabel())
+	}
+	if err := write(mirrorMetadataFile, metadataContent); err != nil {
+		return nil, err
+	}
+
+	// Mirror cluster templates and cluster classes; those are expected to exist for the infrastructure providers only.
+	if providerType != clusterctlv1.InfrastructureProviderType {
+		return mirrored, nil
+	}
+
+	processor := yamlprocessor.NewSimpleProcessor()
+
+	// NOTE: The default cluster template is mirrored only if it exists, because not all the providers have one.
+	if template, err := repositoryClient.Templates(version).Raw(ctx, ""); err == nil {
+		if err := write(processor.GetTemplateName(version, ""), template); err != nil {
+			return nil, err
+		}
+	} else {
+		log.V(1).Info("Skipping default cluster template", "provider", providerConfig.ManifestLabel(), "version", version, "reason", err.Error())
+	}
+
+	for _, flavor := range options.Flavors {
+		template, err := repositoryClient.Templates(version).Raw(ctx, flavor)
+		if err != nil {
+			return nil, err
+		}
+		if err := write(processor.GetTemplateName(version, flavor), template); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, class := range options.ClusterClasses {
+		template, err := repositoryClient.ClusterClasses(version).Raw(ctx, class)
+		if err != nil {
+			return nil, err
+		}
+		if err := write(processor.GetClusterClassTemplateName(version, class), template); err != nil {
+			return nil, err
+		}
+	}
+
+	return mirrored, nil
+}
+
+// mirrorCertManager mirrors the cert-manager release defined in the clusterctl config.
+func (c *clusterctlClient) mirrorCertManager(ctx context.Context, writer mirrorWriter, options MirrorOptions, images map[string]string) (*MirroredProvider, error) {
+	certManagerConfig, err := c.configClient.CertManager().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	// Given that cert manager components yaml are stored in a repository like providers components yaml,
+	// we are using the same machinery to retrieve the file by using a fake provider object using
+	// the cert manager repository url.
+	certManagerFakeProvider := config.NewProvider(mirrorCertManagerName, certManagerConfig.URL(), "")
+	repositoryClient, err := c.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: certManagerFakeProvider})
+	if err != nil {
+		return nil, err
+	}
+
+	componentsFile, err := componentsFileName(certManagerConfig.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := repositoryClient.Components().Raw(ctx, repository.ComponentsOptions{Version: certManagerConfig.Version()})
+	if err != nil {
+		return nil, err
+	}
+	components, err = c.mirrorImages(components, config.CertManagerImageComponent, options.ImageRepository, images)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to mirror images for cert-manager")
+	}
+	if err := writer.WriteFile(mirrorCertManagerName, certManagerConfig.Version(), componentsFile, components); err != nil {
+		return nil, err
+	}
+
+	return &MirroredProvider{
+		Name:           mirrorCertManagerName,
+		Version:        certManagerConfig.Version(),
+		ManifestLabel:  mirrorCertManagerName,
+		ComponentsFile: componentsFile,
+		Files:          []string{componentsFile},
+	}, nil
+}
+
+// mirrorImages applies image overrides defined in the clusterctl config and the image repository for the mirror
+// to the given components YAML, and records the images required by the components YAML.
+// NOTE: The components YAML is serialized again only if at least one image has been changed.
+func (c *clusterctlClient) mirrorImages(components []byte, component, imageRepository string, images map[string]string) ([]byte, error) {
+	objs, err := utilyaml.ToUnstructured(components)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse yaml")
+	}
+
+	changed := false
+	objs, err = util.FixImages(objs, func(image string) (string, error) {
+		target, err := c.configClient.ImageMeta().AlterImage(component, image)
+		if err != nil {
+			return "", err
+		}
+		if imageRepository != "" {
+			targetImage, err := container.ImageFromString(target)
+			if err != nil {
+				return "", err
+			}
+			targetImage.Repository = strings.TrimSuffix(imageRepository, "/")
+			target = targetImage.String()
+		}
+
+		images[image] = target
+		if target != image {
+			changed = true
+		}
+		return target, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return components, nil
+	}
+	return utilyaml.FromUnstructured(objs)
+}
+
+// componentsFileName returns the name of the components file from a repository URL.
+func componentsFileName(repositoryURL string) (string, error) {
+	rURL, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse repository url %q", repositoryURL)
+	}
+	return path.Base(rURL.Path), nil
+}
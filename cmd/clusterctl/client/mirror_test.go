@@ -0,0 +1,345 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+)
+
+func Test_clusterctlClient_Mirror(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   MirrorOptions
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name: "mirrors default providers, infrastructure providers and cert-manager",
+			options: MirrorOptions{
+				InfrastructureProviders: []string{"infra:v3.0.0"},
+			},
+			wantFiles: []string{
+				"bootstrap-kubeadm/v2.0.0/bootstrap-components.yaml",
+				"bootstrap-kubeadm/v2.0.0/metadata.yaml",
+				"cert-manager/" + config.CertManagerDefaultVersion + "/cert-manager.yaml",
+				"cluster-api/v1.0.0/core-components.yaml",
+				"cluster-api/v1.0.0/metadata.yaml",
+				"clusterctl.yaml",
+				"control-plane-kubeadm/v2.0.0/control-plane-components.yaml",
+				"control-plane-kubeadm/v2.0.0/metadata.yaml",
+				"images.txt",
+				"infrastructure-infra/v3.0.0/cluster-template.yaml",
+				"infrastructure-infra/v3.0.0/infrastructure-components.yaml",
+				"infrastructure-infra/v3.0.0/metadata.yaml",
+			},
+		},
+		{
+			name: "mirrors flavors and cluster classes for infrastructure providers",
+			options: MirrorOptions{
+				CoreProvider:            "cluster-api:v1.1.0",
+				BootstrapProviders:      []string{NoopProvider},
+				ControlPlaneProviders:   []string{NoopProvider},
+				InfrastructureProviders: []string{"infra:v3.0.0"},
+				Flavors:                 []string{"dev"},
+				ClusterClasses:          []string{"quick-start"},
+				SkipCertManager:         true,
+			},
+			wantFiles: []string{
+				"cluster-api/v1.1.0/core-components.yaml",
+				"cluster-api/v1.1.0/metadata.yaml",
+				"clusterctl.yaml",
+				"images.txt",
+				"infrastructure-infra/v3.0.0/cluster-template-dev.yaml",
+				"infrastructure-infra/v3.0.0/cluster-template.yaml",
+				"infrastructure-infra/v3.0.0/clusterclass-quick-start.yaml",
+				"infrastructure-infra/v3.0.0/infrastructure-components.yaml",
+				"infrastructure-infra/v3.0.0/metadata.yaml",
+			},
+		},
+		{
+			name: "fails if a flavor does not exist",
+			options: MirrorOptions{
+				InfrastructureProviders: []string{"infra:v3.0.0"},
+				Flavors:                 []string{"does-not-exist"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a provider does not exist",
+			options: MirrorOptions{
+				InfrastructureProviders: []string{"does-not-exist"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			directory := t.TempDir()
+			tt.options.Directory = directory
+
+			_, err := fakeMirrorClient().Mirror(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotFiles := []string{}
+			g.Expect(filepath.WalkDir(directory, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(directory, path)
+				gotFiles = append(gotFiles, filepath.ToSlash(rel))
+				return err
+			})).To(Succeed())
+			g.Expect(gotFiles).To(Equal(tt.wantFiles))
+		})
+	}
+}
+
+func Test_clusterctlClient_Mirror_images(t *testing.T) {
+	g := NewWithT(t)
+
+	directory := t.TempDir()
+	got, err := fakeMirrorClient().Mirror(ctx, MirrorOptions{
+		BootstrapProviders:      []string{NoopProvider},
+		ControlPlaneProviders:   []string{NoopProvider},
+		InfrastructureProviders: []string{"infra:v3.0.0"},
+		SkipCertManager:         true,
+		ImageRepository:         "registry.example.com/mirror/",
+		Directory:               directory,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(got.Images).To(Equal([]MirroredImage{
+		{
+			Source: "registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v0.5.3",
+			Target: "registry.example.com/mirror/cluster-api-aws-controller:v0.5.3",
+		},
+	}))
+
+	// Images in the mirrored components must be rewritten, while variables must be preserved.
+	components, err := os.ReadFile(filepath.Clean(filepath.Join(directory, "infrastructure-infra", "v3.0.0", "infrastructure-components.yaml")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(components)).To(ContainSubstring("image: registry.example.com/mirror/cluster-api-aws-controller:v0.5.3"))
+	g.Expect(string(components)).To(ContainSubstring("${SOME_VARIABLE}"))
+
+	// Components without images must be preserved as is.
+	components, err = os.ReadFile(filepath.Clean(filepath.Join(directory, "cluster-api", "v1.0.0", "core-components.yaml")))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(components).To(Equal(componentsYAML("ns1")))
+
+	images, err := os.ReadFile(filepath.Clean(filepath.Join(directory, MirrorImagesFile)))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(images)).To(Equal("registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v0.5.3 registry.example.com/mirror/cluster-api-aws-controller:v0.5.3\n"))
+}
+
+func Test_clusterctlClient_Mirror_localRepository(t *testing.T) {
+	g := NewWithT(t)
+
+	directory := t.TempDir()
+	_, err := fakeMirrorClient().Mirror(ctx, MirrorOptions{
+		InfrastructureProviders: []string{"infra:v3.0.0", "infra:v3.1.0"},
+		Directory:               directory,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The generated clusterctl config must point to the mirrored releases.
+	content, err := os.ReadFile(filepath.Clean(filepath.Join(directory, MirrorConfigFile)))
+	g.Expect(err).ToNot(HaveOccurred())
+	mirrorConfig := &mirrorConfig{}
+	g.Expect(yaml.Unmarshal(content, mirrorConfig)).To(Succeed())
+	g.Expect(mirrorConfig.Providers).To(HaveLen(4))
+	g.Expect(mirrorConfig.CertManager).ToNot(BeNil())
+	g.Expect(mirrorConfig.CertManager.Version).To(Equal(config.CertManagerDefaultVersion))
+
+	// The mirrored releases must be readable by local repositories.
+	var infraConfig mirrorConfigProvider
+	for _, p := range mirrorConfig.Providers {
+		if p.Type == clusterctlv1.InfrastructureProviderType {
+			infraConfig = p
+		}
+	}
+	g.Expect(infraConfig.URL).To(Equal(filepath.Join(directory, "infrastructure-infra", "latest", "infrastructure-components.yaml")))
+
+	infraClient, err := repository.New(ctx, config.NewProvider(infraConfig.Name, infraConfig.URL, infraConfig.Type), newFakeConfig(ctx))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infraClient.DefaultVersion()).To(Equal("v3.1.0"))
+
+	versions, err := infraClient.GetVersions(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(versions).To(ConsistOf("v3.0.0", "v3.1.0"))
+
+	components, err := infraClient.Components().Get(ctx, repository.ComponentsOptions{Version: "v3.0.0", SkipTemplateProcess: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(components.Images()).To(ConsistOf("registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v0.5.3"))
+
+	template, err := infraClient.Templates("v3.0.0").Get(ctx, "", "ns1", false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Objs()).To(HaveLen(1))
+}
+
+func Test_clusterctlClient_Mirror_archive(t *testing.T) {
+	g := NewWithT(t)
+
+	archive := filepath.Join(t.TempDir(), "mirror.tar")
+	got, err := fakeMirrorClient().Mirror(ctx, MirrorOptions{
+		BootstrapProviders:      []string{NoopProvider},
+		ControlPlaneProviders:   []string{NoopProvider},
+		InfrastructureProviders: []string{"infra:v3.0.0"},
+		Archive:                 archive,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got.Providers).To(HaveLen(3))
+
+	f, err := os.Open(filepath.Clean(archive))
+	g.Expect(err).ToNot(HaveOccurred())
+	defer f.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(tr)
+		g.Expect(err).ToNot(HaveOccurred())
+		files[h.Name] = content
+	}
+
+	g.Expect(files).To(HaveKey("oci-layout"))
+	g.Expect(files).To(HaveKey("index.json"))
+
+	index := &repository.OCIIndex{}
+	g.Expect(json.Unmarshal(files["index.json"], index)).To(Succeed())
+
+	refs := map[string]repository.OCIDescriptor{}
+	for _, m := range index.Manifests {
+		g.Expect(m.ArtifactType).To(Equal(repository.OCIArtifactType))
+		refs[m.Annotations[repository.OCIRefNameAnnotation]] = m
+	}
+	g.Expect(refs).To(HaveLen(3))
+	g.Expect(refs).To(HaveKey("cluster-api:v1.0.0"))
+	g.Expect(refs).To(HaveKey("infrastructure-infra:v3.0.0"))
+	g.Expect(refs).To(HaveKey("cert-manager:" + config.CertManagerDefaultVersion))
+
+	// All the blobs referenced by the manifests must be included in the archive.
+	blob := func(digest string) []byte {
+		content, ok := files["blobs/"+strings.Replace(digest, ":", "/", 1)]
+		g.Expect(ok).To(BeTrue(), "missing blob %s", digest)
+		g.Expect(repository.OCIDigest(content)).To(Equal(digest))
+		return content
+	}
+	manifest := &repository.OCIManifest{}
+	g.Expect(json.Unmarshal(blob(refs["infrastructure-infra:v3.0.0"].Digest), manifest)).To(Succeed())
+	g.Expect(blob(manifest.Config.Digest)).To(Equal(repository.OCIEmptyConfig))
+
+	layers := map[string][]byte{}
+	for _, l := range manifest.Layers {
+		layers[l.Annotations[repository.OCITitleAnnotation]] = blob(l.Digest)
+	}
+	g.Expect(layers).To(HaveLen(3))
+	g.Expect(layers).To(HaveKey("infrastructure-components.yaml"))
+	g.Expect(layers).To(HaveKey("metadata.yaml"))
+	g.Expect(layers).To(HaveKeyWithValue("cluster-template.yaml", templateYAML("ns4", "test")))
+}
+
+func Test_clusterctlClient_Mirror_validation(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := fakeMirrorClient().Mirror(ctx, MirrorOptions{})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = fakeMirrorClient().Mirror(ctx, MirrorOptions{Directory: "foo", Archive: "bar"})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = fakeMirrorClient().Mirror(ctx, MirrorOptions{CoreProvider: NoopProvider, Directory: t.TempDir()})
+	g.Expect(err).To(HaveOccurred())
+}
+
+// fakeMirrorClient returns a clusterctl client with repositories for the core, kubeadm, infra providers and cert-manager,
+// using realistic repository URLs.
+func fakeMirrorClient() *fakeClient {
+	coreConfig := config.NewProvider(config.ClusterAPIProviderName, "https://github.com/kubernetes-sigs/cluster-api/releases/latest/core-components.yaml", clusterctlv1.CoreProviderType)
+	bootstrapConfig := config.NewProvider(config.KubeadmBootstrapProviderName, "https://github.com/kubernetes-sigs/cluster-api/releases/latest/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType)
+	controlPlaneConfig := config.NewProvider(config.KubeadmControlPlaneProviderName, "https://github.com/kubernetes-sigs/cluster-api/releases/latest/control-plane-components.yaml", clusterctlv1.ControlPlaneProviderType)
+	infraConfig := config.NewProvider("infra", "https://github.com/example/infra/releases/latest/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType)
+	certManagerConfig := config.NewProvider("cert-manager", config.CertManagerDefaultURL, "")
+
+	config1 := fakeConfig([]config.Provider{coreConfig, bootstrapConfig, controlPlaneConfig, infraConfig}, nil)
+
+	metadata := func(major, minor int32) *clusterctlv1.Metadata {
+		return &clusterctlv1.Metadata{
+			ReleaseSeries: []clusterctlv1.ReleaseSeries{
+				{Major: major, Minor: minor, Contract: currentContractVersion},
+			},
+		}
+	}
+
+	core := newFakeRepository(ctx, coreConfig, config1).
+		WithPaths("root", "components.yaml").
+		WithDefaultVersion("v1.0.0").
+		WithFile("v1.0.0", "components.yaml", componentsYAML("ns1")).
+		WithMetadata("v1.0.0", metadata(1, 0)).
+		WithFile("v1.1.0", "components.yaml", componentsYAML("ns1")).
+		WithMetadata("v1.1.0", metadata(1, 1))
+	bootstrap := newFakeRepository(ctx, bootstrapConfig, config1).
+		WithPaths("root", "components.yaml").
+		WithDefaultVersion("v2.0.0").
+		WithFile("v2.0.0", "components.yaml", componentsYAML("ns2")).
+		WithMetadata("v2.0.0", metadata(2, 0))
+	controlPlane := newFakeRepository(ctx, controlPlaneConfig, config1).
+		WithPaths("root", "components.yaml").
+		WithDefaultVersion("v2.0.0").
+		WithFile("v2.0.0", "components.yaml", componentsYAML("ns3")).
+		WithMetadata("v2.0.0", metadata(2, 0))
+	infra := newFakeRepository(ctx, infraConfig, config1).
+		WithPaths("root", "components.yaml").
+		WithDefaultVersion("v3.0.0").
+		WithFile("v3.0.0", "components.yaml", infraComponentsYAML("ns4")).
+		WithMetadata("v3.0.0", metadata(3, 0)).
+		WithFile("v3.0.0", "cluster-template.yaml", templateYAML("ns4", "test")).
+		WithFile("v3.0.0", "cluster-template-dev.yaml", templateYAML("ns4", "test")).
+		WithFile("v3.0.0", "clusterclass-quick-start.yaml", clusterClassYAML("ns4", "quick-start")).
+		WithFile("v3.1.0", "components.yaml", infraComponentsYAML("ns4")).
+		WithMetadata("v3.1.0", metadata(3, 1)).
+		WithFile("v3.1.0", "cluster-template.yaml", templateYAML("ns4", "test"))
+	certManager := newFakeRepository(ctx, certManagerConfig, config1).
+		WithPaths("root", "cert-manager.yaml").
+		WithDefaultVersion(config.CertManagerDefaultVersion).
+		WithFile(config.CertManagerDefaultVersion, "cert-manager.yaml", componentsYAML("cert-manager"))
+
+	return fakeClusterCtlClient(config1, []*fakeRepositoryClient{core, bootstrap, controlPlane, infra, certManager}, nil)
+}
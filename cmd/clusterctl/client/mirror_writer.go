@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+)
+
+const (
+	// MirrorConfigFile is the name of the clusterctl configuration file generated in a mirror directory.
+	MirrorConfigFile = "clusterctl.yaml"
+
+	// MirrorImagesFile is the name of the file with the list of images generated in a mirror directory;
+	// each line contains the source image and the target image, separated by a space.
+	MirrorImagesFile = "images.txt"
+)
+
+// mirrorWriter stores mirrored files.
+type mirrorWriter interface {
+	// WriteFile stores a file for a provider release.
+	WriteFile(manifestLabel, version, file string, content []byte) error
+
+	// Close completes the mirror.
+	Close(result *MirrorResult) error
+}
+
+// mirrorDirectoryWriter stores mirrored files in a directory, using the layout of clusterctl local repositories.
+type mirrorDirectoryWriter struct {
+	directory string
+}
+
+func newMirrorDirectoryWriter(directory string) *mirrorDirectoryWriter {
+	return &mirrorDirectoryWriter{directory: directory}
+}
+
+func (w *mirrorDirectoryWriter) WriteFile(manifestLabel, version, file string, content []byte) error {
+	filePath := filepath.Join(w.directory, manifestLabel, version, file)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %q", filePath)
+	}
+	if err := os.WriteFile(filePath, content, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", filePath)
+	}
+	return nil
+}
+
+// mirrorConfig is the clusterctl configuration file pointing to the mirrored releases.
+type mirrorConfig struct {
+	Providers   []mirrorConfigProvider `json:"providers,omitempty"`
+	CertManager *mirrorConfigProvider  `json:"cert-manager,omitempty"`
+}
+
+type mirrorConfigProvider struct {
+	Name    string                    `json:"name,omitempty"`
+	URL     string                    `json:"url"`
+	Type    clusterctlv1.ProviderType `json:"type,omitempty"`
+	Version string                    `json:"version,omitempty"`
+}
+
+// Close writes a clusterctl configuration file pointing to the mirrored releases and the list of images required by the mirrored releases.
+func (w *mirrorDirectoryWriter) Close(result *MirrorResult) error {
+	directory, err := filepath.Abs(w.directory)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get absolute path for %q", w.directory)
+	}
+
+	// Providers point to the latest version in the mirror, so it is possible to use any mirrored version with the usual name:version syntax.
+	// Instead cert-manager points to the mirrored version, because it is not possible to get the latest version without a metadata file.
+	mirrorConfig := mirrorConfig{}
+	for _, p := range result.Providers {
+		if p.Type == "" {
+			mirrorConfig.CertManager = &mirrorConfigProvider{
+				URL:     filepath.Join(directory, p.ManifestLabel, p.Version, p.ComponentsFile),
+				Version: p.Version,
+			}
+			continue
+		}
+		if slices.ContainsFunc(mirrorConfig.Providers, func(c mirrorConfigProvider) bool { return c.Name == p.Name && c.Type == p.Type }) {
+			continue
+		}
+		mirrorConfig.Providers = append(mirrorConfig.Providers, mirrorConfigProvider{
+			Name: p.Name,
+			URL:  filepath.Join(directory, p.ManifestLabel, "latest", p.ComponentsFile),
+			Type: p.Type,
+		})
+	}
+
+	content, err := yaml.Marshal(mirrorConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal clusterctl configuration for the mirror")
+	}
+	if err := os.WriteFile(filepath.Join(w.directory, MirrorConfigFile), content, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", MirrorConfigFile)
+	}
+
+	images := strings.Builder{}
+	for _, i := range result.Images {
+		fmt.Fprintf(&images, "%s %s\n", i.Source, i.Target)
+	}
+	if err := os.WriteFile(filepath.Join(w.directory, MirrorImagesFile), []byte(images.String()), 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", MirrorImagesFile)
+	}
+	return nil
+}
+
+// mirrorArchiveWriter stores mirrored files in a tarball using the OCI image layout; each release is stored as
+// an OCI artifact with reference name {provider-label}:{version}.
+type mirrorArchiveWriter struct {
+	archive   string
+	artifacts map[string]*repository.OCIArtifact
+}
+
+func newMirrorArchiveWriter(archive string) *mirrorArchiveWriter {
+	return &mirrorArchiveWriter{
+		archive:   archive,
+		artifacts: map[string]*repository.OCIArtifact{},
+	}
+}
+
+func (w *mirrorArchiveWriter) WriteFile(manifestLabel, version, file string, content []byte) error {
+	ref := fmt.Sprintf("%s:%s", manifestLabel, version)
+	if _, ok := w.artifacts[ref]; !ok {
+		w.artifacts[ref] = &repository.OCIArtifact{Files: map[string][]byte{}}
+	}
+	w.artifacts[ref].Files[file] = content
+	return nil
+}
+
+// Close writes the tarball with all the mirrored releases.
+func (w *mirrorArchiveWriter) Close(_ *MirrorResult) error {
+	index := repository.OCIIndex{
+		SchemaVersion: 2,
+		MediaType:     repository.OCIImageIndexMediaType,
+		Manifests:     []repository.OCIDescriptor{},
+	}
+	blobs := map[string][]byte{}
+	for _, ref := range slices.Sorted(maps.Keys(w.artifacts)) {
+		manifest, manifestBlobs, err := w.artifacts[ref].Manifest()
+		if err != nil {
+			return err
+		}
+		for digest, blob := range manifestBlobs {
+			blobs[digest] = blob
+		}
+
+		descriptor := repository.NewOCIDescriptor(repository.OCIImageManifestMediaType, manifest)
+		descriptor.ArtifactType = repository.OCIArtifactType
+		descriptor.Annotations = map[string]string{repository.OCIRefNameAnnotation: ref}
+		index.Manifests = append(index.Manifests, descriptor)
+		blobs[descriptor.Digest] = manifest
+	}
+
+	layout, err := json.Marshal(repository.OCIImageLayout{ImageLayoutVersion: repository.OCIImageLayoutVersion})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal OCI image layout")
+	}
+	indexContent, err := json.Marshal(index)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal OCI index")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(w.archive), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %q", w.archive)
+	}
+	f, err := os.OpenFile(w.archive, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %q", w.archive)
+	}
+	defer f.Close()
+
+	// Files are written in a deterministic order and with a fixed modification time, so the same mirror always gets the same tarball.
+	tw := tar.NewWriter(f)
+	files := map[string][]byte{
+		"oci-layout": layout,
+		"index.json": indexContent,
+	}
+	for digest, blob := range blobs {
+		files[filepath.ToSlash(filepath.Join("blobs", strings.Replace(digest, ":", "/", 1)))] = blob
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(files[name])),
+			ModTime: time.Unix(0, 0),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to write %q to %q", name, w.archive)
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return errors.Wrapf(err, "failed to write %q to %q", name, w.archive)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %q", w.archive)
+	}
+	return f.Close()
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 )
@@ -39,6 +40,14 @@ type MoveOptions struct {
 	// namespace will be used.
 	Namespace string
 
+	// ClusterNames restricts the move to the Clusters with the given names and to the objects related to them.
+	// If unspecified, all the Clusters in the namespace are moved.
+	ClusterNames []string
+
+	// ClusterLabelSelector restricts the move to the Clusters matching the label selector and to the objects related to them.
+	// If unspecified, all the Clusters in the namespace are moved.
+	ClusterLabelSelector string
+
 	// ExperimentalResourceMutatorFn accepts any number of resource mutator functions that are applied on all resources being moved.
 	// This is an experimental feature and is exposed only from the library and not (yet) through the CLI.
 	ExperimentalResourceMutators []cluster.ResourceMutatorFunc
@@ -51,6 +60,17 @@ type MoveOptions struct {
 
 	// DryRun means the move action is a dry run, no real action will be performed.
 	DryRun bool
+
+	// JournalFile defines the file where the progress of the move operation is recorded, so a move operation
+	// failed halfway can be resumed or rolled back. If unspecified, no journal is recorded.
+	JournalFile string
+
+	// Resume resumes a failed move operation recorded in JournalFile.
+	Resume bool
+
+	// Rollback rolls back a failed move operation recorded in JournalFile, by deleting the objects created
+	// in the target management cluster and resuming the Clusters in the source management cluster.
+	Rollback bool
 }
 
 func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error {
@@ -66,6 +86,29 @@ func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error
 		return errors.Errorf("at least one of FromDirectory, ToDirectory and ToKubeconfig must be set")
 	}
 
+	if options.FromDirectory != "" && (len(options.ClusterNames) > 0 || options.ClusterLabelSelector != "") {
+		return errors.Errorf("can't set ClusterNames or ClusterLabelSelector when using FromDirectory")
+	}
+
+	if options.Resume || options.Rollback {
+		if options.Resume && options.Rollback {
+			return errors.Errorf("can't set both Resume and Rollback")
+		}
+		if options.JournalFile == "" {
+			return errors.Errorf("JournalFile must be set when using Resume or Rollback")
+		}
+		if options.FromDirectory != "" || options.ToDirectory != "" || options.DryRun {
+			return errors.Errorf("can't set FromDirectory, ToDirectory or DryRun when using Resume or Rollback")
+		}
+		if options.ToKubeconfig == (Kubeconfig{}) {
+			return errors.Errorf("ToKubeconfig must be set when using Resume or Rollback")
+		}
+		if len(options.ClusterNames) > 0 || options.ClusterLabelSelector != "" {
+			return errors.Errorf("can't set ClusterNames or ClusterLabelSelector when using Resume or Rollback")
+		}
+		return c.resumeOrRollbackMove(ctx, options)
+	}
+
 	if options.ToDirectory != "" {
 		return c.toDirectory(ctx, options)
 	} else if options.FromDirectory != "" {
@@ -99,7 +142,31 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		}
 	}
 
-	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
+	clusterSelector, err := getClusterSelector(options)
+	if err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().Move(ctx, options.Namespace, clusterSelector, toCluster, options.DryRun, options.JournalFile, options.ExperimentalResourceMutators...)
+}
+
+func (c *clusterctlClient) resumeOrRollbackMove(ctx context.Context, options MoveOptions) error {
+	// Get the client for interacting with the source management cluster.
+	fromCluster, err := c.getClusterClient(ctx, options.FromKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// Get the client for interacting with the target management cluster.
+	toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if options.Rollback {
+		return fromCluster.ObjectMover().RollbackMove(ctx, toCluster, options.JournalFile, options.ExperimentalResourceMutators...)
+	}
+	return fromCluster.ObjectMover().ResumeMove(ctx, toCluster, options.JournalFile, options.ExperimentalResourceMutators...)
 }
 
 func (c *clusterctlClient) fromDirectory(ctx context.Context, options MoveOptions) error {
@@ -134,7 +201,27 @@ func (c *clusterctlClient) toDirectory(ctx context.Context, options MoveOptions)
 		return err
 	}
 
-	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, options.ToDirectory)
+	clusterSelector, err := getClusterSelector(options)
+	if err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, clusterSelector, options.ToDirectory)
+}
+
+// getClusterSelector returns the ClusterSelector for the Clusters to be moved.
+func getClusterSelector(options MoveOptions) (cluster.ClusterSelector, error) {
+	clusterSelector := cluster.ClusterSelector{
+		Names: options.ClusterNames,
+	}
+	if options.ClusterLabelSelector != "" {
+		selector, err := labels.Parse(options.ClusterLabelSelector)
+		if err != nil {
+			return cluster.ClusterSelector{}, errors.Wrapf(err, "invalid Cluster label selector %q", options.ClusterLabelSelector)
+		}
+		clusterSelector.Labels = selector
+	}
+	return clusterSelector, nil
 }
 
 func (c *clusterctlClient) getClusterClient(ctx context.Context, kubeconfig Kubeconfig) (cluster.Client, error) {
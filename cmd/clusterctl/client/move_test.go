@@ -107,6 +107,49 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "does not return error if clusters are selected",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:       Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:         Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterNames:         []string{"foo"},
+					ClusterLabelSelector: "env=prod",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if the cluster label selector is not valid",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:       Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:         Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterLabelSelector: "env in (prod",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if clusters are selected with FromDirectory",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					ToKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					FromDirectory: "/var/cache/fromDirectory",
+					ClusterNames:  []string{"foo"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "does not return an error if dryRun but neither FromDirectory, ToDirectory, or ToKubeconfig is set",
 			fields: fields{
@@ -122,6 +165,82 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "does not return error when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Resume:         true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return error when rolling back a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Rollback:       true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if both Resume and Rollback are set",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Resume:         true,
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if JournalFile is not set when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Resume:         true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if DryRun is set when rolling back a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Rollback:       true,
+					DryRun:         true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -295,13 +414,23 @@ type fakeObjectMover struct {
 	moveErr          error
 	toDirectoryErr   error
 	fromDirectoryErr error
+	resumeErr        error
+	rollbackErr      error
 }
 
-func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _ bool, _ ...cluster.ResourceMutatorFunc) error {
+func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.ClusterSelector, _ cluster.Client, _ bool, _ string, _ ...cluster.ResourceMutatorFunc) error {
 	return f.moveErr
 }
 
-func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ string) error {
+func (f *fakeObjectMover) ResumeMove(_ context.Context, _ cluster.Client, _ string, _ ...cluster.ResourceMutatorFunc) error {
+	return f.resumeErr
+}
+
+func (f *fakeObjectMover) RollbackMove(_ context.Context, _ cluster.Client, _ string, _ ...cluster.ResourceMutatorFunc) error {
+	return f.rollbackErr
+}
+
+func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ cluster.ClusterSelector, _ string) error {
 	return f.toDirectoryErr
 }
 
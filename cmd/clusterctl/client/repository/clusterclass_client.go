@@ -29,6 +29,9 @@ import (
 // ClusterClassClient has methods to work with cluster class templates hosted on a provider repository.
 // Templates are yaml files to be used for creating a guest cluster.
 type ClusterClassClient interface {
+	// Raw returns the cluster class template with the given name as it is stored in the provider repository.
+	Raw(ctx context.Context, name string) ([]byte, error)
+
 	Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error)
 }
 
@@ -59,13 +62,10 @@ func newClusterClassClient(input ClusterClassClientInput) *clusterClassClient {
 	}
 }
 
-func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error) {
+// Raw returns the cluster class template with the given name as it is stored in the provider repository.
+func (cc *clusterClassClient) Raw(ctx context.Context, name string) ([]byte, error) {
 	log := logf.Log
 
-	if targetNamespace == "" {
-		return nil, errors.New("invalid arguments: please provide a targetNamespace")
-	}
-
 	version := cc.version
 	filename := cc.processor.GetClusterClassTemplateName(version, name)
 
@@ -89,6 +89,18 @@ func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace str
 	} else {
 		log.V(1).Info("Using", "override", filename, "provider", cc.provider.ManifestLabel(), "version", version)
 	}
+	return rawArtifact, nil
+}
+
+func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error) {
+	if targetNamespace == "" {
+		return nil, errors.New("invalid arguments: please provide a targetNamespace")
+	}
+
+	rawArtifact, err := cc.Raw(ctx, name)
+	if err != nil {
+		return nil, err
+	}
 
 	return NewTemplate(TemplateInput{
 		rawArtifact,
@@ -172,6 +172,8 @@ type ComponentsOptions struct {
 	// SkipTemplateProcess allows for skipping the call to the template processor, including also variable replacement in the component YAML.
 	// NOTE this works only if the rawYaml is a valid yaml by itself, like e.g when using envsubst/the simple processor.
 	SkipTemplateProcess bool
+	// FeatureGates to be set in the --feature-gates argument of the provider's controller.
+	FeatureGates map[string]bool
 }
 
 // ComponentsInput represents all the inputs required by NewComponents.
@@ -192,6 +194,7 @@ type ComponentsInput struct {
 // 3. Ensure all the provider components are deployed in the target namespace (apply only to namespaced objects)
 // 4. Ensure all the ClusterRoleBinding which are referencing namespaced objects have the name prefixed with the namespace name
 // 5. Adds labels to all the components in order to allow easy identification of the provider objects.
+// 6. If requested, sets feature gates in the --feature-gates argument of the provider's controller.
 func NewComponents(input ComponentsInput) (Components, error) {
 	variables, err := input.Processor.GetVariables(input.RawYaml)
 	if err != nil {
@@ -222,6 +225,12 @@ func NewComponents(input ComponentsInput) (Components, error) {
 		return nil, errors.Wrap(err, "failed to apply image overrides")
 	}
 
+	// Apply feature gates, if defined
+	objs, err = util.FixFeatureGates(objs, input.Options.FeatureGates)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply feature gates")
+	}
+
 	// Inspect the list of objects for the images required by the provider component.
 	images, err := util.InspectImages(objs)
 	if err != nil {
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// NOTE: clusterctl stores provider repositories as OCI artifacts using the same layout used by common tools like ORAS,
+// so provider artifacts can be created and inspected also without clusterctl.
+// Each provider version is stored as an OCI image manifest with an empty config and one layer for each file in the
+// release (components YAML, metadata.yaml, templates etc.); the name of each file is stored in the
+// org.opencontainers.image.title annotation of the corresponding layer.
+// Only the subset of the OCI image specification required by clusterctl is implemented here;
+// see https://github.com/opencontainers/image-spec for more details.
+
+const (
+	// OCIArtifactType is the artifact type for clusterctl provider artifacts.
+	OCIArtifactType = "application/vnd.cluster-api.clusterctl.provider.v1"
+
+	// OCIFileMediaType is the media type for the layers of clusterctl provider artifacts.
+	OCIFileMediaType = "application/vnd.cluster-api.clusterctl.file.v1+yaml"
+
+	// OCIImageManifestMediaType is the media type for OCI image manifests.
+	OCIImageManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// OCIImageIndexMediaType is the media type for OCI image indexes.
+	OCIImageIndexMediaType = "application/vnd.oci.image.index.v1+json"
+
+	// OCIEmptyMediaType is the media type for the empty config of OCI artifacts.
+	OCIEmptyMediaType = "application/vnd.oci.empty.v1+json"
+
+	// OCIImageLayoutVersion is the version of the OCI image layout.
+	OCIImageLayoutVersion = "1.0.0"
+
+	// OCITitleAnnotation is the annotation storing the file name of a layer.
+	OCITitleAnnotation = "org.opencontainers.image.title"
+
+	// OCIRefNameAnnotation is the annotation storing the reference name of a manifest in an OCI image layout.
+	OCIRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// OCIEmptyConfig is the content of the empty config of OCI artifacts.
+var OCIEmptyConfig = []byte("{}")
+
+// OCIDescriptor describes content stored in an OCI registry or in an OCI image layout.
+type OCIDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// OCIManifest is an OCI image manifest.
+type OCIManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        OCIDescriptor     `json:"config"`
+	Layers        []OCIDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// OCIIndex is an OCI image index.
+type OCIIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []OCIDescriptor `json:"manifests"`
+}
+
+// OCIImageLayout is the content of the oci-layout file at the root of an OCI image layout.
+type OCIImageLayout struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// NewOCIDescriptor returns the descriptor for the given content.
+func NewOCIDescriptor(mediaType string, content []byte) OCIDescriptor {
+	return OCIDescriptor{
+		MediaType: mediaType,
+		Digest:    OCIDigest(content),
+		Size:      int64(len(content)),
+	}
+}
+
+// OCIDigest returns the sha256 digest of the given content.
+func OCIDigest(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// OCIArtifact is a clusterctl provider artifact, with the content of each file indexed by file name.
+type OCIArtifact struct {
+	// Files of the artifact, indexed by file name.
+	Files map[string][]byte
+}
+
+// Manifest returns the OCI image manifest for the artifact, and the blobs referenced by the manifest indexed by digest.
+// Layers are sorted by file name, so the same artifact always gets the same manifest.
+func (a *OCIArtifact) Manifest() ([]byte, map[string][]byte, error) {
+	blobs := map[string][]byte{}
+
+	config := NewOCIDescriptor(OCIEmptyMediaType, OCIEmptyConfig)
+	blobs[config.Digest] = OCIEmptyConfig
+
+	manifest := OCIManifest{
+		SchemaVersion: 2,
+		MediaType:     OCIImageManifestMediaType,
+		ArtifactType:  OCIArtifactType,
+		Config:        config,
+		Layers:        []OCIDescriptor{},
+	}
+	for _, name := range slices.Sorted(maps.Keys(a.Files)) {
+		layer := NewOCIDescriptor(OCIFileMediaType, a.Files[name])
+		layer.Annotations = map[string]string{OCITitleAnnotation: name}
+		manifest.Layers = append(manifest.Layers, layer)
+		blobs[layer.Digest] = a.Files[name]
+	}
+
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal OCI manifest")
+	}
+	return content, blobs, nil
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	ociScheme           = "oci"
+	ociLatestVersionTag = "latest"
+	ociTagsPerPageLimit = 1000
+	ociRequestTimeout   = 30 * time.Second
+)
+
+// ociLinkNextRegexp parses the Link header used by OCI registries for paginating the list of tags.
+var ociLinkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// ociRepository provides support for providers hosted in an OCI registry.
+//
+// Each provider version must be stored as an OCI artifact tagged with the version, with one layer for each file in the release;
+// the name of each file must be stored in the org.opencontainers.image.title annotation of the corresponding layer,
+// like e.g. the artifacts created by "oras push" or "clusterctl mirror --archive".
+//
+// The URL is expected to be in the form oci://{registry}/{repository}/{version}/{components.yaml}, e.g.
+// oci://registry.example.com/cluster-api/infrastructure-aws/latest/infrastructure-components.yaml
+// registry: registry.example.com
+// repository: cluster-api/infrastructure-aws
+// version: latest, i.e. the latest tag that obeys the syntax and semantics of the "Semantic Versioning" specification.
+// components.yaml: infrastructure-components.yaml
+//
+// Credentials for the registry are read from the docker config file.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	credentials           ociCredentialsFunc
+	registry              string
+	repository            string
+	defaultVersion        string
+	componentsPath        string
+
+	// authorization is the value of the Authorization header to be used for requests to the registry,
+	// obtained by answering to the authentication challenge of the registry.
+	authorization string
+}
+
+var _ Repository = &ociRepository{}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIHTTPClient(c *http.Client) ociRepositoryOption {
+	return func(o *ociRepository) {
+		o.httpClient = c
+	}
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the url is an OCI repository
+	// NB. format is oci://{registry}/{repository}/{version}/{components.yaml}
+	urlSplit := strings.Split(strings.Trim(rURL.Path, "/"), "/")
+	if rURL.Scheme != ociScheme || rURL.Host == "" || len(urlSplit) < 3 {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}/{version}/{componentsPath}")
+	}
+
+	componentsPath := urlSplit[len(urlSplit)-1]
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	if defaultVersion != ociLatestVersionTag {
+		if _, err := version.ParseSemantic(defaultVersion); err != nil {
+			return nil, errors.Errorf("invalid version: %q. Version must obey the syntax and semantics of the \"Semantic Versioning\" specification (http://semver.org/) and path format oci://{registry}/{repository}/{version}/{componentsPath}", defaultVersion)
+		}
+	}
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		registry:              rURL.Host,
+		repository:            strings.Join(urlSplit[:len(urlSplit)-2], "/"),
+		defaultVersion:        defaultVersion,
+		componentsPath:        componentsPath,
+	}
+	for _, o := range opts {
+		o(repo)
+	}
+
+	dockerConfigPath, _ := configVariablesClient.Get(config.OCIDockerConfigVariable)
+	repo.credentials = dockerConfigCredentials(dockerConfigPath)
+
+	if defaultVersion == ociLatestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (o *ociRepository) DefaultVersion() string {
+	return o.defaultVersion
+}
+
+// RootPath returns the empty string as it is not applicable to OCI repositories.
+func (o *ociRepository) RootPath() string {
+	return ""
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (o *ociRepository) ComponentsPath() string {
+	return o.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in the OCI repository, i.e. the tags that
+// obey the syntax and semantics of the "Semantic Versioning" specification.
+func (o *ociRepository) GetVersions(ctx context.Context) ([]string, error) {
+	cacheID := fmt.Sprintf("%s://%s/%s", ociScheme, o.registry, o.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	next := fmt.Sprintf("/v2/%s/tags/list?n=%d", o.repository, ociTagsPerPageLimit)
+	for next != "" {
+		content, header, err := o.get(ctx, next)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list tags for %q", cacheID)
+		}
+
+		tags := struct {
+			Tags []string `json:"tags"`
+		}{}
+		if err := json.Unmarshal(content, &tags); err != nil {
+			return nil, errors.Wrapf(err, "failed to list tags for %q: failed to decode response", cacheID)
+		}
+		for _, t := range tags.Tags {
+			// discard tags that are not valid semantic versions (the user can point explicitly to such releases)
+			if _, err := version.ParseSemantic(t); err != nil {
+				continue
+			}
+			versions = append(versions, t)
+		}
+
+		next = ""
+		if m := ociLinkNextRegexp.FindStringSubmatch(header.Get("Link")); m != nil {
+			next = m[1]
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (o *ociRepository) GetFile(ctx context.Context, version, fileName string) ([]byte, error) {
+	log := logf.Log
+
+	var err error
+	switch version {
+	case ociLatestVersionTag:
+		version, err = latestRelease(ctx, o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the latest release")
+		}
+	case "":
+		version = o.defaultVersion
+	}
+
+	cacheID := fmt.Sprintf("%s://%s/%s:%s/%s", ociScheme, o.registry, o.repository, version, fileName)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifestContent, _, err := o.get(ctx, fmt.Sprintf("/v2/%s/manifests/%s", o.repository, version), OCIImageManifestMediaType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get manifest for %s:%s", o.repository, version)
+	}
+	manifest := &OCIManifest{}
+	if err := json.Unmarshal(manifestContent, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode manifest for %s:%s", o.repository, version)
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[OCITitleAnnotation] != fileName {
+			continue
+		}
+
+		log.V(5).Info("Fetching", "file", fileName, "repository", o.repository, "version", version, "digest", layer.Digest)
+		content, _, err := o.get(ctx, fmt.Sprintf("/v2/%s/blobs/%s", o.repository, layer.Digest))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get file %q from %s:%s", fileName, o.repository, version)
+		}
+		if digest := OCIDigest(content); digest != layer.Digest {
+			return nil, errors.Errorf("failed to get file %q from %s:%s: expected digest %s, got %s", fileName, o.repository, version, layer.Digest, digest)
+		}
+
+		cacheFiles[cacheID] = content
+		return content, nil
+	}
+	return nil, errors.Errorf("failed to get file %q from %s:%s: file not found in the artifact", fileName, o.repository, version)
+}
+
+// get performs a GET request to the registry API, answering to the authentication challenge of the registry if required.
+func (o *ociRepository) get(ctx context.Context, apiPath string, accept ...string) ([]byte, http.Header, error) {
+	response, err := o.do(ctx, apiPath, accept)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		_ = response.Body.Close()
+
+		authorization, err := o.authorize(ctx, challenge)
+		if err != nil {
+			return nil, nil, err
+		}
+		o.authorization = authorization
+
+		response, err = o.do(ctx, apiPath, accept)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer response.Body.Close()
+	}
+
+	if response.StatusCode != http.StatusOK {
+		switch response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return nil, nil, errors.Errorf("unauthorized access to %s, please check your credentials", o.registry)
+		case http.StatusNotFound:
+			return nil, nil, errNotFound
+		}
+		return nil, nil, errors.Errorf("unexpected response from %s: %s", o.registry, response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read response from %s", o.registry)
+	}
+	return content, response.Header, nil
+}
+
+func (o *ociRepository) do(ctx context.Context, apiPath string, accept []string) (*http.Response, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, ociRequestTimeout, errors.New("http request timeout expired"))
+
+	u := fmt.Sprintf("https://%s%s", ociRegistryHost(o.registry), apiPath)
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to create request for %q", u)
+	}
+	if len(accept) > 0 {
+		request.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if o.authorization != "" {
+		request.Header.Set("Authorization", o.authorization)
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q", u)
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnClose cancels the context of a request when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// ociRegistryHost returns the host serving the registry API for a registry; this is required for Docker Hub only.
+func ociRegistryHost(registry string) string {
+	if registry == "docker.io" {
+		return "registry-1.docker.io"
+	}
+	return registry
+}
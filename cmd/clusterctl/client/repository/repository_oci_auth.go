@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ociCredentials are the credentials for an OCI registry.
+type ociCredentials struct {
+	Username string
+	Password string
+
+	// IdentityToken is a refresh token to be exchanged with an access token using the OAuth2 flow.
+	IdentityToken string
+}
+
+// ociCredentialsFunc returns the credentials for an OCI registry; nil is returned if there are no credentials for the registry.
+type ociCredentialsFunc func(registry string) (*ociCredentials, error)
+
+// dockerConfig is the subset of the docker config file used for getting credentials for OCI registries.
+type dockerConfig struct {
+	Auths       map[string]dockerConfigAuth `json:"auths,omitempty"`
+	CredsStore  string                      `json:"credsStore,omitempty"`
+	CredHelpers map[string]string           `json:"credHelpers,omitempty"`
+}
+
+type dockerConfigAuth struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+// dockerConfigCredentials returns an ociCredentialsFunc reading credentials from the docker config file in the given path;
+// if the path is empty, the docker config file in $DOCKER_CONFIG or in $HOME/.docker is used.
+// Credentials can be stored in the config file or in credential helpers.
+func dockerConfigCredentials(configPath string) ociCredentialsFunc {
+	return func(registry string) (*ociCredentials, error) {
+		if configPath == "" {
+			configDir := os.Getenv("DOCKER_CONFIG")
+			if configDir == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return nil, nil //nolint:nilerr // If there is no home directory, there is no docker config file.
+				}
+				configDir = filepath.Join(home, ".docker")
+			}
+			configPath = filepath.Join(configDir, "config.json")
+		}
+
+		content, err := os.ReadFile(configPath) //nolint:gosec
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to read docker config file %q", configPath)
+		}
+		config := &dockerConfig{}
+		if err := json.Unmarshal(content, config); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode docker config file %q", configPath)
+		}
+
+		// Docker Hub credentials are stored with a legacy key.
+		key := registry
+		if registry == "docker.io" {
+			key = "https://index.docker.io/v1/"
+		}
+
+		// Credential helpers for the registry take precedence over the default credential store.
+		if helper, ok := config.CredHelpers[key]; ok {
+			return dockerCredentialHelper(helper, key)
+		}
+		if config.CredsStore != "" {
+			return dockerCredentialHelper(config.CredsStore, key)
+		}
+
+		for k, auth := range config.Auths {
+			if dockerConfigRegistry(k) != dockerConfigRegistry(key) {
+				continue
+			}
+			credentials := &ociCredentials{
+				Username:      auth.Username,
+				Password:      auth.Password,
+				IdentityToken: auth.IdentityToken,
+			}
+			if auth.Auth != "" {
+				decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to decode credentials for %q in docker config file %q", registry, configPath)
+				}
+				username, password, ok := strings.Cut(string(decoded), ":")
+				if !ok {
+					return nil, errors.Errorf("failed to decode credentials for %q in docker config file %q: invalid format", registry, configPath)
+				}
+				credentials.Username = username
+				credentials.Password = password
+			}
+			return credentials, nil
+		}
+		return nil, nil
+	}
+}
+
+// dockerConfigRegistry normalizes the keys of the auths section in the docker config file, which
+// can be in the form registry.example.com, https://registry.example.com or https://registry.example.com/v1/.
+func dockerConfigRegistry(key string) string {
+	if u, err := url.Parse(key); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return strings.Split(key, "/")[0]
+}
+
+// dockerCredentialHelper gets credentials for a registry from a docker credential helper,
+// see https://github.com/docker/docker-credential-helpers for more details.
+func dockerCredentialHelper(helper, registry string) (*ociCredentials, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get") //nolint:gosec
+	cmd.Stdin = strings.NewReader(registry)
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	if err := cmd.Run(); err != nil {
+		// Credential helpers return an error if there are no credentials for the registry.
+		if strings.Contains(stdout.String(), "credentials not found") {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get credentials for %q from docker-credential-%s", registry, helper)
+	}
+
+	response := struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode credentials for %q from docker-credential-%s", registry, helper)
+	}
+
+	// Credential helpers return identity tokens using <token> as a username.
+	if response.Username == "<token>" {
+		return &ociCredentials{IdentityToken: response.Secret}, nil
+	}
+	return &ociCredentials{Username: response.Username, Password: response.Secret}, nil
+}
+
+// authorize answers to the authentication challenge of the registry, returning the value of the Authorization header
+// to be used for the following requests; both the Basic and the Bearer token authentication schemes are supported,
+// see https://distribution.github.io/distribution/spec/auth/token/ for more details.
+func (o *ociRepository) authorize(ctx context.Context, challenge string) (string, error) {
+	credentials, err := o.credentials(o.registry)
+	if err != nil {
+		return "", err
+	}
+
+	scheme, params := parseOCIAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil || credentials.Username == "" {
+			return "", errors.Errorf("unauthorized access to %s, please add credentials for the registry to the docker config file", o.registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials.Username+":"+credentials.Password)), nil
+	case "bearer":
+		token, err := o.getToken(ctx, params, credentials)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+	return "", errors.Errorf("unauthorized access to %s: unsupported authentication scheme %q", o.registry, scheme)
+}
+
+// getToken gets a token from the authorization service of the registry.
+func (o *ociRepository) getToken(ctx context.Context, params map[string]string, credentials *ociCredentials) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", errors.Errorf("unauthorized access to %s: the authentication challenge doesn't define a realm", o.registry)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", o.repository)
+	}
+
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, ociRequestTimeout, errors.New("http request timeout expired"))
+	defer cancel()
+
+	var request *http.Request
+	var err error
+	if credentials != nil && credentials.IdentityToken != "" {
+		// Exchange the identity token with an access token using the OAuth2 refresh token flow.
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", credentials.IdentityToken)
+		form.Set("service", params["service"])
+		form.Set("scope", scope)
+		form.Set("client_id", "clusterctl")
+		request, err = http.NewRequestWithContext(timeoutctx, http.MethodPost, realm, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create token request for %s", o.registry)
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		query := url.Values{}
+		if params["service"] != "" {
+			query.Set("service", params["service"])
+		}
+		query.Set("scope", scope)
+		request, err = http.NewRequestWithContext(timeoutctx, http.MethodGet, realm+"?"+query.Encode(), http.NoBody)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create token request for %s", o.registry)
+		}
+		if credentials != nil && credentials.Username != "" {
+			request.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get token for %s", o.registry)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to get token for %s: %s, please check your credentials", o.registry, response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get token for %s", o.registry)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(content, &token); err != nil {
+		return "", errors.Wrapf(err, "failed to decode token for %s", o.registry)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", errors.Errorf("failed to get token for %s: empty token", o.registry)
+}
+
+// parseOCIAuthChallenge parses a WWW-Authenticate header, e.g.
+// Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull".
+func parseOCIAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(strings.TrimSpace(rest), ",") {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+			continue
+		}
+		v, r, _ := strings.Cut(value, ",")
+		params[key] = strings.TrimSpace(v)
+		rest = r
+	}
+	return scheme, params
+}
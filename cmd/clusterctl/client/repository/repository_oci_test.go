@@ -0,0 +1,504 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// fakeOCIRegistry is a minimal in-process OCI registry, implementing the subset of the OCI distribution API used by clusterctl
+// and the token authentication flow.
+type fakeOCIRegistry struct {
+	server    *httptest.Server
+	username  string
+	password  string
+	token     string
+	tags      map[string][]byte
+	blobs     map[string][]byte
+	pageLimit int
+}
+
+func newFakeOCIRegistry(t *testing.T, repository string) *fakeOCIRegistry {
+	t.Helper()
+
+	r := &fakeOCIRegistry{
+		username:  "user",
+		password:  "pass",
+		token:     "secret-token",
+		tags:      map[string][]byte{},
+		blobs:     map[string][]byte{},
+		pageLimit: 2,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != r.username || password != r.password || req.URL.Query().Get("scope") != fmt.Sprintf("repository:%s:pull", repository) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"token": %q}`, r.token)
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+r.token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry",scope="repository:%s:pull"`, r.server.URL, repository))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		p := strings.TrimPrefix(req.URL.Path, "/v2/"+repository+"/")
+		switch {
+		case p == "tags/list":
+			tags := []string{}
+			for t := range r.tags {
+				tags = append(tags, t)
+			}
+			slices.Sort(tags)
+			last := req.URL.Query().Get("last")
+			start := 0
+			for i, t := range tags {
+				if t == last {
+					start = i + 1
+				}
+			}
+			end := min(start+r.pageLimit, len(tags))
+			if end < len(tags) {
+				w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, repository, r.pageLimit, url.QueryEscape(tags[end-1])))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repository, "tags": tags[start:end]})
+		case strings.HasPrefix(p, "manifests/"):
+			manifest, ok := r.tags[strings.TrimPrefix(p, "manifests/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", OCIImageManifestMediaType)
+			_, _ = w.Write(manifest)
+		case strings.HasPrefix(p, "blobs/"):
+			blob, ok := r.blobs[strings.TrimPrefix(p, "blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(blob)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r.server = httptest.NewTLSServer(mux)
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *fakeOCIRegistry) withArtifact(tag string, files map[string][]byte) *fakeOCIRegistry {
+	manifest, blobs, err := (&OCIArtifact{Files: files}).Manifest()
+	if err != nil {
+		panic(err)
+	}
+	for digest, blob := range blobs {
+		r.blobs[digest] = blob
+	}
+	r.tags[tag] = manifest
+	return r
+}
+
+func (r *fakeOCIRegistry) host() string {
+	u, _ := url.Parse(r.server.URL)
+	return u.Host
+}
+
+// dockerConfigFile writes a docker config file with the credentials for the registry.
+func (r *fakeOCIRegistry) dockerConfigFile(t *testing.T) string {
+	t.Helper()
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	content := fmt.Sprintf(`{"auths": {"https://%s": {"auth": %q}}}`, r.host(), base64.StdEncoding.EncodeToString([]byte(r.username+":"+r.password)))
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return configFile
+}
+
+func ociMetadata(major, minor int32) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3
+kind: Metadata
+releaseSeries:
+- major: %d
+  minor: %d
+  contract: v1beta2
+`, major, minor))
+}
+
+func fakeOCIProviderRegistry(t *testing.T) *fakeOCIRegistry {
+	t.Helper()
+
+	return newFakeOCIRegistry(t, "capi/infrastructure-foo").
+		withArtifact("v1.0.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("components-v1.0.0"),
+			"metadata.yaml":                  ociMetadata(1, 0),
+		}).
+		withArtifact("v1.1.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("components-v1.1.0"),
+			"metadata.yaml":                  ociMetadata(1, 1),
+			"cluster-template.yaml":          []byte("template-v1.1.0"),
+		}).
+		withArtifact("v1.2.0-rc.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("components-v1.2.0-rc.0"),
+			"metadata.yaml":                  ociMetadata(1, 2),
+		}).
+		withArtifact("main", map[string][]byte{
+			"infrastructure-components.yaml": []byte("components-main"),
+		})
+}
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	registry := fakeOCIProviderRegistry(t)
+	dockerConfig := registry.dockerConfigFile(t)
+
+	tests := []struct {
+		name               string
+		url                string
+		wantRepository     string
+		wantDefaultVersion string
+		wantComponentsPath string
+		wantErr            bool
+	}{
+		{
+			name:               "can create a new OCI repository with a version",
+			url:                fmt.Sprintf("oci://%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", registry.host()),
+			wantRepository:     "capi/infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:               "can create a new OCI repository with latest, ignoring pre-releases",
+			url:                fmt.Sprintf("oci://%s/capi/infrastructure-foo/latest/infrastructure-components.yaml", registry.host()),
+			wantRepository:     "capi/infrastructure-foo",
+			wantDefaultVersion: "v1.1.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:    "fails if the url is not in the expected format",
+			url:     fmt.Sprintf("oci://%s/v1.0.0/infrastructure-components.yaml", registry.host()),
+			wantErr: true,
+		},
+		{
+			name:    "fails if the version is not valid",
+			url:     fmt.Sprintf("oci://%s/capi/infrastructure-foo/main/infrastructure-components.yaml", registry.host()),
+			wantErr: true,
+		},
+		{
+			name:    "fails if the url has not the oci scheme",
+			url:     fmt.Sprintf("https://%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", registry.host()),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			resetCaches()
+			providerConfig := config.NewProvider("foo", tt.url, clusterctlv1.InfrastructureProviderType)
+			variables := test.NewFakeVariableClient().WithVar(config.OCIDockerConfigVariable, dockerConfig)
+
+			got, err := NewOCIRepository(context.Background(), providerConfig, variables, injectOCIHTTPClient(registry.server.Client()))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			repo := got.(*ociRepository)
+			g.Expect(repo.registry).To(Equal(registry.host()))
+			g.Expect(repo.repository).To(Equal(tt.wantRepository))
+			g.Expect(repo.DefaultVersion()).To(Equal(tt.wantDefaultVersion))
+			g.Expect(repo.ComponentsPath()).To(Equal(tt.wantComponentsPath))
+			g.Expect(repo.RootPath()).To(BeEmpty())
+		})
+	}
+}
+
+func Test_ociRepository_GetVersions(t *testing.T) {
+	g := NewWithT(t)
+
+	resetCaches()
+	registry := fakeOCIProviderRegistry(t)
+	repo := newTestOCIRepository(t, registry, registry.dockerConfigFile(t))
+
+	got, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	// NOTE: the fake registry returns two tags per page, so pagination is exercised; tags that are not semantic versions are ignored.
+	g.Expect(got).To(ConsistOf("v1.0.0", "v1.1.0", "v1.2.0-rc.0"))
+}
+
+func Test_ociRepository_GetFile(t *testing.T) {
+	registry := fakeOCIProviderRegistry(t)
+	dockerConfig := registry.dockerConfigFile(t)
+
+	tests := []struct {
+		name     string
+		version  string
+		fileName string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "get file from a version",
+			version:  "v1.1.0",
+			fileName: "cluster-template.yaml",
+			want:     "template-v1.1.0",
+		},
+		{
+			name:     "get file from the default version",
+			version:  "",
+			fileName: "infrastructure-components.yaml",
+			want:     "components-v1.0.0",
+		},
+		{
+			name:     "get file from latest",
+			version:  "latest",
+			fileName: "infrastructure-components.yaml",
+			want:     "components-v1.1.0",
+		},
+		{
+			name:     "fails if the file does not exist",
+			version:  "v1.0.0",
+			fileName: "cluster-template.yaml",
+			wantErr:  true,
+		},
+		{
+			name:     "fails if the version does not exist",
+			version:  "v2.0.0",
+			fileName: "infrastructure-components.yaml",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			resetCaches()
+			repo := newTestOCIRepository(t, registry, dockerConfig)
+
+			got, err := repo.GetFile(context.Background(), tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(got)).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_GetFile_unauthorized(t *testing.T) {
+	g := NewWithT(t)
+
+	resetCaches()
+	registry := fakeOCIProviderRegistry(t)
+
+	// No credentials for the registry.
+	repo := newTestOCIRepository(t, registry, filepath.Join(t.TempDir(), "does-not-exist.json"))
+	_, err := repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+	g.Expect(err).To(HaveOccurred())
+
+	// Wrong credentials for the registry.
+	registry.password = "wrong"
+	repo = newTestOCIRepository(t, registry, registry.dockerConfigFile(t))
+	registry.password = "pass"
+	_, err = repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("please check your credentials"))
+}
+
+func Test_ociRepository_GetFile_digestMismatch(t *testing.T) {
+	g := NewWithT(t)
+
+	resetCaches()
+	registry := fakeOCIProviderRegistry(t)
+	repo := newTestOCIRepository(t, registry, registry.dockerConfigFile(t))
+
+	// Tamper with the blobs in the registry.
+	for digest := range registry.blobs {
+		registry.blobs[digest] = []byte("tampered")
+	}
+
+	_, err := repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("expected digest"))
+}
+
+func Test_parseOCIAuthChallenge(t *testing.T) {
+	tests := []struct {
+		challenge  string
+		wantScheme string
+		wantParams map[string]string
+	}{
+		{
+			challenge:  `Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo/bar:pull,push"`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://auth.example.com/token", "service": "registry.example.com", "scope": "repository:foo/bar:pull,push"},
+		},
+		{
+			challenge:  `Basic realm="Registry Realm"`,
+			wantScheme: "Basic",
+			wantParams: map[string]string{"realm": "Registry Realm"},
+		},
+		{
+			challenge:  `Bearer realm=https://auth.example.com/token, service=registry`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://auth.example.com/token", "service": "registry"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.challenge, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, params := parseOCIAuthChallenge(tt.challenge)
+			g.Expect(scheme).To(Equal(tt.wantScheme))
+			g.Expect(params).To(Equal(tt.wantParams))
+		})
+	}
+}
+
+func Test_dockerConfigCredentials(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+
+	tests := []struct {
+		name     string
+		config   string
+		registry string
+		want     *ociCredentials
+		wantErr  bool
+	}{
+		{
+			name:     "credentials for a registry",
+			config:   fmt.Sprintf(`{"auths": {"registry.example.com": {"auth": %q}}}`, auth),
+			registry: "registry.example.com",
+			want:     &ociCredentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:     "credentials for a registry defined with a url",
+			config:   fmt.Sprintf(`{"auths": {"https://registry.example.com/v1/": {"auth": %q}}}`, auth),
+			registry: "registry.example.com",
+			want:     &ociCredentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:     "credentials for Docker Hub",
+			config:   fmt.Sprintf(`{"auths": {"https://index.docker.io/v1/": {"auth": %q}}}`, auth),
+			registry: "docker.io",
+			want:     &ociCredentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:     "identity token",
+			config:   `{"auths": {"registry.example.com": {"identitytoken": "refresh-token"}}}`,
+			registry: "registry.example.com",
+			want:     &ociCredentials{IdentityToken: "refresh-token"},
+		},
+		{
+			name:     "no credentials for the registry",
+			config:   fmt.Sprintf(`{"auths": {"other.example.com": {"auth": %q}}}`, auth),
+			registry: "registry.example.com",
+			want:     nil,
+		},
+		{
+			name:     "invalid credentials",
+			config:   `{"auths": {"registry.example.com": {"auth": "not-base64"}}}`,
+			registry: "registry.example.com",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configFile := filepath.Join(t.TempDir(), "config.json")
+			g.Expect(os.WriteFile(configFile, []byte(tt.config), 0600)).To(Succeed())
+
+			got, err := dockerConfigCredentials(configFile)(tt.registry)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_dockerConfigCredentials_credentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper scripts are not supported on windows")
+	}
+	g := NewWithT(t)
+
+	// Create a fake credential helper, returning credentials for registry.example.com only.
+	binDir := t.TempDir()
+	helper := `#!/bin/sh
+read registry
+if [ "$registry" = "registry.example.com" ]; then
+  echo '{"ServerURL": "registry.example.com", "Username": "helper-user", "Secret": "helper-pass"}'
+  exit 0
+fi
+echo "credentials not found in native keychain"
+exit 1
+`
+	g.Expect(os.WriteFile(filepath.Join(binDir, "docker-credential-fake"), []byte(helper), 0700)).To(Succeed()) //nolint:gosec
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	g.Expect(os.WriteFile(configFile, []byte(`{"credsStore": "fake"}`), 0600)).To(Succeed())
+
+	got, err := dockerConfigCredentials(configFile)("registry.example.com")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal(&ociCredentials{Username: "helper-user", Password: "helper-pass"}))
+
+	got, err = dockerConfigCredentials(configFile)("other.example.com")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
+
+func newTestOCIRepository(t *testing.T, registry *fakeOCIRegistry, dockerConfig string) Repository {
+	t.Helper()
+
+	providerConfig := config.NewProvider("foo", fmt.Sprintf("oci://%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", registry.host()), clusterctlv1.InfrastructureProviderType)
+	variables := test.NewFakeVariableClient().WithVar(config.OCIDockerConfigVariable, dockerConfig)
+	repo, err := NewOCIRepository(context.Background(), providerConfig, variables, injectOCIHTTPClient(registry.server.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
@@ -29,6 +29,9 @@ import (
 // TemplateClient has methods to work with cluster templates hosted on a provider repository.
 // Templates are yaml files to be used for creating a guest cluster.
 type TemplateClient interface {
+	// Raw returns the cluster template for the given flavor as it is stored in the provider repository.
+	Raw(ctx context.Context, flavor string) ([]byte, error)
+
 	Get(ctx context.Context, flavor, targetNamespace string, listVariablesOnly bool) (Template, error)
 }
 
@@ -68,13 +71,10 @@ func newTemplateClient(input TemplateClientInput) *templateClient {
 // Get return the template for the flavor specified.
 // In case the template does not exists, an error is returned.
 // Get assumes the following naming convention for templates: cluster-template[-<flavor_name>].yaml.
-func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (Template, error) {
+// Raw returns the cluster template for the given flavor as it is stored in the provider repository.
+func (c *templateClient) Raw(ctx context.Context, flavor string) ([]byte, error) {
 	log := logf.Log
 
-	if targetNamespace == "" {
-		return nil, errors.New("invalid arguments: please provide a targetNamespace")
-	}
-
 	version := c.version
 	name := c.processor.GetTemplateName(version, flavor)
 
@@ -98,6 +98,18 @@ func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string
 	} else {
 		log.V(1).Info("Using", "override", name, "provider", c.provider.ManifestLabel(), "version", version)
 	}
+	return rawArtifact, nil
+}
+
+func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (Template, error) {
+	if targetNamespace == "" {
+		return nil, errors.New("invalid arguments: please provide a targetNamespace")
+	}
+
+	rawArtifact, err := c.Raw(ctx, flavor)
+	if err != nil {
+		return nil, err
+	}
 
 	return NewTemplate(TemplateInput{
 		rawArtifact,
@@ -20,13 +20,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 )
 
+// rolloutStatusPollInterval is the interval between checks of the rollout status when watching.
+var rolloutStatusPollInterval = 5 * time.Second
+
 // RolloutRestartOptions carries the options supported by RolloutRestart.
 type RolloutRestartOptions struct {
 	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
@@ -69,6 +77,57 @@ type RolloutResumeOptions struct {
 	Namespace string
 }
 
+// RolloutUndoOptions carries the options supported by RolloutUndo.
+type RolloutUndoOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resources for the rollout command
+	Resources []string
+
+	// Namespace where the resource(s) live. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+
+	// ToRevision is the revision to rollback to. If 0, the resources are rolled back to the previous revision.
+	ToRevision int64
+}
+
+// RolloutHistoryOptions carries the options supported by RolloutHistory.
+type RolloutHistoryOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resource for the rollout command
+	Resource string
+
+	// Namespace where the resource lives. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+}
+
+// RolloutStatusOptions carries the options supported by RolloutStatus.
+type RolloutStatusOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resource for the rollout command
+	Resource string
+
+	// Namespace where the resource lives. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+
+	// Watch instructs RolloutStatus to wait until the rollout is completed or failed.
+	Watch bool
+
+	// Timeout defines the maximum time to wait for the rollout to complete when Watch is set. If 0, RolloutStatus waits forever.
+	Timeout time.Duration
+}
+
 func (c *clusterctlClient) RolloutRestart(ctx context.Context, options RolloutRestartOptions) error {
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -120,6 +179,77 @@ func (c *clusterctlClient) RolloutResume(ctx context.Context, options RolloutRes
 	return nil
 }
 
+func (c *clusterctlClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions) error {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, options.Resources)
+	if err != nil {
+		return err
+	}
+	for _, ref := range objRefs {
+		if err := c.alphaClient.Rollout().ObjectRollbacker(ctx, clusterClient.Proxy(), ref, options.ToRevision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *clusterctlClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]alpha.RolloutRevision, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, []string{options.Resource})
+	if err != nil {
+		return nil, err
+	}
+	return c.alphaClient.Rollout().ObjectHistory(ctx, clusterClient.Proxy(), objRefs[0])
+}
+
+func (c *clusterctlClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) (*alpha.RolloutStatus, error) {
+	log := logf.Log
+
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, []string{options.Resource})
+	if err != nil {
+		return nil, err
+	}
+	ref := objRefs[0]
+
+	status, err := c.alphaClient.Rollout().ObjectStatus(ctx, clusterClient.Proxy(), ref)
+	if err != nil || !options.Watch {
+		return status, err
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	lastMessage := ""
+	err = wait.PollUntilContextCancel(ctx, rolloutStatusPollInterval, true, func(ctx context.Context) (bool, error) {
+		status, err = c.alphaClient.Rollout().ObjectStatus(ctx, clusterClient.Proxy(), ref)
+		if err != nil {
+			return false, err
+		}
+		if status.Message != lastMessage && !status.Done && !status.Failed {
+			log.Info(status.Message)
+			lastMessage = status.Message
+		}
+		return status.Done || status.Failed, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed waiting for the rollout of %s/%s to complete", ref.Kind, ref.Name)
+	}
+	return status, nil
+}
+
 func getObjectRefs(clusterClient cluster.Client, namespace string, resources []string) ([]corev1.ObjectReference, error) {
 	// If the option specifying the Namespace is empty, try to detect it.
 	if namespace == "" {
@@ -19,9 +19,11 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -53,6 +55,27 @@ func fakeClientForRollout() *fakeClient {
 		},
 	}
 
+	md3 := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "md-rolled-out",
+			Generation: 1,
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           ptr.To[int32](1),
+			UpToDateReplicas:   ptr.To[int32](1),
+			AvailableReplicas:  ptr.To[int32](1),
+		},
+	}
+
 	ctx := context.Background()
 
 	config1 := newFakeConfig(ctx).
@@ -63,7 +86,8 @@ func fakeClientForRollout() *fakeClient {
 		WithProviderInventory(core.Name(), core.Type(), "v1.0.0", "cluster-api-system").
 		WithProviderInventory(infra.Name(), infra.Type(), "v2.0.0", "infra-system").
 		WithObjs(md1).
-		WithObjs(md2)
+		WithObjs(md2).
+		WithObjs(md3)
 
 	client := newFakeClient(ctx, config1).
 		WithCluster(cluster1)
@@ -354,3 +378,72 @@ func Test_clusterctlClient_RolloutResume(t *testing.T) {
 		})
 	}
 }
+
+func Test_clusterctlClient_RolloutStatus(t *testing.T) {
+	rolloutStatusPollInterval = 10 * time.Millisecond
+	defer func() {
+		rolloutStatusPollInterval = 5 * time.Second
+	}()
+
+	tests := []struct {
+		name     string
+		options  RolloutStatusOptions
+		wantErr  bool
+		wantDone bool
+	}{
+		{
+			name: "return an error if machinedeployment is not found",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/foo",
+				Namespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "return the current status if not watching",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/md-1",
+				Namespace:  "default",
+			},
+			wantDone: false,
+		},
+		{
+			name: "return when the rollout is completed if watching",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/md-rolled-out",
+				Namespace:  "default",
+				Watch:      true,
+			},
+			wantDone: true,
+		},
+		{
+			name: "return an error if the rollout is not completed before the timeout",
+			options: RolloutStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Resource:   "machinedeployment/md-1",
+				Namespace:  "default",
+				Watch:      true,
+				Timeout:    100 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			status, err := fakeClientForRollout().RolloutStatus(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status.Done).To(Equal(tt.wantDone))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimerecorder "sigs.k8s.io/cluster-api/internal/runtime/recorder"
+)
+
+// RuntimeReplayOptions carries the options supported by RuntimeReplay.
+type RuntimeReplayOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	// Kubeconfig is only used when reading records from a ConfigMap.
+	Kubeconfig Kubeconfig
+
+	// File is the path of a file with the records of calls to runtime extensions,
+	// as written when using the --runtime-extension-record-file flag of the Cluster API controller.
+	File string
+
+	// ConfigMapNamespace and ConfigMapName identify a ConfigMap with the records of calls to runtime extensions,
+	// as written when using the --runtime-extension-record-configmap flag of the Cluster API controller.
+	ConfigMapNamespace string
+	ConfigMapName      string
+
+	// RecordID is the ID of the record to be replayed. If 0, the newest record is replayed.
+	RecordID int64
+
+	// URL is the URL of the runtime extension the request should be sent to, e.g. https://localhost:9443.
+	URL string
+
+	// CABundle is the PEM encoded CA bundle used to validate the certificate of the runtime extension.
+	// If empty, the system CA bundle is used.
+	CABundle []byte
+
+	// Timeout is the timeout of the call. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// RuntimeReplayResult is the result of RuntimeReplay.
+type RuntimeReplayResult struct {
+	// Record is the replayed record, which includes the recorded response.
+	Record runtimerecorder.Record
+
+	// Response is the response received when replaying the record.
+	Response runtimehooksv1.ResponseObject
+}
+
+func (c *clusterctlClient) RuntimeReplay(ctx context.Context, options RuntimeReplayOptions) (*RuntimeReplayResult, error) {
+	if options.URL == "" {
+		return nil, errors.New("the URL of the runtime extension must be set")
+	}
+	if options.Timeout == 0 {
+		options.Timeout = runtimehooksv1.DefaultHandlersTimeoutSeconds * time.Second
+	}
+
+	var sink runtimerecorder.Sink
+	switch {
+	case options.File != "" && options.ConfigMapName != "":
+		return nil, errors.New("records can be read either from a file or from a ConfigMap, not both")
+	case options.File != "":
+		sink = runtimerecorder.NewFileSink(options.File)
+	case options.ConfigMapName != "":
+		if options.ConfigMapNamespace == "" {
+			return nil, errors.New("the namespace of the ConfigMap must be set")
+		}
+		clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+		if err != nil {
+			return nil, err
+		}
+		client, err := clusterClient.Proxy().NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sink = runtimerecorder.NewConfigMapSink(client, options.ConfigMapNamespace, options.ConfigMapName)
+	default:
+		return nil, errors.New("either a file or a ConfigMap to read records from must be set")
+	}
+
+	records, err := sink.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := selectRecord(records, options.RecordID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		return nil, errors.Wrap(err, "failed to create runtime catalog")
+	}
+
+	response, err := internalruntimeclient.Replay(ctx, catalog, runtimev1.ClientConfig{URL: options.URL, CABundle: options.CABundle}, record, options.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &RuntimeReplayResult{
+		Record:   record,
+		Response: response,
+	}, nil
+}
+
+// selectRecord returns the record with the given ID, or the newest record if id is 0.
+func selectRecord(records []runtimerecorder.Record, id int64) (runtimerecorder.Record, error) {
+	if len(records) == 0 {
+		return runtimerecorder.Record{}, errors.New("no records found")
+	}
+	if id == 0 {
+		return records[len(records)-1], nil
+	}
+	for _, record := range records {
+		if record.ID == id {
+			return record, nil
+		}
+	}
+	return runtimerecorder.Record{}, errors.Errorf("record %d not found", id)
+}
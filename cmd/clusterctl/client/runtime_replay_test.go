@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/testcerts"
+
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	runtimerecorder "sigs.k8s.io/cluster-api/internal/runtime/recorder"
+)
+
+func Test_clusterctlClient_RuntimeReplay(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	// Start a runtime extension replying to BeforeClusterCreate requests with the name of the Cluster in the request.
+	var gotPath string
+	cert, err := tls.X509KeyPair(testcerts.ServerCert, testcerts.ServerKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		request := &runtimehooksv1.BeforeClusterCreateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response := &runtimehooksv1.BeforeClusterCreateResponse{
+			CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusSuccess,
+					Message: request.Cluster.Name,
+				},
+			},
+		}
+		respBody, err := json.Marshal(response)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(respBody)
+	}))
+	srv.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+	}
+	srv.StartTLS()
+	defer srv.Close()
+	url := fmt.Sprintf("https://%s", srv.Listener.Addr().String())
+
+	newRecord := func(id int64, clusterName string) runtimerecorder.Record {
+		return runtimerecorder.Record{
+			ID:                id,
+			ExtensionConfig:   "my-extension",
+			ExtensionHandler:  "before-cluster-create.my-extension",
+			APIVersion:        runtimehooksv1.GroupVersion.String(),
+			HandlerAPIVersion: runtimehooksv1.GroupVersion.String(),
+			Hook:              "BeforeClusterCreate",
+			Request:           json.RawMessage(fmt.Sprintf(`{"cluster":{"metadata":{"name":%q}}}`, clusterName)),
+			Response:          json.RawMessage(`{"status":"Success"}`),
+		}
+	}
+	records := []runtimerecorder.Record{newRecord(1, "cluster-1"), newRecord(2, "cluster-2")}
+
+	file := filepath.Join(t.TempDir(), "records.json")
+	g.Expect(runtimerecorder.NewFileSink(file).Write(ctx, records)).To(Succeed())
+
+	data, err := runtimerecorder.Encode(records)
+	g.Expect(err).ToNot(HaveOccurred())
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "capi-system",
+			Name:      "records",
+		},
+		Data: map[string]string{
+			runtimerecorder.ConfigMapDataKey: string(data),
+		},
+	}
+
+	config1 := newFakeConfig(ctx)
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config1).
+		WithObjs(configMap)
+	client := newFakeClient(ctx, config1).
+		WithCluster(cluster1)
+
+	tests := []struct {
+		name        string
+		options     RuntimeReplayOptions
+		wantID      int64
+		wantMessage string
+		wantErr     bool
+	}{
+		{
+			name: "replay the newest record from a file",
+			options: RuntimeReplayOptions{
+				File: file,
+			},
+			wantID:      2,
+			wantMessage: "cluster-2",
+		},
+		{
+			name: "replay a record from a file",
+			options: RuntimeReplayOptions{
+				File:     file,
+				RecordID: 1,
+			},
+			wantID:      1,
+			wantMessage: "cluster-1",
+		},
+		{
+			name: "replay a record from a ConfigMap",
+			options: RuntimeReplayOptions{
+				Kubeconfig:         Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				ConfigMapNamespace: "capi-system",
+				ConfigMapName:      "records",
+				RecordID:           1,
+			},
+			wantID:      1,
+			wantMessage: "cluster-1",
+		},
+		{
+			name: "fails if the record does not exist",
+			options: RuntimeReplayOptions{
+				File:     file,
+				RecordID: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if there are no records",
+			options: RuntimeReplayOptions{
+				File: filepath.Join(t.TempDir(), "does-not-exist.json"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails if neither a file nor a ConfigMap are set",
+			options: RuntimeReplayOptions{},
+			wantErr: true,
+		},
+		{
+			name: "fails if both a file and a ConfigMap are set",
+			options: RuntimeReplayOptions{
+				File:               file,
+				ConfigMapNamespace: "capi-system",
+				ConfigMapName:      "records",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			options := tt.options
+			options.URL = url
+			options.CABundle = testcerts.CACert
+
+			got, err := client.RuntimeReplay(ctx, options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Record.ID).To(Equal(tt.wantID))
+			g.Expect(got.Response.GetStatus()).To(Equal(runtimehooksv1.ResponseStatusSuccess))
+			g.Expect(got.Response.GetMessage()).To(Equal(tt.wantMessage))
+			g.Expect(gotPath).To(Equal("/hooks.runtime.cluster.x-k8s.io/v1alpha1/beforeclustercreate/before-cluster-create"))
+		})
+	}
+
+	// The URL of the runtime extension is required.
+	_, err = client.RuntimeReplay(ctx, RuntimeReplayOptions{File: file})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// ClusterSummarySortByName sorts ClusterSummaries by namespace and name.
+	ClusterSummarySortByName = "name"
+
+	// ClusterSummarySortByClass sorts ClusterSummaries by ClusterClass.
+	ClusterSummarySortByClass = "class"
+
+	// ClusterSummarySortByVersion sorts ClusterSummaries by version.
+	ClusterSummarySortByVersion = "version"
+
+	// ClusterSummarySortByAge sorts ClusterSummaries by creation timestamp, from the oldest to the newest.
+	ClusterSummarySortByAge = "age"
+
+	// ClusterSummarySortByAvailable sorts ClusterSummaries by Available condition, with not available Clusters first.
+	ClusterSummarySortByAvailable = "available"
+)
+
+// ClusterSummarySortBy is the list of valid sort options for ClusterSummaries.
+var ClusterSummarySortBy = []string{ClusterSummarySortByName, ClusterSummarySortByClass, ClusterSummarySortByVersion, ClusterSummarySortByAge, ClusterSummarySortByAvailable}
+
+// ClusterSummary summarizes the status of a Cluster API cluster, e.g. to be shown in a fleet view.
+type ClusterSummary struct {
+	// Namespace of the Cluster.
+	Namespace string `json:"namespace"`
+
+	// Name of the Cluster.
+	Name string `json:"name"`
+
+	// ClusterClass used by the Cluster, if any.
+	// The ClusterClass namespace is included only if it is different from the Cluster namespace.
+	ClusterClass string `json:"clusterClass,omitempty"`
+
+	// Version is the Kubernetes version of the control plane, if reported by the control plane.
+	Version string `json:"version,omitempty"`
+
+	// DesiredVersion is the Kubernetes version defined in the Cluster topology, if any.
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// Phase of the Cluster.
+	Phase string `json:"phase,omitempty"`
+
+	// ControlPlane groups the replica counters of the control plane.
+	ControlPlane ReplicaSummary `json:"controlPlane"`
+
+	// Workers groups the replica counters of the workers.
+	Workers ReplicaSummary `json:"workers"`
+
+	// Available is the Available condition of the Cluster, if any.
+	Available *metav1.Condition `json:"available,omitempty"`
+
+	// ControlPlaneAvailable is the ControlPlaneAvailable condition of the Cluster, if any.
+	ControlPlaneAvailable *metav1.Condition `json:"controlPlaneAvailable,omitempty"`
+
+	// WorkersAvailable is the WorkersAvailable condition of the Cluster, if any.
+	WorkersAvailable *metav1.Condition `json:"workersAvailable,omitempty"`
+
+	// RollingOut is the RollingOut condition of the Cluster, if any.
+	RollingOut *metav1.Condition `json:"rollingOut,omitempty"`
+
+	// TopologyReconciled is the TopologyReconciled condition of the Cluster, if any; its reason
+	// documents the upgrade state of Clusters with a managed topology, e.g. ClusterUpgrading.
+	TopologyReconciled *metav1.Condition `json:"topologyReconciled,omitempty"`
+
+	// Paused is true if the Cluster is paused.
+	Paused bool `json:"paused,omitempty"`
+
+	// Deleting is true if the Cluster is being deleted.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// CreationTimestamp of the Cluster.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+// ReplicaSummary groups the replica counters of a control plane or of the workers of a Cluster.
+type ReplicaSummary struct {
+	DesiredReplicas   *int32 `json:"desiredReplicas,omitempty"`
+	Replicas          *int32 `json:"replicas,omitempty"`
+	AvailableReplicas *int32 `json:"availableReplicas,omitempty"`
+	ReadyReplicas     *int32 `json:"readyReplicas,omitempty"`
+	UpToDateReplicas  *int32 `json:"upToDateReplicas,omitempty"`
+}
+
+// DiscoverClustersOptions define options for the DiscoverClusters process.
+type DiscoverClustersOptions struct {
+	// Namespace where the Clusters are located; if empty, Clusters in all the namespaces are discovered.
+	Namespace string
+
+	// LabelSelector selects the Clusters to be discovered by label; if nil, all the Clusters are discovered.
+	LabelSelector labels.Selector
+
+	// SortBy defines how to sort the ClusterSummaries; if empty, ClusterSummaries are sorted by namespace and name.
+	SortBy string
+}
+
+// DiscoverClusters returns a summary of the status of all the Cluster API clusters matching the given options.
+// Nb. Each Cluster is collapsed to a single ClusterSummary, mostly built from the rollups already computed in
+// the Cluster status, so only the control plane object is read in addition to the Cluster.
+func DiscoverClusters(ctx context.Context, c client.Client, options DiscoverClustersOptions) ([]ClusterSummary, error) {
+	listOptions := []client.ListOption{}
+	if options.Namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(options.Namespace))
+	}
+	if options.LabelSelector != nil {
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: options.LabelSelector})
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, listOptions...); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	summaries := make([]ClusterSummary, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		summaries = append(summaries, newClusterSummary(ctx, c, &clusterList.Items[i]))
+	}
+
+	if err := SortClusterSummaries(summaries, options.SortBy); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func newClusterSummary(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) ClusterSummary {
+	summary := ClusterSummary{
+		Namespace:             cluster.Namespace,
+		Name:                  cluster.Name,
+		Phase:                 cluster.Status.Phase,
+		Available:             conditions.Get(cluster, clusterv1.AvailableCondition),
+		ControlPlaneAvailable: conditions.Get(cluster, clusterv1.ClusterControlPlaneAvailableCondition),
+		WorkersAvailable:      conditions.Get(cluster, clusterv1.ClusterWorkersAvailableCondition),
+		RollingOut:            conditions.Get(cluster, clusterv1.RollingOutCondition),
+		TopologyReconciled:    conditions.Get(cluster, clusterv1.ClusterTopologyReconciledCondition),
+		Paused:                ptr.Deref(cluster.Spec.Paused, false),
+		Deleting:              !cluster.DeletionTimestamp.IsZero(),
+		CreationTimestamp:     cluster.CreationTimestamp,
+	}
+
+	if cluster.Spec.Topology.IsDefined() {
+		summary.ClusterClass = cluster.Spec.Topology.ClassRef.Name
+		if cluster.Spec.Topology.ClassRef.Namespace != "" && cluster.Spec.Topology.ClassRef.Namespace != cluster.Namespace {
+			summary.ClusterClass = cluster.Spec.Topology.ClassRef.Namespace + "/" + cluster.Spec.Topology.ClassRef.Name
+		}
+		summary.DesiredVersion = cluster.Spec.Topology.Version
+	}
+
+	if cp := cluster.Status.ControlPlane; cp != nil {
+		summary.ControlPlane = ReplicaSummary{
+			DesiredReplicas:   cp.DesiredReplicas,
+			Replicas:          cp.Replicas,
+			AvailableReplicas: cp.AvailableReplicas,
+			ReadyReplicas:     cp.ReadyReplicas,
+			UpToDateReplicas:  cp.UpToDateReplicas,
+		}
+	}
+	if w := cluster.Status.Workers; w != nil {
+		summary.Workers = ReplicaSummary{
+			DesiredReplicas:   w.DesiredReplicas,
+			Replicas:          w.Replicas,
+			AvailableReplicas: w.AvailableReplicas,
+			ReadyReplicas:     w.ReadyReplicas,
+			UpToDateReplicas:  w.UpToDateReplicas,
+		}
+	}
+
+	// Gets the version from the control plane, if possible.
+	// Nb. errors are ignored, because the summary should be shown also if the control plane is not yet created or not reachable.
+	if controlPlane, err := external.GetObjectFromContractVersionedRef(ctx, c, cluster.Spec.ControlPlaneRef, cluster.Namespace); err == nil {
+		if version, err := contract.ControlPlane().StatusVersion().Get(controlPlane); err == nil && version != nil {
+			summary.Version = *version
+		}
+	}
+
+	return summary
+}
+
+// SortClusterSummaries sorts ClusterSummaries according to the sortBy option; Clusters with the same sort key are sorted by namespace and name.
+func SortClusterSummaries(summaries []ClusterSummary, sortBy string) error {
+	var less func(a, b ClusterSummary) int
+	switch sortBy {
+	case "", ClusterSummarySortByName:
+		less = func(_, _ ClusterSummary) int { return 0 }
+	case ClusterSummarySortByClass:
+		less = func(a, b ClusterSummary) int { return strings.Compare(a.ClusterClass, b.ClusterClass) }
+	case ClusterSummarySortByVersion:
+		less = compareVersion
+	case ClusterSummarySortByAge:
+		less = func(a, b ClusterSummary) int { return a.CreationTimestamp.Compare(b.CreationTimestamp.Time) }
+	case ClusterSummarySortByAvailable:
+		less = func(a, b ClusterSummary) int { return availableOrder(a) - availableOrder(b) }
+	default:
+		return errors.Errorf("invalid sort option %q, valid values: %v", sortBy, ClusterSummarySortBy)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if c := less(summaries[i], summaries[j]); c != 0 {
+			return c < 0
+		}
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	return nil
+}
+
+// compareVersion compares the versions of two Clusters using semantic versioning, with Clusters without a valid version last.
+func compareVersion(a, b ClusterSummary) int {
+	va, errA := semver.ParseTolerant(a.Version)
+	vb, errB := semver.ParseTolerant(b.Version)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a.Version, b.Version)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	default:
+		return va.Compare(vb)
+	}
+}
+
+// availableOrder returns the sort order for the Available condition of a Cluster: False, then Unknown or missing, then True.
+func availableOrder(s ClusterSummary) int {
+	if s.Available == nil {
+		return 1
+	}
+	switch s.Available.Status {
+	case metav1.ConditionFalse:
+		return 0
+	case metav1.ConditionTrue:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_DiscoverClusters(t *testing.T) {
+	upgrading := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "upgrading",
+			Labels:    map[string]string{"env": "prod"},
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: clusterv1.Topology{
+				ClassRef: clusterv1.ClusterClassRef{Name: "class1", Namespace: "classes"},
+				Version:  "v1.34.0",
+			},
+		},
+		Status: clusterv1.ClusterStatus{
+			ControlPlane: &clusterv1.ClusterControlPlaneStatus{
+				DesiredReplicas:   ptr.To[int32](3),
+				Replicas:          ptr.To[int32](3),
+				AvailableReplicas: ptr.To[int32](3),
+				UpToDateReplicas:  ptr.To[int32](1),
+			},
+			Workers: &clusterv1.WorkersStatus{
+				DesiredReplicas:   ptr.To[int32](5),
+				Replicas:          ptr.To[int32](5),
+				AvailableReplicas: ptr.To[int32](4),
+			},
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.AvailableCondition, Status: metav1.ConditionTrue, Reason: clusterv1.AvailableReason},
+				{Type: clusterv1.ClusterTopologyReconciledCondition, Status: metav1.ConditionFalse, Reason: clusterv1.ClusterTopologyReconciledClusterUpgradingReason},
+			},
+		},
+	}
+
+	objs := []client.Object{upgrading}
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster2").WithLabels(map[string]string{"env": "prod"}).WithControlPlane(test.NewFakeControlPlane("cp")).Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns2", "cluster3").WithLabels(map[string]string{"env": "prod"}).Objs()...)
+
+	tests := []struct {
+		name      string
+		options   DiscoverClustersOptions
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "All namespaces",
+			options:   DiscoverClustersOptions{},
+			wantNames: []string{"ns1/cluster1", "ns1/cluster2", "ns1/upgrading", "ns2/cluster3"},
+		},
+		{
+			name:      "Namespace",
+			options:   DiscoverClustersOptions{Namespace: "ns2"},
+			wantNames: []string{"ns2/cluster3"},
+		},
+		{
+			name:      "Label selector",
+			options:   DiscoverClustersOptions{Namespace: "ns1", LabelSelector: labels.SelectorFromSet(labels.Set{"env": "prod"})},
+			wantNames: []string{"ns1/cluster2", "ns1/upgrading"},
+		},
+		{
+			name:      "Sort by class",
+			options:   DiscoverClustersOptions{Namespace: "ns1", SortBy: ClusterSummarySortByClass},
+			wantNames: []string{"ns1/cluster2", "ns1/cluster1", "ns1/upgrading"},
+		},
+		{
+			name:    "Invalid sort option",
+			options: DiscoverClustersOptions{SortBy: "foo"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c, err := test.NewFakeProxy().WithObjs(objs...).NewClient(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := DiscoverClusters(context.Background(), c, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotNames := []string{}
+			for _, s := range got {
+				gotNames = append(gotNames, s.Namespace+"/"+s.Name)
+			}
+			g.Expect(gotNames).To(Equal(tt.wantNames))
+		})
+	}
+
+	t.Run("Summary", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := test.NewFakeProxy().WithObjs(objs...).NewClient(context.Background())
+		g.Expect(err).ToNot(HaveOccurred())
+
+		got, err := DiscoverClusters(context.Background(), c, DiscoverClustersOptions{Namespace: "ns1", LabelSelector: labels.SelectorFromSet(labels.Set{"env": "prod"})})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(HaveLen(2))
+
+		// Cluster without topology and without status.
+		g.Expect(got[0].ClusterClass).To(BeEmpty())
+		g.Expect(got[0].DesiredVersion).To(BeEmpty())
+		g.Expect(got[0].ControlPlane).To(Equal(ReplicaSummary{}))
+		g.Expect(got[0].Available).To(BeNil())
+
+		// Cluster with topology, upgrading.
+		g.Expect(got[1].ClusterClass).To(Equal("classes/class1"))
+		g.Expect(got[1].DesiredVersion).To(Equal("v1.34.0"))
+		g.Expect(got[1].ControlPlane.DesiredReplicas).To(Equal(ptr.To[int32](3)))
+		g.Expect(got[1].ControlPlane.UpToDateReplicas).To(Equal(ptr.To[int32](1)))
+		g.Expect(got[1].Workers.AvailableReplicas).To(Equal(ptr.To[int32](4)))
+		g.Expect(got[1].Available).ToNot(BeNil())
+		g.Expect(got[1].Available.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(got[1].TopologyReconciled).ToNot(BeNil())
+		g.Expect(got[1].TopologyReconciled.Reason).To(Equal(clusterv1.ClusterTopologyReconciledClusterUpgradingReason))
+	})
+}
+
+func Test_SortClusterSummaries(t *testing.T) {
+	now := time.Now()
+	available := func(status metav1.ConditionStatus) *metav1.Condition {
+		return &metav1.Condition{Type: clusterv1.AvailableCondition, Status: status}
+	}
+	summaries := func() []ClusterSummary {
+		return []ClusterSummary{
+			{Namespace: "ns2", Name: "c", Version: "v1.33.0", CreationTimestamp: metav1.NewTime(now.Add(-1 * time.Hour)), Available: available(metav1.ConditionTrue)},
+			{Namespace: "ns1", Name: "b", Version: "v1.100.0", CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour))},
+			{Namespace: "ns1", Name: "a", Version: "v1.33.0", CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)), Available: available(metav1.ConditionFalse)},
+		}
+	}
+
+	tests := []struct {
+		sortBy    string
+		wantNames []string
+	}{
+		{sortBy: "", wantNames: []string{"a", "b", "c"}},
+		{sortBy: ClusterSummarySortByName, wantNames: []string{"a", "b", "c"}},
+		{sortBy: ClusterSummarySortByVersion, wantNames: []string{"a", "c", "b"}},
+		{sortBy: ClusterSummarySortByAge, wantNames: []string{"b", "a", "c"}},
+		{sortBy: ClusterSummarySortByAvailable, wantNames: []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := summaries()
+			g.Expect(SortClusterSummaries(got, tt.sortBy)).To(Succeed())
+
+			gotNames := []string{}
+			for _, s := range got {
+				gotNames = append(gotNames, s.Name)
+			}
+			g.Expect(gotNames).To(Equal(tt.wantNames))
+		})
+	}
+}
@@ -80,6 +80,17 @@ func (c *clusterctlClient) PlanUpgrade(ctx context.Context, options PlanUpgradeO
 			Contract:  plan.Contract,
 			Providers: plan.Providers,
 		}
+
+		// Checks compatibility of the workload Clusters with the target provider versions; this is done only
+		// for the upgrade plan that can be applied with the current version of clusterctl.
+		if plan.Contract != c.currentContractVersion {
+			continue
+		}
+		clusters, err := clusterClient.ProviderUpgrader().CheckCompatibility(ctx, plan)
+		if err != nil {
+			return nil, err
+		}
+		aliasUpgradePlan[i].Clusters = clusters
 	}
 
 	return aliasUpgradePlan, nil
@@ -125,6 +136,10 @@ type ApplyUpgradeOptions struct {
 
 	// WaitProviderTimeout sets the timeout per provider upgrade.
 	WaitProviderTimeout time.Duration
+
+	// Force instructs the upgrade apply command to proceed even if there are workload Clusters
+	// not compatible with the target versions of the providers.
+	Force bool
 }
 
 func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error {
@@ -176,6 +191,7 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 	opts := cluster.UpgradeOptions{
 		WaitProviders:       options.WaitProviders,
 		WaitProviderTimeout: options.WaitProviderTimeout,
+		Force:               options.Force,
 	}
 
 	// If we are upgrading a specific set of providers only, process the providers and call ApplyCustomPlan.
@@ -222,6 +238,95 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 	return clusterClient.ProviderUpgrader().ApplyPlan(ctx, opts, options.Contract)
 }
 
+// RollbackUpgradeOptions carries the options supported by upgrade rollback.
+type RollbackUpgradeOptions struct {
+	// Kubeconfig to use for accessing the management cluster. If empty, default discovery rules apply.
+	Kubeconfig Kubeconfig
+
+	// CoreProvider name (e.g. cluster-api) to roll back.
+	CoreProvider string
+
+	// BootstrapProviders names (e.g. kubeadm) to roll back.
+	BootstrapProviders []string
+
+	// ControlPlaneProviders names (e.g. kubeadm) to roll back.
+	ControlPlaneProviders []string
+
+	// InfrastructureProviders names (e.g. aws) to roll back.
+	InfrastructureProviders []string
+
+	// IPAMProviders names (e.g. infoblox) to roll back.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders names (e.g. test) to roll back.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders names (e.g. helm) to roll back.
+	AddonProviders []string
+}
+
+func (c *clusterctlClient) RollbackUpgrade(ctx context.Context, options RollbackUpgradeOptions) error {
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := clusterClient.ProviderInventory().EnsureCustomResourceDefinitions(ctx); err != nil {
+		return err
+	}
+
+	// Prepare the list of providers to roll back; if no provider is specified, all the providers
+	// with a snapshot taken by the last upgrade are rolled back.
+	var providers []clusterctlv1.Provider
+	providers, err = appendProviders(providers, clusterctlv1.CoreProviderType, options.CoreProvider)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.BootstrapProviderType, options.BootstrapProviders...)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.ControlPlaneProviderType, options.ControlPlaneProviders...)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.InfrastructureProviderType, options.InfrastructureProviders...)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.IPAMProviderType, options.IPAMProviders...)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders...)
+	if err != nil {
+		return err
+	}
+	providers, err = appendProviders(providers, clusterctlv1.AddonProviderType, options.AddonProviders...)
+	if err != nil {
+		return err
+	}
+
+	for i := range providers {
+		if providers[i].Version != "" {
+			return errors.Errorf("invalid provider name %q: the version can't be specified when rolling back, the provider is rolled back to the version before the last upgrade", providers[i].ProviderName+":"+providers[i].Version)
+		}
+
+		// Try to detect the namespace where the provider lives
+		providers[i].Namespace, err = clusterClient.ProviderInventory().GetProviderNamespace(ctx, providers[i].ProviderName, providers[i].GetProviderType())
+		if err != nil {
+			return err
+		}
+		if providers[i].Namespace == "" {
+			return errors.Errorf("failed to identify the namespace for the %q provider", providers[i].ProviderName)
+		}
+	}
+
+	return clusterClient.ProviderUpgrader().Rollback(ctx, providers...)
+}
+
 func addUpgradeItems(ctx context.Context, clusterClient cluster.Client, upgradeItems []cluster.UpgradeItem, providerType clusterctlv1.ProviderType, providers ...string) ([]cluster.UpgradeItem, error) {
 	for _, upgradeReference := range providers {
 		providerUpgradeItem, err := parseUpgradeItem(ctx, clusterClient, upgradeReference, providerType)
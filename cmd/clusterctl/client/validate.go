@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/validation"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+// ValidateTemplateOptions carries the options supported by ValidateTemplate.
+type ValidateTemplateOptions struct {
+	// Objs to be validated, e.g. the objects of a workload cluster template.
+	Objs []unstructured.Unstructured
+}
+
+// validationFeatureGate maps a feature gate checked by the Cluster API webhooks to the variable controlling it
+// in the Cluster API core provider components, with the same default.
+type validationFeatureGate struct {
+	variable     string
+	defaultValue bool
+}
+
+var validationFeatureGates = map[string]validationFeatureGate{
+	string(feature.ClusterTopology):           {variable: "CLUSTER_TOPOLOGY", defaultValue: false},
+	string(feature.MachinePool):               {variable: "EXP_MACHINE_POOL", defaultValue: true},
+	string(feature.RuntimeSDK):                {variable: "EXP_RUNTIME_SDK", defaultValue: false},
+	string(feature.MachineSetPreflightChecks): {variable: "EXP_MACHINE_SET_PREFLIGHT_CHECKS", defaultValue: true},
+	string(feature.MachineTaintPropagation):   {variable: "EXP_MACHINE_TAINT_PROPAGATION", defaultValue: false},
+}
+
+func (c *clusterctlClient) ValidateTemplate(ctx context.Context, options ValidateTemplateOptions) ([]validation.Issue, error) {
+	// Validate using the same feature gates clusterctl init would set on the Cluster API core provider.
+	featureGates := map[string]bool{}
+	for gate, fg := range validationFeatureGates {
+		featureGates[gate] = fg.defaultValue
+		value, err := c.configClient.Variables().Get(fg.variable)
+		if err != nil {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for variable %s", fg.variable)
+		}
+		featureGates[gate] = enabled
+	}
+
+	return validation.Validate(ctx, options.Objs, validation.Options{FeatureGates: featureGates})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/validation"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+func Test_clusterctlClient_ValidateTemplate(t *testing.T) {
+	clusterClass := `
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: ClusterClass
+metadata:
+  name: quick-start
+  namespace: ns1
+spec:
+  controlPlane:
+    templateRef:
+      apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+      kind: KubeadmControlPlaneTemplate
+      name: quick-start-control-plane
+  infrastructure:
+    templateRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: DockerClusterTemplate
+      name: quick-start-cluster
+`
+
+	tests := []struct {
+		name       string
+		vars       map[string]string
+		wantIssues []validation.Issue
+		wantErr    bool
+	}{
+		{
+			name: "feature gates default to the Cluster API core provider defaults",
+			vars: map[string]string{},
+			wantIssues: []validation.Issue{
+				{Severity: validation.ErrorSeverity, Kind: "ClusterClass", Namespace: "ns1", Name: "quick-start", Message: "spec: Forbidden: can be set only if the ClusterTopology feature flag is enabled"},
+			},
+		},
+		{
+			name:       "feature gates are read from clusterctl variables",
+			vars:       map[string]string{"CLUSTER_TOPOLOGY": "true"},
+			wantIssues: []validation.Issue{},
+		},
+		{
+			name:    "fails for invalid feature gate variables",
+			vars:    map[string]string{"CLUSTER_TOPOLOGY": "foo"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			config := newFakeConfig(ctx)
+			for k, v := range tt.vars {
+				config.WithVar(k, v)
+			}
+			c := newFakeClient(ctx, config)
+
+			objs, err := utilyaml.ToUnstructured([]byte(clusterClass))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			issues, err := c.ValidateTemplate(ctx, ValidateTemplateOptions{Objs: objs})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(issues).To(Equal(tt.wantIssues))
+		})
+	}
+}
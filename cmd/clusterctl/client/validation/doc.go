@@ -0,0 +1,24 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package validation implements offline validation of Cluster API objects, e.g. the objects of a cluster template.
+
+Objects are converted to the Cluster API hub types and then run through the same defaulting and validation
+logic used by the Cluster API admission webhooks; ClusterClasses defined in the same set of objects are used
+to validate Clusters with a managed topology, including ClusterClass variables.
+*/
+package validation
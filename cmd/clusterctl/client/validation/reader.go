@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// clusterClassReader is a client.Reader serving ClusterClasses from memory, simulating ClusterClasses
+// already reconciled by the ClusterClass controller.
+type clusterClassReader struct {
+	clusterClasses map[client.ObjectKey]*clusterv1.ClusterClass
+}
+
+var _ client.Reader = &clusterClassReader{}
+
+// add a ClusterClass to the reader, computing the status the ClusterClass controller would set.
+// NOTE: Variables provided by external patches via the DiscoverVariables hook can't be computed offline;
+// ClusterClasses using them are left not reconciled, so the webhooks only partially validate Clusters using them.
+func (r *clusterClassReader) add(clusterClass *clusterv1.ClusterClass) {
+	cc := clusterClass.DeepCopy()
+	r.clusterClasses[client.ObjectKeyFromObject(cc)] = cc
+
+	for _, patch := range cc.Spec.Patches {
+		if patch.External != nil && patch.External.DiscoverVariablesExtension != "" {
+			return
+		}
+	}
+
+	cc.Generation = 1
+	cc.Status.ObservedGeneration = 1
+	cc.Status.Variables = []clusterv1.ClusterClassStatusVariable{}
+	for _, variable := range cc.Spec.Variables {
+		cc.Status.Variables = append(cc.Status.Variables, clusterv1.ClusterClassStatusVariable{
+			Name:                variable.Name,
+			DefinitionsConflict: ptr.To(false),
+			Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+				{
+					From:                      clusterv1.VariableDefinitionFromInline,
+					Required:                  variable.Required,
+					DeprecatedV1Beta1Metadata: variable.DeprecatedV1Beta1Metadata,
+					Schema:                    variable.Schema,
+				},
+			},
+		})
+	}
+	sort.SliceStable(cc.Status.Variables, func(i, j int) bool {
+		return cc.Status.Variables[i].Name < cc.Status.Variables[j].Name
+	})
+	conditions.Set(cc, metav1.Condition{
+		Type:   clusterv1.ClusterClassVariablesReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ClusterClassVariablesReadyReason,
+	})
+}
+
+// Get returns a ClusterClass from memory; any other object is reported as not found.
+func (r *clusterClassReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	clusterClass, ok := obj.(*clusterv1.ClusterClass)
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	cc, ok := r.clusterClasses[key]
+	if !ok {
+		return apierrors.NewNotFound(clusterv1.GroupVersion.WithResource("clusterclasses").GroupResource(), key.Name)
+	}
+	cc.DeepCopyInto(clusterClass)
+	return nil
+}
+
+// List always returns an empty list, given that the objects being validated are assumed to not exist yet.
+func (r *clusterClassReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/webhooks"
+)
+
+// Severity defines how severe is an Issue.
+type Severity string
+
+const (
+	// ErrorSeverity identifies issues that would cause the object to be rejected by the Cluster API webhooks.
+	ErrorSeverity Severity = "Error"
+
+	// WarningSeverity identifies issues that would be surfaced as warnings by the Cluster API webhooks.
+	WarningSeverity Severity = "Warning"
+)
+
+// Issue is a problem detected while validating an object.
+type Issue struct {
+	Severity  Severity
+	Kind      string
+	Namespace string
+	Name      string
+	Message   string
+}
+
+// ObjectRef returns a reference to the object the Issue is about, e.g. Cluster/default/my-cluster.
+func (i Issue) ObjectRef() string {
+	if i.Namespace == "" {
+		return fmt.Sprintf("%s/%s", i.Kind, i.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", i.Kind, i.Namespace, i.Name)
+}
+
+// String returns a human readable representation of the Issue.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Severity, i.ObjectRef(), i.Message)
+}
+
+// HasErrors returns true if any of the issues has ErrorSeverity.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == ErrorSeverity {
+			return true
+		}
+	}
+	return false
+}
+
+// Options defines the options for Validate.
+type Options struct {
+	// FeatureGates to be used for validation; they should match the feature gates of the Cluster API core provider
+	// in the management cluster where the objects are going to be applied, e.g. ClusterTopology.
+	FeatureGates map[string]bool
+}
+
+// validateFunc defaults and validates an object already converted to the hub type.
+type validateFunc func(ctx context.Context, obj client.Object) (admission.Warnings, error)
+
+// Validate runs objects through the defaulting and validation logic of the Cluster API webhooks without requiring
+// a management cluster; objects with a Kind not served by the Cluster API core webhooks are ignored.
+// Clusters with a managed topology are validated against ClusterClasses in the same set of objects; if the
+// ClusterClass is not included, the Cluster topology is only partially validated and a warning is returned.
+// NOTE: Feature gates are global for the current process, this is acceptable because Validate is designed
+// to be used from a CLI.
+func Validate(ctx context.Context, objs []unstructured.Unstructured, options Options) ([]Issue, error) {
+	if len(options.FeatureGates) > 0 {
+		if err := feature.MutableGates.SetFromMap(options.FeatureGates); err != nil {
+			return nil, errors.Wrap(err, "failed to set feature gates for validation")
+		}
+	}
+
+	reader := &clusterClassReader{clusterClasses: map[client.ObjectKey]*clusterv1.ClusterClass{}}
+	validators := map[schema.GroupVersionKind]validateFunc{
+		clusterv1.GroupVersion.WithKind("Cluster"):            newValidateFunc[*clusterv1.Cluster](&webhooks.Cluster{Client: reader}, &webhooks.Cluster{Client: reader}),
+		clusterv1.GroupVersion.WithKind("ClusterClass"):       newValidateFunc[*clusterv1.ClusterClass](nil, &webhooks.ClusterClass{Client: reader}),
+		clusterv1.GroupVersion.WithKind("Machine"):            newValidateFunc[*clusterv1.Machine](&webhooks.Machine{}, &webhooks.Machine{}),
+		clusterv1.GroupVersion.WithKind("MachineDeployment"):  newValidateFunc[*clusterv1.MachineDeployment](&webhooks.MachineDeployment{}, &webhooks.MachineDeployment{}),
+		clusterv1.GroupVersion.WithKind("MachineSet"):         newValidateFunc[*clusterv1.MachineSet](&webhooks.MachineSet{}, &webhooks.MachineSet{}),
+		clusterv1.GroupVersion.WithKind("MachinePool"):        newValidateFunc[*clusterv1.MachinePool](&webhooks.MachinePool{}, &webhooks.MachinePool{}),
+		clusterv1.GroupVersion.WithKind("MachineHealthCheck"): newValidateFunc[*clusterv1.MachineHealthCheck](&webhooks.MachineHealthCheck{}, &webhooks.MachineHealthCheck{}),
+		addonsv1.GroupVersion.WithKind("ClusterResourceSet"):  newValidateFunc[*addonsv1.ClusterResourceSet](nil, &webhooks.ClusterResourceSet{}),
+	}
+
+	type hubObject struct {
+		obj      client.Object
+		validate validateFunc
+	}
+
+	// Convert all the objects to the hub version first, so ClusterClasses are available when validating Clusters
+	// no matter of the order of objects in the template.
+	issues := []Issue{}
+	hubObjs := []hubObject{}
+	for i := range objs {
+		o := objs[i]
+		hubGVK, validate, ok := validatorFor(validators, o.GroupVersionKind())
+		if !ok {
+			continue
+		}
+
+		obj, err := toHub(&o, hubGVK)
+		if err != nil {
+			issues = append(issues, newIssue(&o, ErrorSeverity, err.Error()))
+			continue
+		}
+
+		if clusterClass, ok := obj.(*clusterv1.ClusterClass); ok {
+			reader.add(clusterClass)
+		}
+		hubObjs = append(hubObjs, hubObject{obj: obj, validate: validate})
+	}
+
+	// Requests are processed like a dry-run create, which is what happens when applying a template for the first time.
+	ctx = admission.NewContextWithRequest(ctx, admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			DryRun:    ptr.To(true),
+		},
+	})
+	for _, h := range hubObjs {
+		warnings, err := h.validate(ctx, h.obj)
+		for _, w := range warnings {
+			issues = append(issues, newIssue(h.obj, WarningSeverity, w))
+		}
+		if err != nil {
+			for _, msg := range errorMessages(err) {
+				issues = append(issues, newIssue(h.obj, ErrorSeverity, msg))
+			}
+		}
+	}
+	return issues, nil
+}
+
+// newValidateFunc returns a validateFunc running the given webhook defaulter, if any, and then the webhook validator.
+func newValidateFunc[T client.Object](defaulter admission.Defaulter[T], validator admission.Validator[T]) validateFunc {
+	return func(ctx context.Context, obj client.Object) (admission.Warnings, error) {
+		o, ok := obj.(T)
+		if !ok {
+			return nil, errors.Errorf("unexpected type %T", obj)
+		}
+		if defaulter != nil {
+			if err := defaulter.Default(ctx, o); err != nil {
+				return nil, err
+			}
+		}
+		return validator.ValidateCreate(ctx, o)
+	}
+}
+
+// validatorFor returns the hub GroupVersionKind and the validateFunc for an object with the given GroupVersionKind.
+func validatorFor(validators map[schema.GroupVersionKind]validateFunc, gvk schema.GroupVersionKind) (schema.GroupVersionKind, validateFunc, bool) {
+	for hubGVK, validate := range validators {
+		if hubGVK.GroupKind() == gvk.GroupKind() {
+			return hubGVK, validate, true
+		}
+	}
+	return schema.GroupVersionKind{}, nil, false
+}
+
+// toHub converts an object to the hub version of its type.
+// Unknown fields are reported as errors, like the API server does with strict field validation.
+func toHub(u *unstructured.Unstructured, hubGVK schema.GroupVersionKind) (client.Object, error) {
+	hub, err := scheme.Scheme.New(hubGVK)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s", hubGVK)
+	}
+
+	if u.GroupVersionKind() == hubGVK {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(u.Object, hub, true); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %s", u.GetAPIVersion())
+		}
+		return hub.(client.Object), nil
+	}
+
+	spoke, err := scheme.Scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return nil, errors.Errorf("apiVersion %s is not supported for %s", u.GetAPIVersion(), u.GetKind())
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(u.Object, spoke, true); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", u.GetAPIVersion())
+	}
+	convertible, ok := spoke.(conversion.Convertible)
+	if !ok {
+		return nil, errors.Errorf("apiVersion %s is not convertible to %s", u.GetAPIVersion(), hubGVK.GroupVersion())
+	}
+	if err := convertible.ConvertTo(hub.(conversion.Hub)); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s to %s", u.GetAPIVersion(), hubGVK.GroupVersion())
+	}
+	return hub.(client.Object), nil
+}
+
+// errorMessages splits an error returned by a webhook into a list of messages, one for each field error.
+func errorMessages(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) {
+		return []string{err.Error()}
+	}
+
+	status := statusErr.Status()
+	if status.Details == nil || len(status.Details.Causes) == 0 {
+		return []string{status.Message}
+	}
+
+	messages := []string{}
+	for _, cause := range status.Details.Causes {
+		if cause.Field == "" {
+			messages = append(messages, cause.Message)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+	}
+	return messages
+}
+
+type objectReference interface {
+	GetNamespace() string
+	GetName() string
+}
+
+func newIssue(obj objectReference, severity Severity, message string) Issue {
+	kind := ""
+	switch o := obj.(type) {
+	case *unstructured.Unstructured:
+		kind = o.GetKind()
+	case runtime.Object:
+		kind = kindFor(o)
+	}
+	return Issue{
+		Severity:  severity,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Message:   strings.TrimSpace(message),
+	}
+}
+
+// kindFor returns the Kind of a typed object; objects converted to the hub version do not have TypeMeta set.
+func kindFor(obj runtime.Object) string {
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return ""
+	}
+	return gvks[0].Kind
+}
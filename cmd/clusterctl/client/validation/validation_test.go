@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const clusterClassYAML = `
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: ClusterClass
+metadata:
+  name: quick-start
+  namespace: ns1
+spec:
+  controlPlane:
+    templateRef:
+      apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+      kind: KubeadmControlPlaneTemplate
+      name: quick-start-control-plane
+  infrastructure:
+    templateRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: DockerClusterTemplate
+      name: quick-start-cluster
+  workers:
+    machineDeployments:
+    - class: default-worker
+      bootstrap:
+        templateRef:
+          apiVersion: bootstrap.cluster.x-k8s.io/v1beta2
+          kind: KubeadmConfigTemplate
+          name: quick-start-default-worker-bootstraptemplate
+      infrastructure:
+        templateRef:
+          apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+          kind: DockerMachineTemplate
+          name: quick-start-default-worker-machinetemplate
+  variables:
+  - name: imageRepository
+    required: true
+    schema:
+      openAPIV3Schema:
+        type: string
+        minLength: 1
+`
+
+// clusterYAML returns a Cluster using the quick-start ClusterClass with the given version, variables and MachineDeployment class.
+func clusterYAML(version, variables, mdClass string) string {
+	return fmt.Sprintf(`
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: Cluster
+metadata:
+  name: my-cluster
+  namespace: ns1
+spec:
+  topology:
+    classRef:
+      name: quick-start
+    version: %s
+    variables:%s
+    workers:
+      machineDeployments:
+      - class: %s
+        name: md-0
+`, version, variables, mdClass)
+}
+
+const imageRepositoryVariable = `
+    - name: imageRepository
+      value: "registry.k8s.io"`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         []string
+		featureGates map[string]bool
+		wantIssues   []Issue
+	}{
+		{
+			name: "no issues for a valid Cluster and ClusterClass",
+			yaml: []string{
+				clusterYAML("v1.33.0", imageRepositoryVariable, "default-worker"),
+				clusterClassYAML,
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues:   []Issue{},
+		},
+		{
+			name: "objects not served by Cluster API webhooks are ignored",
+			yaml: []string{
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n  namespace: ns1\ndata:\n  foo: bar\n",
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues:   []Issue{},
+		},
+		{
+			name: "error for a missing required variable",
+			yaml: []string{
+				clusterClassYAML,
+				clusterYAML("v1.33.0", " []", "default-worker"),
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "Cluster", Namespace: "ns1", Name: "my-cluster", Message: "spec.topology.variables: Required value: required variable \"imageRepository\" must be set"},
+			},
+		},
+		{
+			name: "error for an invalid version",
+			yaml: []string{
+				clusterClassYAML,
+				clusterYAML("v1.33.x", imageRepositoryVariable, "default-worker"),
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "Cluster", Namespace: "ns1", Name: "my-cluster", Message: "spec.topology.version: Invalid value: \"v1.33.x\": version must be a valid semantic version"},
+			},
+		},
+		{
+			name: "error for a MachineDeployment class not defined in the ClusterClass",
+			yaml: []string{
+				clusterClassYAML,
+				clusterYAML("v1.33.0", imageRepositoryVariable, "not-existing-worker"),
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "Cluster", Namespace: "ns1", Name: "my-cluster", Message: "spec.topology.workers.machineDeployments[0].class: Invalid value: \"not-existing-worker\": MachineDeploymentClass with name \"not-existing-worker\" does not exist in ClusterClass \"quick-start\""},
+			},
+		},
+		{
+			name: "error for unknown fields",
+			yaml: []string{
+				"apiVersion: cluster.x-k8s.io/v1beta2\nkind: MachineHealthCheck\nmetadata:\n  name: my-mhc\n  namespace: ns1\nspec:\n  clusterName: my-cluster\n  foo: bar\n",
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "MachineHealthCheck", Namespace: "ns1", Name: "my-mhc", Message: "failed to decode cluster.x-k8s.io/v1beta2: strict decoding error: unknown field \"spec.foo\""},
+			},
+		},
+		{
+			name: "older API versions are converted before validation",
+			yaml: []string{
+				"apiVersion: cluster.x-k8s.io/v1beta1\nkind: Cluster\nmetadata:\n  name: my-cluster\n  namespace: ns1\nspec: {}\n",
+			},
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "Cluster", Namespace: "ns1", Name: "my-cluster", Message: "spec: Forbidden: one of spec.controlPlaneRef, spec.infrastructureRef or spec.topology must be set"},
+			},
+		},
+		{
+			name: "error for ClusterClass when the ClusterTopology feature gate is disabled",
+			yaml: []string{
+				clusterClassYAML,
+			},
+			featureGates: map[string]bool{"ClusterTopology": false},
+			wantIssues: []Issue{
+				{Severity: ErrorSeverity, Kind: "ClusterClass", Namespace: "ns1", Name: "quick-start", Message: "spec: Forbidden: can be set only if the ClusterTopology feature flag is enabled"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []unstructured.Unstructured{}
+			for _, y := range tt.yaml {
+				o, err := utilyaml.ToUnstructured([]byte(y))
+				g.Expect(err).ToNot(HaveOccurred())
+				objs = append(objs, o...)
+			}
+
+			issues, err := Validate(context.Background(), objs, Options{FeatureGates: tt.featureGates})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(issues).To(Equal(tt.wantIssues))
+			g.Expect(HasErrors(issues)).To(Equal(len(tt.wantIssues) > 0))
+		})
+	}
+}
+
+func TestValidate_ClusterClassNotIncluded(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := utilyaml.ToUnstructured([]byte(clusterYAML("v1.33.0", imageRepositoryVariable, "default-worker")))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	issues, err := Validate(context.Background(), objs, Options{FeatureGates: map[string]bool{"ClusterTopology": true}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(issues).To(HaveLen(1))
+	g.Expect(issues[0].Severity).To(Equal(WarningSeverity))
+	g.Expect(issues[0].ObjectRef()).To(Equal("Cluster/ns1/my-cluster"))
+	g.Expect(issues[0].Message).To(ContainSubstring("ClusterClass ns1/quick-start, but this ClusterClass does not exist"))
+	g.Expect(HasErrors(issues)).To(BeFalse())
+}
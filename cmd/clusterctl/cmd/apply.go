@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type applyOptions struct {
+	kubeconfig          string
+	kubeconfigContext   string
+	filename            string
+	dryRun              bool
+	waitProviders       bool
+	waitProviderTimeout int
+	force               bool
+}
+
+var applyOpts = &applyOptions{}
+
+var applyCmd = &cobra.Command{
+	Use:     "apply",
+	GroupID: groupManagement,
+	Short:   "Bring a management cluster to the state defined in a file",
+	Long: templates.LongDesc(`
+		Bring a management cluster to the state defined in a file.
+
+		The file defines the providers that should be installed in the management cluster, with their versions,
+		target namespaces and feature gates, and the values of the variables used in the provider components YAML.
+
+		The apply command compares the file with the providers installed in the management cluster, and then
+		installs the missing providers, upgrades the providers to the desired versions and deletes the providers
+		not included in the file; CRDs and namespaces of deleted providers are preserved.
+
+		See https://cluster-api.sigs.k8s.io for more details.`),
+
+	Example: templates.Examples(`
+		# Show the changes required to bring the management cluster to the state defined in mgmt.yaml.
+		clusterctl apply -f mgmt.yaml --dry-run
+
+		# Bring the management cluster to the state defined in mgmt.yaml.
+		clusterctl apply -f mgmt.yaml
+
+		# Example of a management cluster spec:
+		apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3
+		kind: ManagementCluster
+		providers:
+		- name: cluster-api
+		  type: CoreProvider
+		  version: v1.11.0
+		  featureGates:
+		    ClusterTopology: true
+		- name: kubeadm
+		  type: BootstrapProvider
+		  version: v1.11.0
+		- name: kubeadm
+		  type: ControlPlaneProvider
+		  version: v1.11.0
+		- name: aws
+		  type: InfrastructureProvider
+		  namespace: capa-system
+		variables:
+		  AWS_B64ENCODED_CREDENTIALS: "..."`),
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runApply()
+	},
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyOpts.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If empty, default discovery rules apply.")
+	applyCmd.Flags().StringVar(&applyOpts.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	applyCmd.Flags().StringVarP(&applyOpts.filename, "filename", "f", "",
+		"Path to the file defining the desired state of the management cluster. Use - to read from stdin.")
+	applyCmd.Flags().BoolVar(&applyOpts.dryRun, "dry-run", false,
+		"Only print the changes required to bring the management cluster to the desired state, without applying them.")
+	applyCmd.Flags().BoolVar(&applyOpts.waitProviders, "wait-providers", false,
+		"Wait for providers to be installed or upgraded.")
+	applyCmd.Flags().IntVar(&applyOpts.waitProviderTimeout, "wait-provider-timeout", 5*60,
+		"Wait timeout per provider install or upgrade in seconds. This value is ignored if --wait-providers is false")
+	applyCmd.Flags().BoolVar(&applyOpts.force, "force", false,
+		"Apply upgrades even if there are workload Clusters not compatible with the target versions of the providers.")
+
+	_ = applyCmd.MarkFlagRequired("filename")
+
+	RootCmd.AddCommand(applyCmd)
+}
+
+func runApply() error {
+	ctx := context.Background()
+
+	managementCluster, err := readManagementCluster(applyOpts.filename)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	plan, err := c.ApplyManagementCluster(ctx, client.ApplyManagementClusterOptions{
+		Kubeconfig:          client.Kubeconfig{Path: applyOpts.kubeconfig, Context: applyOpts.kubeconfigContext},
+		ManagementCluster:   *managementCluster,
+		DryRun:              applyOpts.dryRun,
+		WaitProviders:       applyOpts.waitProviders,
+		WaitProviderTimeout: time.Duration(applyOpts.waitProviderTimeout) * time.Second,
+		Force:               applyOpts.force,
+	})
+	if err != nil {
+		return err
+	}
+
+	if plan.IsEmpty() {
+		fmt.Println("The management cluster is already in the desired state.")
+		return nil
+	}
+	if !applyOpts.dryRun {
+		fmt.Println("The management cluster has been brought to the desired state.")
+		return nil
+	}
+	return printManagementClusterPlan(plan)
+}
+
+// readManagementCluster reads the management cluster spec from a file, or from stdin if the filename is -.
+func readManagementCluster(filename string) (*clusterctlv1.ManagementCluster, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename) //nolint:gosec
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", filename)
+	}
+
+	managementCluster := &clusterctlv1.ManagementCluster{}
+	if err := yaml.UnmarshalStrict(data, managementCluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", filename)
+	}
+	if managementCluster.APIVersion != clusterctlv1.GroupVersion.String() || managementCluster.Kind != "ManagementCluster" {
+		return nil, errors.Errorf("invalid management cluster spec %q: expected apiVersion %s and kind ManagementCluster", filename, clusterctlv1.GroupVersion.String())
+	}
+	return managementCluster, nil
+}
+
+func printManagementClusterPlan(plan client.ManagementClusterPlan) error {
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tNAMESPACE\tTYPE\tACTION\tCURRENT VERSION\tNEXT VERSION")
+	for _, provider := range plan.Delete {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", provider.Name, provider.Namespace, provider.Type, "Delete", provider.Version, "")
+	}
+	for _, upgradeItem := range plan.Upgrade {
+		action := "Upgrade"
+		if upgradeItem.NextVersion == upgradeItem.Version {
+			action = "Reinstall"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", upgradeItem.Name, upgradeItem.Namespace, upgradeItem.Type, action, upgradeItem.Version, upgradeItem.NextVersion)
+	}
+	for _, provider := range plan.Install {
+		namespace := provider.Namespace
+		if namespace == "" {
+			namespace = "(default)"
+		}
+		nextVersion := provider.Version
+		if nextVersion == "" {
+			nextVersion = "(latest)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", provider.Name, namespace, provider.Type, "Install", "", nextVersion)
+	}
+	return w.Flush()
+}
@@ -19,11 +19,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
@@ -32,6 +36,19 @@ import (
 	cmdtree "sigs.k8s.io/cluster-api/internal/util/tree"
 )
 
+const (
+	// DescribeClusterOutputJSON is an option used to print the cluster description in JSON format.
+	DescribeClusterOutputJSON = "json"
+	// DescribeClusterOutputYAML is an option used to print the cluster description in YAML format.
+	DescribeClusterOutputYAML = "yaml"
+)
+
+// DescribeClusterOutputs is a list of valid cluster description outputs; if unspecified the cluster description is printed as a tree view.
+var DescribeClusterOutputs = []string{DescribeClusterOutputJSON, DescribeClusterOutputYAML}
+
+// describeClusterWatchInterval is the interval between two checks for changes in the cluster description when watching.
+var describeClusterWatchInterval = 5 * time.Second
+
 type describeClusterOptions struct {
 	kubeconfig              string
 	kubeconfigContext       string
@@ -44,6 +61,8 @@ type describeClusterOptions struct {
 	grouping                bool
 	v1beta2                 bool
 	color                   bool
+	output                  string
+	watch                   bool
 }
 
 var dc = &describeClusterOptions{}
@@ -72,7 +91,13 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
-		clusterctl describe cluster test-1 --echo`),
+		clusterctl describe cluster test-1 --echo
+
+		# Describe the cluster named test-1 in JSON format.
+		clusterctl describe cluster test-1 -o json
+
+		# Describe the cluster named test-1, printing the description again every time it changes.
+		clusterctl describe cluster test-1 --watch`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -111,6 +136,10 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("v1beta2",
 		"this field will be removed when v1beta1 will be dropped.")
 	describeClusterClusterCmd.Flags().BoolVarP(&dc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", "",
+		fmt.Sprintf("Output format. Valid values: %v. If unspecified, the cluster is described using a tree view.", DescribeClusterOutputs))
+	describeClusterClusterCmd.Flags().BoolVarP(&dc.watch, "watch", "w", false,
+		"Watch the cluster, printing the description again every time it changes.")
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -127,12 +156,19 @@ func init() {
 func runDescribeCluster(cmd *cobra.Command, name string) error {
 	ctx := context.Background()
 
+	if dc.output != "" && dc.output != DescribeClusterOutputJSON && dc.output != DescribeClusterOutputYAML {
+		return errors.Errorf("invalid output format %q, valid values: %v", dc.output, DescribeClusterOutputs)
+	}
+	if dc.output != "" && !dc.v1beta2 {
+		return errors.New("the --output flag can't be used with --v1beta2=false")
+	}
+
 	c, err := client.New(ctx, cfgFile)
 	if err != nil {
 		return err
 	}
 
-	tree, err := c.DescribeCluster(ctx, client.DescribeClusterOptions{
+	options := client.DescribeClusterOptions{
 		Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
 		Namespace:               dc.namespace,
 		ClusterName:             name,
@@ -144,18 +180,61 @@ func runDescribeCluster(cmd *cobra.Command, name string) error {
 		Echo:                    dc.echo,
 		Grouping:                dc.grouping,
 		V1Beta1:                 !dc.v1beta2,
-	})
-	if err != nil {
-		return err
 	}
 
 	if cmd.Flags().Changed("color") {
 		color.NoColor = !dc.color
 	}
 
+	if !dc.watch {
+		tree, err := c.DescribeCluster(ctx, options)
+		if err != nil {
+			return err
+		}
+		return printDescribeCluster(tree, os.Stdout)
+	}
+
+	// Watch the cluster, printing the description again only when something changes;
+	// nb. the machine-readable representation is used for the comparison, because the tree view includes condition ages.
+	var last *cmdtree.ObjectTreeNode
+	return wait.PollUntilContextCancel(ctx, describeClusterWatchInterval, true, func(ctx context.Context) (bool, error) {
+		tree, err := c.DescribeCluster(ctx, options)
+		if err != nil {
+			return false, err
+		}
+
+		current := cmdtree.ConvertObjectTree(tree)
+		if last != nil && apiequality.Semantic.DeepEqual(last, current) {
+			return false, nil
+		}
+
+		// Separate the cluster description from the previous one.
+		if last != nil {
+			separator := "\n"
+			if dc.output == DescribeClusterOutputYAML {
+				separator = "---\n"
+			}
+			if _, err := fmt.Fprint(os.Stdout, separator); err != nil {
+				return false, err
+			}
+		}
+		last = current
+
+		return false, printDescribeCluster(tree, os.Stdout)
+	})
+}
+
+func printDescribeCluster(tree *tree.ObjectTree, w io.Writer) error {
+	switch dc.output {
+	case DescribeClusterOutputJSON:
+		return cmdtree.PrintObjectTreeJSON(tree, w)
+	case DescribeClusterOutputYAML:
+		return cmdtree.PrintObjectTreeYAML(tree, w)
+	}
+
 	switch dc.v1beta2 {
 	case true:
-		if err := cmdtree.PrintObjectTree(tree, os.Stdout); err != nil {
+		if err := cmdtree.PrintObjectTree(tree, w); err != nil {
 			return errors.Wrap(err, "failed to print object tree")
 		}
 	default:
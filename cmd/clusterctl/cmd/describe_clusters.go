@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type describeClustersOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	allNamespaces     bool
+	selector          string
+	sortBy            string
+	output            string
+}
+
+var dcs = &describeClustersOptions{}
+
+var describeClustersCmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Describe all the workload clusters",
+	Long: templates.LongDesc(`
+		Provide an "at glance" view of all the Cluster API clusters in a management cluster, with one row
+		for each cluster showing the ClusterClass, the Kubernetes version, the control plane and workers
+		replicas, the topology upgrade state and the availability of the cluster.
+
+		Use "clusterctl describe cluster NAME" to get more details about a specific cluster.`),
+
+	Example: templates.Examples(`
+		# Describe all the clusters in the current namespace.
+		clusterctl describe clusters
+
+		# Describe all the clusters in all the namespaces.
+		clusterctl describe clusters -A
+
+		# Describe all the clusters with the env=prod label, showing not available clusters first.
+		clusterctl describe clusters -A -l env=prod --sort-by available
+
+		# Describe all the clusters in the current namespace in JSON format.
+		clusterctl describe clusters -o json`),
+
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runDescribeClusters()
+	},
+}
+
+func init() {
+	describeClustersCmd.Flags().StringVar(&dcs.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	describeClustersCmd.Flags().StringVar(&dcs.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	describeClustersCmd.Flags().StringVarP(&dcs.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are located. If unspecified, the current namespace will be used.")
+	describeClustersCmd.Flags().BoolVarP(&dcs.allNamespaces, "all-namespaces", "A", false,
+		"Describe workload clusters in all the namespaces.")
+	describeClustersCmd.Flags().StringVarP(&dcs.selector, "selector", "l", "",
+		"Label query for selecting the workload clusters to describe, e.g. env=prod.")
+	describeClustersCmd.Flags().StringVar(&dcs.sortBy, "sort-by", tree.ClusterSummarySortByName,
+		fmt.Sprintf("Sort workload clusters by one of %v; clusters with the same value are sorted by namespace and name.", tree.ClusterSummarySortBy))
+	describeClustersCmd.Flags().StringVarP(&dcs.output, "output", "o", "",
+		fmt.Sprintf("Output format. Valid values: %v. If unspecified, workload clusters are described using a table view.", DescribeClusterOutputs))
+
+	describeClustersCmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
+
+	describeCmd.AddCommand(describeClustersCmd)
+}
+
+func runDescribeClusters() error {
+	ctx := context.Background()
+
+	if dcs.output != "" && dcs.output != DescribeClusterOutputJSON && dcs.output != DescribeClusterOutputYAML {
+		return errors.Errorf("invalid output format %q, valid values: %v", dcs.output, DescribeClusterOutputs)
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := c.DescribeClusters(ctx, client.DescribeClustersOptions{
+		Kubeconfig:    client.Kubeconfig{Path: dcs.kubeconfig, Context: dcs.kubeconfigContext},
+		Namespace:     dcs.namespace,
+		AllNamespaces: dcs.allNamespaces,
+		LabelSelector: dcs.selector,
+		SortBy:        dcs.sortBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printDescribeClusters(summaries, dcs.output, os.Stdout)
+}
+
+func printDescribeClusters(summaries []tree.ClusterSummary, output string, w io.Writer) error {
+	switch output {
+	case DescribeClusterOutputJSON:
+		out, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to convert cluster summaries to JSON")
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case DescribeClusterOutputYAML:
+		out, err := yaml.Marshal(summaries)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert cluster summaries to YAML")
+		}
+		_, err = fmt.Fprint(w, string(out))
+		return err
+	}
+
+	if len(summaries) == 0 {
+		_, err := fmt.Fprintln(w, "No clusters found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tCLASS\tVERSION\tCONTROL PLANE\tWORKERS\tAVAILABLE\tTOPOLOGY\tAGE")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Namespace,
+			s.Name,
+			valueOrDash(s.ClusterClass),
+			clusterSummaryVersion(s),
+			replicaSummary(s.ControlPlane),
+			replicaSummary(s.Workers),
+			clusterSummaryAvailable(s),
+			clusterSummaryTopology(s),
+			duration.HumanDuration(time.Since(s.CreationTimestamp.Time)),
+		)
+	}
+	return tw.Flush()
+}
+
+// clusterSummaryVersion returns the version of a Cluster, highlighting when the Cluster is going to be upgraded to another version.
+func clusterSummaryVersion(s tree.ClusterSummary) string {
+	switch {
+	case s.Version == "":
+		return valueOrDash(s.DesiredVersion)
+	case s.DesiredVersion != "" && s.DesiredVersion != s.Version:
+		return fmt.Sprintf("%s -> %s", s.Version, s.DesiredVersion)
+	default:
+		return s.Version
+	}
+}
+
+// replicaSummary returns available/desired replicas, plus the number of replicas not yet up-to-date, if any.
+func replicaSummary(r tree.ReplicaSummary) string {
+	if r.DesiredReplicas == nil && r.Replicas == nil {
+		return "-"
+	}
+	desired := r.DesiredReplicas
+	if desired == nil {
+		desired = r.Replicas
+	}
+	ret := fmt.Sprintf("%d/%d", ptr.Deref(r.AvailableReplicas, 0), *desired)
+	if r.UpToDateReplicas != nil && r.Replicas != nil && *r.UpToDateReplicas < *r.Replicas {
+		ret += fmt.Sprintf(" (%d not up-to-date)", *r.Replicas-*r.UpToDateReplicas)
+	}
+	return ret
+}
+
+// clusterSummaryAvailable returns the status of the Available condition of a Cluster, or Deleting if the Cluster is being deleted.
+func clusterSummaryAvailable(s tree.ClusterSummary) string {
+	if s.Deleting {
+		return "Deleting"
+	}
+	return conditionStatus(s.Available)
+}
+
+// clusterSummaryTopology returns the reason of the TopologyReconciled condition of a Cluster, e.g. ClusterUpgrading.
+func clusterSummaryTopology(s tree.ClusterSummary) string {
+	if s.TopologyReconciled == nil {
+		return "-"
+	}
+	if s.TopologyReconciled.Status == metav1.ConditionTrue {
+		return "Reconciled"
+	}
+	return valueOrDash(s.TopologyReconciled.Reason)
+}
+
+func conditionStatus(c *metav1.Condition) string {
+	if c == nil {
+		return "-"
+	}
+	return string(c.Status)
+}
+
+func valueOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -44,6 +44,7 @@ type generateClusterOptions struct {
 	configMapDataKey   string
 
 	listVariables bool
+	validate      bool
 
 	output string
 }
@@ -91,7 +92,11 @@ var generateClusterClusterCmd = &cobra.Command{
 		clusterctl generate cluster my-cluster --from ~/workspace/cluster-template.yaml
 
 		# Prints the list of variables required by the yaml file for creating workload cluster.
-		clusterctl generate cluster my-cluster --list-variables`),
+		clusterctl generate cluster my-cluster --list-variables
+
+		# Generates a yaml file for creating workload clusters, after validating it
+		# using the same logic of the Cluster API webhooks.
+		clusterctl generate cluster my-cluster --validate`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -142,6 +147,8 @@ func init() {
 	// other flags
 	generateClusterClusterCmd.Flags().BoolVar(&gc.listVariables, "list-variables", false,
 		"Returns the list of variables expected by the template instead of the template yaml")
+	generateClusterClusterCmd.Flags().BoolVar(&gc.validate, "validate", false,
+		"Validate the template using the same logic of the Cluster API webhooks before printing it; validation issues are printed to stderr and the template is not printed if there are errors")
 	generateClusterClusterCmd.Flags().StringVar(&gc.output, "write-to", "", "Specify the output file to write the template to, defaults to STDOUT if the flag is not set")
 
 	generateCmd.AddCommand(generateClusterClusterCmd)
@@ -200,5 +207,15 @@ func runGenerateClusterTemplate(cmd *cobra.Command, name string) error {
 		return printVariablesOutput(template, templateOptions)
 	}
 
+	if gc.validate {
+		issues, err := c.ValidateTemplate(ctx, client.ValidateTemplateOptions{Objs: template.Objs()})
+		if err != nil {
+			return err
+		}
+		if err := printValidationIssues(issues); err != nil {
+			return err
+		}
+	}
+
 	return printYamlOutput(template, gc.output)
 }
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type mirrorOptions struct {
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+	flavors                   []string
+	clusterClasses            []string
+	skipCertManager           bool
+	imageRepository           string
+	directory                 string
+	archive                   string
+}
+
+var mirrorOpts = &mirrorOptions{}
+
+var mirrorCmd = &cobra.Command{
+	Use:     "mirror",
+	GroupID: groupManagement,
+	Short:   "Mirror provider releases for disconnected environments",
+	Long: templates.LongDesc(`
+		Mirror provider releases for disconnected environments.
+
+		Downloads the components YAML, the metadata and the cluster templates of the selected providers,
+		and the cert-manager components YAML, from the repositories defined in the clusterctl configuration.
+
+		Releases can be stored in a directory using the layout of clusterctl local repositories, together with
+		a clusterctl configuration file pointing to the mirrored releases, or in a tarball using the OCI image layout.
+
+		Images in the mirrored components YAML are rewritten applying the image overrides defined in the clusterctl
+		configuration and the --image-repository flag; the list of the required images is printed at the end, so it
+		is possible to copy them to the target registry.
+
+		See https://cluster-api.sigs.k8s.io for more details.`),
+
+	Example: templates.Examples(`
+		# Mirror the latest release of the core and kubeadm providers, of the aws infrastructure provider
+		# and of cert-manager to the given directory.
+		clusterctl mirror --infrastructure aws --directory /mirror
+
+		# Mirror specific versions of the providers, including the given cluster template flavors
+		# and cluster classes of the infrastructure providers.
+		clusterctl mirror --core cluster-api:v1.11.0 --infrastructure docker:v1.11.0 \
+			--flavor development --cluster-class quick-start --directory /mirror
+
+		# Mirror the providers to a tarball, rewriting all the images to use the given registry.
+		clusterctl mirror --infrastructure aws --image-repository registry.example.com/cluster-api --archive mirror.tar
+
+		# Use the mirrored providers.
+		clusterctl init --config /mirror/clusterctl.yaml --infrastructure aws`),
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runMirror()
+	},
+}
+
+func init() {
+	mirrorCmd.Flags().StringVar(&mirrorOpts.coreProvider, "core", "",
+		"Core provider version (e.g. cluster-api:v1.1.5) to mirror. If unspecified, Cluster API's latest release is used.")
+	mirrorCmd.Flags().StringSliceVarP(&mirrorOpts.infrastructureProviders, "infrastructure", "i", nil,
+		"Infrastructure providers and versions (e.g. aws:v0.5.0) to mirror.")
+	mirrorCmd.Flags().StringSliceVarP(&mirrorOpts.bootstrapProviders, "bootstrap", "b", nil,
+		"Bootstrap providers and versions (e.g. kubeadm:v1.1.5) to mirror. If unspecified, Kubeadm bootstrap provider's latest release is used.")
+	mirrorCmd.Flags().StringSliceVarP(&mirrorOpts.controlPlaneProviders, "control-plane", "c", nil,
+		"Control plane providers and versions (e.g. kubeadm:v1.1.5) to mirror. If unspecified, the Kubeadm control plane provider's latest release is used.")
+	mirrorCmd.Flags().StringSliceVar(&mirrorOpts.ipamProviders, "ipam", nil,
+		"IPAM providers and versions (e.g. in-cluster:v0.1.0) to mirror.")
+	mirrorCmd.Flags().StringSliceVar(&mirrorOpts.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers and versions to mirror.")
+	mirrorCmd.Flags().StringSliceVar(&mirrorOpts.addonProviders, "addon", nil,
+		"Add-on providers and versions (e.g. helm:v0.1.0) to mirror.")
+	mirrorCmd.Flags().StringSliceVarP(&mirrorOpts.flavors, "flavor", "f", nil,
+		"Cluster template flavors to mirror for each infrastructure provider, in addition to the default cluster template.")
+	mirrorCmd.Flags().StringSliceVar(&mirrorOpts.clusterClasses, "cluster-class", nil,
+		"Cluster classes to mirror for each infrastructure provider.")
+	mirrorCmd.Flags().BoolVar(&mirrorOpts.skipCertManager, "skip-cert-manager", false,
+		"Do not mirror cert-manager.")
+	mirrorCmd.Flags().StringVar(&mirrorOpts.imageRepository, "image-repository", "",
+		"Container registry to pull images from in the mirrored components YAML (e.g. registry.example.com/cluster-api).")
+	mirrorCmd.Flags().StringVar(&mirrorOpts.directory, "directory", "",
+		"Directory where to store the mirrored releases, using the layout of clusterctl local repositories.")
+	mirrorCmd.Flags().StringVar(&mirrorOpts.archive, "archive", "",
+		"Tarball where to store the mirrored releases, using the OCI image layout.")
+
+	mirrorCmd.MarkFlagsOneRequired("directory", "archive")
+	mirrorCmd.MarkFlagsMutuallyExclusive("directory", "archive")
+
+	RootCmd.AddCommand(mirrorCmd)
+}
+
+func runMirror() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.Mirror(ctx, client.MirrorOptions{
+		CoreProvider:              mirrorOpts.coreProvider,
+		BootstrapProviders:        mirrorOpts.bootstrapProviders,
+		ControlPlaneProviders:     mirrorOpts.controlPlaneProviders,
+		InfrastructureProviders:   mirrorOpts.infrastructureProviders,
+		IPAMProviders:             mirrorOpts.ipamProviders,
+		RuntimeExtensionProviders: mirrorOpts.runtimeExtensionProviders,
+		AddonProviders:            mirrorOpts.addonProviders,
+		Flavors:                   mirrorOpts.flavors,
+		ClusterClasses:            mirrorOpts.clusterClasses,
+		SkipCertManager:           mirrorOpts.skipCertManager,
+		ImageRepository:           mirrorOpts.imageRepository,
+		Directory:                 mirrorOpts.directory,
+		Archive:                   mirrorOpts.archive,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tVERSION\tFILES")
+	for _, p := range result.Providers {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", p.ManifestLabel, p.Version, len(p.Files))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "SOURCE IMAGE\tTARGET IMAGE")
+	for _, i := range result.Images {
+		fmt.Fprintf(w, "%s\t%s\n", i.Source, i.Target)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if mirrorOpts.directory != "" {
+		fmt.Printf("\nUse the mirrored providers with: clusterctl --config %s ...\n", filepath.Join(mirrorOpts.directory, client.MirrorConfigFile))
+	}
+	return nil
+}
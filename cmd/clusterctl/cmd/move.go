@@ -19,7 +19,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
+	"github.com/adrg/xdg"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/rest"
@@ -38,9 +40,14 @@ type moveOptions struct {
 	toKubeconfig          string
 	toKubeconfigContext   string
 	namespace             string
+	clusterNames          []string
+	clusterSelector       string
 	fromDirectory         string
 	toDirectory           string
 	dryRun                bool
+	journal               string
+	resume                bool
+	rollback              bool
 	hideAPIWarnings       string
 }
 
@@ -59,11 +66,23 @@ var moveCmd = &cobra.Command{
 		Move Cluster API objects and all dependencies between management clusters.
 		clusterctl move --to-kubeconfig=target-kubeconfig.yaml
 
+		Move only the Clusters named my-cluster and my-other-cluster and their dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster=my-cluster,my-other-cluster
+
+		Move only the Clusters with the env=prod label and their dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster-selector=env=prod
+
 		Write Cluster API objects and all dependencies from a management cluster to directory.
 		clusterctl move --to-directory /tmp/backup-directory
 
 		Read Cluster API objects and all dependencies from a directory into a management cluster.
 		clusterctl move --from-directory /tmp/backup-directory
+
+		Resume a move operation failed halfway.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --resume
+
+		Roll back a move operation failed halfway, deleting the objects already created in the target management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --rollback
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
@@ -82,18 +101,38 @@ func init() {
 		"Context to be used within the kubeconfig file for the destination management cluster. If empty, current context will be used.")
 	moveCmd.Flags().StringVarP(&mo.namespace, "namespace", "n", "",
 		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
+	moveCmd.Flags().StringSliceVar(&mo.clusterNames, "cluster", nil,
+		"The name of the Clusters to move, together with their dependencies. If unspecified, all the Clusters in the namespace are moved.")
+	moveCmd.Flags().StringVar(&mo.clusterSelector, "cluster-selector", "",
+		"Label selector for the Clusters to move, together with their dependencies. If unspecified, all the Clusters in the namespace are moved.")
 	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
 		"Enable dry run, don't really perform the move actions")
 	moveCmd.Flags().StringVar(&mo.toDirectory, "to-directory", "",
 		"Write Cluster API objects and all dependencies from a management cluster to directory.")
 	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
 		"Read Cluster API objects and all dependencies from a directory into a management cluster.")
+	moveCmd.Flags().StringVar(&mo.journal, "journal", "",
+		"Path to the file where the progress of the move operation is recorded, so a move operation failed halfway can be resumed or rolled back. If unspecified, $XDG_CONFIG_HOME/cluster-api/move-journal.yaml is used.")
+	moveCmd.Flags().BoolVar(&mo.resume, "resume", false,
+		"Resume a move operation failed halfway, as recorded in the journal.")
+	moveCmd.Flags().BoolVar(&mo.rollback, "rollback", false,
+		"Roll back a move operation failed halfway, as recorded in the journal, deleting the objects already created in the destination management cluster and resuming the Clusters in the source management cluster.")
 	moveCmd.Flags().StringVar(&mo.hideAPIWarnings, "hide-api-warnings", "default",
 		"Set of API server warnings to hide. Valid sets are \"default\" (includes metadata.finalizer warnings), \"all\" , and \"none\".")
 
 	moveCmd.MarkFlagsMutuallyExclusive("to-directory", "to-kubeconfig")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "to-directory")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "cluster")
+	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "cluster-selector")
+	moveCmd.MarkFlagsMutuallyExclusive("resume", "rollback")
+	for _, flag := range []string{"resume", "rollback"} {
+		moveCmd.MarkFlagsMutuallyExclusive(flag, "dry-run")
+		moveCmd.MarkFlagsMutuallyExclusive(flag, "to-directory")
+		moveCmd.MarkFlagsMutuallyExclusive(flag, "from-directory")
+		moveCmd.MarkFlagsMutuallyExclusive(flag, "cluster")
+		moveCmd.MarkFlagsMutuallyExclusive(flag, "cluster-selector")
+	}
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -108,6 +147,16 @@ func runMove() error {
 		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory or --from-directory")
 	}
 
+	// The journal is recorded only when moving objects between management clusters.
+	journal := mo.journal
+	if journal == "" && mo.toDirectory == "" && mo.fromDirectory == "" {
+		configDirectory, err := xdg.ConfigFile(config.ConfigFolderXDG)
+		if err != nil {
+			return err
+		}
+		journal = filepath.Join(configDirectory, "move-journal.yaml")
+	}
+
 	configClient, err := config.New(ctx, cfgFile)
 	if err != nil {
 		return err
@@ -162,11 +211,16 @@ func runMove() error {
 	}
 
 	return c.Move(ctx, client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		FromDirectory:  mo.fromDirectory,
-		ToDirectory:    mo.toDirectory,
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:       client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:         client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		FromDirectory:        mo.fromDirectory,
+		ToDirectory:          mo.toDirectory,
+		Namespace:            mo.namespace,
+		ClusterNames:         mo.clusterNames,
+		ClusterLabelSelector: mo.clusterSelector,
+		DryRun:               mo.dryRun,
+		JournalFile:          journal,
+		Resume:               mo.resume,
+		Rollback:             mo.rollback,
 	})
 }
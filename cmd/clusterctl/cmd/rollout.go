@@ -43,7 +43,17 @@ var (
 
 		# Resume an already paused machinedeployment or kubeadmcontrolplane
 		clusterctl alpha rollout resume machinedeployment/my-md-0
-		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp`)
+		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp
+
+		# Rollback a machinedeployment to the previous revision
+		clusterctl alpha rollout undo machinedeployment/my-md-0
+
+		# View the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0
+
+		# Watch the rollout status of a machinedeployment or kubeadmcontrolplane
+		clusterctl alpha rollout status machinedeployment/my-md-0
+		clusterctl alpha rollout status kubeadmcontrolplane/my-kcp`)
 
 	rolloutCmd = &cobra.Command{
 		Use:     "rollout SUBCOMMAND",
@@ -58,4 +68,7 @@ func init() {
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutRestart(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutPause(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutResume(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutUndo(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutHistory(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutStatus(cfgFile))
 }
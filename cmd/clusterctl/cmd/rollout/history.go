@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+// historyOptions is the start of the data required to perform the operation.
+type historyOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	resource          string
+	namespace         string
+	revision          int64
+}
+
+var historyOpt = &historyOptions{}
+
+var (
+	historyLong = templates.LongDesc(`
+		View previous rollout revisions of a cluster-api resource.
+
+	        For each revision, the diff of the Machine template from the previous revision is shown.
+	        Only revisions of MachineSets still existing in the management cluster are listed.`)
+
+	historyExample = templates.Examples(`
+		# View the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0
+
+		# View the changes introduced by revision 3 of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0 --revision=3`)
+)
+
+// NewCmdRolloutHistory returns a Command instance for 'rollout history' sub command.
+func NewCmdRolloutHistory(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "history RESOURCE",
+		DisableFlagsInUseLine: true,
+		Short:                 "View rollout history of a cluster-api resource",
+		Long:                  historyLong,
+		Example:               historyExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(cfgFile, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&historyOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&historyOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVarP(&historyOpt.namespace, "namespace", "n", "", "Namespace where the resource reside. If unspecified, the defult namespace will be used.")
+	cmd.Flags().Int64Var(&historyOpt.revision, "revision", historyOpt.revision, "See the details of the revision specified. Default to 0 (all revisions).")
+
+	return cmd
+}
+
+func runHistory(cfgFile string, _ *cobra.Command, args []string) error {
+	historyOpt.resource = args[0]
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := c.RolloutHistory(ctx, client.RolloutHistoryOptions{
+		Kubeconfig: client.Kubeconfig{Path: historyOpt.kubeconfig, Context: historyOpt.kubeconfigContext},
+		Namespace:  historyOpt.namespace,
+		Resource:   historyOpt.resource,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 {
+		return errors.Errorf("no rollout history found for %s", historyOpt.resource)
+	}
+
+	if historyOpt.revision > 0 {
+		for _, r := range revisions {
+			if r.Revision == historyOpt.revision {
+				printRevisionDiff(r)
+				return nil
+			}
+		}
+		return errors.Errorf("unable to find revision %d for %s", historyOpt.revision, historyOpt.resource)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REVISION\tMACHINESET")
+	for _, r := range revisions {
+		_, _ = fmt.Fprintf(w, "%d\t%s\n", r.Revision, r.MachineSet.Name)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, r := range revisions[1:] {
+		fmt.Println()
+		printRevisionDiff(r)
+	}
+	return nil
+}
+
+// printRevisionDiff prints the diff of the Machine template of a revision from the previous revision.
+func printRevisionDiff(r alpha.RolloutRevision) {
+	fmt.Printf("Revision %d (MachineSet %s):\n", r.Revision, r.MachineSet.Name)
+	if r.TemplateDiff == "" {
+		fmt.Println("  No changes to the Machine template from the previous revision")
+		return
+	}
+	fmt.Println(r.TemplateDiff)
+}
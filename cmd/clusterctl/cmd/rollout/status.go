@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+// statusOptions is the start of the data required to perform the operation.
+type statusOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	resource          string
+	namespace         string
+	watch             bool
+	timeout           time.Duration
+}
+
+var statusOpt = &statusOptions{}
+
+var (
+	statusLong = templates.LongDesc(`
+		Show the status of the rollout of a cluster-api resource.
+
+	        By default, the command watches the status of the rollout until it is completed or failed.`)
+
+	statusExample = templates.Examples(`
+		# Watch the rollout status of a machinedeployment
+		clusterctl alpha rollout status machinedeployment/my-md-0
+
+		# Watch the rollout status of a kubeadmcontrolplane, waiting at most 30 minutes
+		clusterctl alpha rollout status kubeadmcontrolplane/my-kcp --timeout=30m
+
+		# Show the current rollout status of a machinedeployment without waiting
+		clusterctl alpha rollout status machinedeployment/my-md-0 --watch=false`)
+)
+
+// NewCmdRolloutStatus returns a Command instance for 'rollout status' sub command.
+func NewCmdRolloutStatus(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "status RESOURCE",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the status of the rollout of a cluster-api resource",
+		Long:                  statusLong,
+		Example:               statusExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatus(cfgFile, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&statusOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&statusOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVarP(&statusOpt.namespace, "namespace", "n", "", "Namespace where the resource reside. If unspecified, the defult namespace will be used.")
+	cmd.Flags().BoolVarP(&statusOpt.watch, "watch", "w", true, "Watch the status of the rollout until it is completed or failed.")
+	cmd.Flags().DurationVar(&statusOpt.timeout, "timeout", 0, "The length of time to wait before ending watch, zero means never. Any other values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
+
+	return cmd
+}
+
+func runStatus(cfgFile string, _ *cobra.Command, args []string) error {
+	statusOpt.resource = args[0]
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.RolloutStatus(ctx, client.RolloutStatusOptions{
+		Kubeconfig: client.Kubeconfig{Path: statusOpt.kubeconfig, Context: statusOpt.kubeconfigContext},
+		Namespace:  statusOpt.namespace,
+		Resource:   statusOpt.resource,
+		Watch:      statusOpt.watch,
+		Timeout:    statusOpt.timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status.Failed {
+		return errors.New(status.Message)
+	}
+	fmt.Println(status.Message)
+	return nil
+}
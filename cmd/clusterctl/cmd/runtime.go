@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/runtime"
+)
+
+var (
+	runtimeLong = templates.LongDesc(`
+		Debug Runtime Extensions.`)
+
+	runtimeExample = templates.Examples(`
+		# Re-send the newest request recorded by the Cluster API controller to a Runtime Extension running locally
+		clusterctl alpha runtime replay --from-file records.json --url https://localhost:9443 --ca-file ca.crt`)
+
+	runtimeCmd = &cobra.Command{
+		Use:     "runtime SUBCOMMAND",
+		Short:   "Debug Runtime Extensions",
+		Long:    runtimeLong,
+		Example: runtimeExample,
+	}
+)
+
+func init() {
+	// subcommands
+	runtimeCmd.AddCommand(runtime.NewCmdRuntimeReplay(cfgFile))
+}
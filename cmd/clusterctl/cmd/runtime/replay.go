@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runtime implements the clusterctl runtime command.
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+// replayOptions is the start of the data required to perform the operation.
+type replayOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	fromFile          string
+	fromConfigMap     string
+	id                int64
+	url               string
+	caFile            string
+	timeout           time.Duration
+}
+
+var replayOpt = &replayOptions{}
+
+var (
+	replayLong = templates.LongDesc(`
+		Re-send a recorded request to a Runtime Extension.
+
+		Requests and responses of calls to Runtime Extensions are recorded by the Cluster API controller when
+		one of the --runtime-extension-record-file or --runtime-extension-record-configmap flags is set.
+		This command reads the recorded calls, re-sends the request of one of them to a Runtime Extension,
+		e.g. a Runtime Extension running locally, and compares the response with the recorded response.`)
+
+	replayExample = templates.Examples(`
+		# Re-send the newest recorded request to a Runtime Extension running locally.
+		clusterctl alpha runtime replay --from-file records.json --url https://localhost:9443 --ca-file ca.crt
+
+		# Re-send the recorded request with ID 42, reading records from a ConfigMap in the management cluster.
+		clusterctl alpha runtime replay --from-configmap capi-system/runtime-extension-records --id 42 --url https://localhost:9443 --ca-file ca.crt`)
+)
+
+// NewCmdRuntimeReplay returns a Command instance for 'runtime replay' sub command.
+func NewCmdRuntimeReplay(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "replay",
+		DisableFlagsInUseLine: true,
+		Short:                 "Re-send a recorded request to a Runtime Extension",
+		Long:                  replayLong,
+		Example:               replayExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			return runReplay(cfgFile)
+		},
+	}
+	cmd.Flags().StringVar(&replayOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&replayOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVar(&replayOpt.fromFile, "from-file", "",
+		"Path of the file with the recorded calls to Runtime Extensions.")
+	cmd.Flags().StringVar(&replayOpt.fromConfigMap, "from-configmap", "",
+		"Namespace and name of the ConfigMap in the management cluster with the recorded calls to Runtime Extensions, in the format <namespace>/<name>.")
+	cmd.Flags().Int64Var(&replayOpt.id, "id", 0,
+		"ID of the recorded call to be replayed. If unspecified, the newest recorded call is replayed.")
+	cmd.Flags().StringVar(&replayOpt.url, "url", "",
+		"URL of the Runtime Extension, e.g. https://localhost:9443.")
+	cmd.Flags().StringVar(&replayOpt.caFile, "ca-file", "",
+		"Path of the PEM encoded CA bundle used to validate the certificate of the Runtime Extension. If unspecified, the system CA bundle is used.")
+	cmd.Flags().DurationVar(&replayOpt.timeout, "timeout", 10*time.Second,
+		"Timeout of the call to the Runtime Extension.")
+
+	cmd.MarkFlagsMutuallyExclusive("from-file", "from-configmap")
+	cmd.MarkFlagsOneRequired("from-file", "from-configmap")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func runReplay(cfgFile string) error {
+	ctx := context.Background()
+
+	options := client.RuntimeReplayOptions{
+		Kubeconfig: client.Kubeconfig{Path: replayOpt.kubeconfig, Context: replayOpt.kubeconfigContext},
+		File:       replayOpt.fromFile,
+		RecordID:   replayOpt.id,
+		URL:        replayOpt.url,
+		Timeout:    replayOpt.timeout,
+	}
+	if replayOpt.fromConfigMap != "" {
+		namespace, name, ok := strings.Cut(replayOpt.fromConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return errors.Errorf("invalid --from-configmap %q: must be in the format <namespace>/<name>", replayOpt.fromConfigMap)
+		}
+		options.ConfigMapNamespace = namespace
+		options.ConfigMapName = name
+	}
+	if replayOpt.caFile != "" {
+		caBundle, err := os.ReadFile(replayOpt.caFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", replayOpt.caFile)
+		}
+		options.CABundle = caBundle
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.RuntimeReplay(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replayed call %d to extension handler %q for hook %s/%s, recorded at %s\n\n",
+		result.Record.ID, result.Record.ExtensionHandler, result.Record.APIVersion, result.Record.Hook, result.Record.Time.Format(time.RFC3339))
+
+	responseData, err := json.Marshal(result.Response)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal response")
+	}
+	responseYAML, err := yaml.JSONToYAML(responseData)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert response to YAML")
+	}
+	fmt.Printf("Response:\n%s\n", responseYAML)
+
+	if result.Record.Error != "" {
+		fmt.Printf("The recorded call failed with error: %s\n", result.Record.Error)
+		return nil
+	}
+	equal, err := equalResponses(result.Record.Response, responseData)
+	if err != nil {
+		return err
+	}
+	if equal {
+		fmt.Println("The response is equal to the recorded response.")
+		return nil
+	}
+	recordedYAML, err := yaml.JSONToYAML(result.Record.Response)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert recorded response to YAML")
+	}
+	fmt.Printf("The response is different from the recorded response:\n%s\n", recordedYAML)
+	return nil
+}
+
+// equalResponses compares two responses encoded as JSON, ignoring apiVersion and kind.
+func equalResponses(a, b []byte) (bool, error) {
+	var objA, objB map[string]any
+	if err := json.Unmarshal(a, &objA); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal recorded response")
+	}
+	if err := json.Unmarshal(b, &objB); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal response")
+	}
+	for _, obj := range []map[string]any{objA, objB} {
+		delete(obj, "apiVersion")
+		delete(obj, "kind")
+	}
+	return reflect.DeepEqual(objA, objB), nil
+}
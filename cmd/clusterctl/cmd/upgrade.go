@@ -37,6 +37,7 @@ var upgradeCmd = &cobra.Command{
 func init() {
 	upgradeCmd.AddCommand(upgradePlanCmd)
 	upgradeCmd.AddCommand(upgradeApplyCmd)
+	upgradeCmd.AddCommand(upgradeRollbackCmd)
 	RootCmd.AddCommand(upgradeCmd)
 }
 
@@ -40,6 +40,7 @@ type upgradeApplyOptions struct {
 	addonProviders            []string
 	waitProviders             bool
 	waitProviderTimeout       int
+	force                     bool
 }
 
 var ua = &upgradeApplyOptions{}
@@ -53,6 +54,9 @@ var upgradeApplyCmd = &cobra.Command{
 		New version should be applied ensuring all the providers uses the same cluster API version
 		in order to guarantee the proper functioning of the management cluster.
 
+		Before applying the upgrade, the workload Clusters are checked for compatibility with the target versions
+		of the providers; the upgrade is blocked if there are Clusters not compatible, unless --force is used.
+
  		Specifying the provider using namespace/name:version is deprecated and will be dropped in a future release.`),
 	Example: templates.Examples(`
 		# Upgrades all the providers in the management cluster to the latest version available which is compliant
@@ -93,6 +97,8 @@ func init() {
 		"Wait for providers to be upgraded.")
 	upgradeApplyCmd.Flags().IntVar(&ua.waitProviderTimeout, "wait-provider-timeout", 5*60,
 		"Wait timeout per provider upgrade in seconds. This value is ignored if --wait-providers is false")
+	upgradeApplyCmd.Flags().BoolVar(&ua.force, "force", false,
+		"Apply the upgrade even if there are workload Clusters not compatible with the target versions of the providers.")
 }
 
 func runUpgradeApply() error {
@@ -130,5 +136,6 @@ func runUpgradeApply() error {
 		AddonProviders:            ua.addonProviders,
 		WaitProviders:             ua.waitProviders,
 		WaitProviderTimeout:       time.Duration(ua.waitProviderTimeout) * time.Second,
+		Force:                     ua.force,
 	})
 }
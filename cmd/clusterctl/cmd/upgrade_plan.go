@@ -48,7 +48,12 @@ var upgradePlanCmd = &cobra.Command{
 
 		Then, for each provider, the following upgrade options are provided:
 		- The latest patch release for the current Cluster API contract version.
-		- The latest patch release for the next Cluster API contract version, if available.`),
+		- The latest patch release for the next Cluster API contract version, if available.
+
+		For the upgrade options that can be applied by the current version of clusterctl, the workload
+		Clusters are checked for compatibility with the target versions of the providers, e.g. if the
+		Kubernetes version of a Cluster is supported, or if a ClusterClass uses API versions dropped
+		by the target versions of the providers.`),
 
 	Example: templates.Examples(`
 		# Gets the recommended target versions for upgrading Cluster API providers.
@@ -126,11 +131,23 @@ func runUpgradePlan() error {
 		}
 		fmt.Println("")
 
+		compatible, err := printClustersCompatibility(plan)
+		if err != nil {
+			return err
+		}
+
 		if upgradeAvailable {
 			if plan.Contract == clusterv1.GroupVersion.Version {
-				fmt.Println("You can now apply the upgrade by executing the following command:")
-				fmt.Println("")
-				fmt.Printf("clusterctl upgrade apply --contract %s\n", plan.Contract)
+				if compatible {
+					fmt.Println("You can now apply the upgrade by executing the following command:")
+					fmt.Println("")
+					fmt.Printf("clusterctl upgrade apply --contract %s\n", plan.Contract)
+				} else {
+					fmt.Println("Some workload Clusters are not compatible with the target versions of the providers;")
+					fmt.Println("please address the issues above or force the upgrade by executing the following command:")
+					fmt.Println("")
+					fmt.Printf("clusterctl upgrade apply --contract %s --force\n", plan.Contract)
+				}
 			} else {
 				fmt.Printf("The current version of clusterctl could not upgrade to %s contract (only %s supported).\n", plan.Contract, clusterv1.GroupVersion.Version)
 			}
@@ -142,3 +159,41 @@ func runUpgradePlan() error {
 
 	return nil
 }
+
+// printClustersCompatibility prints the compatibility of the workload Clusters with the target versions of the providers
+// in an upgrade plan, and returns false if at least one Cluster is not compatible.
+func printClustersCompatibility(plan client.UpgradePlan) (bool, error) {
+	if len(plan.Clusters) == 0 {
+		return true, nil
+	}
+
+	compatible := true
+	fmt.Println("Workload Clusters compatibility with the target versions of the providers:")
+	fmt.Println("")
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tCLUSTERCLASS\tKUBERNETES VERSION\tCOMPATIBLE")
+	for _, c := range plan.Clusters {
+		status := "Yes"
+		if !c.IsCompatible() {
+			status = "No"
+			compatible = false
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Namespace, c.Name, c.ClusterClass, c.KubernetesVersion, status)
+	}
+	if err := w.Flush(); err != nil {
+		return false, err
+	}
+	fmt.Println("")
+
+	hasIssues := false
+	for _, c := range plan.Clusters {
+		for _, issue := range c.Issues {
+			fmt.Printf("%s: Cluster %s/%s: %s\n", issue.Severity, c.Namespace, c.Name, issue.Message)
+			hasIssues = true
+		}
+	}
+	if hasIssues {
+		fmt.Println("")
+	}
+	return compatible, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type upgradeRollbackOptions struct {
+	kubeconfig                string
+	kubeconfigContext         string
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+}
+
+var ur = &upgradeRollbackOptions{}
+
+var upgradeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll back Cluster API core and providers in a management cluster to the version before the last upgrade",
+	Long: templates.LongDesc(`
+		The upgrade rollback command restores Cluster API providers to the version they had before the last upgrade,
+		using the snapshot of the provider components and of the inventory taken by clusterctl upgrade apply.
+
+		Deployments, RBAC, webhooks and the other provider components are restored as they were before the upgrade.
+		CRDs are restored too, but the rollback is refused if objects have been already migrated to API versions
+		not defined in the CRDs before the upgrade.
+
+		If no provider is specified, all the providers with a snapshot taken by the last upgrade are rolled back.`),
+	Example: templates.Examples(`
+		# Rolls back all the providers upgraded by the last upgrade.
+		clusterctl upgrade rollback
+
+		# Rolls back only the aws provider.
+		clusterctl upgrade rollback --infrastructure aws`),
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runUpgradeRollback()
+	},
+}
+
+func init() {
+	upgradeRollbackCmd.Flags().StringVar(&ur.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	upgradeRollbackCmd.Flags().StringVar(&ur.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+
+	upgradeRollbackCmd.Flags().StringVar(&ur.coreProvider, "core", "",
+		"Core provider (e.g. cluster-api) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVarP(&ur.infrastructureProviders, "infrastructure", "i", nil,
+		"Infrastructure providers (e.g. aws) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVarP(&ur.bootstrapProviders, "bootstrap", "b", nil,
+		"Bootstrap providers (e.g. kubeadm) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVarP(&ur.controlPlaneProviders, "control-plane", "c", nil,
+		"ControlPlane providers (e.g. kubeadm) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVar(&ur.ipamProviders, "ipam", nil,
+		"IPAM providers (e.g. infoblox) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVar(&ur.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers (e.g. test) to roll back.")
+	upgradeRollbackCmd.Flags().StringSliceVar(&ur.addonProviders, "addon", nil,
+		"Add-on providers (e.g. helm) to roll back.")
+}
+
+func runUpgradeRollback() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.RollbackUpgrade(ctx, client.RollbackUpgradeOptions{
+		Kubeconfig:                client.Kubeconfig{Path: ur.kubeconfig, Context: ur.kubeconfigContext},
+		CoreProvider:              ur.coreProvider,
+		BootstrapProviders:        ur.bootstrapProviders,
+		ControlPlaneProviders:     ur.controlPlaneProviders,
+		InfrastructureProviders:   ur.infrastructureProviders,
+		IPAMProviders:             ur.ipamProviders,
+		RuntimeExtensionProviders: ur.runtimeExtensionProviders,
+		AddonProviders:            ur.addonProviders,
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/validation"
 )
 
 // printYamlOutput prints the yaml content of a generated template to stdout or to a local file if specified.
@@ -53,6 +54,22 @@ func printYamlOutput(printer client.YamlPrinter, outputFile string) error {
 	return nil
 }
 
+// printValidationIssues prints the issues found while validating a template to stderr, and returns an error
+// if any of them would cause objects to be rejected by the Cluster API webhooks.
+func printValidationIssues(issues []validation.Issue) error {
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == validation.ErrorSeverity {
+			errorCount++
+		}
+		fmt.Fprintln(os.Stderr, issue.String())
+	}
+	if errorCount > 0 {
+		return errors.Errorf("template validation failed with %d error(s)", errorCount)
+	}
+	return nil
+}
+
 // printVariablesOutput prints the expected variables in the template to stdout.
 func printVariablesOutput(template client.Template, options client.GetClusterTemplateOptions) error {
 	// Decorate the variable map for printing
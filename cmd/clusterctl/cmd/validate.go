@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type validateOptions struct {
+	filenames []string
+}
+
+var validateOpts = &validateOptions{}
+
+var validateCmd = &cobra.Command{
+	Use:     "validate",
+	GroupID: groupManagement,
+	Short:   "Validate workload cluster templates",
+	Long: templates.LongDesc(`
+		Validate workload cluster templates without requiring a management cluster.
+
+		Cluster API objects in the template are validated using the same logic of the Cluster API webhooks;
+		Clusters with a managed topology are validated against the ClusterClasses defined in the template,
+		including ClusterClass variables.
+
+		Feature gates are set according to the variables used when installing the Cluster API core provider,
+		e.g. CLUSTER_TOPOLOGY, EXP_MACHINE_POOL or EXP_RUNTIME_SDK.`),
+
+	Example: templates.Examples(`
+		# Validates a workload cluster template.
+		clusterctl validate -f my-cluster.yaml
+
+		# Validates a workload cluster template and the ClusterClass it uses.
+		clusterctl validate -f my-cluster.yaml -f my-clusterclass.yaml
+
+		# Validates a workload cluster template after substituting variables.
+		clusterctl generate yaml --from cluster-template.yaml | clusterctl validate -f -`),
+
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runValidate()
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringSliceVarP(&validateOpts.filenames, "filename", "f", nil,
+		"Path to the file with the objects to be validated. Can be repeated. Use - to read from stdin.")
+
+	_ = validateCmd.MarkFlagRequired("filename")
+
+	RootCmd.AddCommand(validateCmd)
+}
+
+func runValidate() error {
+	ctx := context.Background()
+
+	objs := []unstructured.Unstructured{}
+	for _, filename := range validateOpts.filenames {
+		o, err := readObjects(filename)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, o...)
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	issues, err := c.ValidateTemplate(ctx, client.ValidateTemplateOptions{Objs: objs})
+	if err != nil {
+		return err
+	}
+	if err := printValidationIssues(issues); err != nil {
+		return err
+	}
+	fmt.Printf("Validated %d object(s), no errors found.\n", len(objs))
+	return nil
+}
+
+// readObjects reads objects from a YAML file, or from stdin if the filename is -.
+func readObjects(filename string) ([]unstructured.Unstructured, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename) //nolint:gosec
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", filename)
+	}
+
+	objs, err := utilyaml.ToUnstructured(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", filename)
+	}
+	return objs, nil
+}
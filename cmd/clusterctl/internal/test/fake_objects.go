@@ -48,6 +48,7 @@ import (
 type FakeCluster struct {
 	namespace              string
 	name                   string
+	labels                 map[string]string
 	paused                 bool
 	controlPlane           *FakeControlPlane
 	machinePools           []*FakeMachinePool
@@ -118,6 +119,11 @@ func (f *FakeCluster) WithTopologyClassNamespace(namespace string) *FakeCluster
 	return f
 }
 
+func (f *FakeCluster) WithLabels(labels map[string]string) *FakeCluster {
+	f.labels = labels
+	return f
+}
+
 func (f *FakeCluster) WithPaused() *FakeCluster {
 	f.paused = true
 	return f
@@ -145,6 +151,7 @@ func (f *FakeCluster) Objs() []client.Object {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      f.name,
 			Namespace: f.namespace,
+			Labels:    f.labels,
 			// Labels: cluster.x-k8s.io/cluster-name=cluster MISSING??
 		},
 		Spec: clusterv1.ClusterSpec{
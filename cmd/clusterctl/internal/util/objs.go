@@ -17,6 +17,11 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +35,7 @@ const (
 	deploymentKind          = "Deployment"
 	daemonSetKind           = "DaemonSet"
 	controllerContainerName = "manager"
+	featureGatesArg         = "--feature-gates"
 )
 
 // InspectImages identifies the container images required to install the objects defined in the objs.
@@ -192,3 +198,142 @@ func IsDeploymentWithManager(obj unstructured.Unstructured) bool {
 	}
 	return false
 }
+
+// InspectFeatureGates returns the feature gates set in the --feature-gates argument of the provider's controller,
+// i.e. the containers named 'manager' in the Deployments defined in objs.
+func InspectFeatureGates(objs []unstructured.Unstructured) (map[string]bool, error) {
+	featureGates := map[string]bool{}
+	for i := range objs {
+		o := objs[i]
+		if o.GetKind() != deploymentKind {
+			continue
+		}
+
+		d := &appsv1.Deployment{}
+		if err := scheme.Scheme.Convert(&o, d, nil); err != nil {
+			return nil, err
+		}
+
+		for _, c := range d.Spec.Template.Spec.Containers {
+			if c.Name != controllerContainerName {
+				continue
+			}
+			value, _ := getFeatureGatesArg(c.Args)
+			gates, err := parseFeatureGates(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse feature gates for deployment %s", d.Name)
+			}
+			for name, enabled := range gates {
+				featureGates[name] = enabled
+			}
+		}
+	}
+	return featureGates, nil
+}
+
+// FixFeatureGates sets the given feature gates in the --feature-gates argument of the provider's controller,
+// i.e. the containers named 'manager' in the Deployments defined in objs; feature gates already set in the argument
+// and not included in featureGates are preserved.
+func FixFeatureGates(objs []unstructured.Unstructured, featureGates map[string]bool) ([]unstructured.Unstructured, error) {
+	if len(featureGates) == 0 {
+		return objs, nil
+	}
+
+	found := false
+	for i := range objs {
+		o := &objs[i]
+		if o.GetKind() != deploymentKind {
+			continue
+		}
+
+		// Convert Unstructured into a typed object
+		d := &appsv1.Deployment{}
+		if err := scheme.Scheme.Convert(o, d, nil); err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for j := range d.Spec.Template.Spec.Containers {
+			container := &d.Spec.Template.Spec.Containers[j]
+			if container.Name != controllerContainerName {
+				continue
+			}
+
+			value, setValue := getFeatureGatesArg(container.Args)
+			gates, err := parseFeatureGates(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse feature gates for deployment %s", d.Name)
+			}
+			for name, enabled := range featureGates {
+				gates[name] = enabled
+			}
+			container.Args = setValue(formatFeatureGates(gates))
+			found = true
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		// Convert typed object back to Unstructured
+		if err := scheme.Scheme.Convert(d, o, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if !found {
+		return nil, errors.Errorf("failed to set feature gates: there are no Deployments with a container named %q", controllerContainerName)
+	}
+	return objs, nil
+}
+
+// getFeatureGatesArg returns the value of the --feature-gates argument, supporting both the --feature-gates=value
+// and the --feature-gates value syntax, and a func for setting a new value for the argument.
+func getFeatureGatesArg(args []string) (string, func(string) []string) {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, featureGatesArg+"="); ok {
+			return value, func(newValue string) []string {
+				args[i] = fmt.Sprintf("%s=%s", featureGatesArg, newValue)
+				return args
+			}
+		}
+		if arg == featureGatesArg && i+1 < len(args) {
+			return args[i+1], func(newValue string) []string {
+				args[i+1] = newValue
+				return args
+			}
+		}
+	}
+	return "", func(newValue string) []string {
+		return append(args, fmt.Sprintf("%s=%s", featureGatesArg, newValue))
+	}
+}
+
+func parseFeatureGates(value string) (map[string]bool, error) {
+	featureGates := map[string]bool{}
+	for _, gate := range strings.Split(value, ",") {
+		gate = strings.TrimSpace(gate)
+		if gate == "" {
+			continue
+		}
+		name, rawEnabled, ok := strings.Cut(gate, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid feature gate %q, it must be in the form name=true|false", gate)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(rawEnabled))
+		if err != nil {
+			return nil, errors.Errorf("invalid value for feature gate %q, it must be true or false", name)
+		}
+		featureGates[strings.TrimSpace(name)] = enabled
+	}
+	return featureGates, nil
+}
+
+func formatFeatureGates(featureGates map[string]bool) string {
+	gates := make([]string, 0, len(featureGates))
+	for name, enabled := range featureGates {
+		gates = append(gates, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(gates)
+	return strings.Join(gates, ",")
+}
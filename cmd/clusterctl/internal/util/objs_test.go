@@ -362,3 +362,94 @@ func TestIsDeploymentWithManager(t *testing.T) {
 		})
 	}
 }
+
+func TestFixFeatureGates(t *testing.T) {
+	deploymentWithArgs := func(containerName string, args ...string) unstructured.Unstructured {
+		d := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: deploymentKind, APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "controller-manager"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: containerName, Args: args}},
+					},
+				},
+			},
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(d)
+		if err != nil {
+			panic(err)
+		}
+		return unstructured.Unstructured{Object: obj}
+	}
+
+	tests := []struct {
+		name         string
+		obj          unstructured.Unstructured
+		featureGates map[string]bool
+		wantArgs     []string
+		wantGates    map[string]bool
+		wantErr      bool
+	}{
+		{
+			name:         "update feature gates in the --feature-gates=value argument",
+			obj:          deploymentWithArgs(controllerContainerName, "--leader-elect", "--feature-gates=MachinePool=true,ClusterTopology=false"),
+			featureGates: map[string]bool{"ClusterTopology": true, "RuntimeSDK": true},
+			wantArgs:     []string{"--leader-elect", "--feature-gates=ClusterTopology=true,MachinePool=true,RuntimeSDK=true"},
+			wantGates:    map[string]bool{"ClusterTopology": true, "MachinePool": true, "RuntimeSDK": true},
+		},
+		{
+			name:         "update feature gates in the --feature-gates value argument",
+			obj:          deploymentWithArgs(controllerContainerName, "--feature-gates", "MachinePool=true"),
+			featureGates: map[string]bool{"MachinePool": false},
+			wantArgs:     []string{"--feature-gates", "MachinePool=false"},
+			wantGates:    map[string]bool{"MachinePool": false},
+		},
+		{
+			name:         "add the --feature-gates argument if missing",
+			obj:          deploymentWithArgs(controllerContainerName, "--leader-elect"),
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantArgs:     []string{"--leader-elect", "--feature-gates=ClusterTopology=true"},
+			wantGates:    map[string]bool{"ClusterTopology": true},
+		},
+		{
+			name:         "no changes if there are no feature gates to set",
+			obj:          deploymentWithArgs("not-manager", "--leader-elect"),
+			featureGates: nil,
+			wantArgs:     []string{"--leader-elect"},
+			wantGates:    map[string]bool{},
+		},
+		{
+			name:         "fails if there are no provider's controllers",
+			obj:          deploymentWithArgs("not-manager", "--leader-elect"),
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantErr:      true,
+		},
+		{
+			name:         "fails if the --feature-gates argument is not valid",
+			obj:          deploymentWithArgs(controllerContainerName, "--feature-gates=MachinePool"),
+			featureGates: map[string]bool{"ClusterTopology": true},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := FixFeatureGates([]unstructured.Unstructured{tt.obj}, tt.featureGates)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			d := &appsv1.Deployment{}
+			g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(got[0].Object, d)).To(Succeed())
+			g.Expect(d.Spec.Template.Spec.Containers[0].Args).To(Equal(tt.wantArgs))
+
+			gotGates, err := InspectFeatureGates(got)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(gotGates).To(Equal(tt.wantGates))
+		})
+	}
+}
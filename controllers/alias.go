@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
@@ -54,6 +55,9 @@ type ClusterReconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// SecretCachingClient is the client used to read the cluster certificate Secrets.
+	SecretCachingClient client.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -65,6 +69,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Client:                      r.Client,
 		APIReader:                   r.APIReader,
 		ClusterCache:                r.ClusterCache,
+		SecretCachingClient:         r.SecretCachingClient,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConnectionGracePeriod: r.RemoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, options)
@@ -126,6 +131,7 @@ type MachineDeploymentReconciler struct {
 	Client        client.Client
 	APIReader     client.Reader
 	RuntimeClient runtimeclient.Client
+	ClusterCache  clustercache.ClusterCache
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
@@ -136,6 +142,7 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		RuntimeClient:    r.RuntimeClient,
+		ClusterCache:     r.ClusterCache,
 		WatchFilterValue: r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -315,15 +322,19 @@ type ExtensionConfigReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// CircuitBreakerEvents, if set, receives events for ExtensionConfigs whose circuit breaker changed state.
+	CircuitBreakerEvents <-chan event.GenericEvent
 }
 
 func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&extensionconfigcontroller.Reconciler{
-		Client:             r.Client,
-		APIReader:          r.APIReader,
-		RuntimeClient:      r.RuntimeClient,
-		PartialSecretCache: r.PartialSecretCache,
-		ReadOnly:           r.ReadOnly,
-		WatchFilterValue:   r.WatchFilterValue,
+		Client:               r.Client,
+		APIReader:            r.APIReader,
+		RuntimeClient:        r.RuntimeClient,
+		PartialSecretCache:   r.PartialSecretCache,
+		ReadOnly:             r.ReadOnly,
+		WatchFilterValue:     r.WatchFilterValue,
+		CircuitBreakerEvents: r.CircuitBreakerEvents,
 	}).SetupWithManager(ctx, mgr, options)
 }
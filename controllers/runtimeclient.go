@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
+	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
+)
+
+// RuntimeClientOptions are the options for NewRuntimeClient.
+type RuntimeClientOptions struct {
+	CertFile string // Path of the PEM-encoded client certificate.
+	KeyFile  string // Path of the PEM-encoded client key.
+	Catalog  *runtimecatalog.Catalog
+	Client   client.Client
+
+	// InProcessExtensions are Runtime Extensions called in-process instead of via HTTPS.
+	// InProcessExtensions are added to the registry on WarmUp.
+	InProcessExtensions []*runtimeserver.InProcessExtension
+
+	// CircuitBreakerEvents, if set, receives an event for the ExtensionConfig whenever the state
+	// of its circuit breaker changes. Events are dropped if the channel is full.
+	CircuitBreakerEvents chan<- event.GenericEvent
+}
+
+// NewRuntimeClient returns a new Runtime SDK client backed by its own extension registry,
+// to be used as RuntimeClient of the reconcilers in this package.
+// The returned CertWatcher is nil if CertFile or KeyFile are not set, otherwise it must be added to the manager.
+func NewRuntimeClient(options RuntimeClientOptions) (runtimeclient.Client, *certwatcher.CertWatcher, error) {
+	return internalruntimeclient.New(internalruntimeclient.Options{
+		CertFile:             options.CertFile,
+		KeyFile:              options.KeyFile,
+		Catalog:              options.Catalog,
+		Registry:             runtimeregistry.New(),
+		Client:               options.Client,
+		InProcessExtensions:  options.InProcessExtensions,
+		CircuitBreakerEvents: options.CircuitBreakerEvents,
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
+	fakev1alpha1 "sigs.k8s.io/cluster-api/internal/runtime/test/v1alpha1"
+)
+
+func TestNewRuntimeClient(t *testing.T) {
+	g := NewWithT(t)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": "foo",
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	cat := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+	g.Expect(fakev1alpha1.AddToCatalog(cat)).To(Succeed())
+
+	extension, err := runtimeserver.NewInProcessExtension(runtimeserver.InProcessExtensionOptions{
+		Name:    "in-process",
+		Catalog: cat,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(extension.AddExtensionHandler(runtimeserver.ExtensionHandler{
+		Hook: fakev1alpha1.FakeHook,
+		Name: "fake",
+		HandlerFunc: func(_ context.Context, request *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+			response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+			response.SetMessage(request.Second)
+		},
+	})).To(Succeed())
+
+	// Creating a client with two in-process extensions with the same name fails.
+	_, _, err = NewRuntimeClient(RuntimeClientOptions{
+		Catalog:             cat,
+		InProcessExtensions: []*runtimeserver.InProcessExtension{extension, extension},
+	})
+	g.Expect(err).To(HaveOccurred())
+
+	c, certWatcher, err := NewRuntimeClient(RuntimeClientOptions{
+		Catalog:             cat,
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build(),
+		InProcessExtensions: []*runtimeserver.InProcessExtension{extension},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(certWatcher).To(BeNil())
+	g.Expect(c.WarmUp(&runtimev1.ExtensionConfigList{})).To(Succeed())
+
+	// The in-process extension is called without any ExtensionConfig.
+	obj := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "foo",
+		},
+	}
+	response := &fakev1alpha1.FakeResponse{}
+	g.Expect(c.CallAllExtensions(context.Background(), fakev1alpha1.FakeHook, obj, &fakev1alpha1.FakeRequest{Second: "second"}, response)).To(Succeed())
+	g.Expect(response.GetMessage()).To(Equal("second"))
+}
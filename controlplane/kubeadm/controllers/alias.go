@@ -42,6 +42,9 @@ type KubeadmControlPlaneReconciler struct {
 	EtcdCallTimeout time.Duration
 	EtcdLogger      *zap.Logger
 
+	// EtcdSnapshotDirectory is the directory used by the Directory sink to store etcd snapshots.
+	EtcdSnapshotDirectory string
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -59,7 +62,55 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 		EtcdDialTimeout:             r.EtcdDialTimeout,
 		EtcdCallTimeout:             r.EtcdCallTimeout,
 		EtcdLogger:                  r.EtcdLogger,
+		EtcdSnapshotDirectory:       r.EtcdSnapshotDirectory,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConditionsGracePeriod: r.RemoteConditionsGracePeriod,
 	}).SetupWithManager(ctx, mgr, options)
 }
+
+// EtcdSnapshotReconciler reconciles an EtcdSnapshot object.
+type EtcdSnapshotReconciler struct {
+	Client              client.Client
+	SecretCachingClient client.Client
+	ClusterCache        clustercache.ClusterCache
+
+	EtcdDialTimeout time.Duration
+	EtcdCallTimeout time.Duration
+	EtcdLogger      *zap.Logger
+
+	// EtcdSnapshotDirectory is the root directory used by the Directory sink.
+	EtcdSnapshotDirectory string
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+// SetupWithManager sets up the reconciler with the Manager.
+func (r *EtcdSnapshotReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&kubeadmcontrolplanecontrollers.EtcdSnapshotReconciler{
+		Client:                r.Client,
+		SecretCachingClient:   r.SecretCachingClient,
+		ClusterCache:          r.ClusterCache,
+		EtcdDialTimeout:       r.EtcdDialTimeout,
+		EtcdCallTimeout:       r.EtcdCallTimeout,
+		EtcdLogger:            r.EtcdLogger,
+		EtcdSnapshotDirectory: r.EtcdSnapshotDirectory,
+		WatchFilterValue:      r.WatchFilterValue,
+	}).SetupWithManager(ctx, mgr, options)
+}
+
+// EtcdBackupPolicyReconciler reconciles an EtcdBackupPolicy object.
+type EtcdBackupPolicyReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+// SetupWithManager sets up the reconciler with the Manager.
+func (r *EtcdBackupPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&kubeadmcontrolplanecontrollers.EtcdBackupPolicyReconciler{
+		Client:           r.Client,
+		WatchFilterValue: r.WatchFilterValue,
+	}).SetupWithManager(ctx, mgr, options)
+}
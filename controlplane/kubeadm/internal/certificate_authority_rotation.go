@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// CertificateAuthorityRotationPhase is a phase of the certificate authority rotation.
+type CertificateAuthorityRotationPhase string
+
+const (
+	// CertificateAuthorityRotationTrustPhase is the phase where new certificate authorities are added to
+	// the trust bundles, while the old certificate authorities are still used for signing.
+	CertificateAuthorityRotationTrustPhase = CertificateAuthorityRotationPhase("Trust")
+
+	// CertificateAuthorityRotationSignPhase is the phase where the new certificate authorities are used for signing,
+	// while the old certificate authorities are still part of the trust bundles.
+	CertificateAuthorityRotationSignPhase = CertificateAuthorityRotationPhase("Sign")
+
+	// CertificateAuthorityRotationDropPhase is the phase where the old certificate authorities are removed from
+	// the trust bundles.
+	CertificateAuthorityRotationDropPhase = CertificateAuthorityRotationPhase("Drop")
+
+	// CertificateAuthorityRotationCompletedPhase is the phase reached when the rotation is completed.
+	CertificateAuthorityRotationCompletedPhase = CertificateAuthorityRotationPhase("Completed")
+)
+
+// CertificateAuthorityRotationData keeps track of a certificate authority rotation.
+type CertificateAuthorityRotationData struct {
+	// after is the value of spec.certificateAuthorityRotation.after which triggered the rotation.
+	After metav1.Time `json:"after"`
+
+	// phase is the current phase of the rotation.
+	Phase CertificateAuthorityRotationPhase `json:"phase"`
+
+	// phaseStartTime is the time when the current phase started.
+	// All the Machines created before this time must be replaced before moving to the next phase.
+	PhaseStartTime metav1.Time `json:"phaseStartTime"`
+}
+
+// CertificateAuthorityRotationDataFromAnnotation gets CertificateAuthorityRotationData from the
+// CertificateAuthorityRotationAnnotation in KCP; nil is returned if the annotation is not set.
+func CertificateAuthorityRotationDataFromAnnotation(kcp *controlplanev1.KubeadmControlPlane) (*CertificateAuthorityRotationData, error) {
+	value, ok := kcp.Annotations[controlplanev1.CertificateAuthorityRotationAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	ret := &CertificateAuthorityRotationData{}
+	if err := json.Unmarshal([]byte(value), ret); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal value %s for %s annotation", value, controlplanev1.CertificateAuthorityRotationAnnotation)
+	}
+	return ret, nil
+}
+
+// Marshal a CertificateAuthorityRotationData into an annotation value.
+func (d *CertificateAuthorityRotationData) Marshal() (string, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal value for %s annotation", controlplanev1.CertificateAuthorityRotationAnnotation)
+	}
+	return string(b), nil
+}
+
+// InProgress returns true if the certificate authority rotation is not completed yet.
+func (d *CertificateAuthorityRotationData) InProgress() bool {
+	return d != nil && d.Phase != CertificateAuthorityRotationCompletedPhase
+}
+
+// NeedsRollout returns true if the Machine was created before the current phase of the certificate
+// authority rotation started, and thus it must be replaced.
+func (d *CertificateAuthorityRotationData) NeedsRollout(machine *clusterv1.Machine) bool {
+	return d.InProgress() && machine.CreationTimestamp.Before(&d.PhaseStartTime)
+}
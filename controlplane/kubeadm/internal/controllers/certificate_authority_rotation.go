@@ -0,0 +1,360 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	clog "sigs.k8s.io/cluster-api/util/log"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// reconcileCertificateAuthorityRotation rotates the cluster certificate authorities and the service account key pair
+// if spec.certificateAuthorityRotation.after is set to a time in the past, and this time is later than the one
+// of the last rotation.
+// The rotation is implemented as follows:
+//   - The rotation goes through the Trust, Sign and Drop phases, see secret.Certificate TrustNext, SignWithNext and DropPrevious.
+//   - When a phase starts, the certificate Secrets controlled by KCP, the cluster-info ConfigMap in the workload cluster
+//     and the kubeconfig Secret (if controlled by KCP) are updated.
+//   - Then all the Machines created before the phase started are rolled out; control plane Machines are rolled out by KCP
+//     (see UpToDate), Machines controlled by MachineDeployments are rolled out by setting spec.rollout.after.
+//   - The next phase starts only when all the Machines of the Cluster have been created after the current phase started.
+//     Machines that KCP cannot roll out, e.g. Machines controlled by MachinePools or standalone Machines, must be
+//     replaced by the user; while waiting for them the CertificateAuthorityRotating condition has the RotationBlocked reason.
+//
+// The state of the rotation is tracked in the CertificateAuthorityRotationAnnotation; when a new phase is started
+// the reconcile returns early, so the Machines to be rolled out are computed again in the next reconcile.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	// Note: Use the same precision as the value stored in the CertificateAuthorityRotationAnnotation.
+	after := kcp.Spec.CertificateAuthorityRotation.After.Rfc3339Copy()
+
+	rotationData, err := internal.CertificateAuthorityRotationDataFromAnnotation(kcp)
+	if err != nil {
+		setCertificateAuthorityRotationInternalError(kcp)
+		return ctrl.Result{}, err
+	}
+
+	if !rotationData.InProgress() {
+		// Nothing to do if a rotation was never requested, or if the last rotation requested is already completed.
+		if after.IsZero() || (rotationData != nil && !rotationData.After.Before(&after)) {
+			if rotationData == nil {
+				conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)
+				return ctrl.Result{}, nil
+			}
+			setCertificateAuthorityNotRotating(kcp, "")
+			return ctrl.Result{}, nil
+		}
+
+		if after.After(time.Now()) {
+			setCertificateAuthorityNotRotating(kcp, fmt.Sprintf("Certificate authority rotation scheduled after %s", after.UTC().Format(time.RFC3339)))
+			return ctrl.Result{}, nil
+		}
+
+		// Certificate authorities can be rotated only after they have been used to initialize the control plane.
+		if !conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneInitializedCondition) {
+			setCertificateAuthorityNotRotating(kcp, "Waiting for the control plane to be initialized before rotating certificate authorities")
+			return ctrl.Result{}, nil
+		}
+
+		log.Info(fmt.Sprintf("Starting certificate authority rotation requested after %s", after.UTC().Format(time.RFC3339)))
+		return r.startCertificateAuthorityRotationPhase(ctx, controlPlane, &internal.CertificateAuthorityRotationData{After: after}, internal.CertificateAuthorityRotationTrustPhase)
+	}
+
+	// Make sure Machines controlled by MachineDeployments are rolled out.
+	// Note: This is done at every reconcile so MachineDeployments created after the phase started are rolled out as well.
+	if err := r.rolloutMachineDeploymentsForCertificateAuthorityRotation(ctx, controlPlane.Cluster, rotationData); err != nil {
+		setCertificateAuthorityRotationInternalError(kcp)
+		return ctrl.Result{}, err
+	}
+
+	// Wait for all the Machines of the Cluster to be created after the current phase started.
+	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, controlPlane.Cluster, rotationData.NeedsRollout)
+	if err != nil {
+		setCertificateAuthorityRotationInternalError(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to list Machines to be rolled out for certificate authority rotation")
+	}
+	if len(machines) > 0 {
+		// Control plane Machines are rolled out by KCP, Machines controlled by MachineDeployments are rolled out
+		// via spec.rollout.after; all the other Machines must be replaced by the user.
+		rollingOutMachines := machines.Filter(collections.Or(
+			collections.ControlPlaneMachines(controlPlane.Cluster.Name),
+			func(machine *clusterv1.Machine) bool {
+				_, ok := machine.Labels[clusterv1.MachineDeploymentNameLabel]
+				return ok
+			},
+		))
+		blockingMachines := machines.Difference(rollingOutMachines)
+
+		messages := []string{}
+		if len(blockingMachines) > 0 {
+			messages = append(messages, machineNamesMessage(blockingMachines)+" must be replaced manually (not controlled by the KubeadmControlPlane or by a MachineDeployment)")
+		}
+		if len(rollingOutMachines) > 0 {
+			messages = append(messages, "waiting for "+machineNamesMessage(rollingOutMachines)+" to be rolled out")
+		}
+		message := strings.Join(messages, "; ")
+
+		if len(blockingMachines) > 0 {
+			log.Info(fmt.Sprintf("Certificate authority rotation is blocked: %s", message))
+			setCertificateAuthorityRotationBlocked(kcp, rotationData.Phase, message)
+		} else {
+			setCertificateAuthorityRotating(kcp, rotationData.Phase, message)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	return r.startCertificateAuthorityRotationPhase(ctx, controlPlane, rotationData, nextCertificateAuthorityRotationPhase(rotationData.Phase))
+}
+
+// startCertificateAuthorityRotationPhase updates certificates, cluster-info and kubeconfig for a phase of the
+// certificate authority rotation, and then records the new phase in the CertificateAuthorityRotationAnnotation.
+// NOTE: All the operations are idempotent, so they can be safely retried if one of them fails.
+func (r *KubeadmControlPlaneReconciler) startCertificateAuthorityRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane, rotationData *internal.CertificateAuthorityRotationData, phase internal.CertificateAuthorityRotationPhase) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if phase != internal.CertificateAuthorityRotationCompletedPhase {
+		certificates, err := r.lookupCertificatesForCertificateAuthorityRotation(ctx, controlPlane)
+		if err != nil {
+			setCertificateAuthorityRotationInternalError(kcp)
+			return ctrl.Result{}, err
+		}
+
+		for _, certificate := range certificates {
+			var err error
+			switch phase {
+			case internal.CertificateAuthorityRotationTrustPhase:
+				err = certificate.TrustNext()
+			case internal.CertificateAuthorityRotationSignPhase:
+				err = certificate.SignWithNext()
+			case internal.CertificateAuthorityRotationDropPhase:
+				err = certificate.DropPrevious()
+			}
+			if err != nil {
+				setCertificateAuthorityRotationInternalError(kcp)
+				return ctrl.Result{}, errors.Wrapf(err, "failed to start certificate authority rotation phase %s", phase)
+			}
+			if err := r.Client.Update(ctx, certificate.Secret); err != nil {
+				setCertificateAuthorityRotationInternalError(kcp)
+				return ctrl.Result{}, errors.Wrapf(err, "failed to update certificate Secret %s", klog.KObj(certificate.Secret))
+			}
+		}
+
+		// Worker Machines read the cluster certificate authority from the cluster-info ConfigMap when joining, so
+		// it must always contain the current trust bundle.
+		if clusterCA := certificates.GetByPurpose(secret.ClusterCA); clusterCA != nil {
+			workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+			if err != nil {
+				setCertificateAuthorityRotationInternalError(kcp)
+				return ctrl.Result{}, errors.Wrap(err, "failed to update cluster-info ConfigMap: failed to create client to workload cluster")
+			}
+			if err := workloadCluster.UpdateClusterInfoCertificateAuthorities(ctx, clusterCA.KeyPair.Cert); err != nil {
+				setCertificateAuthorityRotationInternalError(kcp)
+				return ctrl.Result{}, err
+			}
+		}
+
+		if err := r.regenerateKubeconfigForCertificateAuthorityRotation(ctx, controlPlane); err != nil {
+			setCertificateAuthorityRotationInternalError(kcp)
+			return ctrl.Result{}, err
+		}
+	}
+
+	rotationData.Phase = phase
+	rotationData.PhaseStartTime = metav1.Now()
+	value, err := rotationData.Marshal()
+	if err != nil {
+		setCertificateAuthorityRotationInternalError(kcp)
+		return ctrl.Result{}, err
+	}
+	annotations.AddAnnotations(kcp, map[string]string{controlplanev1.CertificateAuthorityRotationAnnotation: value})
+
+	if phase == internal.CertificateAuthorityRotationCompletedPhase {
+		log.Info("Certificate authority rotation completed")
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CertificateAuthorityRotation",
+			"Completed certificate authority rotation for cluster %s", klog.KObj(controlPlane.Cluster))
+		setCertificateAuthorityNotRotating(kcp, "")
+		return ctrl.Result{}, nil
+	}
+
+	log.Info(fmt.Sprintf("Started certificate authority rotation phase %s", phase))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CertificateAuthorityRotation",
+		"Started certificate authority rotation phase %s for cluster %s", phase, klog.KObj(controlPlane.Cluster))
+	setCertificateAuthorityRotating(kcp, phase, "phase started")
+	return ctrl.Result{RequeueAfter: certificateAuthorityRotationRequeueAfter}, nil
+}
+
+// lookupCertificatesForCertificateAuthorityRotation returns the certificates that can be rotated by KCP, i.e.
+// certificates generated by KCP and stored in Secrets controlled by it.
+func (r *KubeadmControlPlaneReconciler) lookupCertificatesForCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (secret.Certificates, error) {
+	clusterConfiguration := controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.DeepCopy()
+	certificates := secret.NewCertificatesForInitialControlPlane(clusterConfiguration)
+	// Note: Read certificates with the uncached client, because the Secrets are updated right after.
+	if err := certificates.Lookup(ctx, r.Client, util.ObjectKey(controlPlane.Cluster)); err != nil {
+		return nil, errors.Wrap(err, "failed to look up cluster certificates")
+	}
+
+	ret := secret.Certificates{}
+	for _, certificate := range certificates {
+		if certificate.External || certificate.Secret == nil {
+			continue
+		}
+		if !util.IsControlledBy(certificate.Secret, controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind).GroupKind()) {
+			continue
+		}
+		ret = append(ret, certificate)
+	}
+	return ret, nil
+}
+
+// regenerateKubeconfigForCertificateAuthorityRotation regenerates the kubeconfig Secret, if controlled by KCP,
+// so it trusts and it is signed by the current certificate authorities.
+func (r *KubeadmControlPlaneReconciler) regenerateKubeconfigForCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	configSecret, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), secret.Kubeconfig)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to retrieve kubeconfig Secret")
+	}
+
+	if !util.IsControlledBy(configSecret, controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind).GroupKind()) {
+		return nil
+	}
+
+	if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret, kubeconfig.KeyEncryptionAlgorithm(controlPlane.GetKeyEncryptionAlgorithm())); err != nil {
+		return errors.Wrap(err, "failed to regenerate kubeconfig")
+	}
+	return nil
+}
+
+// rolloutMachineDeploymentsForCertificateAuthorityRotation sets spec.rollout.after on the MachineDeployments of the
+// Cluster, so all the Machines created before the current phase of the certificate authority rotation are replaced.
+func (r *KubeadmControlPlaneReconciler) rolloutMachineDeploymentsForCertificateAuthorityRotation(ctx context.Context, cluster *clusterv1.Cluster, rotationData *internal.CertificateAuthorityRotationData) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return errors.Wrap(err, "failed to list MachineDeployments")
+	}
+
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if !md.DeletionTimestamp.IsZero() || !md.Spec.Rollout.After.Before(&rotationData.PhaseStartTime) {
+			continue
+		}
+
+		original := md.DeepCopy()
+		md.Spec.Rollout.After = rotationData.PhaseStartTime
+		if err := r.Client.Patch(ctx, md, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "failed to patch MachineDeployment %s", klog.KObj(md))
+		}
+		log.Info(fmt.Sprintf("Triggered rollout of MachineDeployment %s for certificate authority rotation phase %s", klog.KObj(md), rotationData.Phase), "MachineDeployment", klog.KObj(md))
+	}
+	return nil
+}
+
+// machineNamesMessage returns a message listing the names of the given Machines, e.g. "Machines m1, m2".
+func machineNamesMessage(machines collections.Machines) string {
+	machineNames := machines.Names()
+	sort.Strings(machineNames)
+	message := "Machine"
+	if len(machineNames) > 1 {
+		message += "s"
+	}
+	return message + " " + clog.ListToString(machineNames, func(s string) string { return s }, 3)
+}
+
+func nextCertificateAuthorityRotationPhase(phase internal.CertificateAuthorityRotationPhase) internal.CertificateAuthorityRotationPhase {
+	switch phase {
+	case internal.CertificateAuthorityRotationTrustPhase:
+		return internal.CertificateAuthorityRotationSignPhase
+	case internal.CertificateAuthorityRotationSignPhase:
+		return internal.CertificateAuthorityRotationDropPhase
+	default:
+		return internal.CertificateAuthorityRotationCompletedPhase
+	}
+}
+
+func certificateAuthorityRotationPhaseDescription(phase internal.CertificateAuthorityRotationPhase) string {
+	switch phase {
+	case internal.CertificateAuthorityRotationTrustPhase:
+		return "Trusting new certificate authorities (phase 1 of 3)"
+	case internal.CertificateAuthorityRotationSignPhase:
+		return "Signing with new certificate authorities (phase 2 of 3)"
+	default:
+		return "Dropping old certificate authorities (phase 3 of 3)"
+	}
+}
+
+func setCertificateAuthorityRotating(kcp *controlplanev1.KubeadmControlPlane, phase internal.CertificateAuthorityRotationPhase, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+		Message: fmt.Sprintf("%s: %s", certificateAuthorityRotationPhaseDescription(phase), message),
+	})
+}
+
+func setCertificateAuthorityRotationBlocked(kcp *controlplanev1.KubeadmControlPlane, phase internal.CertificateAuthorityRotationPhase, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason,
+		Message: fmt.Sprintf("%s: %s", certificateAuthorityRotationPhaseDescription(phase), message),
+	})
+}
+
+func setCertificateAuthorityNotRotating(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityNotRotatingReason,
+		Message: message,
+	})
+}
+
+func setCertificateAuthorityRotationInternalError(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileCertificateAuthorityRotation(t *testing.T) {
+	t.Run("do nothing if rotation is not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		res, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(BeNil())
+		g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.CertificateAuthorityRotationAnnotation))
+	})
+	t.Run("do not start rotation scheduled in the future", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Spec.CertificateAuthorityRotation.After = metav1.NewTime(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC))
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		res, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneCertificateAuthorityNotRotatingReason))
+		g.Expect(condition.Message).To(Equal("Certificate authority rotation scheduled after 2100-01-01T00:00:00Z"))
+		g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.CertificateAuthorityRotationAnnotation))
+	})
+	t.Run("rotate certificate authorities", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.UID = "kcp-uid"
+		kcp.Spec.CertificateAuthorityRotation.After = metav1.NewTime(time.Now().Add(-1 * time.Minute))
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneInitializedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.KubeadmControlPlaneInitializedReason,
+		})
+		clusterKey := util.ObjectKey(cluster)
+		controllerRef := *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
+
+		md := &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      "md",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+			},
+		}
+		newMachine := func(name string, controlPlane bool, creationTimestamp time.Time) *clusterv1.Machine {
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         cluster.Namespace,
+					Name:              name,
+					Labels:            map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+					CreationTimestamp: metav1.NewTime(creationTimestamp),
+				},
+			}
+			if controlPlane {
+				m.Labels[clusterv1.MachineControlPlaneLabel] = ""
+			} else {
+				m.Labels[clusterv1.MachineDeploymentNameLabel] = md.Name
+			}
+			return m
+		}
+		fakeClient := newFakeClient(
+			md,
+			newMachine("cp", true, time.Now().Add(-1*time.Hour)),
+			newMachine("worker", false, time.Now().Add(-1*time.Hour)),
+		)
+
+		certificates := secret.NewCertificatesForInitialControlPlane(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.DeepCopy())
+		g.Expect(certificates.Generate()).To(Succeed())
+		g.Expect(certificates.SaveGenerated(ctx, fakeClient, clusterKey, controllerRef)).To(Succeed())
+		g.Expect(kubeconfig.CreateSecretWithOwner(ctx, fakeClient, clusterKey, "1.2.3.4:6443", controllerRef)).To(Succeed())
+		oldClusterCA := certificates.GetByPurpose(secret.ClusterCA).KeyPair
+
+		workloadCluster := &fakeWorkloadCluster{}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster}
+		controlPlane.InjectTestManagementCluster(&fakeManagementCluster{Workload: workloadCluster})
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		getClusterCA := func() *secret.Certificate {
+			certificates := secret.NewCertificatesForInitialControlPlane(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.DeepCopy())
+			g.Expect(certificates.Lookup(ctx, fakeClient, clusterKey)).To(Succeed())
+			return certificates.GetByPurpose(secret.ClusterCA)
+		}
+		getKubeconfigCACerts := func() int {
+			data, err := kubeconfig.FromSecret(ctx, fakeClient, clusterKey)
+			g.Expect(err).ToNot(HaveOccurred())
+			config, err := clientcmd.Load(data)
+			g.Expect(err).ToNot(HaveOccurred())
+			caCerts, err := certutil.ParseCertsPEM(config.Clusters[cluster.Name].CertificateAuthorityData)
+			g.Expect(err).ToNot(HaveOccurred())
+			return len(caCerts)
+		}
+		expectPhase := func(phase internal.CertificateAuthorityRotationPhase) *internal.CertificateAuthorityRotationData {
+			data, err := internal.CertificateAuthorityRotationDataFromAnnotation(kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(data).ToNot(BeNil())
+			g.Expect(data.Phase).To(Equal(phase))
+			return data
+		}
+		expectCondition := func(status metav1.ConditionStatus, reason, message string) {
+			condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(status))
+			g.Expect(condition.Reason).To(Equal(reason))
+			g.Expect(condition.Message).To(Equal(message))
+		}
+
+		// Start the rotation: the new certificate authority is trusted but not used for signing yet.
+		res, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		expectPhase(internal.CertificateAuthorityRotationTrustPhase)
+		expectCondition(metav1.ConditionTrue, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+			"Trusting new certificate authorities (phase 1 of 3): phase started")
+		clusterCA := getClusterCA()
+		g.Expect(clusterCA.IsRotating()).To(BeTrue())
+		g.Expect(clusterCA.KeyPair.Key).To(Equal(oldClusterCA.Key))
+		g.Expect(certutil.ParseCertsPEM(clusterCA.KeyPair.Cert)).To(HaveLen(2))
+		g.Expect(workloadCluster.clusterInfoCAData).To(Equal(clusterCA.KeyPair.Cert))
+		g.Expect(getKubeconfigCACerts()).To(Equal(2))
+		newClusterCA := clusterCA.Secret.Data[secret.TLSNextCrtDataName]
+
+		// Wait for all the Machines to be rolled out.
+		res, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		data := expectPhase(internal.CertificateAuthorityRotationTrustPhase)
+		expectCondition(metav1.ConditionTrue, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+			"Trusting new certificate authorities (phase 1 of 3): waiting for Machines cp, worker to be rolled out")
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+		g.Expect(md.Spec.Rollout.After).To(Equal(data.PhaseStartTime))
+
+		// Replace Machines; the next phases start immediately because there are no Machines created before them.
+		for _, name := range []string{"cp", "worker"} {
+			g.Expect(fakeClient.Delete(ctx, newMachine(name, false, time.Time{}))).To(Succeed())
+		}
+		g.Expect(fakeClient.Create(ctx, newMachine("new-cp", true, time.Now().Add(time.Hour)))).To(Succeed())
+
+		res, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		expectPhase(internal.CertificateAuthorityRotationSignPhase)
+		clusterCA = getClusterCA()
+		g.Expect(clusterCA.KeyPair.Key).To(Equal(clusterCA.Secret.Data[secret.TLSNextKeyDataName]))
+		g.Expect(certutil.ParseCertsPEM(clusterCA.KeyPair.Cert)).To(HaveLen(2))
+		g.Expect(workloadCluster.clusterInfoCAData).To(Equal(clusterCA.KeyPair.Cert))
+
+		res, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		expectPhase(internal.CertificateAuthorityRotationDropPhase)
+		expectCondition(metav1.ConditionTrue, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+			"Dropping old certificate authorities (phase 3 of 3): phase started")
+		clusterCA = getClusterCA()
+		g.Expect(clusterCA.IsRotating()).To(BeFalse())
+		g.Expect(clusterCA.KeyPair.Cert).To(Equal(newClusterCA))
+		g.Expect(workloadCluster.clusterInfoCAData).To(Equal(newClusterCA))
+		g.Expect(getKubeconfigCACerts()).To(Equal(1))
+
+		// Complete the rotation.
+		res, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		expectPhase(internal.CertificateAuthorityRotationCompletedPhase)
+		expectCondition(metav1.ConditionFalse, controlplanev1.KubeadmControlPlaneCertificateAuthorityNotRotatingReason, "")
+
+		// A new rotation is not started until spec.certificateAuthorityRotation.after is changed.
+		res, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		expectPhase(internal.CertificateAuthorityRotationCompletedPhase)
+		g.Expect(getClusterCA().KeyPair.Cert).To(Equal(newClusterCA))
+	})
+	t.Run("block rotation on Machines not rolled out by KCP or MachineDeployments", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.UID = "kcp-uid"
+		kcp.Spec.CertificateAuthorityRotation.After = metav1.NewTime(time.Now().Add(-1 * time.Minute))
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneInitializedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.KubeadmControlPlaneInitializedReason,
+		})
+		clusterKey := util.ObjectKey(cluster)
+		controllerRef := *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
+
+		newMachine := func(name string, labels map[string]string, creationTimestamp time.Time) *clusterv1.Machine {
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         cluster.Namespace,
+					Name:              name,
+					Labels:            map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+					CreationTimestamp: metav1.NewTime(creationTimestamp),
+				},
+			}
+			for k, v := range labels {
+				m.Labels[k] = v
+			}
+			return m
+		}
+		fakeClient := newFakeClient(
+			newMachine("cp", map[string]string{clusterv1.MachineControlPlaneLabel: ""}, time.Now().Add(-1*time.Hour)),
+			newMachine("mp-machine", map[string]string{clusterv1.MachinePoolNameLabel: "mp"}, time.Now().Add(-1*time.Hour)),
+			newMachine("standalone", nil, time.Now().Add(-1*time.Hour)),
+		)
+
+		certificates := secret.NewCertificatesForInitialControlPlane(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.DeepCopy())
+		g.Expect(certificates.Generate()).To(Succeed())
+		g.Expect(certificates.SaveGenerated(ctx, fakeClient, clusterKey, controllerRef)).To(Succeed())
+		g.Expect(kubeconfig.CreateSecretWithOwner(ctx, fakeClient, clusterKey, "1.2.3.4:6443", controllerRef)).To(Succeed())
+
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster}
+		controlPlane.InjectTestManagementCluster(&fakeManagementCluster{Workload: &fakeWorkloadCluster{}})
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+		expectCondition := func(reason, message string) {
+			condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal(reason))
+			g.Expect(condition.Message).To(Equal(message))
+		}
+		expectPhase := func(phase internal.CertificateAuthorityRotationPhase) {
+			data, err := internal.CertificateAuthorityRotationDataFromAnnotation(kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(data).ToNot(BeNil())
+			g.Expect(data.Phase).To(Equal(phase))
+		}
+
+		// Start the rotation.
+		_, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		expectPhase(internal.CertificateAuthorityRotationTrustPhase)
+
+		// The rotation is blocked by the Machines that KCP cannot roll out.
+		_, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		expectPhase(internal.CertificateAuthorityRotationTrustPhase)
+		expectCondition(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason,
+			"Trusting new certificate authorities (phase 1 of 3): Machines mp-machine, standalone must be replaced manually "+
+				"(not controlled by the KubeadmControlPlane or by a MachineDeployment); waiting for Machine cp to be rolled out")
+
+		// The rotation is still blocked after the control plane Machine has been rolled out.
+		g.Expect(fakeClient.Delete(ctx, newMachine("cp", nil, time.Time{}))).To(Succeed())
+		g.Expect(fakeClient.Create(ctx, newMachine("new-cp", map[string]string{clusterv1.MachineControlPlaneLabel: ""}, time.Now().Add(time.Hour)))).To(Succeed())
+		_, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		expectPhase(internal.CertificateAuthorityRotationTrustPhase)
+		expectCondition(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason,
+			"Trusting new certificate authorities (phase 1 of 3): Machines mp-machine, standalone must be replaced manually "+
+				"(not controlled by the KubeadmControlPlane or by a MachineDeployment)")
+
+		// The rotation moves to the next phase once the user replaced the remaining Machines.
+		for _, name := range []string{"mp-machine", "standalone"} {
+			g.Expect(fakeClient.Delete(ctx, newMachine(name, nil, time.Time{}))).To(Succeed())
+		}
+		_, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		expectPhase(internal.CertificateAuthorityRotationSignPhase)
+		expectCondition(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+			"Signing with new certificate authorities (phase 2 of 3): phase started")
+	})
+}
@@ -30,4 +30,12 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdDefragmentationRequeueAfter is how long to wait after an etcd member has been
+	// defragmented (or etcd leadership has been moved) before defragmenting the next member.
+	etcdDefragmentationRequeueAfter = 20 * time.Second
+
+	// certificateAuthorityRotationRequeueAfter is how long to wait before checking again the progress
+	// of a certificate authority rotation.
+	certificateAuthorityRotationRequeueAfter = 1 * time.Minute
 )
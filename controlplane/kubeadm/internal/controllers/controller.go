@@ -79,6 +79,7 @@ const (
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
@@ -95,6 +96,9 @@ type KubeadmControlPlaneReconciler struct {
 	EtcdCallTimeout time.Duration
 	EtcdLogger      *zap.Logger
 
+	// EtcdSnapshotDirectory is the directory used by the Directory sink to store etcd snapshots.
+	EtcdSnapshotDirectory string
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -369,6 +373,10 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.KubeadmControlPlaneScalingUpCondition,
 			controlplanev1.KubeadmControlPlaneScalingDownCondition,
 			controlplanev1.KubeadmControlPlaneRemediatingCondition,
+			controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+			controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+			controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition,
 			controlplanev1.KubeadmControlPlaneDeletingCondition,
 		}},
 	)
@@ -457,6 +465,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil // Explicitly requeue as we are not watching for changes to BootstrapConfig and InfraMachine objects.
 	}
 
+	// Restore etcd from an EtcdSnapshot if requested; this takes precedence over all the other operations
+	// because it replaces all the existing control plane Machines.
+	if result, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	v1beta1conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyV1Beta1Condition, controlPlane.Machines.ConditionGetters(), v1beta1conditions.AddSourceRef())
@@ -507,6 +521,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{}, nil // Note: Changes to Machines trigger another reconcile.
 	}
 
+	// Rotate certificate authorities if requested; this is done before computing Machines needing rollout,
+	// because every phase of the rotation requires all the Machines to be rolled out.
+	if result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	machinesNeedingRollout, machinesUpToDateResults := controlPlane.MachinesNeedingRollout()
 	switch {
@@ -560,6 +580,13 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return r.scaleDownControlPlane(ctx, controlPlane, machineToDelete)
 	}
 
+	// Defragment etcd members if required; this is done only when the control plane is stable, so it doesn't
+	// block e.g. MHC remediation and rollout of changes to recover the control plane, but before the following
+	// operations writing to the workload cluster, because those operations fail when etcd has a NOSPACE alarm.
+	if result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Get the workload cluster client.
 	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
 	if err != nil {
@@ -584,6 +611,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
+
+	// Requeue while a certificate authority rotation is in progress, because KCP is not notified when
+	// worker Machines are rolled out.
+	if conditions.IsTrue(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition) {
+		return ctrl.Result{RequeueAfter: certificateAuthorityRotationRequeueAfter}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileEtcdDefragmentation defragments etcd members if spec.etcdMaintenance.defragmentation is set and
+// the database size or the fragmentation of a member crosses the configured thresholds, or if a NOSPACE alarm
+// has been raised for a member.
+// The defragmentation is implemented as follows:
+//   - Only one member is defragmented at every reconcile, and only if all the etcd members are responsive.
+//   - Followers are defragmented first; if the leader must be defragmented, etcd leadership is moved to another member first.
+//   - After a member has been defragmented, NOSPACE alarms raised for the member are disarmed.
+//   - The same member is not defragmented again before spec.etcdMaintenance.defragmentation.minIntervalSeconds is expired.
+//
+// NOTE: This func is called only when the control plane is stable, i.e. when there are
+// no rollouts, scale up or scale down in progress.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	defragmentation := kcp.Spec.EtcdMaintenance.Defragmentation
+
+	if !controlPlane.IsEtcdManaged() || reflect.DeepEqual(defragmentation, controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{}) {
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)
+		delete(kcp.Annotations, controlplanev1.EtcdDefragmentationAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	// We cannot perform any etcd operation without a list of nodes, and without a consistent view of the etcd cluster.
+	if controlPlane.NodeListError != nil || !controlPlane.EtcdMembersAndMachinesAreMatching || controlPlane.HasDeletingMachine() {
+		setEtcdDefragmentationDeferred(kcp, "Waiting for etcd members and control plane Machines to match")
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		setEtcdDefragmentationInternalError(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to defragment etcd members: failed to create client to workload cluster")
+	}
+
+	statuses, err := workloadCluster.EtcdMembersStatus(ctx, controlPlane.Nodes)
+	if err != nil {
+		setEtcdDefragmentationInternalError(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to defragment etcd members: failed to get etcd members status")
+	}
+
+	// Defragmentation blocks the member being defragmented, so we should not defragment if another member
+	// is not responsive, because this could lead to the etcd cluster losing quorum.
+	for _, status := range statuses {
+		if !status.Responsive {
+			setEtcdDefragmentationDeferred(kcp, fmt.Sprintf("Waiting for etcd member %s to be responsive", status.Name))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	defragmentationData, err := etcdDefragmentationDataFromAnnotation(kcp)
+	if err != nil {
+		setEtcdDefragmentationInternalError(kcp)
+		return ctrl.Result{}, err
+	}
+
+	// Drop info about members that do not exist anymore.
+	for name := range defragmentationData.Members {
+		if !hasEtcdMemberStatus(statuses, name) {
+			delete(defragmentationData.Members, name)
+		}
+	}
+
+	// Compute the list of members to be defragmented.
+	reconciliationTime := time.Now()
+	minInterval := time.Duration(ptr.Deref(defragmentation.MinIntervalSeconds, controlplanev1.DefaultEtcdDefragmentationMinIntervalSeconds)) * time.Second
+	membersToDefragment := []*internal.EtcdMemberStatus{}
+	defragmentationReasons := map[string]string{}
+	for _, status := range statuses {
+		reason := etcdDefragmentationReason(status, defragmentation)
+		if reason == "" {
+			continue
+		}
+		if lastDefragmentation, ok := defragmentationData.Members[status.Name]; ok && lastDefragmentation.Add(minInterval).After(reconciliationTime) {
+			log.V(4).Info(fmt.Sprintf("Skipping defragmentation of etcd member %s (%s), it has been defragmented less than %s ago", status.Name, reason, minInterval))
+			continue
+		}
+		membersToDefragment = append(membersToDefragment, status)
+		defragmentationReasons[status.Name] = reason
+	}
+
+	if len(membersToDefragment) == 0 {
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.KubeadmControlPlaneEtcdNotDefragmentingReason,
+		})
+		return ctrl.Result{}, setEtcdDefragmentationData(kcp, defragmentationData)
+	}
+
+	// Defragment followers first, leader last.
+	sort.SliceStable(membersToDefragment, func(i, j int) bool {
+		if membersToDefragment[i].IsLeader != membersToDefragment[j].IsLeader {
+			return !membersToDefragment[i].IsLeader
+		}
+		return membersToDefragment[i].Name < membersToDefragment[j].Name
+	})
+	member := membersToDefragment[0]
+	reason := defragmentationReasons[member.Name]
+
+	// If the member to be defragmented is the leader, move leadership to another member first.
+	// Note: If there is only one member, it is not possible to move leadership, and the member is defragmented as it is.
+	if member.IsLeader && len(statuses) > 1 {
+		leaderMachine := etcdMemberMachine(controlPlane, member.Name)
+		if leaderMachine == nil {
+			setEtcdDefragmentationDeferred(kcp, fmt.Sprintf("Waiting for a Machine to exist for etcd member %s", member.Name))
+			return ctrl.Result{}, nil
+		}
+		etcdLeaderCandidate := controlPlane.Machines.Filter(func(m *clusterv1.Machine) bool {
+			return m.Name != leaderMachine.Name
+		}).Newest()
+		if err := workloadCluster.ForwardEtcdLeadership(ctx, leaderMachine, etcdLeaderCandidate, controlPlane.Nodes); err != nil {
+			setEtcdDefragmentationInternalError(kcp)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to move etcd leadership before defragmenting etcd member %s", member.Name)
+		}
+
+		log.Info(fmt.Sprintf("Moved etcd leadership from member %s to Machine %s before defragmentation", member.Name, klog.KObj(etcdLeaderCandidate)))
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+			Message: fmt.Sprintf("Moved etcd leadership away from member %s before defragmentation (%s)", member.Name, reason),
+		})
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	log.Info(fmt.Sprintf("Defragmenting etcd member %s (%s)", member.Name, reason))
+	if err := workloadCluster.DefragmentEtcdMember(ctx, member.Name); err != nil {
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdDefragmentation",
+			"Failed to defragment etcd member %s for cluster %s control plane: %v", member.Name, klog.KObj(controlPlane.Cluster), err)
+		setEtcdDefragmentationInternalError(kcp)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to defragment etcd member %s", member.Name)
+	}
+	defragmentationData.Members[member.Name] = metav1.NewTime(reconciliationTime)
+	if err := setEtcdDefragmentationData(kcp, defragmentationData); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Now that space has been reclaimed, disarm NOSPACE alarms for the member.
+	for _, alarmType := range member.Alarms {
+		if alarmType != etcd.AlarmNoSpace {
+			continue
+		}
+		alarm := etcd.MemberAlarm{MemberID: member.ID, Type: alarmType}
+		if err := workloadCluster.DisarmEtcdAlarm(ctx, alarm, controlPlane.Nodes); err != nil {
+			setEtcdDefragmentationInternalError(kcp)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to disarm %s alarm for etcd member %s", etcd.AlarmTypeName[alarmType], member.Name)
+		}
+		log.Info(fmt.Sprintf("Disarmed %s alarm for etcd member %s", etcd.AlarmTypeName[alarmType], member.Name))
+	}
+
+	message := fmt.Sprintf("Defragmented etcd member %s (%s)", member.Name, reason)
+	if len(membersToDefragment) > 1 {
+		message += fmt.Sprintf(", %d more etcd members to be defragmented", len(membersToDefragment)-1)
+	}
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+		Message: message,
+	})
+	return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+}
+
+// etcdDefragmentationReason returns the reason why an etcd member should be defragmented, if any.
+func etcdDefragmentationReason(status *internal.EtcdMemberStatus, defragmentation controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec) string {
+	for _, alarmType := range status.Alarms {
+		if alarmType == etcd.AlarmNoSpace {
+			return fmt.Sprintf("%s alarm raised", etcd.AlarmTypeName[alarmType])
+		}
+	}
+	if defragmentation.DBSizeThresholdBytes != nil && status.DBSize > *defragmentation.DBSizeThresholdBytes {
+		return fmt.Sprintf("database size %s above threshold %s",
+			resource.NewQuantity(status.DBSize, resource.BinarySI), resource.NewQuantity(*defragmentation.DBSizeThresholdBytes, resource.BinarySI))
+	}
+	if defragmentation.FragmentationThresholdPercent != nil && status.DBSize > 0 {
+		fragmentationPercent := (status.DBSize - status.DBSizeInUse) * 100 / status.DBSize
+		if fragmentationPercent > int64(*defragmentation.FragmentationThresholdPercent) {
+			return fmt.Sprintf("fragmentation %d%% above threshold %d%%", fragmentationPercent, *defragmentation.FragmentationThresholdPercent)
+		}
+	}
+	return ""
+}
+
+func hasEtcdMemberStatus(statuses []*internal.EtcdMemberStatus, name string) bool {
+	for _, status := range statuses {
+		if status.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// etcdMemberMachine returns the Machine hosting an etcd member.
+// Note: this relies on the assumption that node name is equal to the name of the corresponding etcd member.
+func etcdMemberMachine(controlPlane *internal.ControlPlane, memberName string) *clusterv1.Machine {
+	for _, machine := range controlPlane.Machines {
+		if machine.Status.NodeRef.IsDefined() && machine.Status.NodeRef.Name == memberName {
+			return machine
+		}
+	}
+	return nil
+}
+
+func setEtcdDefragmentationDeferred(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentationDeferredReason,
+		Message: message,
+	})
+}
+
+func setEtcdDefragmentationInternalError(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
+
+func setEtcdDefragmentationData(kcp *controlplanev1.KubeadmControlPlane, data *EtcdDefragmentationData) error {
+	if len(data.Members) == 0 {
+		delete(kcp.Annotations, controlplanev1.EtcdDefragmentationAnnotation)
+		return nil
+	}
+	value, err := data.Marshal()
+	if err != nil {
+		return err
+	}
+	annotations.AddAnnotations(kcp, map[string]string{
+		controlplanev1.EtcdDefragmentationAnnotation: value,
+	})
+	return nil
+}
+
+// EtcdDefragmentationData struct is used to keep track of information stored in the EtcdDefragmentationAnnotation in KCP.
+type EtcdDefragmentationData struct {
+	// members is a map of etcd member names to the time of their last defragmentation.
+	// Times are represented in RFC3339 form and are in UTC.
+	Members map[string]metav1.Time `json:"members"`
+}
+
+// etcdDefragmentationDataFromAnnotation gets EtcdDefragmentationData from the EtcdDefragmentationAnnotation in KCP.
+func etcdDefragmentationDataFromAnnotation(kcp *controlplanev1.KubeadmControlPlane) (*EtcdDefragmentationData, error) {
+	ret := &EtcdDefragmentationData{}
+	if value, ok := kcp.Annotations[controlplanev1.EtcdDefragmentationAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), ret); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal value %s for %s annotation", value, controlplanev1.EtcdDefragmentationAnnotation)
+		}
+	}
+	if ret.Members == nil {
+		ret.Members = map[string]metav1.Time{}
+	}
+	return ret, nil
+}
+
+// Marshal an EtcdDefragmentationData into an annotation value.
+func (d *EtcdDefragmentationData) Marshal() (string, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal value for %s annotation", controlplanev1.EtcdDefragmentationAnnotation)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdDefragmentation(t *testing.T) {
+	newMachine := func(name string, creationTimestamp time.Time) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(creationTimestamp),
+			},
+			Status: clusterv1.MachineStatus{
+				NodeRef: clusterv1.MachineNodeReference{Name: name},
+			},
+		}
+	}
+	now := time.Now()
+	machines := collections.FromMachines(
+		newMachine("m1", now.Add(-3*time.Hour)),
+		newMachine("m2", now.Add(-2*time.Hour)),
+		newMachine("m3", now.Add(-1*time.Hour)),
+	)
+	defragmentation := controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+		DBSizeThresholdBytes:          ptr.To[int64](1 << 30),
+		FragmentationThresholdPercent: ptr.To[int32](50),
+	}
+
+	tests := []struct {
+		name                    string
+		defragmentation         controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec
+		annotations             map[string]string
+		membersMatching         bool
+		statuses                []*internal.EtcdMemberStatus
+		wantRequeue             bool
+		wantStatus              metav1.ConditionStatus
+		wantReason              string
+		wantMessage             string
+		wantForwardLeadership   bool
+		wantDefragmentedMembers []string
+		wantDisarmedAlarms      []etcd.MemberAlarm
+		wantAnnotationMembers   []string
+	}{
+		{
+			name:            "do nothing if defragmentation is not configured",
+			membersMatching: true,
+		},
+		{
+			name:            "defer defragmentation if etcd members and machines are not matching",
+			defragmentation: defragmentation,
+			membersMatching: false,
+			wantStatus:      metav1.ConditionFalse,
+			wantReason:      controlplanev1.KubeadmControlPlaneEtcdDefragmentationDeferredReason,
+			wantMessage:     "Waiting for etcd members and control plane Machines to match",
+		},
+		{
+			name:            "defer defragmentation if an etcd member is not responsive",
+			defragmentation: defragmentation,
+			membersMatching: true,
+			statuses: []*internal.EtcdMemberStatus{
+				{Name: "m1", Responsive: true, ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 10},
+				{Name: "m2", Responsive: false},
+				{Name: "m3", Responsive: true, ID: 3, DBSize: 100, DBSizeInUse: 100},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentationDeferredReason,
+			wantMessage: "Waiting for etcd member m2 to be responsive",
+		},
+		{
+			name:            "no etcd members to defragment",
+			defragmentation: defragmentation,
+			membersMatching: true,
+			statuses: []*internal.EtcdMemberStatus{
+				{Name: "m1", Responsive: true, ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 90},
+				{Name: "m2", Responsive: true, ID: 2, DBSize: 100, DBSizeInUse: 90},
+				{Name: "m3", Responsive: true, ID: 3, DBSize: 100, DBSizeInUse: 90},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: controlplanev1.KubeadmControlPlaneEtcdNotDefragmentingReason,
+		},
+		{
+			name:            "defragment followers first and disarm NOSPACE alarms",
+			defragmentation: defragmentation,
+			membersMatching: true,
+			statuses: []*internal.EtcdMemberStatus{
+				{Name: "m1", Responsive: true, ID: 1, IsLeader: true, DBSize: 2 << 30, DBSizeInUse: 2 << 30},
+				{Name: "m2", Responsive: true, ID: 2, DBSize: 100, DBSizeInUse: 90},
+				{Name: "m3", Responsive: true, ID: 3, DBSize: 100, DBSizeInUse: 90, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+			},
+			wantRequeue:             true,
+			wantStatus:              metav1.ConditionTrue,
+			wantReason:              controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+			wantMessage:             "Defragmented etcd member m3 (NOSPACE alarm raised), 1 more etcd members to be defragmented",
+			wantDefragmentedMembers: []string{"m3"},
+			wantDisarmedAlarms:      []etcd.MemberAlarm{{MemberID: 3, Type: etcd.AlarmNoSpace}},
+			wantAnnotationMembers:   []string{"m3"},
+		},
+		{
+			name:            "move leadership before defragmenting the leader",
+			defragmentation: defragmentation,
+			annotations: map[string]string{
+				controlplanev1.EtcdDefragmentationAnnotation: `{"members":{"m3":"` + now.UTC().Format(time.RFC3339) + `"}}`,
+			},
+			membersMatching: true,
+			statuses: []*internal.EtcdMemberStatus{
+				{Name: "m1", Responsive: true, ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 10},
+				{Name: "m2", Responsive: true, ID: 2, DBSize: 100, DBSizeInUse: 90},
+				{Name: "m3", Responsive: true, ID: 3, DBSize: 100, DBSizeInUse: 10},
+			},
+			wantRequeue:           true,
+			wantStatus:            metav1.ConditionTrue,
+			wantReason:            controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+			wantMessage:           "Moved etcd leadership away from member m1 before defragmentation (fragmentation 90% above threshold 50%)",
+			wantForwardLeadership: true,
+			wantAnnotationMembers: []string{"m3"},
+		},
+		{
+			name:            "defragment members again after min interval is expired",
+			defragmentation: defragmentation,
+			annotations: map[string]string{
+				controlplanev1.EtcdDefragmentationAnnotation: `{"members":{"m4":"2020-01-01T00:00:00Z","m2":"2020-01-01T00:00:00Z"}}`,
+			},
+			membersMatching: true,
+			statuses: []*internal.EtcdMemberStatus{
+				{Name: "m1", Responsive: true, ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 90},
+				{Name: "m2", Responsive: true, ID: 2, DBSize: 2 << 30, DBSizeInUse: 2 << 30},
+				{Name: "m3", Responsive: true, ID: 3, DBSize: 100, DBSizeInUse: 90},
+			},
+			wantRequeue:             true,
+			wantStatus:              metav1.ConditionTrue,
+			wantReason:              controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+			wantMessage:             "Defragmented etcd member m2 (database size 2Gi above threshold 1Gi)",
+			wantDefragmentedMembers: []string{"m2"},
+			wantAnnotationMembers:   []string{"m2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+			kcp.Spec.EtcdMaintenance.Defragmentation = tt.defragmentation
+			kcp.Annotations = tt.annotations
+
+			workloadCluster := &fakeWorkloadCluster{EtcdMembersStatusResult: tt.statuses}
+			controlPlane := &internal.ControlPlane{
+				KCP:                               kcp,
+				Cluster:                           cluster,
+				Machines:                          machines,
+				EtcdMembersAndMachinesAreMatching: tt.membersMatching,
+			}
+			controlPlane.InjectTestManagementCluster(&fakeManagementCluster{Workload: workloadCluster})
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   newFakeClient(),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			res, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+
+			condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)
+			if tt.wantReason == "" {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(tt.wantStatus))
+				g.Expect(condition.Reason).To(Equal(tt.wantReason))
+				g.Expect(condition.Message).To(Equal(tt.wantMessage))
+			}
+
+			g.Expect(workloadCluster.forwardEtcdLeadershipCalled > 0).To(Equal(tt.wantForwardLeadership))
+			g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal(tt.wantDefragmentedMembers))
+			g.Expect(workloadCluster.disarmedEtcdAlarms).To(Equal(tt.wantDisarmedAlarms))
+
+			if len(tt.wantAnnotationMembers) == 0 {
+				g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.EtcdDefragmentationAnnotation))
+				return
+			}
+			data, err := etcdDefragmentationDataFromAnnotation(kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(data.Members).To(HaveLen(len(tt.wantAnnotationMembers)))
+			for _, name := range tt.wantAnnotationMembers {
+				g.Expect(data.Members).To(HaveKey(name))
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/desiredstate"
+	clientutil "sigs.k8s.io/cluster-api/internal/util/client"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileEtcdSnapshotRestore restores etcd from an EtcdSnapshot if requested via the
+// controlplane.cluster.x-k8s.io/restore-etcd-snapshot annotation.
+// The restore process is implemented as follows:
+//   - All the control plane Machines are deleted (skipping drain, wait for volume detach and the KCP pre-terminate hook).
+//   - A new Machine is created running kubeadm init; before running kubeadm init, the etcd snapshot is downloaded
+//     from the URL in the controlplane.cluster.x-k8s.io/restore-etcd-snapshot-url annotation and restored on the Machine.
+//   - Once the bootstrap data for the new Machine have been generated, the annotation is removed, and
+//     KCP goes back to the usual reconcile, e.g. scaling up to the desired number of replicas.
+//
+// If a non-zero result is returned, the reconcile must stop.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdSnapshotRestore(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	snapshotName, ok := kcp.Annotations[controlplanev1.RestoreEtcdSnapshotAnnotation]
+	if !ok {
+		if conditions.Has(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition) {
+			conditions.Set(kcp, metav1.Condition{
+				Type:   controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+				Status: metav1.ConditionFalse,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotNotRestoringReason,
+			})
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// If the Machine restoring etcd has already been created, complete the restore process.
+	for _, machine := range controlPlane.Machines {
+		kubeadmConfig, ok := controlPlane.KubeadmConfigs[machine.Name]
+		if !ok || kubeadmConfig.Annotations[bootstrapv1.KubeadmInitForEtcdRestoreAnnotation] != snapshotName {
+			continue
+		}
+		return r.completeEtcdSnapshotRestore(ctx, controlPlane, machine, kubeadmConfig)
+	}
+
+	snapshot, url, blockedMessage, err := r.getEtcdSnapshotForRestore(ctx, controlPlane, snapshotName)
+	if err != nil {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return ctrl.Result{}, err
+	}
+	if blockedMessage != "" {
+		// Note: If the restore cannot start, continue with the usual reconcile.
+		log.Info(fmt.Sprintf("Cannot restore etcd from EtcdSnapshot %s: %s", klog.KRef(kcp.Namespace, snapshotName), blockedMessage))
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason,
+			Message: blockedMessage,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	if len(controlPlane.Machines) > 0 {
+		return r.deleteMachinesForEtcdSnapshotRestore(ctx, controlPlane, snapshotName)
+	}
+	return r.createMachineForEtcdSnapshotRestore(ctx, controlPlane, snapshot, url)
+}
+
+// getEtcdSnapshotForRestore returns the EtcdSnapshot to be restored and the URL from where the Machine restoring etcd downloads it.
+// If the EtcdSnapshot cannot be restored, a message with the reason is returned.
+func (r *KubeadmControlPlaneReconciler) getEtcdSnapshotForRestore(ctx context.Context, controlPlane *internal.ControlPlane, snapshotName string) (*controlplanev1.EtcdSnapshot, string, string, error) {
+	if !controlPlane.IsEtcdManaged() {
+		return nil, "", "Restoring etcd is supported only for local etcd", nil
+	}
+
+	url := controlPlane.KCP.Annotations[controlplanev1.RestoreEtcdSnapshotURLAnnotation]
+	if url == "" {
+		return nil, "", fmt.Sprintf("Annotation %s must be set to the URL from where the Machine restoring etcd downloads the snapshot", controlplanev1.RestoreEtcdSnapshotURLAnnotation), nil
+	}
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "file://") {
+		return nil, "", fmt.Sprintf("Annotation %s must be an https:// or file:// URL", controlplanev1.RestoreEtcdSnapshotURLAnnotation), nil
+	}
+
+	snapshot := &controlplanev1.EtcdSnapshot{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: controlPlane.KCP.Namespace, Name: snapshotName}, snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", fmt.Sprintf("EtcdSnapshot %s does not exist", snapshotName), nil
+		}
+		return nil, "", "", errors.Wrapf(err, "failed to get EtcdSnapshot %s", klog.KRef(controlPlane.KCP.Namespace, snapshotName))
+	}
+
+	if snapshot.Spec.ClusterName != controlPlane.Cluster.Name {
+		return nil, "", fmt.Sprintf("EtcdSnapshot %s belongs to Cluster %s", snapshotName, snapshot.Spec.ClusterName), nil
+	}
+	if !conditions.IsTrue(snapshot, controlplanev1.EtcdSnapshotCompletedCondition) {
+		return nil, "", fmt.Sprintf("EtcdSnapshot %s is not completed", snapshotName), nil
+	}
+	if snapshot.Status.SHA256 == "" {
+		return nil, "", fmt.Sprintf("EtcdSnapshot %s does not have a checksum to verify the downloaded snapshot", snapshotName), nil
+	}
+	return snapshot, url, "", nil
+}
+
+// deleteMachinesForEtcdSnapshotRestore deletes all the control plane Machines.
+// Note: drain, wait for volume detach and the KCP pre-terminate hook are skipped, because they require
+// a working control plane, which is usually not the case when restoring etcd.
+func (r *KubeadmControlPlaneReconciler) deleteMachinesForEtcdSnapshotRestore(ctx context.Context, controlPlane *internal.ControlPlane, snapshotName string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, machine := range controlPlane.Machines {
+		_, excludeDrain := machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]
+		_, excludeVolumeDetach := machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation]
+		_, hasPreTerminateHook := machine.Annotations[controlplanev1.PreTerminateHookCleanupAnnotation]
+		if !excludeDrain || !excludeVolumeDetach || hasPreTerminateHook {
+			original := machine.DeepCopy()
+			if machine.Annotations == nil {
+				machine.Annotations = map[string]string{}
+			}
+			machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation] = ""
+			machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation] = ""
+			delete(machine.Annotations, controlplanev1.PreTerminateHookCleanupAnnotation)
+			if err := r.Client.Patch(ctx, machine, client.MergeFrom(original)); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to patch Machine %s", klog.KObj(machine))
+			}
+		}
+
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete Machine %s", klog.KObj(machine))
+		}
+		log.Info(fmt.Sprintf("Deleting Machine %s to restore etcd from EtcdSnapshot %s", klog.KObj(machine), snapshotName), "Machine", klog.KObj(machine))
+	}
+
+	conditions.Set(controlPlane.KCP, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason,
+		Message: fmt.Sprintf("Deleting control plane Machines before restoring etcd from EtcdSnapshot %s", snapshotName),
+	})
+	return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+}
+
+// createMachineForEtcdSnapshotRestore creates the Machine that initializes the control plane using the data from the EtcdSnapshot.
+func (r *KubeadmControlPlaneReconciler) createMachineForEtcdSnapshotRestore(ctx context.Context, controlPlane *internal.ControlPlane, snapshot *controlplanev1.EtcdSnapshot, url string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var kubeadmConfig *bootstrapv1.KubeadmConfig
+	newMachine, err := r.generateMachine(ctx, controlPlane.Cluster, kcp, false, fd, func(c *bootstrapv1.KubeadmConfig) {
+		desiredstate.SetEtcdRestore(c, snapshot.Name, url, snapshot.Status.SHA256)
+		kubeadmConfig = c
+	})
+	if err != nil {
+		log.Error(err, "Failed to create control plane Machine to restore etcd")
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdRestore", "Failed to create control plane Machine to restore etcd for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the KubeadmConfig to be in the cache, so the next reconcile can identify the Machine restoring etcd.
+	if err := clientutil.WaitForObjectsToBeAddedToTheCache(ctx, r.Client, "KubeadmConfig creation", kubeadmConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Info(fmt.Sprintf("Machine %s created (etcd restore)", klog.KObj(newMachine)),
+		"Machine", klog.KObj(newMachine), "EtcdSnapshot", klog.KObj(snapshot))
+
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason,
+		Message: fmt.Sprintf("Waiting for bootstrap data for Machine %s restoring etcd from EtcdSnapshot %s", newMachine.Name, snapshot.Name),
+	})
+	return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+}
+
+// completeEtcdSnapshotRestore waits for the bootstrap data of the Machine restoring etcd to be generated, and then
+// it cleans up the restore annotations.
+func (r *KubeadmControlPlaneReconciler) completeEtcdSnapshotRestore(ctx context.Context, controlPlane *internal.ControlPlane, machine *clusterv1.Machine, kubeadmConfig *bootstrapv1.KubeadmConfig) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	snapshotName := kcp.Annotations[controlplanev1.RestoreEtcdSnapshotAnnotation]
+
+	if machine.Spec.Bootstrap.DataSecretName == nil {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason,
+			Message: fmt.Sprintf("Waiting for bootstrap data for Machine %s restoring etcd from EtcdSnapshot %s", machine.Name, snapshotName),
+		})
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+	}
+
+	original := kubeadmConfig.DeepCopy()
+	delete(kubeadmConfig.Annotations, bootstrapv1.KubeadmInitForEtcdRestoreAnnotation)
+	if err := r.Client.Patch(ctx, kubeadmConfig, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to patch KubeadmConfig %s", klog.KObj(kubeadmConfig))
+	}
+
+	// Note: The change to KCP annotations is persisted when patching KCP at the end of the reconcile.
+	delete(kcp.Annotations, controlplanev1.RestoreEtcdSnapshotAnnotation)
+	delete(kcp.Annotations, controlplanev1.RestoreEtcdSnapshotURLAnnotation)
+	log.Info(fmt.Sprintf("Bootstrap data for Machine %s restoring etcd from EtcdSnapshot %s generated", klog.KObj(machine), snapshotName), "Machine", klog.KObj(machine))
+
+	conditions.Set(kcp, metav1.Condition{
+		Type:   controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotNotRestoringReason,
+	})
+	return ctrl.Result{}, nil
+}
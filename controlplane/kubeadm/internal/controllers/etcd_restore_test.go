@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+const testSnapshotSHA256 = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestReconcileEtcdSnapshotRestore(t *testing.T) {
+	newSnapshot := func(completed bool) *controlplanev1.EtcdSnapshot {
+		s := &controlplanev1.EtcdSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "snapshot", UID: "snapshot-uid"},
+			Spec: controlplanev1.EtcdSnapshotSpec{
+				Sink: controlplanev1.EtcdSnapshotSink{Type: controlplanev1.EtcdSnapshotSecretSinkType},
+			},
+		}
+		if completed {
+			s.Status.SizeBytes = ptr.To(int64(8))
+			s.Status.SHA256 = testSnapshotSHA256
+			s.Status.Conditions = []metav1.Condition{
+				{Type: controlplanev1.EtcdSnapshotCompletedCondition, Status: metav1.ConditionTrue},
+			}
+		}
+		return s
+	}
+	newMachine := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Annotations: map[string]string{
+					controlplanev1.PreTerminateHookCleanupAnnotation: "",
+				},
+			},
+		}
+	}
+
+	t.Run("set the condition to NotRestoring if there is no restore annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason,
+		})
+
+		r := &KubeadmControlPlaneReconciler{Client: newFakeClient()}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.Machines{}}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotNotRestoringReason))
+	})
+
+	t.Run("block the restore if the EtcdSnapshot is not completed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "https://storage.example.com/snapshot.db.gz",
+		}
+		snapshot := newSnapshot(false)
+		snapshot.Spec.ClusterName = cluster.Name
+		machine := newMachine("m1")
+
+		fakeClient := newFakeClient(snapshot, machine)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.FromMachines(machine)}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason))
+		g.Expect(condition.Message).To(Equal("EtcdSnapshot snapshot is not completed"))
+
+		// Machines must not be deleted.
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})).To(Succeed())
+	})
+
+	t.Run("block the restore if the URL of the snapshot is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{controlplanev1.RestoreEtcdSnapshotAnnotation: "snapshot"}
+		snapshot := newSnapshot(true)
+		snapshot.Spec.ClusterName = cluster.Name
+		machine := newMachine("m1")
+
+		fakeClient := newFakeClient(snapshot, machine)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.FromMachines(machine)}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason))
+		g.Expect(condition.Message).To(Equal("Annotation controlplane.cluster.x-k8s.io/restore-etcd-snapshot-url must be set to the URL from where the Machine restoring etcd downloads the snapshot"))
+
+		// Machines must not be deleted.
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})).To(Succeed())
+	})
+
+	t.Run("block the restore if the URL of the snapshot is not https:// or file://", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "http://storage.example.com/snapshot.db.gz",
+		}
+		snapshot := newSnapshot(true)
+		snapshot.Spec.ClusterName = cluster.Name
+		machine := newMachine("m1")
+
+		fakeClient := newFakeClient(snapshot, machine)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.FromMachines(machine)}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason))
+		g.Expect(condition.Message).To(Equal("Annotation controlplane.cluster.x-k8s.io/restore-etcd-snapshot-url must be an https:// or file:// URL"))
+
+		// Machines must not be deleted.
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})).To(Succeed())
+	})
+
+	t.Run("block the restore if the EtcdSnapshot does not have a checksum", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "https://storage.example.com/snapshot.db.gz",
+		}
+		snapshot := newSnapshot(true)
+		snapshot.Spec.ClusterName = cluster.Name
+		snapshot.Status.SHA256 = ""
+		machine := newMachine("m1")
+
+		fakeClient := newFakeClient(snapshot, machine)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.FromMachines(machine)}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoreBlockedReason))
+		g.Expect(condition.Message).To(Equal("EtcdSnapshot snapshot does not have a checksum to verify the downloaded snapshot"))
+
+		// Machines must not be deleted.
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})).To(Succeed())
+	})
+
+	t.Run("delete control plane Machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "https://storage.example.com/snapshot.db.gz",
+		}
+		snapshot := newSnapshot(true)
+		snapshot.Spec.ClusterName = cluster.Name
+		m1 := newMachine("m1")
+		m1.Finalizers = []string{clusterv1.MachineFinalizer}
+		m2 := newMachine("m2")
+		m2.Finalizers = []string{clusterv1.MachineFinalizer}
+
+		fakeClient := newFakeClient(snapshot, m1, m2)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.FromMachines(m1, m2)}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+
+		for _, m := range []*clusterv1.Machine{m1, m2} {
+			machine := &clusterv1.Machine{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(m), machine)).To(Succeed())
+			g.Expect(machine.DeletionTimestamp.IsZero()).To(BeFalse())
+			g.Expect(machine.Annotations).To(HaveKey(clusterv1.ExcludeNodeDrainingAnnotation))
+			g.Expect(machine.Annotations).To(HaveKey(clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation))
+			g.Expect(machine.Annotations).ToNot(HaveKey(controlplanev1.PreTerminateHookCleanupAnnotation))
+		}
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason))
+	})
+
+	t.Run("create the Machine restoring etcd", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, tmpl := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.UID = "kcp-uid"
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "file:///mnt/backup/snapshot.db.gz",
+		}
+		snapshot := newSnapshot(true)
+		snapshot.Spec.ClusterName = cluster.Name
+		// Note: the size of the snapshot is not limited, because the snapshot is downloaded by the Machine restoring etcd.
+		snapshot.Status.SizeBytes = ptr.To(int64(2 * 1024 * 1024 * 1024))
+
+		fakeClient := newFakeClient(builder.GenericInfrastructureMachineTemplateCRD, cluster.DeepCopy(), kcp.DeepCopy(), tmpl.DeepCopy(), snapshot)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:              fakeClient,
+			SecretCachingClient: fakeClient,
+			recorder:            record.NewFakeRecorder(32),
+			disableRemoveManagedFieldsForLabelsAndAnnotations: true,
+		}
+		controlPlane := &internal.ControlPlane{KCP: kcp, Cluster: cluster, Machines: collections.Machines{}}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeFalse())
+
+		machineList := &clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(cluster.Namespace))).To(Succeed())
+		g.Expect(machineList.Items).To(HaveLen(1))
+
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: machineList.Items[0].Spec.Bootstrap.ConfigRef.Name}, kubeadmConfig)).To(Succeed())
+		g.Expect(kubeadmConfig.Annotations).To(HaveKeyWithValue(bootstrapv1.KubeadmInitForEtcdRestoreAnnotation, "snapshot"))
+		g.Expect(kubeadmConfig.Spec.JoinConfiguration.IsDefined()).To(BeFalse())
+		g.Expect(kubeadmConfig.Spec.Files).To(HaveLen(3))
+		g.Expect(kubeadmConfig.Spec.Files[0].Content).To(Equal("file:///mnt/backup/snapshot.db.gz"))
+		g.Expect(kubeadmConfig.Spec.Files[1].Content).To(Equal(testSnapshotSHA256))
+		g.Expect(kubeadmConfig.Spec.PreKubeadmCommands).To(HaveLen(1))
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringReason))
+	})
+
+	t.Run("complete the restore once bootstrap data have been generated", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Annotations = map[string]string{
+			controlplanev1.RestoreEtcdSnapshotAnnotation:    "snapshot",
+			controlplanev1.RestoreEtcdSnapshotURLAnnotation: "https://storage.example.com/snapshot.db.gz",
+		}
+		machine := newMachine("m1")
+		machine.Spec.Bootstrap.DataSecretName = ptr.To("m1")
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "m1",
+				Annotations: map[string]string{
+					bootstrapv1.KubeadmInitForEtcdRestoreAnnotation: "snapshot",
+				},
+			},
+		}
+		fakeClient := newFakeClient(machine, kubeadmConfig)
+		r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+		controlPlane := &internal.ControlPlane{
+			KCP:            kcp,
+			Cluster:        cluster,
+			Machines:       collections.FromMachines(machine),
+			KubeadmConfigs: map[string]*bootstrapv1.KubeadmConfig{"m1": kubeadmConfig},
+		}
+
+		res, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+
+		g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.RestoreEtcdSnapshotAnnotation))
+		g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.RestoreEtcdSnapshotURLAnnotation))
+
+		gotKubeadmConfig := &bootstrapv1.KubeadmConfig{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kubeadmConfig), gotKubeadmConfig)).To(Succeed())
+		g.Expect(gotKubeadmConfig.Annotations).ToNot(HaveKey(bootstrapv1.KubeadmInitForEtcdRestoreAnnotation))
+
+		condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotRestoringCondition)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotNotRestoringReason))
+	})
+}
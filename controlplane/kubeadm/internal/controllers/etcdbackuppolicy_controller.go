@@ -0,0 +1,336 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// EtcdBackupPolicyReconciler reconciles an EtcdBackupPolicy object.
+type EtcdBackupPolicyReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	// Only used for testing.
+	overrideNow func() time.Time
+}
+
+func (r *EtcdBackupPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Client == nil {
+		return errors.New("Client must not be nil")
+	}
+
+	clusterToEtcdBackupPolicies, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &controlplanev1.EtcdBackupPolicyList{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "etcdbackuppolicy")
+	_, err = capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&controlplanev1.EtcdBackupPolicy{}).
+		Owns(&controlplanev1.EtcdSnapshot{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToEtcdBackupPolicies),
+			predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue),
+			predicates.Any(mgr.GetScheme(), predicateLog,
+				predicates.ClusterPausedTransitions(mgr.GetScheme(), predicateLog),
+				predicates.ClusterControlPlaneInitialized(mgr.GetScheme(), predicateLog),
+			),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+	return nil
+}
+
+func (r *EtcdBackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the EtcdBackupPolicy instance.
+	policy := &controlplanev1.EtcdBackupPolicy{}
+	if err := r.Client.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. EtcdSnapshots owned by the policy are automatically garbage collected.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Nothing to do if the EtcdBackupPolicy is being deleted.
+	if !policy.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("Cluster", klog.KRef(policy.Namespace, policy.Spec.ClusterName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, policy.Namespace, policy.Spec.ClusterName)
+	if err != nil {
+		log.Error(err, "Failed to fetch Cluster for EtcdBackupPolicy")
+		return ctrl.Result{}, err
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(policy, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, policy); err != nil || isPaused || requeue {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		// Patch ObservedGeneration only if the reconciliation completed successfully
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []string{
+				clusterv1.PausedCondition,
+				controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+			}},
+		}
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, policy, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Reconcile labels.
+	if policy.Labels == nil {
+		policy.Labels = make(map[string]string)
+	}
+	policy.Labels[clusterv1.ClusterNameLabel] = policy.Spec.ClusterName
+
+	// Ensure the EtcdBackupPolicy is owned by the Cluster it belongs to.
+	policy.SetOwnerReferences(util.EnsureOwnerRef(policy.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}))
+
+	return r.reconcile(ctx, cluster, policy)
+}
+
+func (r *EtcdBackupPolicyReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, policy *controlplanev1.EtcdBackupPolicy) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	snapshots, err := r.getSnapshotsForPolicy(ctx, policy)
+	if err != nil {
+		conditions.Set(policy, metav1.Condition{
+			Type:    controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  controlplanev1.EtcdBackupPolicyLastSnapshotInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return ctrl.Result{}, err
+	}
+
+	// Delete EtcdSnapshots exceeding the retention policy.
+	snapshots, err = r.deleteSnapshotsExceedingRetention(ctx, policy, snapshots)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	setLastSnapshotCompletedCondition(policy, snapshots)
+
+	// Snapshots can only be taken once the control plane is initialized; a new reconcile
+	// is triggered when the Cluster's ControlPlaneInitialized condition becomes true.
+	if !conditions.IsTrue(cluster, clusterv1.ClusterControlPlaneInitializedCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	now := r.now()
+	interval := time.Duration(policy.Spec.IntervalSeconds) * time.Second
+	if len(snapshots) > 0 {
+		if next := snapshots[0].CreationTimestamp.Add(interval); now.Before(next) {
+			return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	snapshot := &controlplanev1.EtcdSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: policy.Namespace,
+			Name:      fmt.Sprintf("%s-%s", policy.Name, now.UTC().Format("20060102150405")),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:               policy.Spec.ClusterName,
+				controlplanev1.EtcdBackupPolicyNameLabel: format.MustFormatValue(policy.Name),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(policy, controlplanev1.GroupVersion.WithKind("EtcdBackupPolicy")),
+			},
+		},
+		Spec: controlplanev1.EtcdSnapshotSpec{
+			ClusterName: policy.Spec.ClusterName,
+			Sink:        policy.Spec.Sink,
+		},
+	}
+	if err := r.Client.Create(ctx, snapshot); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create EtcdSnapshot %s", klog.KObj(snapshot))
+	}
+	log.Info(fmt.Sprintf("Created EtcdSnapshot %s", snapshot.Name), "EtcdSnapshot", klog.KObj(snapshot))
+
+	policy.Status.LastSnapshotName = snapshot.Name
+	policy.Status.LastSnapshotTime = metav1.NewTime(now)
+	conditions.Set(policy, metav1.Condition{
+		Type:    controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.EtcdBackupPolicyLastSnapshotNotCompletedReason,
+		Message: fmt.Sprintf("Waiting for EtcdSnapshot %s to be completed", snapshot.Name),
+	})
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// getSnapshotsForPolicy returns the EtcdSnapshots created by an EtcdBackupPolicy, sorted from the newest to the oldest.
+func (r *EtcdBackupPolicyReconciler) getSnapshotsForPolicy(ctx context.Context, policy *controlplanev1.EtcdBackupPolicy) ([]*controlplanev1.EtcdSnapshot, error) {
+	snapshotList := &controlplanev1.EtcdSnapshotList{}
+	if err := r.Client.List(ctx, snapshotList,
+		client.InNamespace(policy.Namespace),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel:               policy.Spec.ClusterName,
+			controlplanev1.EtcdBackupPolicyNameLabel: format.MustFormatValue(policy.Name),
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to list EtcdSnapshots")
+	}
+
+	snapshots := make([]*controlplanev1.EtcdSnapshot, 0, len(snapshotList.Items))
+	for i := range snapshotList.Items {
+		snapshot := &snapshotList.Items[i]
+		// Only consider EtcdSnapshots controlled by the policy, because label values might be hashed.
+		if !metav1.IsControlledBy(snapshot, policy) {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if !snapshots[i].CreationTimestamp.Equal(&snapshots[j].CreationTimestamp) {
+			return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+		}
+		return snapshots[i].Name > snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+// deleteSnapshotsExceedingRetention deletes EtcdSnapshots exceeding the retention policy, and returns the remaining ones.
+// The most recent EtcdSnapshot is always retained, while older EtcdSnapshots are retained only if they are completed
+// and the number of completed EtcdSnapshots does not exceed maxSnapshots.
+func (r *EtcdBackupPolicyReconciler) deleteSnapshotsExceedingRetention(ctx context.Context, policy *controlplanev1.EtcdBackupPolicy, snapshots []*controlplanev1.EtcdSnapshot) ([]*controlplanev1.EtcdSnapshot, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	maxSnapshots := int(ptr.Deref(policy.Spec.MaxSnapshots, controlplanev1.DefaultEtcdBackupPolicyMaxSnapshots))
+
+	retained := make([]*controlplanev1.EtcdSnapshot, 0, len(snapshots))
+	completed := 0
+	var errs []error
+	for i, snapshot := range snapshots {
+		if !snapshot.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		isCompleted := conditions.IsTrue(snapshot, controlplanev1.EtcdSnapshotCompletedCondition)
+		if i == 0 || (isCompleted && completed < maxSnapshots) {
+			if isCompleted {
+				completed++
+			}
+			retained = append(retained, snapshot)
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, snapshot); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete EtcdSnapshot %s", klog.KObj(snapshot)))
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleted EtcdSnapshot %s exceeding the retention policy", snapshot.Name), "EtcdSnapshot", klog.KObj(snapshot))
+	}
+	return retained, kerrors.NewAggregate(errs)
+}
+
+func setLastSnapshotCompletedCondition(policy *controlplanev1.EtcdBackupPolicy, snapshots []*controlplanev1.EtcdSnapshot) {
+	if len(snapshots) == 0 {
+		conditions.Set(policy, metav1.Condition{
+			Type:   controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.EtcdBackupPolicyNoSnapshotsReason,
+		})
+		return
+	}
+
+	last := snapshots[0]
+	policy.Status.LastSnapshotName = last.Name
+	policy.Status.LastSnapshotTime = last.CreationTimestamp
+
+	if conditions.IsTrue(last, controlplanev1.EtcdSnapshotCompletedCondition) {
+		conditions.Set(policy, metav1.Condition{
+			Type:   controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.EtcdBackupPolicyLastSnapshotCompletedReason,
+		})
+		return
+	}
+
+	message := fmt.Sprintf("Waiting for EtcdSnapshot %s to be completed", last.Name)
+	if c := conditions.Get(last, controlplanev1.EtcdSnapshotCompletedCondition); c != nil && c.Message != "" {
+		message = fmt.Sprintf("EtcdSnapshot %s is not completed: %s", last.Name, c.Message)
+	}
+	conditions.Set(policy, metav1.Condition{
+		Type:    controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.EtcdBackupPolicyLastSnapshotNotCompletedReason,
+		Message: message,
+	})
+}
+
+func (r *EtcdBackupPolicyReconciler) now() time.Time {
+	if r.overrideNow != nil {
+		return r.overrideNow()
+	}
+	return time.Now()
+}
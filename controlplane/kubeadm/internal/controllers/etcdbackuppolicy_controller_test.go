@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestEtcdBackupPolicyReconciler_reconcile(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"},
+		Status: clusterv1.ClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	policy := &controlplanev1.EtcdBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "policy", UID: "policy-uid"},
+		Spec: controlplanev1.EtcdBackupPolicySpec{
+			ClusterName:     "cluster",
+			IntervalSeconds: 3600,
+			MaxSnapshots:    ptr.To(int32(2)),
+			Sink:            controlplanev1.EtcdSnapshotSink{Type: controlplanev1.EtcdSnapshotSecretSinkType},
+		},
+	}
+
+	snapshot := func(name string, created time.Time, completed bool) *controlplanev1.EtcdSnapshot {
+		s := &controlplanev1.EtcdSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:               "cluster",
+					controlplanev1.EtcdBackupPolicyNameLabel: "policy",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(policy, controlplanev1.GroupVersion.WithKind("EtcdBackupPolicy")),
+				},
+			},
+		}
+		if completed {
+			s.Status.Conditions = []metav1.Condition{
+				{Type: controlplanev1.EtcdSnapshotCompletedCondition, Status: metav1.ConditionTrue},
+			}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name              string
+		cluster           *clusterv1.Cluster
+		snapshots         []client.Object
+		wantRequeueAfter  time.Duration
+		wantSnapshotNames []string
+		wantReason        string
+	}{
+		{
+			name:              "do not create snapshots if the control plane is not initialized",
+			cluster:           &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"}},
+			wantSnapshotNames: []string{},
+			wantReason:        controlplanev1.EtcdBackupPolicyNoSnapshotsReason,
+		},
+		{
+			name:              "create the first snapshot",
+			cluster:           cluster,
+			wantRequeueAfter:  time.Hour,
+			wantSnapshotNames: []string{"policy-20250101100000"},
+			wantReason:        controlplanev1.EtcdBackupPolicyLastSnapshotNotCompletedReason,
+		},
+		{
+			name:    "wait for the interval to elapse",
+			cluster: cluster,
+			snapshots: []client.Object{
+				snapshot("policy-20250101093000", now.Add(-30*time.Minute), true),
+			},
+			wantRequeueAfter:  30 * time.Minute,
+			wantSnapshotNames: []string{"policy-20250101093000"},
+			wantReason:        controlplanev1.EtcdBackupPolicyLastSnapshotCompletedReason,
+		},
+		{
+			name:    "delete snapshots exceeding the retention policy and create a new snapshot",
+			cluster: cluster,
+			snapshots: []client.Object{
+				snapshot("policy-20250101090000", now.Add(-1*time.Hour), true),
+				snapshot("policy-20250101080000", now.Add(-2*time.Hour), false),
+				snapshot("policy-20250101070000", now.Add(-3*time.Hour), true),
+				snapshot("policy-20250101060000", now.Add(-4*time.Hour), true),
+			},
+			wantRequeueAfter:  time.Hour,
+			wantSnapshotNames: []string{"policy-20250101070000", "policy-20250101090000", "policy-20250101100000"},
+			wantReason:        controlplanev1.EtcdBackupPolicyLastSnapshotNotCompletedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(tt.snapshots...).Build()
+			r := &EtcdBackupPolicyReconciler{
+				Client:      c,
+				overrideNow: func() time.Time { return now },
+			}
+
+			p := policy.DeepCopy()
+			res, err := r.reconcile(ctx, tt.cluster, p)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter).To(Equal(tt.wantRequeueAfter))
+
+			snapshotList := &controlplanev1.EtcdSnapshotList{}
+			g.Expect(c.List(ctx, snapshotList)).To(Succeed())
+			names := []string{}
+			for _, s := range snapshotList.Items {
+				names = append(names, s.Name)
+			}
+			g.Expect(names).To(ConsistOf(tt.wantSnapshotNames))
+
+			condition := conditions.Get(p, controlplanev1.EtcdBackupPolicyLastSnapshotCompletedCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+		})
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcdsnapshot"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/cache"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/finalizers"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// EtcdSnapshotReconciler reconciles an EtcdSnapshot object.
+type EtcdSnapshotReconciler struct {
+	Client              client.Client
+	SecretCachingClient client.Client
+	ClusterCache        clustercache.ClusterCache
+
+	EtcdDialTimeout time.Duration
+	EtcdCallTimeout time.Duration
+	EtcdLogger      *zap.Logger
+
+	// EtcdSnapshotDirectory is the root directory used by the Directory sink.
+	EtcdSnapshotDirectory string
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	managementCluster internal.ManagementCluster
+}
+
+func (r *EtcdSnapshotReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Client == nil || r.SecretCachingClient == nil || r.ClusterCache == nil ||
+		r.EtcdDialTimeout == time.Duration(0) || r.EtcdCallTimeout == time.Duration(0) {
+		return errors.New("Client, SecretCachingClient and ClusterCache must not be nil and " +
+			"EtcdDialTimeout and EtcdCallTimeout must not be 0")
+	}
+
+	clusterToEtcdSnapshots, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &controlplanev1.EtcdSnapshotList{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "etcdsnapshot")
+	_, err = capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&controlplanev1.EtcdSnapshot{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToEtcdSnapshots),
+			predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue),
+			predicates.Any(mgr.GetScheme(), predicateLog,
+				predicates.ClusterPausedTransitions(mgr.GetScheme(), predicateLog),
+				predicates.ClusterControlPlaneInitialized(mgr.GetScheme(), predicateLog),
+			),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	if r.managementCluster == nil {
+		r.managementCluster = &internal.Management{
+			Client:              r.Client,
+			SecretCachingClient: r.SecretCachingClient,
+			ClusterCache:        r.ClusterCache,
+			EtcdDialTimeout:     r.EtcdDialTimeout,
+			EtcdCallTimeout:     r.EtcdCallTimeout,
+			EtcdLogger:          r.EtcdLogger,
+			ClientCertCache:     cache.New[internal.ClientCertEntry](24 * time.Hour),
+		}
+	}
+
+	return nil
+}
+
+func (r *EtcdSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the EtcdSnapshot instance.
+	snapshot := &controlplanev1.EtcdSnapshot{}
+	if err := r.Client.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	log = log.WithValues("Cluster", klog.KRef(snapshot.Namespace, snapshot.Spec.ClusterName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// Add finalizer first if not set to avoid the race condition between init and delete.
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, snapshot, controlplanev1.EtcdSnapshotFinalizer); err != nil || finalizerAdded {
+		return ctrl.Result{}, err
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(snapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Handle deletion reconciliation loop.
+	// Note: Deletion does not depend on the Cluster, because the Cluster might be already gone.
+	if !snapshot.DeletionTimestamp.IsZero() {
+		if err := r.reconcileDelete(ctx, snapshot); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, patchHelper.Patch(ctx, snapshot)
+	}
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, snapshot.Namespace, snapshot.Spec.ClusterName)
+	if err != nil {
+		log.Error(err, "Failed to fetch Cluster for EtcdSnapshot")
+		return ctrl.Result{}, err
+	}
+
+	if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, snapshot); err != nil || isPaused || requeue {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		// Patch ObservedGeneration only if the reconciliation completed successfully
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []string{
+				clusterv1.PausedCondition,
+				controlplanev1.EtcdSnapshotCompletedCondition,
+			}},
+		}
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, snapshot, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Reconcile labels.
+	if snapshot.Labels == nil {
+		snapshot.Labels = make(map[string]string)
+	}
+	snapshot.Labels[clusterv1.ClusterNameLabel] = snapshot.Spec.ClusterName
+
+	// Ensure the EtcdSnapshot is owned by the Cluster it belongs to.
+	snapshot.SetOwnerReferences(util.EnsureOwnerRef(snapshot.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}))
+
+	return ctrl.Result{}, r.reconcile(ctx, cluster, snapshot)
+}
+
+func (r *EtcdSnapshotReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, snapshot *controlplanev1.EtcdSnapshot) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// A snapshot is taken only once, nothing to do if the snapshot is already completed.
+	if conditions.IsTrue(snapshot, controlplanev1.EtcdSnapshotCompletedCondition) {
+		return nil
+	}
+
+	if !conditions.IsTrue(cluster, clusterv1.ClusterControlPlaneInitializedCondition) {
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotWaitingForControlPlaneInitializedReason,
+			Message: "Waiting for Cluster control plane to be initialized",
+		})
+		return nil
+	}
+
+	kcp, err := getKubeadmControlPlaneForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return err
+	}
+	if kcp == nil || kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.IsDefined() {
+		// Note: There is no need to retry, the snapshot fails permanently.
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotFailedReason,
+			Message: "etcd snapshots are supported only for Clusters with a KubeadmControlPlane managing etcd",
+		})
+		return nil
+	}
+
+	sink, err := etcdsnapshot.NewSink(r.Client, r.EtcdSnapshotDirectory, snapshot)
+	if err != nil {
+		// Note: There is no need to retry, a new reconcile is triggered when the controller is restarted with a different configuration.
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotFailedReason,
+			Message: fmt.Sprintf("Invalid sink: %s", err.Error()),
+		})
+		return nil //nolint:nilerr // Errors are surfaced in the Completed condition.
+	}
+
+	keyEncryptionAlgorithm := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm
+	if keyEncryptionAlgorithm == "" {
+		keyEncryptionAlgorithm = bootstrapv1.EncryptionAlgorithmRSA2048
+	}
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, cluster, keyEncryptionAlgorithm)
+	if err != nil {
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return errors.Wrap(err, "failed to create client to workload cluster")
+	}
+
+	etcdSnapshot, err := workloadCluster.EtcdSnapshot(ctx)
+	if err != nil {
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotFailedReason,
+			Message: "Failed to take etcd snapshot, please check controller logs for errors",
+		})
+		return err
+	}
+	defer etcdSnapshot.Close()
+
+	data := etcdsnapshot.Compress(etcdSnapshot)
+	defer data.Close()
+
+	// Compute the checksum of the compressed snapshot while storing it, so it can be verified on restore.
+	checksum := sha256.New()
+	location, size, err := sink.Save(ctx, snapshot, io.TeeReader(data, checksum))
+	if err != nil {
+		conditions.Set(snapshot, metav1.Condition{
+			Type:    controlplanev1.EtcdSnapshotCompletedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.EtcdSnapshotFailedReason,
+			Message: "Failed to store etcd snapshot, please check controller logs for errors",
+		})
+		return errors.Wrap(err, "failed to store etcd snapshot")
+	}
+
+	snapshot.Status.Location = location
+	snapshot.Status.SizeBytes = ptr.To(size)
+	snapshot.Status.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	snapshot.Status.Revision = ptr.To(etcdSnapshot.Revision)
+	snapshot.Status.EtcdVersion = etcdSnapshot.Version
+	snapshot.Status.CompletionTime = metav1.Now()
+	conditions.Set(snapshot, metav1.Condition{
+		Type:   controlplanev1.EtcdSnapshotCompletedCondition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1.EtcdSnapshotCompletedReason,
+	})
+	log.Info(fmt.Sprintf("etcd snapshot stored in %s", location), "sizeBytes", size, "revision", etcdSnapshot.Revision)
+	return nil
+}
+
+func (r *EtcdSnapshotReconciler) reconcileDelete(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) error {
+	// Delete the snapshot data from the sink only if they have been stored.
+	// Note: Partially stored data are either garbage collected (Secret sink) or never moved to the
+	// final location (Directory sink).
+	if snapshot.Status.Location != "" {
+		sink, err := etcdsnapshot.NewSink(r.Client, r.EtcdSnapshotDirectory, snapshot)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete etcd snapshot")
+		}
+		if err := sink.Delete(ctx, snapshot); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(snapshot, controlplanev1.EtcdSnapshotFinalizer)
+	return nil
+}
+
+// getKubeadmControlPlaneForCluster returns the KubeadmControlPlane of a Cluster, or nil if the control plane
+// of the Cluster is not managed by a KubeadmControlPlane.
+func getKubeadmControlPlaneForCluster(ctx context.Context, c client.Reader, cluster *clusterv1.Cluster) (*controlplanev1.KubeadmControlPlane, error) {
+	if cluster.Spec.ControlPlaneRef.Kind != kubeadmControlPlaneKind || cluster.Spec.ControlPlaneRef.APIGroup != controlplanev1.GroupVersion.Group {
+		return nil, nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, kcp); err != nil {
+		return nil, errors.Wrapf(err, "failed to get KubeadmControlPlane %s", klog.KRef(cluster.Namespace, cluster.Spec.ControlPlaneRef.Name))
+	}
+	return kcp, nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcdsnapshot"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestEtcdSnapshotReconciler_reconcile(t *testing.T) {
+	initializedCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: controlplanev1.GroupVersion.Group,
+				Kind:     kubeadmControlPlaneKind,
+				Name:     "kcp",
+			},
+		},
+		Status: clusterv1.ClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kcp"},
+	}
+	kcpWithExternalEtcd := kcp.DeepCopy()
+	kcpWithExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints = []string{"https://etcd:2379"}
+
+	tests := []struct {
+		name            string
+		cluster         *clusterv1.Cluster
+		objs            []client.Object
+		sinkType        controlplanev1.EtcdSnapshotSinkType
+		workloadCluster *fakeWorkloadCluster
+		wantErr         bool
+		wantReason      string
+		wantStatus      metav1.ConditionStatus
+		wantData        string
+	}{
+		{
+			name:       "wait for the control plane to be initialized",
+			cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"}},
+			sinkType:   controlplanev1.EtcdSnapshotSecretSinkType,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: controlplanev1.EtcdSnapshotWaitingForControlPlaneInitializedReason,
+		},
+		{
+			name:       "fail if etcd is external",
+			cluster:    initializedCluster,
+			objs:       []client.Object{kcpWithExternalEtcd},
+			sinkType:   controlplanev1.EtcdSnapshotSecretSinkType,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: controlplanev1.EtcdSnapshotFailedReason,
+		},
+		{
+			name:       "fail if the sink is not valid",
+			cluster:    initializedCluster,
+			objs:       []client.Object{kcp},
+			sinkType:   controlplanev1.EtcdSnapshotDirectorySinkType,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: controlplanev1.EtcdSnapshotFailedReason,
+		},
+		{
+			name:            "return error if taking the snapshot fails",
+			cluster:         initializedCluster,
+			objs:            []client.Object{kcp},
+			sinkType:        controlplanev1.EtcdSnapshotSecretSinkType,
+			workloadCluster: &fakeWorkloadCluster{EtcdSnapshotErr: errors.New("failed")},
+			wantErr:         true,
+			wantStatus:      metav1.ConditionFalse,
+			wantReason:      controlplanev1.EtcdSnapshotFailedReason,
+		},
+		{
+			name:            "take the snapshot and store it in the sink",
+			cluster:         initializedCluster,
+			objs:            []client.Object{kcp},
+			sinkType:        controlplanev1.EtcdSnapshotSecretSinkType,
+			workloadCluster: &fakeWorkloadCluster{EtcdSnapshotData: "etcd-data"},
+			wantStatus:      metav1.ConditionTrue,
+			wantReason:      controlplanev1.EtcdSnapshotCompletedReason,
+			wantData:        "etcd-data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			snapshot := &controlplanev1.EtcdSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "snapshot", UID: "uid"},
+				Spec: controlplanev1.EtcdSnapshotSpec{
+					ClusterName: "cluster",
+					Sink:        controlplanev1.EtcdSnapshotSink{Type: tt.sinkType},
+				},
+			}
+
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			r := &EtcdSnapshotReconciler{
+				Client:            c,
+				managementCluster: &fakeManagementCluster{Workload: tt.workloadCluster},
+			}
+
+			err := r.reconcile(ctx, tt.cluster, snapshot)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			condition := conditions.Get(snapshot, controlplanev1.EtcdSnapshotCompletedCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+
+			if tt.wantData == "" {
+				g.Expect(snapshot.Status.Location).To(BeEmpty())
+				return
+			}
+			g.Expect(snapshot.Status.Location).To(Equal("secret://default/snapshot"))
+			g.Expect(snapshot.Status.SizeBytes).ToNot(BeNil())
+			g.Expect(snapshot.Status.Revision).To(Equal(ptr.To(int64(1))))
+			g.Expect(snapshot.Status.EtcdVersion).To(Equal("3.6.0"))
+			g.Expect(snapshot.Status.CompletionTime.IsZero()).To(BeFalse())
+
+			sink, err := etcdsnapshot.NewSink(c, "", snapshot)
+			g.Expect(err).ToNot(HaveOccurred())
+			r2, err := sink.Load(ctx, snapshot)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer r2.Close()
+			compressed, err := io.ReadAll(r2)
+			g.Expect(err).ToNot(HaveOccurred())
+			// The checksum must match the compressed data stored in the sink.
+			checksum := sha256.Sum256(compressed)
+			g.Expect(snapshot.Status.SHA256).To(Equal(hex.EncodeToString(checksum[:])))
+			gr, err := gzip.NewReader(bytes.NewReader(compressed))
+			g.Expect(err).ToNot(HaveOccurred())
+			data, err := io.ReadAll(gr)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(data)).To(Equal(tt.wantData))
+		})
+	}
+}
+
+func TestEtcdSnapshotReconciler_reconcileDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	snapshot := &controlplanev1.EtcdSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  metav1.NamespaceDefault,
+			Name:       "snapshot",
+			UID:        "uid",
+			Finalizers: []string{controlplanev1.EtcdSnapshotFinalizer},
+		},
+		Spec: controlplanev1.EtcdSnapshotSpec{
+			ClusterName: "cluster",
+			Sink:        controlplanev1.EtcdSnapshotSink{Type: controlplanev1.EtcdSnapshotSecretSinkType},
+		},
+		Status: controlplanev1.EtcdSnapshotStatus{
+			Location: "secret://default/snapshot",
+		},
+	}
+	chunk := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "snapshot-0",
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           "cluster",
+				controlplanev1.EtcdSnapshotNameLabel: "snapshot",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(snapshot, controlplanev1.GroupVersion.WithKind("EtcdSnapshot")),
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(chunk).Build()
+	r := &EtcdSnapshotReconciler{Client: c}
+
+	g.Expect(r.reconcileDelete(ctx, snapshot)).To(Succeed())
+	g.Expect(snapshot.Finalizers).To(BeEmpty())
+
+	secrets := &corev1.SecretList{}
+	g.Expect(c.List(ctx, secrets)).To(Succeed())
+	g.Expect(secrets.Items).To(BeEmpty())
+}
+
+func TestGetKubeadmControlPlaneForCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kcp"},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{},
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(kcp).Build()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: controlplanev1.GroupVersion.Group,
+				Kind:     kubeadmControlPlaneKind,
+				Name:     "kcp",
+			},
+		},
+	}
+	got, err := getKubeadmControlPlaneForCluster(ctx, c, cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).ToNot(BeNil())
+	g.Expect(got.Name).To(Equal("kcp"))
+
+	cluster.Spec.ControlPlaneRef.Kind = "OtherControlPlane"
+	got, err = getKubeadmControlPlaneForCluster(ctx, c, cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
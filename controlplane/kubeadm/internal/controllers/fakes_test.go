@@ -18,6 +18,8 @@ package controllers
 
 import (
 	"context"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -27,6 +29,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/util/collections"
 )
 
@@ -77,9 +80,15 @@ type fakeWorkloadCluster struct {
 	*internal.Workload
 	KubeadmConfigExist         bool
 	APIServerCertificateExpiry *time.Time
+	EtcdSnapshotData           string
+	EtcdSnapshotErr            error
+	EtcdMembersStatusResult    []*internal.EtcdMemberStatus
 
 	forwardEtcdLeadershipCalled int
 	removeEtcdMemberCalled      int
+	defragmentedEtcdMembers     []string
+	disarmedEtcdAlarms          []etcd.MemberAlarm
+	clusterInfoCAData           []byte
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine, _ []*internal.Node) error {
@@ -115,6 +124,36 @@ func (f *fakeWorkloadCluster) UpdateClusterConfiguration(context.Context, semver
 	return nil
 }
 
+func (f *fakeWorkloadCluster) EtcdSnapshot(_ context.Context) (*etcd.Snapshot, error) {
+	if f.EtcdSnapshotErr != nil {
+		return nil, f.EtcdSnapshotErr
+	}
+	return &etcd.Snapshot{
+		ReadCloser: io.NopCloser(strings.NewReader(f.EtcdSnapshotData)),
+		Revision:   1,
+		Version:    "3.6.0",
+	}, nil
+}
+
+func (f *fakeWorkloadCluster) UpdateClusterInfoCertificateAuthorities(_ context.Context, caData []byte) error {
+	f.clusterInfoCAData = caData
+	return nil
+}
+
+func (f *fakeWorkloadCluster) EtcdMembersStatus(_ context.Context, _ []*internal.Node) ([]*internal.EtcdMemberStatus, error) {
+	return f.EtcdMembersStatusResult, nil
+}
+
+func (f *fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, name string) error {
+	f.defragmentedEtcdMembers = append(f.defragmentedEtcdMembers, name)
+	return nil
+}
+
+func (f *fakeWorkloadCluster) DisarmEtcdAlarm(_ context.Context, alarm etcd.MemberAlarm, _ []*internal.Node) error {
+	f.disarmedEtcdAlarms = append(f.disarmedEtcdAlarms, alarm)
+	return nil
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
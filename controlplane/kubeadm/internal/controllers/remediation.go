@@ -26,7 +26,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -36,6 +39,7 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/util"
@@ -70,6 +74,14 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 			continue
 		}
 
+		// If remediation has been handed off to an external remediation controller, delete the remediation request.
+		if shouldCleanup && controlPlane.KCP.Spec.Remediation.TemplateRef.IsDefined() {
+			if err := r.deleteExternalRemediationRequest(ctx, controlPlane.KCP, m); err != nil {
+				errList = append(errList, err)
+				continue
+			}
+		}
+
 		patchHelper, err := patch.NewHelper(m, r.Client)
 		if err != nil {
 			errList = append(errList, err)
@@ -154,6 +166,19 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, nil
 	}
 
+	// Returns if remediation has been handed off to an external remediation controller and a remediation request
+	// still exists for one of the Machines.
+	if controlPlane.KCP.Spec.Remediation.TemplateRef.IsDefined() {
+		machinesWithExternalRemediationRequest, err := r.getMachinesWithExternalRemediationRequest(ctx, controlPlane)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if machinesWithExternalRemediationRequest.Len() > 0 {
+			log.Info("An external remediation is already in progress. Skipping remediation.", "machinesBeingRemediated", strings.Join(machinesWithExternalRemediationRequest.Names(), ", "))
+			return ctrl.Result{}, nil
+		}
+	}
+
 	patchHelper, err := patch.NewHelper(machineToBeRemediated, r.Client)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -348,6 +373,12 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		}
 	}
 
+	// If an external remediation template is configured, hand off remediation of the machine to an external remediation controller
+	// instead of deleting it.
+	if controlPlane.KCP.Spec.Remediation.TemplateRef.IsDefined() {
+		return r.createExternalRemediationRequest(ctx, controlPlane, machineToBeRemediated, remediationInProgressData)
+	}
+
 	// Delete the machine (waiting for cache to observe the deletion at the end of this func, so everything in between is always executed)
 	if err := r.Client.Delete(ctx, machineToBeRemediated); err != nil {
 		v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
@@ -395,6 +426,126 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 	return ctrl.Result{RequeueAfter: time.Millisecond}, nil // Technically there is no need to requeue here. Machine deletion above triggers reconciliation. But we have to return a non-zero Result so reconcile above returns.
 }
 
+// createExternalRemediationRequest hands off remediation of an unhealthy Machine to an external remediation controller
+// by creating a remediation request from spec.remediation.templateRef.
+func (r *KubeadmControlPlaneReconciler) createExternalRemediationRequest(ctx context.Context, controlPlane *internal.ControlPlane, machineToBeRemediated *clusterv1.Machine, remediationInProgressData *RemediationData) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	templateRef := controlPlane.KCP.Spec.Remediation.TemplateRef.ToObjectReference(controlPlane.KCP.Namespace)
+
+	from, err := external.Get(ctx, r.Client, templateRef)
+	if err != nil {
+		v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+
+		conditions.Set(machineToBeRemediated, metav1.Condition{
+			Type:    clusterv1.MachineOwnerRemediatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneMachineRemediationInternalErrorReason,
+			Message: fmt.Sprintf("Error retrieving remediation template %s %s", templateRef.Kind, klog.KRef(templateRef.Namespace, templateRef.Name)),
+		})
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get remediation template for unhealthy machine %s", machineToBeRemediated.Name)
+	}
+
+	// Set the remediation request name to match the Machine name, the name is used to guarantee that
+	// a Machine only ever has a single remediation request.
+	to, err := external.GenerateTemplate(&external.GenerateTemplateInput{
+		Template:    from,
+		TemplateRef: templateRef,
+		Namespace:   machineToBeRemediated.Namespace,
+		Name:        machineToBeRemediated.Name,
+		ClusterName: controlPlane.Cluster.Name,
+		OwnerRef: &metav1.OwnerReference{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Machine",
+			Name:       machineToBeRemediated.Name,
+			UID:        machineToBeRemediated.UID,
+		},
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to generate remediation request for unhealthy machine %s", machineToBeRemediated.Name)
+	}
+
+	if err := r.Client.Create(ctx, to); err != nil {
+		v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+
+		conditions.Set(machineToBeRemediated, metav1.Condition{
+			Type:    clusterv1.MachineOwnerRemediatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneMachineRemediationInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create remediation request for unhealthy machine %s", machineToBeRemediated.Name)
+	}
+
+	log.WithValues(controlPlane.StatusToLogKeyAndValues(nil, nil)...).
+		Info(fmt.Sprintf("%s %s created (remediating unhealthy Machine)", to.GetKind(), klog.KObj(to)))
+	v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationInProgressV1Beta1Reason, clusterv1.ConditionSeverityWarning, "")
+
+	conditions.Set(machineToBeRemediated, metav1.Condition{
+		Type:    clusterv1.MachineOwnerRemediatedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneMachineRemediationWaitingForExternalRemediationReason,
+		Message: fmt.Sprintf("Waiting for %s %s to complete remediation", to.GetKind(), to.GetName()),
+	})
+
+	// Set annotations tracking remediation details on the machine being remediated, so they can be used
+	// to enforce retry limits in case the machine is still unhealthy after the external remediation completes.
+	remediationInProgressValue, err := remediationInProgressData.Marshal()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	annotations.AddAnnotations(machineToBeRemediated, map[string]string{
+		controlplanev1.RemediationForAnnotation: remediationInProgressValue,
+	})
+
+	return ctrl.Result{RequeueAfter: time.Millisecond}, nil // Technically there is no need to requeue here. Patching the Machine triggers reconciliation. But we have to return a non-zero Result so reconcile above returns.
+}
+
+// getMachinesWithExternalRemediationRequest returns the Machines for which a remediation request created from
+// spec.remediation.templateRef exists.
+func (r *KubeadmControlPlaneReconciler) getMachinesWithExternalRemediationRequest(ctx context.Context, controlPlane *internal.ControlPlane) (collections.Machines, error) {
+	machines := collections.Machines{}
+	for _, m := range controlPlane.Machines {
+		if _, err := r.getExternalRemediationRequest(ctx, controlPlane.KCP, m.Name); err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				continue
+			}
+			return nil, err
+		}
+		machines.Insert(m)
+	}
+	return machines, nil
+}
+
+// deleteExternalRemediationRequest deletes the remediation request created from spec.remediation.templateRef
+// for a Machine, if any.
+func (r *KubeadmControlPlaneReconciler) deleteExternalRemediationRequest(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) error {
+	obj, err := r.getExternalRemediationRequest(ctx, kcp, machine.Name)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return nil
+		}
+		return err
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s %s for Machine %s", obj.GetKind(), klog.KObj(obj), machine.Name)
+	}
+	return nil
+}
+
+// getExternalRemediationRequest gets the remediation request created from spec.remediation.templateRef for a Machine.
+func (r *KubeadmControlPlaneReconciler) getExternalRemediationRequest(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, machineName string) (*unstructured.Unstructured, error) {
+	remediationRef := &corev1.ObjectReference{
+		APIVersion: kcp.Spec.Remediation.TemplateRef.APIVersion,
+		Kind:       strings.TrimSuffix(kcp.Spec.Remediation.TemplateRef.Kind, clusterv1.TemplateSuffix),
+		Name:       machineName,
+		Namespace:  kcp.Namespace,
+	}
+	return external.Get(ctx, r.Client, remediationRef)
+}
+
 // Gets the machine to be remediated, which is the "most broken" among the unhealthy machines, determined as the machine
 // having the highest priority issue that other machines have not.
 // The following issues are considered (from highest to lowest priority):
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -1248,6 +1249,114 @@ func TestReconcileUnhealthyMachines(t *testing.T) {
 		removeFinalizer(g, m1)
 		g.Expect(env.Cleanup(ctx, m1, m2, m3, m4)).To(Succeed())
 	})
+	t.Run("Remediation creates an external remediation request for unhealthy machine - 3 CP", func(t *testing.T) {
+		g := NewWithT(t)
+
+		remediationTemplate := createExternalRemediationTemplate(ctx, g, ns.Name)
+
+		m1 := createMachine(ctx, g, ns.Name, "m1-unhealthy-", withMachineHealthCheckFailed())
+		m2 := createMachine(ctx, g, ns.Name, "m2-healthy-", withHealthyEtcdMember(), withHealthyK8sControlPlane())
+		m3 := createMachine(ctx, g, ns.Name, "m3-healthy-", withHealthyEtcdMember(), withHealthyK8sControlPlane())
+
+		controlPlane := &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas: utilptr.To[int32](3),
+					Version:  "v1.19.1",
+					Remediation: controlplanev1.KubeadmControlPlaneRemediationSpec{
+						TemplateRef: remediationTemplate,
+					},
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					Initialization: controlplanev1.KubeadmControlPlaneInitializationStatus{
+						ControlPlaneInitialized: utilptr.To(true),
+					},
+				},
+			},
+			Cluster:  &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			Machines: collections.FromMachines(m1, m2, m3),
+		}
+		controlPlane.EtcdMembers = etcdMembers(controlPlane.Machines)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:            env.GetClient(),
+			recorder:          record.NewFakeRecorder(32),
+			managementCluster: &fakeManagementCluster{Workload: &fakeWorkloadCluster{}},
+		}
+		controlPlane.InjectTestManagementCluster(r.managementCluster)
+
+		ret, err := r.reconcileUnhealthyMachines(ctx, controlPlane)
+
+		g.Expect(ret.IsZero()).To(BeFalse()) // Remediation handed off, requeue
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// The KCP is not tracking the remediation, the remediation data is recorded on the machine being remediated.
+		g.Expect(controlPlane.KCP.Annotations).ToNot(HaveKey(controlplanev1.RemediationInProgressAnnotation))
+		g.Expect(m1.Annotations).To(HaveKey(controlplanev1.RemediationForAnnotation))
+		remediationData, err := RemediationDataFromAnnotation(m1.Annotations[controlplanev1.RemediationForAnnotation])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remediationData.Machine).To(Equal(m1.Name))
+		g.Expect(remediationData.RetryCount).To(Equal(0))
+
+		assertMachineCondition(ctx, g, m1, clusterv1.MachineOwnerRemediatedCondition, metav1.ConditionFalse, controlplanev1.KubeadmControlPlaneMachineRemediationWaitingForExternalRemediationReason, fmt.Sprintf("Waiting for GenericExternalRemediation %s to complete remediation", m1.Name))
+
+		// The machine is not deleted.
+		g.Expect(env.Get(ctx, client.ObjectKeyFromObject(m1), m1)).To(Succeed())
+		g.Expect(m1.DeletionTimestamp.IsZero()).To(BeTrue())
+
+		// The remediation request is created with the same name of the machine, and it is owned by the machine.
+		remediationRequest, err := r.getExternalRemediationRequest(ctx, controlPlane.KCP, m1.Name)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remediationRequest.GetOwnerReferences()).To(HaveLen(1))
+		g.Expect(remediationRequest.GetOwnerReferences()[0].Name).To(Equal(m1.Name))
+		g.Expect(remediationRequest.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "cluster"))
+
+		// Remediation does not happen again while the remediation request exists.
+		ret, err = r.reconcileUnhealthyMachines(ctx, controlPlane)
+		g.Expect(ret.IsZero()).To(BeTrue()) // Remediation skipped
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(env.Cleanup(ctx, remediationRequest, m1, m2, m3)).To(Succeed())
+	})
+	t.Run("External remediation request is deleted when the machine is healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		remediationTemplate := createExternalRemediationTemplate(ctx, g, ns.Name)
+
+		m1 := createMachine(ctx, g, ns.Name, "m1-healthy-", withHealthyEtcdMember(), withHealthyK8sControlPlane(), withStuckRemediation())
+
+		remediationRequest := &unstructured.Unstructured{}
+		remediationRequest.SetAPIVersion(builder.RemediationGroupVersion.String())
+		remediationRequest.SetKind("GenericExternalRemediation")
+		remediationRequest.SetNamespace(ns.Name)
+		remediationRequest.SetName(m1.Name)
+		g.Expect(env.Create(ctx, remediationRequest)).To(Succeed())
+
+		controlPlane := &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Remediation: controlplanev1.KubeadmControlPlaneRemediationSpec{
+						TemplateRef: remediationTemplate,
+					},
+				},
+			},
+			Cluster:  &clusterv1.Cluster{},
+			Machines: collections.FromMachines(m1),
+		}
+		ret, err := r.reconcileUnhealthyMachines(ctx, controlPlane)
+
+		g.Expect(ret.IsZero()).To(BeTrue()) // Remediation skipped
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Eventually(func() bool {
+			_, err := r.getExternalRemediationRequest(ctx, controlPlane.KCP, m1.Name)
+			return apierrors.IsNotFound(errors.Cause(err))
+		}, 10*time.Second).Should(BeTrue())
+
+		g.Expect(env.Cleanup(ctx, m1)).To(Succeed())
+	})
 	t.Run("Subsequent remediation of the same machine increase retry count - 3 CP", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -2730,3 +2839,28 @@ func MustMarshalRemediationData(r *RemediationData) string {
 	}
 	return s
 }
+
+func createExternalRemediationTemplate(ctx context.Context, g *WithT, namespace string) clusterv1.MachineHealthCheckRemediationTemplateReference {
+	g.THelper()
+
+	remediationTemplate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{},
+				},
+			},
+		},
+	}
+	remediationTemplate.SetAPIVersion(builder.RemediationGroupVersion.String())
+	remediationTemplate.SetKind("GenericExternalRemediationTemplate")
+	remediationTemplate.SetNamespace(namespace)
+	remediationTemplate.SetGenerateName("remediation-template-")
+	g.Expect(env.Create(ctx, remediationTemplate)).To(Succeed())
+
+	return clusterv1.MachineHealthCheckRemediationTemplateReference{
+		APIVersion: builder.RemediationGroupVersion.String(),
+		Kind:       "GenericExternalRemediationTemplate",
+		Name:       remediationTemplate.GetName(),
+	}
+}
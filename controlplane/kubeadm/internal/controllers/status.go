@@ -35,6 +35,8 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/internal/util/certificates"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
@@ -161,6 +163,9 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, contro
 	setMachinesReadyCondition(ctx, controlPlane.KCP, controlPlane.Machines)
 	setMachinesUpToDateCondition(ctx, controlPlane.KCP, controlPlane.Machines)
 	setRemediatingCondition(ctx, controlPlane.KCP, controlPlane.MachinesToBeRemediatedByKCP(), controlPlane.UnhealthyMachines())
+	if err := r.setCertificatesExpiringCondition(ctx, controlPlane); err != nil {
+		allErrors = append(allErrors, err)
+	}
 	setDeletingCondition(ctx, controlPlane.KCP, controlPlane.DeletingReason, controlPlane.DeletingMessage)
 	setAvailableCondition(ctx, controlPlane.KCP, controlPlane.IsEtcdManaged(), controlPlane.EtcdMembers, controlPlane.EtcdMembersAndMachinesAreMatching, controlPlane.Machines)
 	if err := setLastRemediation(ctx, controlPlane); err != nil {
@@ -526,6 +531,30 @@ func setRemediatingCondition(ctx context.Context, kcp *controlplanev1.KubeadmCon
 	})
 }
 
+// setCertificatesExpiringCondition surfaces the earliest expiry date of the certificates of the Cluster, i.e. the certificates
+// stored in the cluster certificate Secrets, the client certificates of the kubeconfig Secret and the certificates of the control plane Machines.
+func (r *KubeadmControlPlaneReconciler) setCertificatesExpiringCondition(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	// Certificates expiry dates are not collected while KCP is deleting; preserve the last known state.
+	if !controlPlane.KCP.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	expiries, invalidSources, err := certificates.GetExpiries(ctx, r.SecretCachingClient, r.Client, util.ObjectKey(controlPlane.Cluster), controlPlane.Machines)
+	if err != nil {
+		conditions.Set(controlPlane.KCP, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  controlplanev1.KubeadmControlPlaneCertificatesExpiringInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return errors.Wrap(err, "failed to get certificates expiry dates")
+	}
+
+	controlPlane.KCP.Status.CertificatesExpiryDate = certificates.EarliestExpiryDate(expiries)
+	conditions.Set(controlPlane.KCP, certificates.ExpiringCondition(expiries, invalidSources, time.Now()))
+	return nil
+}
+
 func setDeletingCondition(_ context.Context, kcp *controlplanev1.KubeadmControlPlane, deletingReason, deletingMessage string) {
 	if kcp.DeletionTimestamp.IsZero() {
 		conditions.Set(kcp, metav1.Condition{
@@ -963,6 +963,61 @@ func TestDeletingCondition(t *testing.T) {
 	}
 }
 
+func Test_setCertificatesExpiringCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	r := &KubeadmControlPlaneReconciler{
+		Client: newFakeClient(),
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:      kcp,
+		Cluster:  cluster,
+		Machines: collections.Machines{},
+	}
+
+	// No certificates.
+	g.Expect(r.setCertificatesExpiringCondition(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificatesExpiryDate.IsZero()).To(BeTrue())
+	condition := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(*condition).To(conditions.MatchCondition(metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificatesExpiryUnknownReason,
+		Message: "No certificates found",
+	}, conditions.IgnoreLastTransitionTime(true)))
+
+	// Machine certificates expiring.
+	expiryDate := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	controlPlane.Machines = collections.FromMachines(&clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "m1"},
+		Status:     clusterv1.MachineStatus{CertificatesExpiryDate: metav1.NewTime(expiryDate)},
+	})
+	g.Expect(r.setCertificatesExpiringCondition(ctx, controlPlane)).To(Succeed())
+	g.Expect(kcp.Status.CertificatesExpiryDate).To(Equal(metav1.NewTime(expiryDate)))
+	condition = conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(*condition).To(conditions.MatchCondition(metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificatesExpiringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificatesExpiringReason,
+		Message: fmt.Sprintf("Machine m1 expires at %s", expiryDate.UTC().Format(time.RFC3339)),
+	}, conditions.IgnoreLastTransitionTime(true)))
+}
+
 func Test_shouldSurfaceWhenAvailableTrue(t *testing.T) {
 	reconcileTime := time.Now()
 
@@ -18,6 +18,8 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
@@ -26,6 +28,7 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/collections"
@@ -89,14 +92,23 @@ func (r *KubeadmControlPlaneReconciler) updateControlPlane(
 
 	switch controlPlane.KCP.Spec.Rollout.Strategy.Type {
 	case controlplanev1.RollingUpdateStrategyType:
-		// RolloutStrategy is currently defaulted and validated to be RollingUpdate
-		res, err := r.rollingUpdate(ctx, controlPlane, machinesNeedingRollout, machinesUpToDateResults)
+		// Note: As MaxSurge is validated to be either 0 or 1, the rollout will use at most one additional Machine.
+		maxSurge := int32(controlPlane.KCP.Spec.Rollout.Strategy.RollingUpdate.MaxSurge.IntValue())
+		res, err := r.rollingUpdate(ctx, controlPlane, maxSurge, machinesNeedingRollout, machinesUpToDateResults)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update control plane")
+		}
+		return res, nil
+	case controlplanev1.ScaleInStrategyType:
+		// ScaleIn is a rolling update that never creates Machines above the desired replicas, i.e. an outdated
+		// Machine is always deleted before its replacement is created.
+		res, err := r.rollingUpdate(ctx, controlPlane, 0, machinesNeedingRollout, machinesUpToDateResults)
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to update control plane")
 		}
 		return res, nil
 	default:
-		log.Info("RolloutStrategy type is not set to RollingUpdate, unable to determine the strategy for rolling out machines")
+		log.Info("RolloutStrategy type is not set to RollingUpdate or ScaleIn, unable to determine the strategy for rolling out machines")
 		return ctrl.Result{}, nil
 	}
 }
@@ -104,14 +116,14 @@ func (r *KubeadmControlPlaneReconciler) updateControlPlane(
 func (r *KubeadmControlPlaneReconciler) rollingUpdate(
 	ctx context.Context,
 	controlPlane *internal.ControlPlane,
+	maxSurge int32,
 	machinesNeedingRollout collections.Machines,
 	machinesUpToDateResults map[string]internal.UpToDateResult,
 ) (ctrl.Result, error) {
 	currentReplicas := int32(controlPlane.Machines.Len())
 	currentUpToDateReplicas := int32(controlPlane.UpToDateMachines().Len())
 	desiredReplicas := *controlPlane.KCP.Spec.Replicas
-	maxSurge := int32(controlPlane.KCP.Spec.Rollout.Strategy.RollingUpdate.MaxSurge.IntValue())
-	// Note: As MaxSurge is validated to be either 0 or 1, maxReplicas will be either desiredReplicas or desiredReplicas+1.
+	// Note: As maxSurge is either 0 or 1, maxReplicas will be either desiredReplicas or desiredReplicas+1.
 	maxReplicas := desiredReplicas + maxSurge
 
 	// If currentReplicas < maxReplicas we have to scale up
@@ -150,10 +162,56 @@ func (r *KubeadmControlPlaneReconciler) rollingUpdate(
 			return res, nil
 		}
 		if fallbackToScaleDown {
-			return r.scaleDownControlPlane(ctx, controlPlane, machineToInPlaceUpdateOrScaleDown)
+			return r.scaleDownControlPlaneForRollout(ctx, controlPlane, machineToInPlaceUpdateOrScaleDown)
 		}
 		// In-place update triggered
 		return ctrl.Result{}, nil // Note: Requeue is not needed, changes to Machines trigger another reconcile.
 	}
-	return r.scaleDownControlPlane(ctx, controlPlane, machineToInPlaceUpdateOrScaleDown)
+	return r.scaleDownControlPlaneForRollout(ctx, controlPlane, machineToInPlaceUpdateOrScaleDown)
+}
+
+// scaleDownControlPlaneForRollout deletes a Machine during a rollout.
+// When using the ScaleIn strategy, the Machine is deleted before its replacement is created, so the Machine is deleted
+// only if the etcd cluster and the Kubernetes control plane components will remain operational after the deletion.
+func (r *KubeadmControlPlaneReconciler) scaleDownControlPlaneForRollout(ctx context.Context, controlPlane *internal.ControlPlane, machineToDelete *clusterv1.Machine) (ctrl.Result, error) {
+	if controlPlane.KCP.Spec.Rollout.Strategy.Type == controlplanev1.ScaleInStrategyType &&
+		!r.canSafelyScaleInMachine(ctx, controlPlane, machineToDelete) {
+		log := ctrl.LoggerFrom(ctx)
+		log.Info(fmt.Sprintf("Waiting for the control plane to tolerate the deletion of Machine %s before scaling in", machineToDelete.Name), "Machine", klog.KObj(machineToDelete))
+		r.controller.DeferNextReconcileForObject(controlPlane.KCP, time.Now().Add(5*time.Second))
+		return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, nil
+	}
+	return r.scaleDownControlPlane(ctx, controlPlane, machineToDelete)
+}
+
+// canSafelyScaleInMachine determines if deleting a Machine before its replacement is created will leave the Kubernetes
+// control plane components and the etcd cluster in operational state or not.
+func (r *KubeadmControlPlaneReconciler) canSafelyScaleInMachine(ctx context.Context, controlPlane *internal.ControlPlane, machineToDelete *clusterv1.Machine) bool {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Target list of Machines will have current Machines -1 Machine (the machineToDelete).
+	// As a consequence, Kubernetes control plane components and the etcd member on the Machine are going to be deleted,
+	// no Kubernetes control plane components and no etcd members are going to be added.
+	if !r.targetKubernetesControlPlaneComponentsHealthy(ctx, controlPlane, false, machineToDelete.Name) {
+		return false
+	}
+
+	// If etcd is not managed, no other checks are required.
+	if !controlPlane.IsEtcdManaged() {
+		return true
+	}
+
+	// Note: Differently from remediation, scale in is not triggered by an explicit user intent to delete this Machine,
+	// so KCP doesn't delete the Machine if it is not possible to determine the corresponding etcd member.
+	etcdMemberToBeDeleted := r.tryGetEtcdMemberName(ctx, controlPlane, machineToDelete)
+	if etcdMemberToBeDeleted == "" {
+		log.Info("cannot check etcd cluster health before scale in, etcd member for the Machine is unknown", "Machine", klog.KObj(machineToDelete))
+		return false
+	}
+
+	if len(controlPlane.EtcdMembers) == 0 {
+		log.Info("cannot check etcd cluster health before scale in, etcd member list is empty")
+		return false
+	}
+	return r.targetEtcdClusterHealthy(ctx, controlPlane, false, etcdMemberToBeDeleted)
 }
@@ -487,7 +487,7 @@ func Test_rollingUpdate(t *testing.T) {
 			for _, m := range machinesNeedingRollout {
 				machinesUpToDateResults[m.Name] = internal.UpToDateResult{EligibleForInPlaceUpdate: tt.machineEligibleForInPlaceUpdate}
 			}
-			res, err := r.rollingUpdate(ctx, controlPlane, machinesNeedingRollout, machinesUpToDateResults)
+			res, err := r.rollingUpdate(ctx, controlPlane, tt.maxSurge, machinesNeedingRollout, machinesUpToDateResults)
 			if tt.wantError {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).To(Equal(tt.wantErrorMessage))
@@ -503,6 +503,94 @@ func Test_rollingUpdate(t *testing.T) {
 	}
 }
 
+func Test_scaleDownControlPlaneForRollout(t *testing.T) {
+	tests := []struct {
+		name                string
+		strategyType        controlplanev1.KubeadmControlPlaneRolloutStrategyType
+		machines            []*clusterv1.Machine
+		wantScaleDownCalled bool
+		wantRes             ctrl.Result
+	}{
+		{
+			name:         "RollingUpdate: scale down without checking etcd",
+			strategyType: controlplanev1.RollingUpdateStrategyType,
+			machines: []*clusterv1.Machine{
+				getMachine(metav1.NamespaceDefault, "m1", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m2", withUnhealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m3", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+			},
+			wantScaleDownCalled: true,
+		},
+		{
+			name:         "ScaleIn: scale down when the target etcd cluster and k8s control plane will be healthy",
+			strategyType: controlplanev1.ScaleInStrategyType,
+			machines: []*clusterv1.Machine{
+				getMachine(metav1.NamespaceDefault, "m1", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m2", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m3", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+			},
+			wantScaleDownCalled: true,
+		},
+		{
+			name:         "ScaleIn: wait when the target etcd cluster will lose quorum",
+			strategyType: controlplanev1.ScaleInStrategyType,
+			machines: []*clusterv1.Machine{
+				getMachine(metav1.NamespaceDefault, "m1", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m2", withUnhealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m3", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+			},
+			wantScaleDownCalled: false,
+			wantRes:             ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+		{
+			name:         "ScaleIn: wait when the target k8s control plane will be unhealthy",
+			strategyType: controlplanev1.ScaleInStrategyType,
+			machines: []*clusterv1.Machine{
+				getMachine(metav1.NamespaceDefault, "m1", withHealthyEtcdMember(), withHealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m2", withHealthyEtcdMember(), withUnhealthyK8sControlPlane()),
+				getMachine(metav1.NamespaceDefault, "m3", withHealthyEtcdMember(), withUnhealthyK8sControlPlane()),
+			},
+			wantScaleDownCalled: false,
+			wantRes:             ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var scaleDownCalled bool
+			r := &KubeadmControlPlaneReconciler{
+				controller: capicontrollerutil.NewFakeController(),
+				overrideScaleDownControlPlaneFunc: func(_ context.Context, _ *internal.ControlPlane, _ *clusterv1.Machine) (ctrl.Result, error) {
+					scaleDownCalled = true
+					return ctrl.Result{}, nil
+				},
+			}
+
+			controlPlane := &internal.ControlPlane{
+				KCP: &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						Replicas: ptr.To[int32](3),
+						Rollout: controlplanev1.KubeadmControlPlaneRolloutSpec{
+							Strategy: controlplanev1.KubeadmControlPlaneRolloutStrategy{
+								Type: tt.strategyType,
+							},
+						},
+					},
+				},
+				Cluster:  &clusterv1.Cluster{},
+				Machines: collections.FromMachines(tt.machines...),
+			}
+			controlPlane.EtcdMembers = etcdMembers(controlPlane.Machines)
+
+			res, err := r.scaleDownControlPlaneForRollout(ctx, controlPlane, tt.machines[0])
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(tt.wantRes))
+			g.Expect(scaleDownCalled).To(Equal(tt.wantScaleDownCalled))
+		})
+	}
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desiredstate
+
+import (
+	"slices"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+const (
+	// etcdRestoreDirectory is the directory on the Machine where files required to restore etcd are stored.
+	etcdRestoreDirectory = "/run/kubeadm/etcd-restore"
+
+	// etcdRestoreScriptPath is the path of the script restoring the etcd snapshot on the Machine.
+	etcdRestoreScriptPath = etcdRestoreDirectory + "/restore.sh"
+
+	// etcdRestoreURLPath is the path of the file containing the URL from where the etcd snapshot is downloaded.
+	etcdRestoreURLPath = etcdRestoreDirectory + "/url"
+
+	// etcdRestoreSHA256Path is the path of the file containing the SHA-256 checksum of the etcd snapshot.
+	etcdRestoreSHA256Path = etcdRestoreDirectory + "/sha256"
+
+	// etcdRestorePreflightError is the kubeadm preflight check failing when the etcd data directory is not empty.
+	etcdRestorePreflightError = "DirAvailable--var-lib-etcd"
+)
+
+// etcdRestoreScript downloads the etcd snapshot, verifies its checksum and restores it into the etcd data directory
+// before kubeadm init is run.
+// Note: etcdutl is run from the Machine if available, otherwise from the etcd image of the Kubernetes version
+// being installed using containerd; in the latter case the script does not depend on etcd binaries being
+// available on the Machine.
+// Note: The etcd member is restored with the node name and the advertise address from the kubeadm config, so
+// the member name and the peer URL match the ones used by kubeadm init; the node name defaults to the hostname
+// and the address is detected from the default route only if they are not set, like kubeadm does.
+const etcdRestoreScript = `#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+dir=` + etcdRestoreDirectory + `
+url=$(cat "${dir}/url")
+case "${url}" in
+  file://*)
+    cp "${url#file://}" "${dir}/snapshot.db.gz"
+    ;;
+  https://*)
+    curl --fail --silent --show-error --location --proto =https --proto-redir =https --retry 5 --output "${dir}/snapshot.db.gz" "${url}"
+    ;;
+  *)
+    echo "Unsupported etcd snapshot URL: only https:// and file:// URLs are supported" >&2
+    exit 1
+    ;;
+esac
+if ! echo "$(cat "${dir}/sha256")  ${dir}/snapshot.db.gz" | sha256sum --check --status; then
+  echo "Checksum of the downloaded etcd snapshot does not match the checksum of the EtcdSnapshot" >&2
+  rm -f "${dir}/snapshot.db.gz"
+  exit 1
+fi
+gunzip --force "${dir}/snapshot.db.gz"
+
+name=$(awk '/^nodeRegistration:/ { f = 1; next } f && /^[^ ]/ { f = 0 } f && /^  name:/ { gsub(/["\047]/, "", $2); print $2; exit }' /run/kubeadm/kubeadm.yaml)
+if [[ -z "${name}" ]]; then
+  name=$(hostname | tr '[:upper:]' '[:lower:]')
+fi
+ip=$(sed -n 's/^ *advertiseAddress: *"\{0,1\}\([^" ]*\)"\{0,1\} *$/\1/p' /run/kubeadm/kubeadm.yaml | head -n 1)
+if [[ -z "${ip}" ]]; then
+  ip=$(ip -o route get 1.1.1.1 2>/dev/null | sed -n 's/.* src \([^ ]*\).*/\1/p' || true)
+fi
+if [[ -z "${ip}" ]]; then
+  echo "Failed to detect the address of the etcd member: set initConfiguration.localAPIEndpoint.advertiseAddress" >&2
+  exit 1
+fi
+if [[ "${ip}" == *:* ]]; then
+  ip="[${ip}]"
+fi
+
+args=(snapshot restore "${dir}/snapshot.db"
+  --data-dir /var/lib/etcd
+  --name "${name}"
+  --initial-cluster "${name}=https://${ip}:2380"
+  --initial-advertise-peer-urls "https://${ip}:2380")
+
+rm -rf /var/lib/etcd
+if command -v etcdutl >/dev/null 2>&1; then
+  etcdutl "${args[@]}"
+elif command -v ctr >/dev/null 2>&1; then
+  image=$(kubeadm config images list --config /run/kubeadm/kubeadm.yaml 2>/dev/null | grep '/etcd:')
+  ctr --namespace k8s.io images pull "${image}"
+  ctr --namespace k8s.io run --rm \
+    --mount "type=bind,src=/var/lib,dst=/var/lib,options=rbind:rw" \
+    --mount "type=bind,src=${dir},dst=${dir},options=rbind:ro" \
+    "${image}" etcd-restore \
+    etcdutl "${args[@]}"
+else
+  echo "Failed to restore the etcd snapshot: either etcdutl or ctr must be available on the Machine" >&2
+  exit 1
+fi
+rm -f "${dir}/snapshot.db"
+`
+
+// SetEtcdRestore modifies a KubeadmConfig for kubeadm init so the etcd snapshot downloaded from
+// the given URL is verified against the given SHA-256 checksum and restored before kubeadm init is run.
+func SetEtcdRestore(kubeadmConfig *bootstrapv1.KubeadmConfig, snapshotName, url, sha256 string) {
+	if kubeadmConfig.Annotations == nil {
+		kubeadmConfig.Annotations = map[string]string{}
+	}
+	kubeadmConfig.Annotations[bootstrapv1.KubeadmInitForEtcdRestoreAnnotation] = snapshotName
+
+	spec := &kubeadmConfig.Spec
+	spec.Files = append(spec.Files,
+		bootstrapv1.File{
+			Path:        etcdRestoreURLPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     url,
+		},
+		bootstrapv1.File{
+			Path:        etcdRestoreSHA256Path,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     sha256,
+		},
+		bootstrapv1.File{
+			Path:        etcdRestoreScriptPath,
+			Owner:       "root:root",
+			Permissions: "0700",
+			Content:     etcdRestoreScript,
+		},
+	)
+	spec.PreKubeadmCommands = append(spec.PreKubeadmCommands, etcdRestoreScriptPath)
+	spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = append(spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, etcdRestorePreflightError)
+}
+
+// DropEtcdRestore removes from a KubeadmConfigSpec the changes applied by SetEtcdRestore, so
+// they are not considered when checking if a Machine needs rollout.
+func DropEtcdRestore(spec *bootstrapv1.KubeadmConfigSpec) {
+	spec.Files = slices.DeleteFunc(spec.Files, func(f bootstrapv1.File) bool {
+		return strings.HasPrefix(f.Path, etcdRestoreDirectory+"/")
+	})
+	spec.PreKubeadmCommands = slices.DeleteFunc(spec.PreKubeadmCommands, func(c string) bool {
+		return c == etcdRestoreScriptPath
+	})
+	spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = slices.DeleteFunc(spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, func(e string) bool {
+		return e == etcdRestorePreflightError
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desiredstate
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+func Test_SetAndDropEtcdRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	kubeadmConfig := &bootstrapv1.KubeadmConfig{
+		Spec: bootstrapv1.KubeadmConfigSpec{
+			Files: []bootstrapv1.File{
+				{Path: "/etc/foo", Content: "foo"},
+			},
+			PreKubeadmCommands: []string{"echo foo"},
+			InitConfiguration: bootstrapv1.InitConfiguration{
+				NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+					IgnorePreflightErrors: []string{"Swap"},
+				},
+			},
+		},
+	}
+	original := kubeadmConfig.Spec.DeepCopy()
+
+	sha256 := "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	SetEtcdRestore(kubeadmConfig, "snapshot", "https://storage.example.com/snapshot.db.gz", sha256)
+	g.Expect(kubeadmConfig.Annotations).To(HaveKeyWithValue(bootstrapv1.KubeadmInitForEtcdRestoreAnnotation, "snapshot"))
+	g.Expect(kubeadmConfig.Spec.Files).To(HaveLen(4))
+	g.Expect(kubeadmConfig.Spec.Files[1].Path).To(Equal(etcdRestoreURLPath))
+	g.Expect(kubeadmConfig.Spec.Files[1].Content).To(Equal("https://storage.example.com/snapshot.db.gz"))
+	g.Expect(kubeadmConfig.Spec.Files[2].Path).To(Equal(etcdRestoreSHA256Path))
+	g.Expect(kubeadmConfig.Spec.Files[2].Content).To(Equal(sha256))
+	// The snapshot is downloaded on the Machine only over https and verified against the checksum, and the etcd
+	// member uses the node name and the advertise address from the kubeadm config.
+	g.Expect(kubeadmConfig.Spec.Files[3].Content).To(ContainSubstring("--proto =https"))
+	g.Expect(kubeadmConfig.Spec.Files[3].Content).To(ContainSubstring("sha256sum --check"))
+	g.Expect(kubeadmConfig.Spec.Files[3].Content).To(ContainSubstring("nodeRegistration"))
+	g.Expect(kubeadmConfig.Spec.Files[3].Content).To(ContainSubstring("advertiseAddress"))
+	g.Expect(kubeadmConfig.Spec.PreKubeadmCommands).To(Equal([]string{"echo foo", etcdRestoreScriptPath}))
+	g.Expect(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors).To(Equal([]string{"Swap", etcdRestorePreflightError}))
+
+	DropEtcdRestore(&kubeadmConfig.Spec)
+	g.Expect(kubeadmConfig.Spec).To(Equal(*original))
+}
@@ -19,6 +19,7 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
@@ -38,12 +39,15 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	SnapshotWithVersion(ctx context.Context) (*clientv3.SnapshotResponse, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -90,6 +94,34 @@ var AlarmTypeName = map[AlarmType]string{
 	AlarmCorrupt: "CORRUPT",
 }
 
+// Snapshot is a point-in-time snapshot of the etcd keyspace streamed from an etcd member.
+// The snapshot must be closed by the caller once it has been consumed.
+type Snapshot struct {
+	io.ReadCloser
+
+	// Revision is the revision of the key-value store at the time the snapshot has been taken.
+	Revision int64
+
+	// Version is the version of the etcd member that produced the snapshot.
+	// Note: The version is reported only by etcd >= v3.6; it is empty for older versions.
+	Version string
+}
+
+// MemberStatus represents the status of the etcd member the client is connected to.
+type MemberStatus struct {
+	// MemberID is the ID of the member.
+	MemberID uint64
+
+	// LeaderID is the ID of the member the member considers the current leader.
+	LeaderID uint64
+
+	// DBSize is the size of the backend database physically allocated, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database logically in use, in bytes.
+	DBSizeInUse int64
+}
+
 // Adapted from kubeadm.
 
 // Member struct defines an etcd member; it is used to avoid spreading
@@ -255,3 +287,60 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// Snapshot streams a point-in-time snapshot of the etcd keyspace from the member the client is connected to.
+// Note: The call timeout is not applied to this call, because streaming a snapshot of a big keyspace
+// might take longer; callers are responsible for passing a context with an appropriate deadline.
+func (c *Client) Snapshot(ctx context.Context) (*Snapshot, error) {
+	response, err := c.EtcdClient.SnapshotWithVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd snapshot")
+	}
+
+	snapshot := &Snapshot{
+		ReadCloser: response.Snapshot,
+		Version:    response.Version,
+	}
+	if response.Header != nil {
+		snapshot.Revision = response.Header.GetRevision()
+	}
+	return snapshot, nil
+}
+
+// Status retrieves the status of the member the client is connected to.
+func (c *Client) Status(ctx context.Context) (*MemberStatus, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	response, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd member status")
+	}
+
+	return &MemberStatus{
+		MemberID:    response.Header.GetMemberId(),
+		LeaderID:    response.Leader,
+		DBSize:      response.DbSize,
+		DBSizeInUse: response.DbSizeInUse,
+	}, nil
+}
+
+// Defragment defragments the backend database of the member the client is connected to.
+// Note: The call timeout is not applied to this call, because defragmenting a big database
+// might take longer; callers are responsible for passing a context with an appropriate deadline.
+func (c *Client) Defragment(ctx context.Context) error {
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member: %s", c.Endpoint)
+}
+
+// DisarmAlarm disarms an alarm raised for a cluster member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm etcd alarm %s for member: %v", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
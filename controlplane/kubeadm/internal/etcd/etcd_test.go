@@ -17,6 +17,8 @@ limitations under the License.
 package etcd
 
 import (
+	"io"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -95,3 +97,94 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).ToNot(HaveOccurred())
 }
+
+func TestEtcdSnapshot(t *testing.T) {
+	t.Run("returns the snapshot stream with revision and version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints: []string{"https://etcd-instance:2379"},
+			SnapshotResponse: &clientv3.SnapshotResponse{
+				Header:   &etcdserverpb.ResponseHeader{Revision: 42},
+				Snapshot: io.NopCloser(strings.NewReader("snapshot-data")),
+				Version:  "3.6.0",
+			},
+			StatusResponse: &clientv3.StatusResponse{},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		snapshot, err := client.Snapshot(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		defer snapshot.Close()
+
+		g.Expect(snapshot.Revision).To(Equal(int64(42)))
+		g.Expect(snapshot.Version).To(Equal("3.6.0"))
+		data, err := io.ReadAll(snapshot)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(Equal("snapshot-data"))
+	})
+	t.Run("returns an error if the snapshot cannot be taken", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+			SnapshotError:  errors.New("something went wrong"),
+			StatusResponse: &clientv3.StatusResponse{},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.Snapshot(ctx)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestEtcdMaintenance(t *testing.T) {
+	t.Run("returns the member status, defragments the member and disarms alarms", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints: []string{"https://etcd-instance:2379"},
+			StatusResponse: &clientv3.StatusResponse{
+				Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+				Leader:      5678,
+				DbSize:      100,
+				DbSizeInUse: 40,
+			},
+			DefragmentResponse:  &clientv3.DefragmentResponse{},
+			AlarmDisarmResponse: &clientv3.AlarmResponse{},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		status, err := client.Status(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(Equal(&MemberStatus{MemberID: 1234, LeaderID: 5678, DBSize: 100, DBSizeInUse: 40}))
+
+		g.Expect(client.Defragment(ctx)).To(Succeed())
+		g.Expect(fakeEtcdClient.DefragmentedMember).To(Equal("https://etcd-instance:2379"))
+
+		g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).To(Succeed())
+		g.Expect(fakeEtcdClient.DisarmedAlarmMember).To(Equal(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
+	})
+	t.Run("returns errors if maintenance operations fail", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:    []string{"https://etcd-instance:2379"},
+			StatusResponse:   &clientv3.StatusResponse{},
+			DefragmentError:  errors.New("something went wrong"),
+			AlarmDisarmError: errors.New("something went wrong"),
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(client.Defragment(ctx)).ToNot(Succeed())
+		g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).ToNot(Succeed())
+	})
+}
@@ -29,6 +29,12 @@ type FakeEtcdClient struct { //nolint:revive
 	AlarmResponse *clientv3.AlarmResponse
 	AlarmError    error
 
+	AlarmDisarmResponse *clientv3.AlarmResponse
+	AlarmDisarmError    error
+
+	DefragmentResponse *clientv3.DefragmentResponse
+	DefragmentError    error
+
 	MemberListResponse *clientv3.MemberListResponse
 	MemberListError    error
 
@@ -38,11 +44,16 @@ type FakeEtcdClient struct { //nolint:revive
 	MoveLeaderResponse *clientv3.MoveLeaderResponse
 	MoveLeaderError    error
 
+	SnapshotResponse *clientv3.SnapshotResponse
+	SnapshotError    error
+
 	StatusResponse *clientv3.StatusResponse
 	StatusError    error
 
-	MovedLeader   uint64
-	RemovedMember uint64
+	MovedLeader         uint64
+	RemovedMember       uint64
+	DefragmentedMember  string
+	DisarmedAlarmMember *clientv3.AlarmMember
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -58,6 +69,16 @@ func (c *FakeEtcdClient) Close() error {
 	return nil
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarmMember = m
+	return c.AlarmDisarmResponse, c.AlarmDisarmError
+}
+
+func (c *FakeEtcdClient) Defragment(_ context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	c.DefragmentedMember = endpoint
+	return c.DefragmentResponse, c.DefragmentError
+}
+
 func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse, error) {
 	return c.AlarmResponse, c.AlarmError
 }
@@ -69,6 +90,9 @@ func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.Me
 	c.RemovedMember = i
 	return c.MemberRemoveResponse, c.MemberRemoveError
 }
+func (c *FakeEtcdClient) SnapshotWithVersion(_ context.Context) (*clientv3.SnapshotResponse, error) {
+	return c.SnapshotResponse, c.SnapshotError
+}
 func (c *FakeEtcdClient) Status(_ context.Context, _ string) (*clientv3.StatusResponse, error) {
 	return c.StatusResponse, c.StatusError
 }
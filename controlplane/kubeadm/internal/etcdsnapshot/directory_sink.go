@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+// DirectorySink stores etcd snapshots as files in a directory, e.g. a persistent volume mounted in the controller Pod.
+// Files are stored in <directory>/<namespace>/<cluster name>/<EtcdSnapshot name>.db.gz.
+type DirectorySink struct {
+	Directory string
+}
+
+var _ Sink = &DirectorySink{}
+
+// Save stores the data read from r in a file.
+// Data are written to a temporary file first, so a partially written snapshot never replaces a complete one.
+func (s *DirectorySink) Save(_ context.Context, snapshot *controlplanev1.EtcdSnapshot, r io.Reader) (string, int64, error) {
+	path := s.path(snapshot)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", 0, errors.Wrapf(err, "failed to create directory for etcd snapshot %s", snapshot.Name)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "failed to create file for etcd snapshot %s", snapshot.Name)
+	}
+	defer func() {
+		// Note: Removing the temporary file is a no-op if it has been renamed.
+		_ = os.Remove(f.Name())
+	}()
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return "", 0, errors.Wrapf(err, "failed to write etcd snapshot %s", snapshot.Name)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return "", 0, errors.Wrapf(err, "failed to sync etcd snapshot %s", snapshot.Name)
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, errors.Wrapf(err, "failed to close file for etcd snapshot %s", snapshot.Name)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", 0, errors.Wrapf(err, "failed to rename file for etcd snapshot %s", snapshot.Name)
+	}
+
+	return "file://" + path, size, nil
+}
+
+// Load returns a reader for the file of the EtcdSnapshot.
+func (s *DirectorySink) Load(_ context.Context, snapshot *controlplanev1.EtcdSnapshot) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(snapshot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open file for etcd snapshot %s", snapshot.Name)
+	}
+	return f, nil
+}
+
+// Delete deletes the file of the EtcdSnapshot.
+func (s *DirectorySink) Delete(_ context.Context, snapshot *controlplanev1.EtcdSnapshot) error {
+	if err := os.Remove(s.path(snapshot)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete file for etcd snapshot %s", snapshot.Name)
+	}
+	return nil
+}
+
+func (s *DirectorySink) path(snapshot *controlplanev1.EtcdSnapshot) string {
+	return filepath.Join(s.Directory, snapshot.Namespace, snapshot.Spec.ClusterName, snapshot.Name+".db.gz")
+}
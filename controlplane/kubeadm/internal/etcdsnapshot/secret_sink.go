@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+const (
+	// secretChunkSize is the maximum size of data stored in a single Secret.
+	// Note: Secret data are base64 encoded when stored, so the chunk size must be
+	// kept well below the 1MiB limit of a single object.
+	secretChunkSize = 512 * 1024
+
+	// secretChunkIndexKey is the key of the Secret data containing the index of the chunk.
+	secretChunkIndexKey = "index"
+
+	// secretChunkDataKey is the key of the Secret data containing the chunk.
+	secretChunkDataKey = "data"
+)
+
+// SecretSink stores etcd snapshots in one or more Secrets in the namespace of the EtcdSnapshot.
+// Each Secret contains a chunk of the snapshot, and it is owned by the EtcdSnapshot.
+type SecretSink struct {
+	Client client.Client
+
+	// chunkSize allows overriding secretChunkSize in tests.
+	chunkSize int
+}
+
+var _ Sink = &SecretSink{}
+
+// Save stores the data read from r in one or more Secrets.
+func (s *SecretSink) Save(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot, r io.Reader) (string, int64, error) {
+	// Delete data left over by previous attempts, if any.
+	if err := s.Delete(ctx, snapshot); err != nil {
+		return "", 0, err
+	}
+
+	chunkSize := s.chunkSize
+	if chunkSize == 0 {
+		chunkSize = secretChunkSize
+	}
+
+	buf := make([]byte, chunkSize)
+	var size int64
+	for i := 0; ; i++ {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if createErr := s.createChunk(ctx, snapshot, i, buf[:n]); createErr != nil {
+				return "", 0, createErr
+			}
+			size += int64(n)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return "", 0, errors.Wrap(err, "failed to read etcd snapshot")
+		}
+	}
+
+	return fmt.Sprintf("secret://%s/%s", snapshot.Namespace, snapshot.Name), size, nil
+}
+
+func (s *SecretSink) createChunk(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot, index int, data []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: snapshot.Namespace,
+			Name:      fmt.Sprintf("%s-%d", snapshot.Name, index),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           snapshot.Spec.ClusterName,
+				controlplanev1.EtcdSnapshotNameLabel: format.MustFormatValue(snapshot.Name),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(snapshot, controlplanev1.GroupVersion.WithKind("EtcdSnapshot")),
+			},
+		},
+		Type: clusterv1.ClusterSecretType,
+		Data: map[string][]byte{
+			secretChunkIndexKey: []byte(strconv.Itoa(index)),
+			secretChunkDataKey:  bytes.Clone(data),
+		},
+	}
+	if err := s.Client.Create(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to create Secret %s for etcd snapshot chunk %d", secret.Name, index)
+	}
+	return nil
+}
+
+// Load returns a reader for the data stored in the Secrets of the EtcdSnapshot.
+func (s *SecretSink) Load(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) (io.ReadCloser, error) {
+	secrets, err := s.listChunks(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 {
+		return nil, errors.Errorf("no Secrets found for etcd snapshot %s", snapshot.Name)
+	}
+
+	type chunk struct {
+		index int
+		data  []byte
+	}
+	chunks := make([]chunk, 0, len(secrets))
+	for _, secret := range secrets {
+		index, err := strconv.Atoi(string(secret.Data[secretChunkIndexKey]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse chunk index from Secret %s", secret.Name)
+		}
+		chunks = append(chunks, chunk{index: index, data: secret.Data[secretChunkDataKey]})
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].index < chunks[j].index
+	})
+
+	readers := make([]io.Reader, 0, len(chunks))
+	for i, c := range chunks {
+		if c.index != i {
+			return nil, errors.Errorf("chunk %d of etcd snapshot %s is missing", i, snapshot.Name)
+		}
+		readers = append(readers, bytes.NewReader(c.data))
+	}
+	return io.NopCloser(io.MultiReader(readers...)), nil
+}
+
+// Delete deletes the Secrets of the EtcdSnapshot.
+func (s *SecretSink) Delete(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) error {
+	secrets, err := s.listChunks(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		if err := s.Client.Delete(ctx, &secrets[i]); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete Secret %s", secrets[i].Name)
+		}
+	}
+	return nil
+}
+
+func (s *SecretSink) listChunks(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) ([]corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	if err := s.Client.List(ctx, secretList,
+		client.InNamespace(snapshot.Namespace),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel:           snapshot.Spec.ClusterName,
+			controlplanev1.EtcdSnapshotNameLabel: format.MustFormatValue(snapshot.Name),
+		},
+	); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Secrets for etcd snapshot %s", snapshot.Name)
+	}
+
+	// Only consider Secrets owned by the EtcdSnapshot, because label values might be hashed.
+	secrets := make([]corev1.Secret, 0, len(secretList.Items))
+	for _, secret := range secretList.Items {
+		if metav1.IsControlledBy(&secret, snapshot) {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets, nil
+}
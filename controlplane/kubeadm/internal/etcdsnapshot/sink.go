@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcdsnapshot implements the sinks where etcd snapshots are stored.
+package etcdsnapshot
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+// Sink stores the data of etcd snapshots.
+// Note: Data are opaque for the Sink; compressing snapshots is a responsibility of the caller.
+type Sink interface {
+	// Save stores the data read from r for the given EtcdSnapshot, replacing data
+	// previously stored for the same EtcdSnapshot if any.
+	// It returns the location of the data in the sink and the number of bytes stored.
+	Save(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot, r io.Reader) (string, int64, error)
+
+	// Load returns a reader for the data stored for the given EtcdSnapshot.
+	// The reader must be closed by the caller.
+	Load(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) (io.ReadCloser, error)
+
+	// Delete deletes the data stored for the given EtcdSnapshot.
+	// Deleting data which do not exist is not an error.
+	Delete(ctx context.Context, snapshot *controlplanev1.EtcdSnapshot) error
+}
+
+// NewSink returns the Sink for the given EtcdSnapshot.
+// The directory is the root directory used by the Directory sink; if empty, the Directory sink cannot be used.
+func NewSink(c client.Client, directory string, snapshot *controlplanev1.EtcdSnapshot) (Sink, error) {
+	switch snapshot.Spec.Sink.Type {
+	case controlplanev1.EtcdSnapshotSecretSinkType:
+		return &SecretSink{Client: c}, nil
+	case controlplanev1.EtcdSnapshotDirectorySinkType:
+		if directory == "" {
+			return nil, errors.New("the Directory sink cannot be used because the --etcd-snapshot-directory flag is not set")
+		}
+		return &DirectorySink{Directory: directory}, nil
+	default:
+		return nil, errors.Errorf("unknown sink type %q", snapshot.Spec.Sink.Type)
+	}
+}
+
+// Compress returns a reader streaming the gzip compressed data read from r.
+// The returned reader must be closed by the caller; closing the reader before all the data have
+// been consumed stops the compression.
+func Compress(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		gw := gzip.NewWriter(pw)
+		_, err := io.Copy(gw, r)
+		if closeErr := gw.Close(); err == nil {
+			err = closeErr
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+func TestNewSink(t *testing.T) {
+	g := NewWithT(t)
+
+	snapshot := newEtcdSnapshot(controlplanev1.EtcdSnapshotSecretSinkType)
+	sink, err := NewSink(nil, "", snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sink).To(BeAssignableToTypeOf(&SecretSink{}))
+
+	snapshot = newEtcdSnapshot(controlplanev1.EtcdSnapshotDirectorySinkType)
+	_, err = NewSink(nil, "", snapshot)
+	g.Expect(err).To(HaveOccurred())
+
+	sink, err = NewSink(nil, "/snapshots", snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sink).To(BeAssignableToTypeOf(&DirectorySink{}))
+
+	snapshot = newEtcdSnapshot("Unknown")
+	_, err = NewSink(nil, "/snapshots", snapshot)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCompress(t *testing.T) {
+	g := NewWithT(t)
+
+	r := Compress(strings.NewReader("0123456789"))
+	gr, err := gzip.NewReader(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	data, err := io.ReadAll(gr)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Close()).To(Succeed())
+	g.Expect(string(data)).To(Equal("0123456789"))
+}
+
+func TestSecretSink(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(controlplanev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	snapshot := newEtcdSnapshot(controlplanev1.EtcdSnapshotSecretSinkType)
+	sink := &SecretSink{Client: c, chunkSize: 4}
+
+	// Save splits data in chunks.
+	location, size, err := sink.Save(ctx, snapshot, strings.NewReader("0123456789"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(location).To(Equal("secret://default/snapshot"))
+	g.Expect(size).To(Equal(int64(10)))
+
+	secrets := &corev1.SecretList{}
+	g.Expect(c.List(ctx, secrets, client.InNamespace("default"))).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(3))
+
+	// Load returns chunks in order.
+	r, err := sink.Load(ctx, snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	data, err := io.ReadAll(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Close()).To(Succeed())
+	g.Expect(string(data)).To(Equal("0123456789"))
+
+	// Save replaces data previously stored.
+	_, _, err = sink.Save(ctx, snapshot, strings.NewReader("abcd"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.List(ctx, secrets, client.InNamespace("default"))).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(1))
+
+	// Delete removes all the chunks, and it is a no-op if called again.
+	g.Expect(sink.Delete(ctx, snapshot)).To(Succeed())
+	g.Expect(sink.Delete(ctx, snapshot)).To(Succeed())
+	g.Expect(c.List(ctx, secrets, client.InNamespace("default"))).To(Succeed())
+	g.Expect(secrets.Items).To(BeEmpty())
+
+	_, err = sink.Load(ctx, snapshot)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestDirectorySink(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	snapshot := newEtcdSnapshot(controlplanev1.EtcdSnapshotDirectorySinkType)
+	sink := &DirectorySink{Directory: dir}
+
+	location, size, err := sink.Save(ctx, snapshot, strings.NewReader("0123456789"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(location).To(Equal("file://" + dir + "/default/cluster/snapshot.db.gz"))
+	g.Expect(size).To(Equal(int64(10)))
+
+	r, err := sink.Load(ctx, snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	data, err := io.ReadAll(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Close()).To(Succeed())
+	g.Expect(string(data)).To(Equal("0123456789"))
+
+	g.Expect(sink.Delete(ctx, snapshot)).To(Succeed())
+	g.Expect(sink.Delete(ctx, snapshot)).To(Succeed())
+
+	_, err = sink.Load(ctx, snapshot)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func newEtcdSnapshot(sinkType controlplanev1.EtcdSnapshotSinkType) *controlplanev1.EtcdSnapshot {
+	return &controlplanev1.EtcdSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "snapshot",
+			UID:       "uid",
+		},
+		Spec: controlplanev1.EtcdSnapshotSpec{
+			ClusterName: "cluster",
+			Sink: controlplanev1.EtcdSnapshotSink{
+				Type: sinkType,
+			},
+		},
+	}
+}
@@ -90,6 +90,17 @@ func UpToDate(
 		res.EligibleForInPlaceUpdate = false
 	}
 
+	// Machines that must be replaced as part of the current phase of a certificate authority rotation.
+	certificateAuthorityRotation, err := CertificateAuthorityRotationDataFromAnnotation(kcp)
+	if err != nil {
+		return false, nil, err
+	}
+	if certificateAuthorityRotation.NeedsRollout(machine) {
+		res.LogMessages = append(res.LogMessages, fmt.Sprintf("certificate authority rotation phase %s in progress", certificateAuthorityRotation.Phase))
+		res.ConditionMessages = append(res.ConditionMessages, "Certificate authority rotation in progress")
+		res.EligibleForInPlaceUpdate = false
+	}
+
 	// Machines that do not match with KCP config.
 	// Note: matchesMachineSpec will update res with desired and current objects if necessary.
 	matches, specLogMessages, specConditionMessages, err := matchesMachineSpec(ctx, c, infraMachines, kubeadmConfigs, kcp, cluster, machine, res)
@@ -306,6 +317,10 @@ func PrepareKubeadmConfigsForDiff(desiredKubeadmConfig, currentKubeadmConfig *bo
 	desiredKubeadmConfig = desiredKubeadmConfig.DeepCopy()
 	currentKubeadmConfig = currentKubeadmConfig.DeepCopy()
 
+	// Ignore files, commands and preflight errors added by KCP to restore an etcd snapshot on the init Machine.
+	// Note: Those changes are relevant only for the kubeadm init process, so they should never trigger a rollout.
+	desiredstate.DropEtcdRestore(&currentKubeadmConfig.Spec)
+
 	if convertCurrentInitConfigurationToJoinConfiguration && isKubeadmConfigForInit(currentKubeadmConfig) {
 		// Convert InitConfiguration to JoinConfiguration
 		currentKubeadmConfig.Spec.JoinConfiguration.Timeouts = currentKubeadmConfig.Spec.InitConfiguration.Timeouts
@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -1675,6 +1676,40 @@ func TestUpToDate(t *testing.T) {
 			expectLogMessages:              []string{"rolloutAfter expired"},
 			expectConditionMessages:        []string{"KubeadmControlPlane spec.rolloutAfter expired"},
 		},
+		{
+			name: "certificate authority rotation phase started after the machine was created",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Annotations = map[string]string{
+					controlplanev1.CertificateAuthorityRotationAnnotation: fmt.Sprintf(`{"after":"2025-01-01T00:00:00Z","phase":"Sign","phaseStartTime":%q}`, reconciliationTime.Add(-1*24*time.Hour).UTC().Format(time.RFC3339)), // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 false,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              []string{"certificate authority rotation phase Sign in progress"},
+			expectConditionMessages:        []string{"Certificate authority rotation in progress"},
+		},
+		{
+			name: "certificate authority rotation completed",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Annotations = map[string]string{
+					controlplanev1.CertificateAuthorityRotationAnnotation: fmt.Sprintf(`{"after":"2025-01-01T00:00:00Z","phase":"Completed","phaseStartTime":%q}`, reconciliationTime.Add(-1*24*time.Hour).UTC().Format(time.RFC3339)), // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 true,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              nil,
+			expectConditionMessages:        nil,
+		},
 		{
 			name: "kubernetes version does not match",
 			kcp: func() *controlplanev1.KubeadmControlPlane {
@@ -71,9 +71,13 @@ func (webhook *KubeadmControlPlane) Default(_ context.Context, k *controlplanev1
 		k.Spec.Version = "v" + k.Spec.Version
 	}
 
-	// Enforce RollingUpdate strategy and default MaxSurge if not set.
-	k.Spec.Rollout.Strategy.Type = controlplanev1.RollingUpdateStrategyType
-	k.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = intstr.ValueOrDefault(k.Spec.Rollout.Strategy.RollingUpdate.MaxSurge, intstr.FromInt32(1))
+	// Default to RollingUpdate strategy and default MaxSurge if not set.
+	if k.Spec.Rollout.Strategy.Type == "" {
+		k.Spec.Rollout.Strategy.Type = controlplanev1.RollingUpdateStrategyType
+	}
+	if k.Spec.Rollout.Strategy.Type == controlplanev1.RollingUpdateStrategyType {
+		k.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = intstr.ValueOrDefault(k.Spec.Rollout.Strategy.RollingUpdate.MaxSurge, intstr.FromInt32(1))
+	}
 	return nil
 }
 
@@ -194,6 +198,10 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldK, newK
 		{spec, "machineNaming", "*"},
 		{spec, "rollout"},
 		{spec, "rollout", "*"},
+		{spec, "etcdMaintenance"},
+		{spec, "etcdMaintenance", "*"},
+		{spec, "certificateAuthorityRotation"},
+		{spec, "certificateAuthorityRotation", "*"},
 	}
 
 	allErrs := validateKubeadmControlPlaneSpec(newK.Spec, field.NewPath("spec"))
@@ -323,6 +331,7 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, p
 
 	allErrs = append(allErrs, validateRolloutAndCertValidityFields(s.Rollout, s.KubeadmConfigSpec.ClusterConfiguration, s.Replicas, pathPrefix)...)
 	allErrs = append(allErrs, validateNaming(s.MachineNaming, pathPrefix.Child("machineNaming"))...)
+	allErrs = append(allErrs, validateEtcdMaintenance(s.EtcdMaintenance, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcdMaintenance"))...)
 	return allErrs
 }
 
@@ -335,12 +344,34 @@ func validateRolloutAndCertValidityFields(rolloutSpec controlplanev1.KubeadmCont
 		return nil
 	}
 
-	if rolloutStrategy.Type != controlplanev1.RollingUpdateStrategyType {
+	switch rolloutStrategy.Type {
+	case controlplanev1.RollingUpdateStrategyType:
+		// Note: rollingUpdate is validated below.
+	case controlplanev1.ScaleInStrategyType:
+		if !reflect.DeepEqual(rolloutStrategy.RollingUpdate, controlplanev1.KubeadmControlPlaneRolloutStrategyRollingUpdate{}) {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					pathPrefix.Child("rollout", "strategy", "rollingUpdate"),
+					"rollingUpdate can only be set when type is RollingUpdate",
+				),
+			)
+		}
+		if replicas != nil && *replicas < int32(3) {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("rollout", "strategy", "type"),
+					"when KubeadmControlPlane is configured to scale-in, replica count needs to be at least 3",
+				),
+			)
+		}
+	default:
 		allErrs = append(
 			allErrs,
 			field.Required(
 				pathPrefix.Child("rollout", "strategy", "type"),
-				"only RollingUpdate is supported",
+				"only RollingUpdate and ScaleIn are supported",
 			),
 		)
 	}
@@ -438,6 +469,22 @@ func validateNaming(machineNaming controlplanev1.MachineNamingSpec, pathPrefix *
 	return allErrs
 }
 
+func validateEtcdMaintenance(etcdMaintenance controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec, clusterConfiguration bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if clusterConfiguration.Etcd.External.IsDefined() && !reflect.DeepEqual(etcdMaintenance, controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{}) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"cannot be set when etcd is external",
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateClusterConfiguration(oldClusterConfiguration, newClusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -74,6 +74,12 @@ func TestKubeadmControlPlaneDefault(t *testing.T) {
 	g.Expect(kcp.Spec.Version).To(Equal("v1.18.3"))
 	g.Expect(kcp.Spec.Rollout.Strategy.Type).To(Equal(controlplanev1.RollingUpdateStrategyType))
 	g.Expect(kcp.Spec.Rollout.Strategy.RollingUpdate.MaxSurge.IntVal).To(Equal(int32(1)))
+
+	scaleInKCP := updateDefaultingValidationKCP.DeepCopy()
+	scaleInKCP.Spec.Rollout.Strategy.Type = controlplanev1.ScaleInStrategyType
+	g.Expect(webhook.Default(ctx, scaleInKCP)).To(Succeed())
+	g.Expect(scaleInKCP.Spec.Rollout.Strategy.Type).To(Equal(controlplanev1.ScaleInStrategyType))
+	g.Expect(scaleInKCP.Spec.Rollout.Strategy.RollingUpdate.MaxSurge).To(BeNil())
 }
 
 func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
@@ -117,6 +123,18 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	val := intstr.FromString("1")
 	stringMaxSurge.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = &val
 
+	validScaleIn := valid.DeepCopy()
+	validScaleIn.Spec.Replicas = ptr.To[int32](3)
+	validScaleIn.Spec.Rollout.Strategy = controlplanev1.KubeadmControlPlaneRolloutStrategy{
+		Type: controlplanev1.ScaleInStrategyType,
+	}
+
+	wrongReplicaCountForScaleInStrategy := validScaleIn.DeepCopy()
+	wrongReplicaCountForScaleInStrategy.Spec.Replicas = ptr.To[int32](1)
+
+	rollingUpdateWithScaleInStrategy := validScaleIn.DeepCopy()
+	rollingUpdateWithScaleInStrategy.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(0))
+
 	missingReplicas := valid.DeepCopy()
 	missingReplicas.Spec.Replicas = nil
 
@@ -189,6 +207,14 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.Rollout.Before.CertificatesExpiryDays = 8
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.KubeadmConfigSpec.ClusterConfiguration.CertificateValidityPeriodDays = 7
 
+	validEtcdMaintenance := valid.DeepCopy()
+	validEtcdMaintenance.Spec.EtcdMaintenance.Defragmentation.FragmentationThresholdPercent = ptr.To[int32](50)
+
+	invalidEtcdMaintenanceExternalEtcd := validEtcdMaintenance.DeepCopy()
+	invalidEtcdMaintenanceExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = bootstrapv1.ExternalEtcd{
+		Endpoints: []string{"https://etcd:2379"},
+	}
+
 	tests := []struct {
 		name                  string
 		enableIgnitionFeature bool
@@ -250,6 +276,21 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       stringMaxSurge,
 		},
+		{
+			name:      "should succeed when using the ScaleIn strategy",
+			expectErr: false,
+			kcp:       validScaleIn,
+		},
+		{
+			name:      "should return error when using the ScaleIn strategy and replica count is < 3",
+			expectErr: true,
+			kcp:       wrongReplicaCountForScaleInStrategy,
+		},
+		{
+			name:      "should return error when using the ScaleIn strategy and rollingUpdate is set",
+			expectErr: true,
+			kcp:       rollingUpdateWithScaleInStrategy,
+		},
 		{
 			name:                  "should return error when Ignition configuration is invalid",
 			enableIgnitionFeature: true,
@@ -292,6 +333,16 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificatesExpiryDays,
 		},
+		{
+			name:      "should succeed when etcdMaintenance is set",
+			expectErr: false,
+			kcp:       validEtcdMaintenance,
+		},
+		{
+			name:      "should return error when etcdMaintenance is set and etcd is external",
+			expectErr: true,
+			kcp:       invalidEtcdMaintenanceExternalEtcd,
+		},
 	}
 
 	for _, tt := range tests {
@@ -775,6 +826,15 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		Effect: corev1.TaintEffectNoSchedule,
 	})
 
+	etcdMaintenance := before.DeepCopy()
+	etcdMaintenance.Spec.EtcdMaintenance.Defragmentation = controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+		DBSizeThresholdBytes: ptr.To[int64](1024 * 1024 * 1024),
+		MinIntervalSeconds:   ptr.To[int32](600),
+	}
+
+	certificateAuthorityRotation := before.DeepCopy()
+	certificateAuthorityRotation.Spec.CertificateAuthorityRotation.After = metav1.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name                  string
 		enableIgnitionFeature bool
@@ -1109,6 +1169,18 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:                before,
 			kcp:                   invalidMetadata,
 		},
+		{
+			name:      "should succeed when changing etcdMaintenance",
+			expectErr: false,
+			before:    before,
+			kcp:       etcdMaintenance,
+		},
+		{
+			name:      "should succeed when changing certificateAuthorityRotation",
+			expectErr: false,
+			before:    before,
+			kcp:       certificateAuthorityRotation,
+		},
 		{
 			name:      "should succeed when changing timeouts",
 			expectErr: false,
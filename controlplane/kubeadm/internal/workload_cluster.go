@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
@@ -35,7 +36,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/retry"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -44,6 +47,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/desiredstate"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/util/certs"
 	containerutil "sigs.k8s.io/cluster-api/util/container"
@@ -79,8 +83,13 @@ type WorkloadCluster interface {
 	UpdateEncryptionAlgorithm(encryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) func(*bootstrapv1.ClusterConfiguration)
 	UpdateKubeProxyImageInfo(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	UpdateCoreDNS(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
+	UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error
 	RemoveEtcdMember(ctx context.Context, name string, nodes []*Node) error
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine, nodes []*Node) error
+	EtcdSnapshot(ctx context.Context) (*etcd.Snapshot, error)
+	EtcdMembersStatus(ctx context.Context, nodes []*Node) ([]*EtcdMemberStatus, error)
+	DefragmentEtcdMember(ctx context.Context, name string) error
+	DisarmEtcdAlarm(ctx context.Context, alarm etcd.MemberAlarm, nodes []*Node) error
 	AllowClusterAdminPermissions(ctx context.Context, version semver.Version) error
 	UpdateClusterConfiguration(ctx context.Context, version semver.Version, mutators ...func(*bootstrapv1.ClusterConfiguration)) error
 }
@@ -395,6 +404,43 @@ func (w *Workload) UpdateKubeProxyImageInfo(ctx context.Context, kcp *controlpla
 	return nil
 }
 
+// UpdateClusterInfoCertificateAuthorities updates the certificate authorities in the cluster-info ConfigMap;
+// kubeadm join uses this ConfigMap for discovery, and joining nodes trust the certificate authorities it contains.
+// Note: the kube-controller-manager bootstrap signer re-signs the ConfigMap after it is updated.
+func (w *Workload) UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error {
+	cm := &corev1.ConfigMap{}
+	if err := w.Client.Get(ctx, client.ObjectKey{Name: bootstrapapi.ConfigMapClusterInfo, Namespace: metav1.NamespacePublic}, cm); err != nil {
+		return errors.Wrapf(err, "failed to get %s ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+
+	config, err := clientcmd.Load([]byte(cm.Data[bootstrapapi.KubeConfigKey]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse kubeconfig from %s ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+
+	changed := false
+	for _, cluster := range config.Clusters {
+		if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+			cluster.CertificateAuthorityData = caData
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	out, err := clientcmd.Write(*config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize kubeconfig for %s ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+	original := cm.DeepCopy()
+	cm.Data[bootstrapapi.KubeConfigKey] = string(out)
+	if err := w.Client.Patch(ctx, cm, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "failed to update %s ConfigMap", bootstrapapi.ConfigMapClusterInfo)
+	}
+	return nil
+}
+
 func findKubeProxyContainer(ds *appsv1.DaemonSet) *corev1.Container {
 	containers := ds.Spec.Template.Spec.Containers
 	for idx := range containers {
@@ -18,8 +18,11 @@ package internal
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -136,8 +139,147 @@ func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1
 	return nil
 }
 
+// EtcdSnapshot streams a snapshot of the etcd keyspace from the first available etcd member.
+// The snapshot must be closed by the caller once it has been consumed; closing the snapshot
+// also closes the underlying etcd client.
+func (w *Workload) EtcdSnapshot(ctx context.Context) (*etcd.Snapshot, error) {
+	nodes, err := w.getNodesWithControlPlaneLabel(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etcd client")
+	}
+
+	snapshot, err := etcdClient.Snapshot(ctx)
+	if err != nil {
+		_ = etcdClient.Close()
+		return nil, err
+	}
+
+	// Wrap the snapshot so the etcd client is closed only after the snapshot has been consumed.
+	snapshot.ReadCloser = &etcdSnapshotReadCloser{ReadCloser: snapshot.ReadCloser, etcdClient: etcdClient}
+	return snapshot, nil
+}
+
+// etcdSnapshotReadCloser closes the etcd client used to stream a snapshot when the snapshot is closed.
+type etcdSnapshotReadCloser struct {
+	io.ReadCloser
+	etcdClient *etcd.Client
+}
+
+func (r *etcdSnapshotReadCloser) Close() error {
+	return kerrors.NewAggregate([]error{r.ReadCloser.Close(), r.etcdClient.Close()})
+}
+
+// etcdDefragmentationTimeout is the max time allowed for defragmenting an etcd member.
+const etcdDefragmentationTimeout = 2 * time.Minute
+
+// EtcdMembersStatus returns the status of the etcd members hosted on the given nodes, including alarms raised for each member.
+// Note: this operation relies on the assumption that node name is equal to the name of the corresponding etcd member.
+func (w *Workload) EtcdMembersStatus(ctx context.Context, nodes []*Node) ([]*EtcdMemberStatus, error) {
+	var alarms []etcd.MemberAlarm
+	alarmsRead := false
+	statuses := make([]*EtcdMemberStatus, 0, len(nodes))
+	for _, node := range nodes {
+		memberStatus := &EtcdMemberStatus{Name: node.Name}
+		statuses = append(statuses, memberStatus)
+
+		etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{node.Name})
+		if err != nil {
+			continue
+		}
+
+		status, err := etcdClient.Status(ctx)
+		if err == nil && !alarmsRead {
+			// Alarms are read from the first responsive member, because AlarmList returns alarms for all the members.
+			if alarms, err = etcdClient.Alarms(ctx); err != nil {
+				_ = etcdClient.Close()
+				return nil, err
+			}
+			alarmsRead = true
+		}
+		_ = etcdClient.Close()
+		if err != nil {
+			continue
+		}
+
+		memberStatus.Responsive = true
+		memberStatus.ID = status.MemberID
+		memberStatus.IsLeader = status.MemberID == status.LeaderID
+		memberStatus.DBSize = status.DBSize
+		memberStatus.DBSizeInUse = status.DBSizeInUse
+	}
+
+	for _, alarm := range alarms {
+		if alarm.Type == etcd.AlarmOK {
+			continue
+		}
+		for _, memberStatus := range statuses {
+			if memberStatus.Responsive && memberStatus.ID == alarm.MemberID {
+				memberStatus.Alarms = append(memberStatus.Alarms, alarm.Type)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// DefragmentEtcdMember defragments the backend database of the etcd member with the given name.
+// Note: this operation relies on the assumption that node name is equal to the name of the corresponding etcd member.
+// Note: Defragmentation blocks the member while it is in progress; it is a responsibility of the caller to ensure
+// that the member is not the leader and that members are defragmented one at a time.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, name string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{name})
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	ctx, cancel := context.WithTimeoutCause(ctx, etcdDefragmentationTimeout, errors.New("defragmentation timeout expired"))
+	defer cancel()
+
+	return etcdClient.Defragment(ctx)
+}
+
+// DisarmEtcdAlarm disarms an alarm raised for an etcd member.
+func (w *Workload) DisarmEtcdAlarm(ctx context.Context, alarm etcd.MemberAlarm, nodes []*Node) error {
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.DisarmAlarm(ctx, alarm)
+}
+
 // EtcdMemberStatus contains status information for a single etcd member.
 type EtcdMemberStatus struct {
 	Name       string
 	Responsive bool
+
+	// ID is the ID of the etcd member.
+	// Note: ID and the fields below are set only if the member is responsive.
+	ID uint64
+
+	// IsLeader is true if the member is the etcd leader.
+	IsLeader bool
+
+	// DBSize is the size of the backend database physically allocated, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database logically in use, in bytes.
+	DBSizeInUse int64
+
+	// Alarms is the list of alarms raised for the member.
+	Alarms []etcd.AlarmType
 }
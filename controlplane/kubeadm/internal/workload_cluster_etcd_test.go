@@ -18,6 +18,8 @@ package internal
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/blang/semver/v4"
@@ -532,6 +534,169 @@ func TestForwardEtcdLeadership(t *testing.T) {
 	})
 }
 
+func TestEtcdSnapshot(t *testing.T) {
+	cp1Node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		expectErr           bool
+		expectedData        string
+	}{
+		{
+			name:                "returns an error if it fails to create the etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+			expectErr:           true,
+		},
+		{
+			name: "returns an error if the client errors taking the snapshot",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotError: errors.New("cannot take snapshot"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "streams the snapshot",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					if len(n) != 1 || n[0] != "cp1" {
+						return nil, errors.Errorf("unexpected nodes %v", n)
+					}
+					return &etcd.Client{
+						EtcdClient: &fake2.FakeEtcdClient{
+							SnapshotResponse: &clientv3.SnapshotResponse{
+								Header:   &pb.ResponseHeader{Revision: 10},
+								Snapshot: io.NopCloser(strings.NewReader("snapshot-data")),
+							},
+						},
+					}, nil
+				},
+			},
+			expectedData: "snapshot-data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := fake.NewClientBuilder().WithObjects(cp1Node).Build()
+			w := &Workload{
+				Client:              fakeClient,
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			snapshot, err := w.EtcdSnapshot(ctx)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			data, err := io.ReadAll(snapshot)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(data)).To(Equal(tt.expectedData))
+			g.Expect(snapshot.Revision).To(Equal(int64(10)))
+			g.Expect(snapshot.Close()).To(Succeed())
+		})
+	}
+}
+
+func TestEtcdMembersStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	etcdClientGenerator := &fakeEtcdClientGenerator{
+		forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+			switch n[0] {
+			case "cp1":
+				return &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						StatusResponse: &clientv3.StatusResponse{
+							Header:      &pb.ResponseHeader{MemberId: 1},
+							Leader:      1,
+							DbSize:      100,
+							DbSizeInUse: 50,
+						},
+						AlarmResponse: &clientv3.AlarmResponse{
+							Alarms: []*pb.AlarmMember{
+								{MemberID: 2, Alarm: pb.AlarmType_NOSPACE},
+							},
+						},
+					},
+				}, nil
+			case "cp2":
+				return &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						StatusResponse: &clientv3.StatusResponse{
+							Header:      &pb.ResponseHeader{MemberId: 2},
+							Leader:      1,
+							DbSize:      200,
+							DbSizeInUse: 190,
+						},
+					},
+				}, nil
+			default:
+				return nil, errors.Errorf("unexpected nodes %v", n)
+			}
+		},
+	}
+	w := &Workload{
+		etcdClientGenerator: etcdClientGenerator,
+	}
+
+	nodes := []*Node{
+		{ObjectMeta: ObjectMeta{Name: "cp1"}},
+		{ObjectMeta: ObjectMeta{Name: "cp2"}},
+		{ObjectMeta: ObjectMeta{Name: "cp3"}},
+	}
+	statuses, err := w.EtcdMembersStatus(ctx, nodes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(statuses).To(Equal([]*EtcdMemberStatus{
+		{Name: "cp1", Responsive: true, ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 50},
+		{Name: "cp2", Responsive: true, ID: 2, DBSize: 200, DBSizeInUse: 190, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+		{Name: "cp3"},
+	}))
+}
+
+func TestDefragmentEtcdMember(t *testing.T) {
+	t.Run("returns an error if it fails to create the etcd client", func(t *testing.T) {
+		g := NewWithT(t)
+
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+		}
+		g.Expect(w.DefragmentEtcdMember(ctx, "cp1")).ToNot(Succeed())
+	})
+	t.Run("defragments the etcd member", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			DefragmentResponse: &clientv3.DefragmentResponse{},
+		}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					if len(n) != 1 || n[0] != "cp1" {
+						return nil, errors.Errorf("unexpected nodes %v", n)
+					}
+					return &etcd.Client{EtcdClient: fakeEtcdClient, Endpoint: "cp1"}, nil
+				},
+			},
+		}
+		g.Expect(w.DefragmentEtcdMember(ctx, "cp1")).To(Succeed())
+		g.Expect(fakeEtcdClient.DefragmentedMember).To(Equal("cp1"))
+	})
+}
+
 type fakeEtcdClientGenerator struct {
 	forNodesClient     *etcd.Client
 	forNodesClientFunc func([]string) (*etcd.Client, error)
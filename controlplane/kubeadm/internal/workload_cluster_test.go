@@ -28,6 +28,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -219,6 +221,77 @@ func TestUpdateKubeProxyImageInfo(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterInfoCertificateAuthorities(t *testing.T) {
+	clusterInfo := func(caData string) *corev1.ConfigMap {
+		config := clientcmdapi.NewConfig()
+		config.Clusters[""] = &clientcmdapi.Cluster{
+			Server:                   "https://1.2.3.4:6443",
+			CertificateAuthorityData: []byte(caData),
+		}
+		out, err := clientcmd.Write(*config)
+		if err != nil {
+			panic(err)
+		}
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-info",
+				Namespace: metav1.NamespacePublic,
+			},
+			Data: map[string]string{
+				"kubeconfig":                   string(out),
+				"jws-kubeconfig-abcdef":        "signature",
+				"some-other-cluster-info-data": "foo",
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		caData    string
+		expectErr bool
+	}{
+		{
+			name:   "updates the certificate authorities",
+			objs:   []client.Object{clusterInfo("old-ca")},
+			caData: "old-ca\nnew-ca",
+		},
+		{
+			name:   "does nothing if the certificate authorities are already up-to-date",
+			objs:   []client.Object{clusterInfo("new-ca")},
+			caData: "new-ca",
+		},
+		{
+			name:      "returns error if the cluster-info ConfigMap does not exist",
+			caData:    "new-ca",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			w := &Workload{
+				Client: fake.NewClientBuilder().WithObjects(tt.objs...).Build(),
+			}
+			err := w.UpdateClusterInfoCertificateAuthorities(ctx, []byte(tt.caData))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			g.Expect(w.Client.Get(ctx, client.ObjectKey{Name: "cluster-info", Namespace: metav1.NamespacePublic}, cm)).To(Succeed())
+			g.Expect(cm.Data).To(HaveKeyWithValue("some-other-cluster-info-data", "foo"))
+			config, err := clientcmd.Load([]byte(cm.Data["kubeconfig"]))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(config.Clusters[""].Server).To(Equal("https://1.2.3.4:6443"))
+			g.Expect(string(config.Clusters[""].CertificateAuthorityData)).To(Equal(tt.caData))
+		})
+	}
+}
+
 func TestUpdateUpdateClusterConfigurationInKubeadmConfigMap(t *testing.T) {
 	tests := []struct {
 		name          string
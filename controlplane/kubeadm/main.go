@@ -109,6 +109,7 @@ var (
 	etcdDialTimeout                time.Duration
 	etcdCallTimeout                time.Duration
 	etcdLogLevel                   string
+	etcdSnapshotDirectory          string
 )
 
 func init() {
@@ -210,6 +211,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&etcdLogLevel, "etcd-client-log-level", zapcore.InfoLevel.String(),
 		"Logging level for etcd client. Possible values are: debug, info, warn, error, dpanic, panic, fatal.")
 
+	fs.StringVar(&etcdSnapshotDirectory, "etcd-snapshot-directory", "",
+		"Directory where etcd snapshots are stored when using the Directory sink. If not set, the Directory sink cannot be used.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -432,6 +436,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		EtcdDialTimeout:             etcdDialTimeout,
 		EtcdCallTimeout:             etcdCallTimeout,
 		EtcdLogger:                  etcdLogger,
+		EtcdSnapshotDirectory:       etcdSnapshotDirectory,
 		RemoteConditionsGracePeriod: remoteConditionsGracePeriod,
 		RuntimeClient:               runtimeClient,
 	}).SetupWithManager(ctx, mgr, controller.Options{
@@ -441,6 +446,31 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
 		os.Exit(1)
 	}
+
+	if err := (&kubeadmcontrolplanecontrollers.EtcdSnapshotReconciler{
+		Client:                mgr.GetClient(),
+		SecretCachingClient:   secretCachingClient,
+		ClusterCache:          clusterCache,
+		WatchFilterValue:      watchFilterValue,
+		EtcdDialTimeout:       etcdDialTimeout,
+		EtcdCallTimeout:       etcdCallTimeout,
+		EtcdLogger:            etcdLogger,
+		EtcdSnapshotDirectory: etcdSnapshotDirectory,
+	}).SetupWithManager(ctx, mgr, controller.Options{
+		MaxConcurrentReconciles: kubeadmControlPlaneConcurrency,
+		ReconciliationTimeout:   10 * time.Minute, // increase reconciliation timeout because streaming an etcd snapshot might take a while for big etcd databases.
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdSnapshot")
+		os.Exit(1)
+	}
+
+	if err := (&kubeadmcontrolplanecontrollers.EtcdBackupPolicyReconciler{
+		Client:           mgr.GetClient(),
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdBackupPolicy")
+		os.Exit(1)
+	}
 }
 
 func setupWebhooks(ctx context.Context, mgr ctrl.Manager) {
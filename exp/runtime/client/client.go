@@ -66,6 +66,20 @@ type CallExtensionOptions struct {
 	CacheKeyFunc func(extensionName, extensionConfigResourceVersion string, request runtimehooksv1.RequestObject) string
 }
 
+// CircuitBreakerState is the state of the circuit breaker for calls to an extension.
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed means that calls to the extension are not prevented.
+	CircuitBreakerClosed CircuitBreakerState = "Closed"
+
+	// CircuitBreakerOpen means that calls to the extension fail immediately.
+	CircuitBreakerOpen CircuitBreakerState = "Open"
+
+	// CircuitBreakerHalfOpen means that a single probe call to the extension is allowed, while other calls fail immediately.
+	CircuitBreakerHalfOpen CircuitBreakerState = "HalfOpen"
+)
+
 // Client is the runtime client to interact with extensions.
 type Client interface {
 	// WarmUp can be used to initialize a "cold" RuntimeClient with all
@@ -95,3 +109,11 @@ type Client interface {
 	// CallExtension calls the ExtensionHandler with the given name.
 	CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject client.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...CallExtensionOption) error
 }
+
+// CircuitBreakerStateGetter is implemented by runtime clients which support circuit breakers.
+// It is an optional extension of Client, so that existing implementations of Client keep working.
+type CircuitBreakerStateGetter interface {
+	// GetCircuitBreakerState returns the state of the circuit breaker for calls to the extension defined by the
+	// ExtensionConfig with the given name; it returns false if no circuit breaker is configured for the extension.
+	GetCircuitBreakerState(extensionConfigName string) (CircuitBreakerState, bool)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// InProcessExtension is a Runtime Extension which is called in-process by the Runtime SDK client,
+// instead of being called via HTTPS like a Runtime Extension served by a Server.
+// InProcessExtensions are registered with the Runtime SDK client when it is created, e.g. via NewRuntimeClient in
+// sigs.k8s.io/cluster-api/controllers, and they are added to the registry together with the Runtime Extensions
+// defined by ExtensionConfig objects.
+// Note: The Cluster API core controller manager does not register any InProcessExtension.
+type InProcessExtension struct {
+	name              string
+	catalog           *runtimecatalog.Catalog
+	namespaceSelector *metav1.LabelSelector
+	settings          map[string]string
+	handlers          map[string]ExtensionHandler
+}
+
+// InProcessExtensionOptions are the options for the InProcessExtension.
+type InProcessExtensionOptions struct {
+	// Name is the name of the Runtime Extension.
+	// It takes the place of the name of the ExtensionConfig for Runtime Extensions called via HTTPS,
+	// and thus it must be a valid DNS subdomain name and it must not be used by any ExtensionConfig.
+	Name string
+
+	// Catalog is the catalog used to handle requests.
+	Catalog *runtimecatalog.Catalog
+
+	// NamespaceSelector decides whether to call the Runtime Extension for an object based on whether the
+	// namespace for that object matches the selector.
+	// Defaults to the empty LabelSelector, which matches all objects.
+	NamespaceSelector *metav1.LabelSelector
+
+	// Settings defines key value pairs to be passed to all calls to all supported Runtime Extensions.
+	Settings map[string]string
+}
+
+// NewInProcessExtension creates a new InProcessExtension based on the given Options.
+func NewInProcessExtension(options InProcessExtensionOptions) (*InProcessExtension, error) {
+	if options.Catalog == nil {
+		return nil, errors.Errorf("catalog is required")
+	}
+	if errs := validation.IsDNS1123Subdomain(options.Name); len(errs) != 0 {
+		return nil, errors.Errorf("name %q is invalid: %v", options.Name, errs)
+	}
+	if options.NamespaceSelector == nil {
+		options.NamespaceSelector = &metav1.LabelSelector{}
+	}
+
+	return &InProcessExtension{
+		name:              options.Name,
+		catalog:           options.Catalog,
+		namespaceSelector: options.NamespaceSelector,
+		settings:          options.Settings,
+		handlers:          map[string]ExtensionHandler{},
+	}, nil
+}
+
+// Name returns the name of the InProcessExtension.
+func (e *InProcessExtension) Name() string {
+	return e.name
+}
+
+// NamespaceSelector returns the NamespaceSelector of the InProcessExtension.
+func (e *InProcessExtension) NamespaceSelector() *metav1.LabelSelector {
+	return e.namespaceSelector
+}
+
+// Settings returns the Settings of the InProcessExtension.
+func (e *InProcessExtension) Settings() map[string]string {
+	return e.settings
+}
+
+// AddExtensionHandler adds an extension handler to the InProcessExtension.
+// Note: Extension handlers must be added before the InProcessExtension is registered with the Runtime SDK client.
+func (e *InProcessExtension) AddExtensionHandler(handler ExtensionHandler) error {
+	return addExtensionHandler(e.catalog, e.handlers, handler)
+}
+
+// Call calls the extension handler with the given GroupVersionHook and name.
+// The request and the response must be of the types expected by the extension handler, i.e. no conversion
+// is performed; the Discovery hook can be called with an empty name to get the list of extension handlers.
+func (e *InProcessExtension) Call(ctx context.Context, gvh runtimecatalog.GroupVersionHook, name string, request, response runtime.Object) (err error) {
+	handlerPath := runtimecatalog.GVHToPath(gvh, name)
+
+	var handlerFunc runtimecatalog.Hook
+	var requestType, responseType reflect.Type
+	if gvh.Hook == runtimecatalog.HookName(runtimehooksv1.Discovery) {
+		handlerFunc = discoveryHandler(e.handlers, nil)
+		requestType = reflect.TypeOf(&runtimehooksv1.DiscoveryRequest{})
+		responseType = reflect.TypeOf(&runtimehooksv1.DiscoveryResponse{})
+	} else {
+		handler, ok := e.handlers[handlerPath]
+		if !ok {
+			return errors.Errorf("failed to call extension handler %q of in-process extension %q: handler not found", handlerPath, e.name)
+		}
+		handlerFunc = handler.HandlerFunc
+		requestType = reflect.TypeOf(handler.requestObject)
+		responseType = reflect.TypeOf(handler.responseObject)
+	}
+
+	if reflect.TypeOf(request) != requestType {
+		return errors.Errorf("failed to call extension handler %q of in-process extension %q: request type must be %s but is %T", handlerPath, e.name, requestType, request)
+	}
+	if reflect.TypeOf(response) != responseType {
+		return errors.Errorf("failed to call extension handler %q of in-process extension %q: response type must be %s but is %T", handlerPath, e.name, responseType, response)
+	}
+
+	// Ensure a panic in the extension handler surfaces as an error, like it would for a Runtime Extension called via HTTPS.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to call extension handler %q of in-process extension %q: panic: %v", handlerPath, e.name, r)
+		}
+	}()
+
+	reflect.ValueOf(handlerFunc).Call([]reflect.Value{
+		reflect.ValueOf(ctx),
+		reflect.ValueOf(request),
+		reflect.ValueOf(response),
+	})
+	return nil
+}
@@ -27,8 +27,13 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor, so gzip compressed gRPC requests can be served.
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -36,6 +41,7 @@ import (
 
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimegrpcv1 "sigs.k8s.io/cluster-api/internal/runtime/grpc/v1alpha1"
 )
 
 // DefaultPort is the default port that the webhook server serves.
@@ -44,8 +50,9 @@ var DefaultPort = 9443
 // Server is a runtime webhook server.
 type Server struct {
 	webhook.Server
-	catalog  *runtimecatalog.Catalog
-	handlers map[string]ExtensionHandler
+	catalog    *runtimecatalog.Catalog
+	handlers   map[string]ExtensionHandler
+	grpcServer *grpc.Server
 }
 
 // Options are the options for the Server.
@@ -87,6 +94,14 @@ type Options struct {
 	// TLSOpts is used to allow configuring the TLS config used for the server.
 	// This also allows providing a certificate via GetCertificate.
 	TLSOpts []func(*tls.Config)
+
+	// EnableGRPC enables serving extension handlers via gRPC in addition to HTTPS, on the same port.
+	// Support for gRPC is reported in the response of the Discovery hook, and it is used by the Runtime SDK client
+	// only for ExtensionConfigs with clientConfig.transport set to GRPC, and only for Runtime Hooks with a protobuf
+	// definition, i.e. GeneratePatches and ValidateTopology; all the other extension handlers are called via HTTPS.
+	//
+	// Note: gRPC requires HTTP/2, so TLSOpts must not disable HTTP/2 when gRPC is enabled.
+	EnableGRPC bool
 }
 
 // New creates a new runtime webhook server based on the given Options.
@@ -120,11 +135,19 @@ func New(options Options) (*Server, error) {
 		},
 	)
 
-	return &Server{
+	s := &Server{
 		Server:   webhookServer,
 		catalog:  options.Catalog,
 		handlers: map[string]ExtensionHandler{},
-	}, nil
+	}
+	if options.EnableGRPC {
+		// Note: As ExtensionHandlers are registered dynamically, all gRPC calls are served by a single handler,
+		// using the same paths used for HTTPS.
+		s.grpcServer = grpc.NewServer(
+			grpc.UnknownServiceHandler(s.serveGRPC),
+		)
+	}
+	return s, nil
 }
 
 // ExtensionHandler represents an extension handler.
@@ -159,39 +182,44 @@ type ExtensionHandler struct {
 
 // AddExtensionHandler adds an extension handler to the server.
 func (s *Server) AddExtensionHandler(handler ExtensionHandler) error {
-	gvh, err := s.catalog.GroupVersionHook(handler.Hook)
+	return addExtensionHandler(s.catalog, s.handlers, handler)
+}
+
+// addExtensionHandler validates an extension handler and adds it to the given handlers.
+func addExtensionHandler(catalog *runtimecatalog.Catalog, handlers map[string]ExtensionHandler, handler ExtensionHandler) error {
+	gvh, err := catalog.GroupVersionHook(handler.Hook)
 	if err != nil {
 		return errors.Wrapf(err, "hook %q does not exist in catalog", runtimecatalog.HookName(handler.Hook))
 	}
 	handler.gvh = gvh
 
-	requestObject, err := s.catalog.NewRequest(handler.gvh)
+	requestObject, err := catalog.NewRequest(handler.gvh)
 	if err != nil {
 		return err
 	}
 	handler.requestObject = requestObject
 
-	responseObject, err := s.catalog.NewResponse(handler.gvh)
+	responseObject, err := catalog.NewResponse(handler.gvh)
 	if err != nil {
 		return err
 	}
 	handler.responseObject = responseObject
 
-	if err := s.validateHandler(handler); err != nil {
+	if err := validateHandler(handler); err != nil {
 		return err
 	}
 
 	handlerPath := runtimecatalog.GVHToPath(handler.gvh, handler.Name)
-	if _, ok := s.handlers[handlerPath]; ok {
+	if _, ok := handlers[handlerPath]; ok {
 		return errors.Errorf("there is already a handler registered for path %q", handlerPath)
 	}
 
-	s.handlers[handlerPath] = handler
+	handlers[handlerPath] = handler
 	return nil
 }
 
 // validateHandler validates a handler.
-func (s *Server) validateHandler(handler ExtensionHandler) error {
+func validateHandler(handler ExtensionHandler) error {
 	// Get hook and handler type.
 	hookFuncType := reflect.TypeOf(handler.Hook)
 	handlerFuncType := reflect.TypeOf(handler.HandlerFunc)
@@ -244,7 +272,7 @@ func (s *Server) Start(ctx context.Context) error {
 	// Add discovery handler.
 	err := s.AddExtensionHandler(ExtensionHandler{
 		Hook:        runtimehooksv1.Discovery,
-		HandlerFunc: discoveryHandler(s.handlers),
+		HandlerFunc: discoveryHandler(s.handlers, s.transports()),
 	})
 	if err != nil {
 		return err
@@ -261,8 +289,16 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.Server.Start(ctx)
 }
 
-// discoveryHandler generates a discovery handler based on a list of handlers.
-func discoveryHandler(handlers map[string]ExtensionHandler) func(context.Context, *runtimehooksv1.DiscoveryRequest, *runtimehooksv1.DiscoveryResponse) {
+// transports returns the transports supported by the server.
+func (s *Server) transports() []runtimehooksv1.Transport {
+	if s.grpcServer == nil {
+		return nil
+	}
+	return []runtimehooksv1.Transport{runtimehooksv1.TransportHTTPS, runtimehooksv1.TransportGRPC}
+}
+
+// discoveryHandler generates a discovery handler based on a list of handlers and the supported transports.
+func discoveryHandler(handlers map[string]ExtensionHandler, transports []runtimehooksv1.Transport) func(context.Context, *runtimehooksv1.DiscoveryRequest, *runtimehooksv1.DiscoveryResponse) {
 	cachedHandlers := []runtimehooksv1.ExtensionHandler{}
 	for _, handler := range handlers {
 		cachedHandlers = append(cachedHandlers, runtimehooksv1.ExtensionHandler{
@@ -279,11 +315,18 @@ func discoveryHandler(handlers map[string]ExtensionHandler) func(context.Context
 	return func(_ context.Context, _ *runtimehooksv1.DiscoveryRequest, response *runtimehooksv1.DiscoveryResponse) {
 		response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
 		response.Handlers = cachedHandlers
+		response.Transports = transports
 	}
 }
 
 func (s *Server) wrapHandler(handler ExtensionHandler) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// gRPC requests use the same paths as HTTPS requests, so they are routed to the gRPC server here.
+		if s.grpcServer != nil && r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			s.grpcServer.ServeHTTP(w, r)
+			return
+		}
+
 		response := s.callHandler(handler, r)
 
 		responseBody, err := json.Marshal(response)
@@ -327,3 +370,49 @@ func (s *Server) callHandler(handler ExtensionHandler, r *http.Request) runtimeh
 
 	return response
 }
+
+// serveGRPC serves all the gRPC calls; the gRPC method is the path of the extension handler.
+func (s *Server) serveGRPC(_ any, stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to get method from stream")
+	}
+	handler, ok := s.handlers[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "there is no handler registered for path %q", method)
+	}
+
+	if !runtimegrpcv1.IsSupported(handler.gvh) {
+		return status.Errorf(codes.Unimplemented, "hook %s cannot be called via gRPC, it must be called via HTTPS", handler.gvh.Hook)
+	}
+
+	request := handler.requestObject.DeepCopyObject()
+	response := handler.responseObject.DeepCopyObject().(runtimehooksv1.ResponseObject)
+
+	requestMessage, err := runtimegrpcv1.NewRequest(request)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := stream.RecvMsg(requestMessage); err != nil {
+		return status.Errorf(codes.InvalidArgument, "error unmarshalling request: %v", err)
+	}
+	if err := runtimegrpcv1.ConvertRequestFromProto(requestMessage, request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "error converting request: %v", err)
+	}
+
+	// log.Log is the logger previously set via ctrl.SetLogger.
+	// This implemented analog to the logger in the controller-runtime manager.
+	ctx := ctrl.LoggerInto(stream.Context(), log.Log)
+
+	reflect.ValueOf(handler.HandlerFunc).Call([]reflect.Value{
+		reflect.ValueOf(ctx),
+		reflect.ValueOf(request),
+		reflect.ValueOf(response),
+	})
+
+	responseMessage, err := runtimegrpcv1.ConvertResponseToProto(response)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error converting response: %v", err)
+	}
+	return stream.SendMsg(responseMessage)
+}
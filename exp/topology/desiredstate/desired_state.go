@@ -912,7 +912,7 @@ func (g *generator) computeMachineDeployment(ctx context.Context, s *scope.Scope
 	if !reflect.DeepEqual(machineDeploymentClass.Rollout, clusterv1.MachineDeploymentRolloutSpec{}) {
 		rollout = clusterv1.MachineDeploymentRolloutSpec{
 			Strategy: clusterv1.MachineDeploymentRolloutStrategy{
-				Type: machineDeploymentClass.Rollout.Strategy.Type,
+				Type: clusterv1.MachineDeploymentRolloutStrategyType(machineDeploymentClass.Rollout.Strategy.Type),
 				RollingUpdate: clusterv1.MachineDeploymentRolloutStrategyRollingUpdate{
 					MaxUnavailable: machineDeploymentClass.Rollout.Strategy.RollingUpdate.MaxUnavailable,
 					MaxSurge:       machineDeploymentClass.Rollout.Strategy.RollingUpdate.MaxSurge,
@@ -924,7 +924,7 @@ func (g *generator) computeMachineDeployment(ctx context.Context, s *scope.Scope
 		rollout = clusterv1.MachineDeploymentRolloutSpec{
 			After: machineDeploymentTopology.Rollout.After,
 			Strategy: clusterv1.MachineDeploymentRolloutStrategy{
-				Type: machineDeploymentTopology.Rollout.Strategy.Type,
+				Type: clusterv1.MachineDeploymentRolloutStrategyType(machineDeploymentTopology.Rollout.Strategy.Type),
 				RollingUpdate: clusterv1.MachineDeploymentRolloutStrategyRollingUpdate{
 					MaxUnavailable: machineDeploymentTopology.Rollout.Strategy.RollingUpdate.MaxUnavailable,
 					MaxSurge:       machineDeploymentTopology.Rollout.Strategy.RollingUpdate.MaxSurge,
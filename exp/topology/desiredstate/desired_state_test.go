@@ -1858,7 +1858,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 	clusterClassDuration := int32(20)
 	var clusterClassMinReadySeconds int32 = 20
 	clusterClassStrategy := clusterv1.MachineDeploymentClassRolloutStrategy{
-		Type: clusterv1.OnDeleteMachineDeploymentStrategyType,
+		Type: clusterv1.OnDeleteMachineDeploymentTopologyStrategyType,
 	}
 	clusterClassMDStrategy := clusterv1.MachineDeploymentRolloutStrategy{
 		Type: clusterv1.OnDeleteMachineDeploymentStrategyType,
@@ -1943,7 +1943,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 	topologyDuration := int32(10)
 	var topologyMinReadySeconds int32 = 10
 	topologyStrategy := clusterv1.MachineDeploymentTopologyRolloutStrategy{
-		Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+		Type: clusterv1.RollingUpdateMachineDeploymentTopologyStrategyType,
 	}
 	topologyMDStrategy := clusterv1.MachineDeploymentRolloutStrategy{
 		Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
@@ -46,6 +46,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/internal/util/certificates"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -79,6 +80,10 @@ type Reconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// SecretCachingClient is a client which caches secrets.
+	// It is used to read the cluster certificate Secrets.
+	SecretCachingClient client.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -89,8 +94,8 @@ type Reconciler struct {
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil || r.RemoteConnectionGracePeriod == time.Duration(0) {
-		return errors.New("Client, APIReader and ClusterCache must not be nil and RemoteConnectionGracePeriod must not be 0")
+	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil || r.SecretCachingClient == nil || r.RemoteConnectionGracePeriod == time.Duration(0) {
+		return errors.New("Client, APIReader, ClusterCache and SecretCachingClient must not be nil and RemoteConnectionGracePeriod must not be 0")
 	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "cluster")
@@ -219,6 +224,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retRes ct
 
 	reconcileNormal := append(
 		alwaysReconcile,
+		r.getCertificatesExpiries,
 		r.reconcileKubeconfig,
 		r.reconcileV1Beta1ControlPlaneInitialized,
 	)
@@ -260,6 +266,7 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *clust
 			clusterv1.ClusterRemediatingCondition,
 			clusterv1.ClusterDeletingCondition,
 			clusterv1.ClusterAvailableCondition,
+			clusterv1.ClusterCertificatesExpiringCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, cluster, options...)
@@ -319,6 +326,17 @@ type scope struct {
 	// getDescendantsSucceeded documents if getDescendants succeeded.
 	getDescendantsSucceeded bool
 
+	// certificatesExpiries is the list of expiry dates of the certificates of this Cluster.
+	// It is set after getCertificatesExpiries is called.
+	certificatesExpiries []certificates.Expiry
+
+	// certificatesInvalidSources is the list of sources with certificates of this Cluster that cannot be parsed.
+	// It is set after getCertificatesExpiries is called.
+	certificatesInvalidSources []string
+
+	// getCertificatesExpiriesSucceeded documents if getCertificatesExpiries succeeded.
+	getCertificatesExpiriesSucceeded bool
+
 	// deletingReason is the reason that should be used when setting the Deleting condition.
 	deletingReason string
 
@@ -610,6 +628,21 @@ func (r *Reconciler) getDescendants(ctx context.Context, s *scope) (reconcile.Re
 	return reconcile.Result{}, nil
 }
 
+// getCertificatesExpiries collects the expiry dates of the certificates of the cluster, i.e. of the certificates stored in
+// the cluster certificate Secrets, of the client certificates in the kubeconfig Secret, and of the Machine certificates.
+func (r *Reconciler) getCertificatesExpiries(ctx context.Context, s *scope) (reconcile.Result, error) {
+	expiries, invalidSources, err := certificates.GetExpiries(ctx, r.SecretCachingClient, r.Client, util.ObjectKey(s.cluster), s.descendants.allMachines)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to get certificates expiry dates for cluster %s", klog.KObj(s.cluster))
+	}
+
+	s.certificatesExpiries = expiries
+	s.certificatesInvalidSources = invalidSources
+	s.getCertificatesExpiriesSucceeded = true
+
+	return reconcile.Result{}, nil
+}
+
 // filterOwnedDescendants returns an array of runtime.Objects containing only those descendants that have the cluster
 // as an owner reference, with control plane machines sorted last.
 // Note: this list must include stand-alone MachineSets and stand-alone Machines; instead MachineSets or Machines controlled
@@ -35,6 +35,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/certificates"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -88,6 +89,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) error {
 	setScalingUpCondition(ctx, s.cluster, s.controlPlane, clusterv1.MachinePoolList{}, s.descendants.machineDeployments, s.descendants.machineSets, s.controlPlaneIsNotFound, s.getDescendantsSucceeded)
 	setScalingDownCondition(ctx, s.cluster, s.controlPlane, clusterv1.MachinePoolList{}, s.descendants.machineDeployments, s.descendants.machineSets, s.controlPlaneIsNotFound, s.getDescendantsSucceeded)
 	setRemediatingCondition(ctx, s.cluster, machinesToBeRemediated, unhealthyMachines, s.getDescendantsSucceeded)
+	setCertificatesExpiringCondition(ctx, s.cluster, s.certificatesExpiries, s.certificatesInvalidSources, s.getCertificatesExpiriesSucceeded)
 	setDeletingCondition(ctx, s.cluster, s.deletingReason, s.deletingMessage)
 	setAvailableCondition(ctx, s.cluster, s.clusterClass)
 
@@ -1098,6 +1100,27 @@ func setScalingDownCondition(ctx context.Context, cluster *clusterv1.Cluster, co
 	conditions.Set(cluster, *scalingDownCondition)
 }
 
+func setCertificatesExpiringCondition(_ context.Context, cluster *clusterv1.Cluster, expiries []certificates.Expiry, invalidSources []string, getCertificatesExpiriesSucceeded bool) {
+	// Certificates expiry dates are not collected while the cluster is deleting; preserve the last known state.
+	if !cluster.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	// If we got unexpected errors in reading the certificates (this should happen rarely), surface them.
+	if !getCertificatesExpiriesSucceeded {
+		conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterCertificatesExpiringCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.ClusterCertificatesExpiringInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	cluster.Status.CertificatesExpiryDate = certificates.EarliestExpiryDate(expiries)
+	conditions.Set(cluster, certificates.ExpiringCondition(expiries, invalidSources, time.Now()))
+}
+
 func setDeletingCondition(_ context.Context, cluster *clusterv1.Cluster, deletingReason, deletingMessage string) {
 	if cluster.DeletionTimestamp.IsZero() {
 		conditions.Set(cluster, metav1.Condition{
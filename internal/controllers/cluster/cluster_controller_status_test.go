@@ -29,6 +29,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/certificates"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
@@ -2368,6 +2369,96 @@ func TestSetRemediatingCondition(t *testing.T) {
 	}
 }
 
+func TestSetCertificatesExpiringCondition(t *testing.T) {
+	expiryDate := time.Now().Add(365 * 24 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name                             string
+		cluster                          *clusterv1.Cluster
+		expiries                         []certificates.Expiry
+		invalidSources                   []string
+		getCertificatesExpiriesSucceeded bool
+		expectCondition                  *metav1.Condition
+		expectExpiryDate                 metav1.Time
+	}{
+		{
+			name:                             "deletionTimestamp set",
+			cluster:                          fakeCluster("c", deleted(true)),
+			getCertificatesExpiriesSucceeded: false,
+			expectCondition:                  nil,
+		},
+		{
+			name:                             "failed to get certificates expiries",
+			cluster:                          fakeCluster("c"),
+			getCertificatesExpiriesSucceeded: false,
+			expectCondition: &metav1.Condition{
+				Type:    clusterv1.ClusterCertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.ClusterCertificatesExpiringInternalErrorReason,
+				Message: "Please check controller logs for errors",
+			},
+		},
+		{
+			name:                             "no certificates",
+			cluster:                          fakeCluster("c"),
+			getCertificatesExpiriesSucceeded: true,
+			expectCondition: &metav1.Condition{
+				Type:    clusterv1.ClusterCertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.ClusterCertificatesExpiryUnknownReason,
+				Message: "No certificates found",
+			},
+		},
+		{
+			name:    "certificates not expiring",
+			cluster: fakeCluster("c"),
+			expiries: []certificates.Expiry{
+				{Source: "Secret c-ca", ExpiryDate: expiryDate},
+			},
+			getCertificatesExpiriesSucceeded: true,
+			expectCondition: &metav1.Condition{
+				Type:   clusterv1.ClusterCertificatesExpiringCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.ClusterCertificatesNotExpiringReason,
+			},
+			expectExpiryDate: metav1.NewTime(expiryDate),
+		},
+		{
+			name:    "certificates not expiring and invalid certificates",
+			cluster: fakeCluster("c"),
+			expiries: []certificates.Expiry{
+				{Source: "Secret c-ca", ExpiryDate: expiryDate},
+			},
+			invalidSources:                   []string{"Secret c-etcd"},
+			getCertificatesExpiriesSucceeded: true,
+			expectCondition: &metav1.Condition{
+				Type:    clusterv1.ClusterCertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.ClusterCertificatesExpiryUnknownReason,
+				Message: "Certificates in Secret c-etcd cannot be parsed",
+			},
+			expectExpiryDate: metav1.NewTime(expiryDate),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			setCertificatesExpiringCondition(ctx, tc.cluster, tc.expiries, tc.invalidSources, tc.getCertificatesExpiriesSucceeded)
+
+			condition := conditions.Get(tc.cluster, clusterv1.ClusterCertificatesExpiringCondition)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(*tc.expectCondition, conditions.IgnoreLastTransitionTime(true)))
+			g.Expect(tc.cluster.Status.CertificatesExpiryDate).To(Equal(tc.expectExpiryDate))
+		})
+	}
+}
+
 func TestDeletingCondition(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -89,6 +89,7 @@ func TestMain(m *testing.M) {
 			Client:                      mgr.GetClient(),
 			APIReader:                   mgr.GetClient(),
 			ClusterCache:                clusterCache,
+			SecretCachingClient:         secretCachingClient,
 			RemoteConnectionGracePeriod: 50 * time.Second,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
 			panic(fmt.Sprintf("Failed to start ClusterReconciler: %v", err))
@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -69,6 +70,10 @@ type Reconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// CircuitBreakerEvents, if set, receives events for ExtensionConfigs whose circuit breaker changed state,
+	// so that the CircuitBreakerClosed condition is updated promptly.
+	CircuitBreakerEvents <-chan event.GenericEvent
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -103,6 +108,11 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			),
 			predicates.TypedResourceIsChanged[*metav1.PartialObjectMetadata](mgr.GetScheme(), predicateLog),
 		))
+
+		// The watch on circuit breaker events is only needed when reconciling conditions (readOnly mode doesn't do that).
+		if r.CircuitBreakerEvents != nil {
+			b.WatchesRawSource(source.Channel(r.CircuitBreakerEvents, &handler.EnqueueRequestForObject{}))
+		}
 	}
 
 	if err := b.Complete(r); err != nil {
@@ -189,6 +199,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		if err = r.RuntimeClient.Register(extensionConfig); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to register ExtensionConfig %s/%s", extensionConfig.Namespace, extensionConfig.Name)
 		}
+
+		// An open circuit breaker becomes half-open after some time without emitting an event,
+		// so requeue to keep the CircuitBreakerClosed condition up to date.
+		if conditions.IsFalse(extensionConfig, runtimev1.ExtensionConfigCircuitBreakerClosedCondition) {
+			halfOpenAfter := 30 * time.Second
+			if extensionConfig.Spec.CircuitBreaker.HalfOpenAfterSeconds != 0 {
+				halfOpenAfter = time.Duration(extensionConfig.Spec.CircuitBreaker.HalfOpenAfterSeconds) * time.Second
+			}
+			return ctrl.Result{RequeueAfter: halfOpenAfter}, nil
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -207,6 +227,7 @@ func patchExtensionConfig(ctx context.Context, client client.Client, original, m
 		patch.WithOwnedConditions{Conditions: []string{
 			clusterv1.PausedCondition,
 			runtimev1.ExtensionConfigDiscoveredCondition,
+			runtimev1.ExtensionConfigCircuitBreakerClosedCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, modified, options...)
@@ -272,6 +293,51 @@ func discoverExtensionConfig(ctx context.Context, runtimeClient runtimeclient.Cl
 	return discoveredExtension, nil
 }
 
+// setCircuitBreakerClosedCondition sets the CircuitBreakerClosed condition according to the state
+// of the circuit breaker for the ExtensionConfig in the runtime client.
+func setCircuitBreakerClosedCondition(runtimeClient runtimeclient.Client, extensionConfig *runtimev1.ExtensionConfig) {
+	if !extensionConfig.Spec.CircuitBreaker.IsDefined() {
+		conditions.Set(extensionConfig, metav1.Condition{
+			Type:   runtimev1.ExtensionConfigCircuitBreakerClosedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: runtimev1.ExtensionConfigCircuitBreakerNotConfiguredReason,
+		})
+		return
+	}
+
+	// Note: The circuit breaker is closed if the ExtensionConfig is not registered yet, or if
+	// the runtime client does not report the state of its circuit breakers.
+	state := runtimeclient.CircuitBreakerClosed
+	if getter, ok := runtimeClient.(runtimeclient.CircuitBreakerStateGetter); ok {
+		if s, ok := getter.GetCircuitBreakerState(extensionConfig.Name); ok {
+			state = s
+		}
+	}
+
+	switch state {
+	case runtimeclient.CircuitBreakerOpen:
+		conditions.Set(extensionConfig, metav1.Condition{
+			Type:    runtimev1.ExtensionConfigCircuitBreakerClosedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  runtimev1.ExtensionConfigCircuitBreakerOpenReason,
+			Message: fmt.Sprintf("Calls to extension handlers are failing fast after %d consecutive failures", extensionConfig.Spec.CircuitBreaker.FailureThreshold),
+		})
+	case runtimeclient.CircuitBreakerHalfOpen:
+		conditions.Set(extensionConfig, metav1.Condition{
+			Type:    runtimev1.ExtensionConfigCircuitBreakerClosedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  runtimev1.ExtensionConfigCircuitBreakerHalfOpenReason,
+			Message: "Waiting for a probe call to an extension handler to succeed",
+		})
+	default:
+		conditions.Set(extensionConfig, metav1.Condition{
+			Type:   runtimev1.ExtensionConfigCircuitBreakerClosedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: runtimev1.ExtensionConfigCircuitBreakerClosedReason,
+		})
+	}
+}
+
 // reconcileCABundle reconciles the CA bundle for the ExtensionConfig.
 // Note: This was implemented to behave similar to the cert-manager cainjector.
 // We couldn't use the cert-manager cainjector because it doesn't work with CustomResources.
@@ -361,6 +427,8 @@ func reconcileExtensionConfig(ctx context.Context, c client.Client, runtimeClien
 		errs = append(errs, err)
 	}
 
+	setCircuitBreakerClosedCondition(runtimeClient, extensionConfig)
+
 	// Note: Intentionally always patching ExtensionConfig even if discoverExtensionConfig failed.
 	if err := patchExtensionConfig(ctx, c, original, extensionConfig); err != nil {
 		errs = append(errs, err)
@@ -40,8 +40,10 @@ import (
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	fakev1alpha1 "sigs.k8s.io/cluster-api/internal/runtime/test/v1alpha1"
 	"sigs.k8s.io/cluster-api/util"
@@ -439,6 +441,80 @@ func Test_reconcileCABundle(t *testing.T) {
 	}
 }
 
+func Test_setCircuitBreakerClosedCondition(t *testing.T) {
+	circuitBreaker := runtimev1.CircuitBreaker{FailureThreshold: 3}
+
+	tests := []struct {
+		name           string
+		circuitBreaker runtimev1.CircuitBreaker
+		states         map[string]runtimeclient.CircuitBreakerState
+		noStateGetter  bool
+		wantStatus     metav1.ConditionStatus
+		wantReason     string
+	}{
+		{
+			name:       "circuit breaker not configured",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: runtimev1.ExtensionConfigCircuitBreakerNotConfiguredReason,
+		},
+		{
+			name:           "circuit breaker not yet registered",
+			circuitBreaker: circuitBreaker,
+			wantStatus:     metav1.ConditionTrue,
+			wantReason:     runtimev1.ExtensionConfigCircuitBreakerClosedReason,
+		},
+		{
+			name:           "circuit breaker closed",
+			circuitBreaker: circuitBreaker,
+			states:         map[string]runtimeclient.CircuitBreakerState{"extensionconfig": runtimeclient.CircuitBreakerClosed},
+			wantStatus:     metav1.ConditionTrue,
+			wantReason:     runtimev1.ExtensionConfigCircuitBreakerClosedReason,
+		},
+		{
+			name:           "circuit breaker open",
+			circuitBreaker: circuitBreaker,
+			states:         map[string]runtimeclient.CircuitBreakerState{"extensionconfig": runtimeclient.CircuitBreakerOpen},
+			wantStatus:     metav1.ConditionFalse,
+			wantReason:     runtimev1.ExtensionConfigCircuitBreakerOpenReason,
+		},
+		{
+			name:           "circuit breaker half-open",
+			circuitBreaker: circuitBreaker,
+			states:         map[string]runtimeclient.CircuitBreakerState{"extensionconfig": runtimeclient.CircuitBreakerHalfOpen},
+			wantStatus:     metav1.ConditionFalse,
+			wantReason:     runtimev1.ExtensionConfigCircuitBreakerHalfOpenReason,
+		},
+		{
+			name:           "runtime client does not report circuit breaker state",
+			circuitBreaker: circuitBreaker,
+			states:         map[string]runtimeclient.CircuitBreakerState{"extensionconfig": runtimeclient.CircuitBreakerOpen},
+			noStateGetter:  true,
+			wantStatus:     metav1.ConditionTrue,
+			wantReason:     runtimev1.ExtensionConfigCircuitBreakerClosedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := extensionConfig(nil)
+			config.Spec.CircuitBreaker = tt.circuitBreaker
+			var runtimeClient runtimeclient.Client = fakeruntimeclient.NewRuntimeClientBuilder().WithCircuitBreakerStates(tt.states).Build()
+			if tt.noStateGetter {
+				// Hide the GetCircuitBreakerState method, like a runtime client implemented outside of Cluster API.
+				runtimeClient = struct{ runtimeclient.Client }{runtimeClient}
+			}
+
+			setCircuitBreakerClosedCondition(runtimeClient, config)
+
+			condition := conditions.Get(config, runtimev1.ExtensionConfigCircuitBreakerClosedCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+		})
+	}
+}
+
 func Test_validateExtensionConfig(t *testing.T) {
 	tests := []struct {
 		name           string
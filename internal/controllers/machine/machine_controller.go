@@ -48,9 +48,11 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
@@ -131,6 +133,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	if feature.Gates.Enabled(feature.InPlaceUpdates) && r.RuntimeClient == nil {
 		return errors.New("RuntimeClient must not be nil when InPlaceUpdates feature gate is enabled")
 	}
+	if feature.Gates.Enabled(feature.RuntimeSDK) && r.RuntimeClient == nil {
+		return errors.New("RuntimeClient must not be nil when RuntimeSDK feature gate is enabled")
+	}
 
 	r.predicateLog = ptr.To(ctrl.LoggerFrom(ctx).WithValues("controller", "machine"))
 	clusterToMachines, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &clusterv1.MachineList{}, mgr.GetScheme())
@@ -203,6 +208,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 
 	ctx = ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues("Cluster", klog.KRef(m.Namespace, m.Spec.ClusterName)))
 
+	// Track the intent to call Machine lifecycle hooks for newly created Machines.
+	if err := r.markMachineLifecycleHooksAsPending(ctx, m); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Add finalizer first if not set to avoid the race condition between init and delete.
 	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, m, clusterv1.MachineFinalizer); err != nil || finalizerAdded {
 		return ctrl.Result{}, err
@@ -281,9 +291,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 	}
 
 	// Handle normal reconciliation loop.
+	// NOTE: The BeforeMachineCreate hook is called first, so the Machine can take ownership of the
+	// BootstrapConfig and InfraMachine in the same reconcile in which the hook unblocks provisioning.
 	reconcileNormal := append(
-		alwaysReconcile,
+		[]machineReconcileFunc{r.reconcileBeforeMachineCreateHook},
+		alwaysReconcile...,
+	)
+	reconcileNormal = append(reconcileNormal,
 		r.reconcileInPlaceUpdate,
+		r.reconcileAfterMachineReadyHook,
 	)
 
 	return doReconcile(ctx, reconcileNormal, s)
@@ -471,7 +487,24 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 	s.deletingReason = clusterv1.MachineDeletingReason
 	s.deletingMessage = "Deletion started"
 
-	err := r.isDeleteNodeAllowed(ctx, cluster, m, s.infraMachine)
+	// BeforeMachineDelete lifecycle hook.
+	// Return early without error, will requeue after the time requested by the Runtime Extensions.
+	result, message, err := r.reconcileBeforeMachineDeleteHook(ctx, s)
+	if err != nil {
+		s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
+		s.deletingMessage = "Please check controller logs for errors"
+		return ctrl.Result{}, errors.Wrapf(err, "failed to call %s hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete))
+	}
+	if !result.IsZero() {
+		s.deletingReason = clusterv1.MachineDeletingWaitingForBeforeMachineDeleteHookReason
+		s.deletingMessage = fmt.Sprintf("Waiting for %s hook to succeed", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete))
+		if message != "" {
+			s.deletingMessage += fmt.Sprintf(": %s", message)
+		}
+		return result, nil
+	}
+
+	err = r.isDeleteNodeAllowed(ctx, cluster, m, s.infraMachine)
 	isDeleteNodeAllowed := err == nil
 	if err != nil {
 		switch err {
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+// markMachineLifecycleHooksAsPending tracks the intent to call the BeforeMachineCreate and AfterMachineReady hooks
+// for a newly created Machine.
+// NOTE: This must be called before adding the Machine finalizer, so it is not possible to observe a Machine
+// with the finalizer but without the pending hooks.
+func (r *Reconciler) markMachineLifecycleHooksAsPending(ctx context.Context, m *clusterv1.Machine) error {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+		return nil
+	}
+
+	// Machines that already have the finalizer have been seen by this controller before;
+	// this also ensures Machines existing before the RuntimeSDK feature gate was enabled are not blocked.
+	if !m.DeletionTimestamp.IsZero() || controllerutil.ContainsFinalizer(m, clusterv1.MachineFinalizer) {
+		return nil
+	}
+
+	// Machines representing MachinePool instances are created after the corresponding infrastructure.
+	if _, ok := m.Labels[clusterv1.MachinePoolNameLabel]; ok {
+		return nil
+	}
+
+	// Machines already provisioned, e.g. Machines moved from another management cluster, are not new.
+	if ptr.Deref(m.Status.Initialization.InfrastructureProvisioned, false) || m.Status.NodeRef.IsDefined() {
+		return nil
+	}
+
+	return hooks.MarkAsPending(ctx, r.Client, m, true, runtimehooksv1.BeforeMachineCreate, runtimehooksv1.AfterMachineReady)
+}
+
+// reconcileBeforeMachineCreateHook calls the BeforeMachineCreate hook for a newly created Machine.
+// While the hook is pending the Machine does not take ownership of the BootstrapConfig and of the InfraMachine,
+// and thus providers do not start provisioning the Machine.
+func (r *Reconciler) reconcileBeforeMachineCreateHook(ctx context.Context, s *scope) (ctrl.Result, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || !hooks.IsPending(runtimehooksv1.BeforeMachineCreate, s.machine) {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	hookRequest := &runtimehooksv1.BeforeMachineCreateRequest{
+		Cluster: *cleanupClusterForLifecycleHook(s.cluster),
+		Machine: *cleanupMachineForLifecycleHook(s.machine),
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineCreateResponse{}
+	result, called, err := r.callMachineLifecycleHook(ctx, s.machine, runtimehooksv1.BeforeMachineCreate, hookRequest, hookResponse)
+	if err != nil || !result.IsZero() {
+		if !result.IsZero() {
+			log.Info(fmt.Sprintf("Machine provisioning is blocked by %q hook, retry after %s", runtimecatalog.HookName(runtimehooksv1.BeforeMachineCreate), result.RequeueAfter))
+		}
+		return result, err
+	}
+
+	// Note: This call will not update the resourceVersion on machine, so that the patchHelper in the main
+	// Reconcile func won't get a conflict.
+	if err := hooks.MarkAsDone(ctx, r.Client, s.machine, false, runtimehooksv1.BeforeMachineCreate); err != nil {
+		return ctrl.Result{}, err
+	}
+	if called {
+		log.Info(fmt.Sprintf("Machine provisioning is unblocked by %q hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineCreate)))
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileAfterMachineReadyHook calls the AfterMachineReady hook after the Node hosted on the Machine is ready for the first time.
+func (r *Reconciler) reconcileAfterMachineReadyHook(ctx context.Context, s *scope) (ctrl.Result, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || !hooks.IsPending(runtimehooksv1.AfterMachineReady, s.machine) {
+		return ctrl.Result{}, nil
+	}
+
+	if hooks.IsPending(runtimehooksv1.BeforeMachineCreate, s.machine) || s.node == nil || !noderefutil.IsNodeReady(s.node) {
+		return ctrl.Result{}, nil
+	}
+
+	hookRequest := &runtimehooksv1.AfterMachineReadyRequest{
+		Cluster: *cleanupClusterForLifecycleHook(s.cluster),
+		Machine: *cleanupMachineForLifecycleHook(s.machine),
+	}
+	hookResponse := &runtimehooksv1.AfterMachineReadyResponse{}
+	if _, _, err := r.callMachineLifecycleHook(ctx, s.machine, runtimehooksv1.AfterMachineReady, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Note: This call will not update the resourceVersion on machine, so that the patchHelper in the main
+	// Reconcile func won't get a conflict.
+	return ctrl.Result{}, hooks.MarkAsDone(ctx, r.Client, s.machine, false, runtimehooksv1.AfterMachineReady)
+}
+
+// reconcileBeforeMachineDeleteHook calls the BeforeMachineDelete hook if the Machine is not yet marked as ok to delete,
+// and marks the Machine as ok to delete after all the Runtime Extensions unblocked deletion.
+func (r *Reconciler) reconcileBeforeMachineDeleteHook(ctx context.Context, s *scope) (ctrl.Result, string, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || hooks.IsOkToDelete(s.machine) {
+		return ctrl.Result{}, "", nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	hookRequest := &runtimehooksv1.BeforeMachineDeleteRequest{
+		Cluster: *cleanupClusterForLifecycleHook(s.cluster),
+		Machine: *cleanupMachineForLifecycleHook(s.machine),
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDeleteResponse{}
+	result, called, err := r.callMachineLifecycleHook(ctx, s.machine, runtimehooksv1.BeforeMachineDelete, hookRequest, hookResponse)
+	if err != nil {
+		return ctrl.Result{}, "", err
+	}
+	if !result.IsZero() {
+		log.Info(fmt.Sprintf("Machine deletion is blocked by %q hook, retry after %s", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete), result.RequeueAfter))
+		return result, hookResponse.GetMessage(), nil
+	}
+
+	// Note: This call will not update the resourceVersion on machine, so that the patchHelper in the main
+	// Reconcile func won't get a conflict.
+	if err := hooks.MarkAsOkToDelete(ctx, r.Client, s.machine, false); err != nil {
+		return ctrl.Result{}, "", err
+	}
+	if called {
+		log.Info(fmt.Sprintf("Machine deletion is unblocked by %q hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete)))
+	}
+	return ctrl.Result{}, "", nil
+}
+
+// callMachineLifecycleHook calls all the Runtime Extensions registered for a Machine lifecycle hook.
+// In case of blocking hooks, a non-zero result is returned if any of the Runtime Extensions requested to retry;
+// the response is cached, so Runtime Extensions are not called again before the requested time.
+// The returned bool is false if there are no Runtime Extensions registered for the hook.
+func (r *Reconciler) callMachineLifecycleHook(ctx context.Context, m *clusterv1.Machine, hook runtimecatalog.Hook, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject) (ctrl.Result, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Return quickly if the hook is not defined.
+	extensionHandlers, err := r.RuntimeClient.GetAllExtensions(ctx, hook, m)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	if len(extensionHandlers) == 0 {
+		return ctrl.Result{}, false, nil
+	}
+
+	if cacheEntry, ok := r.hookCache.Has(cache.NewHookEntryKey(m, hook)); ok {
+		if requeueAfter, requeue := cacheEntry.ShouldRequeue(time.Now()); requeue {
+			log.V(5).Info(fmt.Sprintf("Skip calling %s hook, retry after %s", runtimecatalog.HookName(hook), requeueAfter))
+			response.SetMessage(cacheEntry.ResponseMessage)
+			return ctrl.Result{RequeueAfter: requeueAfter}, true, nil
+		}
+	}
+
+	if err := r.RuntimeClient.CallAllExtensions(ctx, hook, m, request, response); err != nil {
+		return ctrl.Result{}, true, err
+	}
+
+	if retryResponse, ok := response.(runtimehooksv1.RetryResponseObject); ok && retryResponse.GetRetryAfterSeconds() != 0 {
+		requeueAfter := time.Duration(retryResponse.GetRetryAfterSeconds()) * time.Second
+		r.hookCache.Add(cache.NewHookEntry(m, hook, time.Now().Add(requeueAfter), retryResponse.GetMessage()))
+		return ctrl.Result{RequeueAfter: requeueAfter}, true, nil
+	}
+	return ctrl.Result{}, true, nil
+}
+
+// cleanupMachineForLifecycleHook returns a copy of the Machine to be sent to Runtime Extensions.
+// Differently from in-place update hooks, Machine lifecycle hooks get the Machine status, e.g. to know the Node name.
+func cleanupMachineForLifecycleHook(machine *clusterv1.Machine) *clusterv1.Machine {
+	machine = machine.DeepCopy()
+	// Set GVK because object is later marshalled with json.Marshal when the hook request is sent.
+	machine.TypeMeta = metav1.TypeMeta{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Machine",
+	}
+	machine.SetManagedFields(nil)
+	return machine
+}
+
+// cleanupClusterForLifecycleHook returns a copy of the Cluster to be sent to Runtime Extensions.
+func cleanupClusterForLifecycleHook(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		// Set GVK because object is later marshalled with json.Marshal when the hook request is sent.
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name,
+			Namespace:   cluster.Namespace,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+		},
+		Spec: *cluster.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+func TestMarkMachineLifecycleHooksAsPending(t *testing.T) {
+	tests := []struct {
+		name           string
+		featureEnabled bool
+		machine        func() *clusterv1.Machine
+		wantPending    bool
+	}{
+		{
+			name:           "feature gate disabled does not mark hooks as pending",
+			featureEnabled: false,
+			machine:        newTestMachine,
+			wantPending:    false,
+		},
+		{
+			name:           "new Machine gets hooks marked as pending",
+			featureEnabled: true,
+			machine:        newTestMachine,
+			wantPending:    true,
+		},
+		{
+			name:           "Machine with finalizer does not get hooks marked as pending",
+			featureEnabled: true,
+			machine: func() *clusterv1.Machine {
+				m := newTestMachine()
+				m.Finalizers = []string{clusterv1.MachineFinalizer}
+				return m
+			},
+			wantPending: false,
+		},
+		{
+			name:           "MachinePool Machine does not get hooks marked as pending",
+			featureEnabled: true,
+			machine: func() *clusterv1.Machine {
+				m := newTestMachine()
+				m.Labels[clusterv1.MachinePoolNameLabel] = "mp"
+				return m
+			},
+			wantPending: false,
+		},
+		{
+			name:           "provisioned Machine does not get hooks marked as pending",
+			featureEnabled: true,
+			machine: func() *clusterv1.Machine {
+				m := newTestMachine()
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+				return m
+			},
+			wantPending: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.featureEnabled)
+
+			machine := tt.machine()
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(newLifecycleHooksTestScheme(g)).WithObjects(machine).Build(),
+			}
+
+			g.Expect(r.markMachineLifecycleHooksAsPending(context.Background(), machine)).To(Succeed())
+			g.Expect(hooks.IsPending(runtimehooksv1.BeforeMachineCreate, machine)).To(Equal(tt.wantPending))
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineReady, machine)).To(Equal(tt.wantPending))
+		})
+	}
+}
+
+func TestReconcileBeforeMachineCreateHook(t *testing.T) {
+	tests := []struct {
+		name               string
+		extensions         []string
+		response           *runtimehooksv1.BeforeMachineCreateResponse
+		wantResult         ctrl.Result
+		wantErr            bool
+		wantPending        bool
+		wantCallAllCounter int
+	}{
+		{
+			name:               "marks hook as done if there are no extensions",
+			extensions:         nil,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+			wantCallAllCounter: 0,
+		},
+		{
+			name:       "requeues if extensions are blocking",
+			extensions: []string{"test-extension"},
+			response: &runtimehooksv1.BeforeMachineCreateResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+					RetryAfterSeconds: 10,
+				},
+			},
+			wantResult:         ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantPending:        true,
+			wantCallAllCounter: 1,
+		},
+		{
+			name:       "marks hook as done if extensions are not blocking",
+			extensions: []string{"test-extension"},
+			response: &runtimehooksv1.BeforeMachineCreateResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				},
+			},
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+			wantCallAllCounter: 1,
+		},
+		{
+			name:       "returns error if extensions fail",
+			extensions: []string{"test-extension"},
+			response: &runtimehooksv1.BeforeMachineCreateResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+				},
+			},
+			wantResult:         ctrl.Result{},
+			wantErr:            true,
+			wantPending:        true,
+			wantCallAllCounter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+			machine := newTestMachine()
+			machine.Annotations[runtimev1.PendingHooksAnnotation] = "BeforeMachineCreate,AfterMachineReady"
+
+			r, runtimeClient := newLifecycleHooksTestReconciler(g, runtimehooksv1.BeforeMachineCreate, tt.extensions, tt.response, machine)
+			s := &scope{cluster: newLifecycleHooksTestCluster(), machine: machine}
+
+			result, err := r.reconcileBeforeMachineCreateHook(context.Background(), s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(hooks.IsPending(runtimehooksv1.BeforeMachineCreate, machine)).To(Equal(tt.wantPending))
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineReady, machine)).To(BeTrue())
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineCreate)).To(Equal(tt.wantCallAllCounter))
+		})
+	}
+}
+
+func TestReconcileAfterMachineReadyHook(t *testing.T) {
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReadyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		pendingHooks       string
+		node               *corev1.Node
+		wantPending        bool
+		wantCallAllCounter int
+	}{
+		{
+			name:               "does not call the hook if the Node does not exist",
+			pendingHooks:       "AfterMachineReady",
+			node:               nil,
+			wantPending:        true,
+			wantCallAllCounter: 0,
+		},
+		{
+			name:               "does not call the hook if the Node is not ready",
+			pendingHooks:       "AfterMachineReady",
+			node:               notReadyNode,
+			wantPending:        true,
+			wantCallAllCounter: 0,
+		},
+		{
+			name:               "does not call the hook if BeforeMachineCreate is still pending",
+			pendingHooks:       "BeforeMachineCreate,AfterMachineReady",
+			node:               readyNode,
+			wantPending:        true,
+			wantCallAllCounter: 0,
+		},
+		{
+			name:               "calls the hook and marks it as done if the Node is ready",
+			pendingHooks:       "AfterMachineReady",
+			node:               readyNode,
+			wantPending:        false,
+			wantCallAllCounter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+			machine := newTestMachine()
+			machine.Annotations[runtimev1.PendingHooksAnnotation] = tt.pendingHooks
+
+			response := &runtimehooksv1.AfterMachineReadyResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+			}
+			r, runtimeClient := newLifecycleHooksTestReconciler(g, runtimehooksv1.AfterMachineReady, []string{"test-extension"}, response, machine)
+			s := &scope{cluster: newLifecycleHooksTestCluster(), machine: machine, node: tt.node}
+
+			result, err := r.reconcileAfterMachineReadyHook(context.Background(), s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.IsZero()).To(BeTrue())
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineReady, machine)).To(Equal(tt.wantPending))
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.AfterMachineReady)).To(Equal(tt.wantCallAllCounter))
+		})
+	}
+}
+
+func TestReconcileBeforeMachineDeleteHook(t *testing.T) {
+	tests := []struct {
+		name               string
+		okToDelete         bool
+		response           *runtimehooksv1.BeforeMachineDeleteResponse
+		wantResult         ctrl.Result
+		wantMessage        string
+		wantOkToDelete     bool
+		wantCallAllCounter int
+	}{
+		{
+			name:               "does not call the hook if the Machine is already ok to delete",
+			okToDelete:         true,
+			wantResult:         ctrl.Result{},
+			wantOkToDelete:     true,
+			wantCallAllCounter: 0,
+		},
+		{
+			name: "requeues if extensions are blocking",
+			response: &runtimehooksv1.BeforeMachineDeleteResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{
+						Status:  runtimehooksv1.ResponseStatusSuccess,
+						Message: "draining workloads",
+					},
+					RetryAfterSeconds: 10,
+				},
+			},
+			wantResult:         ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantMessage:        "draining workloads",
+			wantOkToDelete:     false,
+			wantCallAllCounter: 1,
+		},
+		{
+			name: "marks the Machine as ok to delete if extensions are not blocking",
+			response: &runtimehooksv1.BeforeMachineDeleteResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				},
+			},
+			wantResult:         ctrl.Result{},
+			wantOkToDelete:     true,
+			wantCallAllCounter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+			machine := newTestMachine()
+			if tt.okToDelete {
+				machine.Annotations[runtimev1.OkToDeleteAnnotation] = ""
+			}
+
+			r, runtimeClient := newLifecycleHooksTestReconciler(g, runtimehooksv1.BeforeMachineDelete, []string{"test-extension"}, tt.response, machine)
+			s := &scope{cluster: newLifecycleHooksTestCluster(), machine: machine}
+
+			result, message, err := r.reconcileBeforeMachineDeleteHook(context.Background(), s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(message).To(Equal(tt.wantMessage))
+			g.Expect(hooks.IsOkToDelete(machine)).To(Equal(tt.wantOkToDelete))
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineDelete)).To(Equal(tt.wantCallAllCounter))
+
+			if tt.wantResult.IsZero() {
+				return
+			}
+			// Calling the hook again before RetryAfterSeconds expired uses the cached response.
+			result, message, err = r.reconcileBeforeMachineDeleteHook(context.Background(), s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			g.Expect(message).To(Equal(tt.wantMessage))
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineDelete)).To(Equal(tt.wantCallAllCounter))
+		})
+	}
+}
+
+func newLifecycleHooksTestScheme(g *WithT) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+func newLifecycleHooksTestCluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "default",
+		},
+	}
+}
+
+func newLifecycleHooksTestReconciler(g *WithT, hook runtimecatalog.Hook, extensions []string, response runtimehooksv1.ResponseObject, machine *clusterv1.Machine) (*Reconciler, *fakeruntimeclient.RuntimeClient) {
+	catalog := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(catalog)).To(Succeed())
+	gvh, err := catalog.GroupVersionHook(hook)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	builder := fakeruntimeclient.NewRuntimeClientBuilder().
+		WithCatalog(catalog).
+		WithGetAllExtensionResponses(map[runtimecatalog.GroupVersionHook][]string{
+			gvh: extensions,
+		})
+	if response != nil {
+		builder = builder.WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+			gvh: response,
+		})
+	}
+	runtimeClient := builder.Build()
+
+	return &Reconciler{
+		Client:        fake.NewClientBuilder().WithScheme(newLifecycleHooksTestScheme(g)).WithObjects(machine).Build(),
+		RuntimeClient: runtimeClient,
+		hookCache:     cache.New[cache.HookEntry](cache.HookCacheDefaultTTL),
+	}, runtimeClient
+}
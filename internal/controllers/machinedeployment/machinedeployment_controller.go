@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
@@ -77,6 +78,7 @@ type Reconciler struct {
 	Client        client.Client
 	APIReader     client.Reader
 	RuntimeClient runtimeclient.Client
+	ClusterCache  clustercache.ClusterCache
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
@@ -202,7 +204,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 		return ctrl.Result{}, r.reconcileDelete(ctx, s)
 	}
 
-	return ctrl.Result{}, r.reconcile(ctx, s)
+	return r.reconcile(ctx, s)
 }
 
 type scope struct {
@@ -241,12 +243,13 @@ func patchMachineDeployment(ctx context.Context, patchHelper *patch.Helper, md *
 			clusterv1.MachineDeploymentScalingUpCondition,
 			clusterv1.MachineDeploymentRemediatingCondition,
 			clusterv1.MachineDeploymentDeletingCondition,
+			clusterv1.MachineDeploymentRolloutFailedCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, md, options...)
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, s *scope) error {
+func (r *Reconciler) reconcile(ctx context.Context, s *scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(4).Info("Reconcile MachineDeployment")
 
@@ -275,23 +278,23 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope) error {
 	}))
 
 	if err := r.getTemplatesAndSetOwner(ctx, s); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err := r.getAndAdoptMachineSetsForDeployment(ctx, s); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	var anyManagedFieldIssueMitigated bool
 	for _, ms := range s.machineSets {
 		managedFieldIssueMitigated, err := ssa.MitigateManagedFieldsIssue(ctx, r.Client, ms, machineDeploymentManagerName)
 		if err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 		anyManagedFieldIssueMitigated = anyManagedFieldIssueMitigated || managedFieldIssueMitigated
 	}
 	if anyManagedFieldIssueMitigated {
-		return nil // No requeue needed, changes will trigger another reconcile.
+		return ctrl.Result{}, nil // No requeue needed, changes will trigger another reconcile.
 	}
 
 	// If not already present, add a label specifying the MachineDeployment name to MachineSets.
@@ -308,25 +311,55 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope) error {
 		original := machineSet.DeepCopy()
 		machineSet.Labels[clusterv1.MachineDeploymentNameLabel] = md.Name
 		if err := r.Client.Patch(ctx, machineSet, client.MergeFrom(original)); err != nil {
-			return errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
 		}
 	}
 
+	rolledBack, progressDeadlineRequeueAfter, err := r.reconcileProgressDeadline(ctx, s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if rolledBack {
+		return ctrl.Result{}, nil // No requeue needed, the change to spec.template.spec will trigger another reconcile.
+	}
+
 	templateExists := s.infrastructureTemplateExists && (!md.Spec.Template.Spec.Bootstrap.ConfigRef.IsDefined() || s.bootstrapTemplateExists)
 
+	// Canary status is only reported when using the Canary strategy.
+	if md.Spec.Rollout.Strategy.Type != clusterv1.CanaryMachineDeploymentStrategyType {
+		md.Status.Canary = clusterv1.MachineDeploymentCanaryStatus{}
+	}
+
+	result, err := r.rollout(ctx, md, s, templateExists)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return util.LowestNonZeroResult(result, ctrl.Result{RequeueAfter: progressDeadlineRequeueAfter}), nil
+}
+
+// rollout reconciles the MachineSets controlled by a MachineDeployment according to its rollout strategy.
+func (r *Reconciler) rollout(ctx context.Context, md *clusterv1.MachineDeployment, s *scope, templateExists bool) (ctrl.Result, error) {
 	if ptr.Deref(md.Spec.Paused, false) {
-		return r.sync(ctx, md, s.machineSets, s.machines, templateExists)
+		return ctrl.Result{}, r.sync(ctx, md, s.machineSets, s.machines, templateExists)
 	}
 
 	if md.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType {
-		return r.rolloutRollingUpdate(ctx, md, s.machineSets, s.machines, templateExists)
+		return ctrl.Result{}, r.rolloutRollingUpdate(ctx, md, s.machineSets, s.machines, templateExists)
 	}
 
 	if md.Spec.Rollout.Strategy.Type == clusterv1.OnDeleteMachineDeploymentStrategyType {
-		return r.rolloutOnDelete(ctx, md, s.machineSets, s.machines, templateExists)
+		return ctrl.Result{}, r.rolloutOnDelete(ctx, md, s.machineSets, s.machines, templateExists)
+	}
+
+	if md.Spec.Rollout.Strategy.Type == clusterv1.BlueGreenMachineDeploymentStrategyType {
+		return r.rolloutBlueGreen(ctx, md, s.machineSets, s.machines, templateExists)
+	}
+
+	if md.Spec.Rollout.Strategy.Type == clusterv1.CanaryMachineDeploymentStrategyType {
+		return r.rolloutCanary(ctx, md, s.machineSets, s.machines, templateExists)
 	}
 
-	return errors.Errorf("unexpected deployment strategy type: %s", md.Spec.Rollout.Strategy.Type)
+	return ctrl.Result{}, errors.Errorf("unexpected deployment strategy type: %s", md.Spec.Rollout.Strategy.Type)
 }
 
 // createOrUpdateMachineSetsAndSyncMachineDeploymentRevision applies changes identified by the rolloutPlanner to both newMS and oldMSs.
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileProgressDeadline tracks the progress of the rollout of the new MachineSet, and it marks the rollout as failed
+// when it does not make progress within spec.rollout.progressDeadlineSeconds; if required, it also rolls back the
+// MachineDeployment to the previous MachineSet revision.
+// Note: If the MachineDeployment has been rolled back, the reconcile should not proceed until the change
+// to spec.template.spec is persisted.
+func (r *Reconciler) reconcileProgressDeadline(ctx context.Context, s *scope) (rolledBack bool, requeueAfter time.Duration, err error) {
+	log := ctrl.LoggerFrom(ctx)
+	md := s.machineDeployment
+
+	if md.Spec.Rollout.ProgressDeadlineSeconds == nil {
+		md.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{}
+		conditions.Delete(md, clusterv1.MachineDeploymentRolloutFailedCondition)
+		return false, 0, nil
+	}
+
+	// Note: If there is no new MachineSet yet, progress will be tracked as soon as the new MachineSet is created.
+	now := time.Now().UTC()
+	newMS, oldMSs, _, _ := mdutil.FindNewAndOldMachineSets(md, s.machineSets, metav1.NewTime(now))
+	if newMS == nil {
+		return false, 0, nil
+	}
+
+	// If the new MachineSet changed, start tracking progress for the new MachineSet.
+	// Note: When the MachineDeployment is rolled back, progress for the MachineSet it is rolled back to is tracked
+	// immediately, and thus the RolledBack reason is preserved until the next change of the new MachineSet.
+	if md.Status.Rollout.MachineSetName != newMS.Name {
+		md.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{
+			MachineSetName:   newMS.Name,
+			LastProgressTime: metav1.NewTime(now),
+		}
+		conditions.Set(md, metav1.Condition{
+			Type:   clusterv1.MachineDeploymentRolloutFailedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineDeploymentRolloutNotFailedReason,
+		})
+	}
+
+	// Progress is not estimated while the MachineDeployment is paused.
+	if ptr.Deref(md.Spec.Paused, false) {
+		md.Status.Rollout.LastProgressTime = metav1.NewTime(now)
+		return false, 0, nil
+	}
+
+	// The rollout makes progress every time a Machine of the new MachineSet becomes available.
+	for _, m := range s.machines {
+		if !util.IsControlledBy(m, newMS, clusterv1.GroupVersion.WithKind("MachineSet").GroupKind()) {
+			continue
+		}
+		if c := conditions.Get(m, clusterv1.MachineAvailableCondition); c != nil && c.Status == metav1.ConditionTrue && c.LastTransitionTime.After(md.Status.Rollout.LastProgressTime.Time) {
+			md.Status.Rollout.LastProgressTime = c.LastTransitionTime
+		}
+	}
+
+	// If the rollout is completed, there is nothing else to check.
+	mdReplicas := ptr.Deref(md.Spec.Replicas, 0)
+	if ptr.Deref(newMS.Spec.Replicas, 0) == mdReplicas &&
+		ptr.Deref(newMS.Status.AvailableReplicas, 0) >= mdReplicas &&
+		ptr.Deref(mdutil.GetActualReplicaCountForMachineSets(oldMSs), 0) == 0 {
+		setRolloutNotFailedCondition(md)
+		return false, 0, nil
+	}
+
+	progressDeadline := time.Duration(*md.Spec.Rollout.ProgressDeadlineSeconds) * time.Second
+	deadline := md.Status.Rollout.LastProgressTime.Add(progressDeadline)
+	if now.Before(deadline) {
+		setRolloutNotFailedCondition(md)
+		return false, deadline.Sub(now), nil
+	}
+
+	message := fmt.Sprintf("MachineSet %s did not make progress in %s", newMS.Name, progressDeadline)
+
+	// If the MachineDeployment has already been rolled back, do not roll back again, just surface that also the rollout
+	// of the MachineSet the MachineDeployment has been rolled back to is not making progress.
+	if c := conditions.Get(md, clusterv1.MachineDeploymentRolloutFailedCondition); c != nil && c.Status == metav1.ConditionTrue && c.Reason == clusterv1.MachineDeploymentRolloutRolledBackReason {
+		conditions.Set(md, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentRolloutFailedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineDeploymentRolloutRolledBackReason,
+			Message: fmt.Sprintf("%s after rollback", message),
+		})
+		return false, 0, nil
+	}
+
+	if md.Spec.Rollout.OnProgressDeadlineExceeded != clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction {
+		setRolloutProgressDeadlineExceededCondition(md, message)
+		return false, 0, nil
+	}
+
+	if _, ok := md.Labels[clusterv1.ClusterTopologyOwnedLabel]; ok {
+		setRolloutProgressDeadlineExceededCondition(md, fmt.Sprintf("%s; rollback is not supported for MachineDeployments managed by a ClusterClass", message))
+		return false, 0, nil
+	}
+
+	previousMS := mdutil.FindPreviousRevisionMachineSet(newMS, oldMSs)
+	if previousMS == nil {
+		setRolloutProgressDeadlineExceededCondition(md, fmt.Sprintf("%s; there is no previous MachineSet revision to roll back to", message))
+		return false, 0, nil
+	}
+
+	// Roll back by reverting spec.template.spec to the one of the previous MachineSet revision.
+	// Note: labels and annotations of the MachineDeployment's template are not reverted, because they are propagated in-place
+	// to all the MachineSets, and thus they do not influence the rollout decision.
+	log.Info(fmt.Sprintf("Rolling back to MachineSet %s (revision %s): %s", klog.KObj(previousMS), previousMS.Annotations[clusterv1.RevisionAnnotation], message), "MachineSet", klog.KObj(previousMS))
+	md.Spec.Template.Spec = *previousMS.Spec.Template.Spec.DeepCopy()
+	md.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{
+		MachineSetName:   previousMS.Name,
+		LastProgressTime: metav1.NewTime(now),
+	}
+	conditions.Set(md, metav1.Condition{
+		Type:    clusterv1.MachineDeploymentRolloutFailedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  clusterv1.MachineDeploymentRolloutRolledBackReason,
+		Message: fmt.Sprintf("%s; rolled back to MachineSet %s (revision %s)", message, previousMS.Name, previousMS.Annotations[clusterv1.RevisionAnnotation]),
+	})
+	if r.recorder != nil {
+		r.recorder.Eventf(md, corev1.EventTypeWarning, "RolledBack", "Rolled back to MachineSet %q: %s", previousMS.Name, message)
+	}
+	return true, 0, nil
+}
+
+// setRolloutNotFailedCondition sets the RolloutFailed condition to false, unless the MachineDeployment
+// has been rolled back to the current MachineSet.
+func setRolloutNotFailedCondition(md *clusterv1.MachineDeployment) {
+	if c := conditions.Get(md, clusterv1.MachineDeploymentRolloutFailedCondition); c != nil && c.Reason == clusterv1.MachineDeploymentRolloutRolledBackReason {
+		return
+	}
+	conditions.Set(md, metav1.Condition{
+		Type:   clusterv1.MachineDeploymentRolloutFailedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineDeploymentRolloutNotFailedReason,
+	})
+}
+
+func setRolloutProgressDeadlineExceededCondition(md *clusterv1.MachineDeployment, message string) {
+	conditions.Set(md, metav1.Condition{
+		Type:    clusterv1.MachineDeploymentRolloutFailedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  clusterv1.MachineDeploymentRolloutProgressDeadlineExceededReason,
+		Message: message,
+	})
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileProgressDeadline(t *testing.T) {
+	now := time.Now().UTC()
+	longAgo := metav1.NewTime(now.Add(-time.Hour))
+
+	md := func(version string, action clusterv1.MachineDeploymentProgressDeadlineExceededAction) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "md",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: ptr.To[int32](3),
+				Rollout: clusterv1.MachineDeploymentRolloutSpec{
+					ProgressDeadlineSeconds:    ptr.To[int32](600),
+					OnProgressDeadlineExceeded: action,
+				},
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: version,
+					},
+				},
+			},
+		}
+	}
+	ms := func(name, revision, version string, replicas, availableReplicas int32) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Annotations: map[string]string{
+					clusterv1.RevisionAnnotation: revision,
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: ptr.To(replicas),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: version,
+					},
+				},
+			},
+			Status: clusterv1.MachineSetStatus{
+				Replicas:          ptr.To(replicas),
+				AvailableReplicas: ptr.To(availableReplicas),
+			},
+		}
+	}
+	availableMachine := func(name string, owner *clusterv1.MachineSet, lastTransitionTime metav1.Time) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(owner, clusterv1.GroupVersion.WithKind("MachineSet")),
+				},
+			},
+			Status: clusterv1.MachineStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               clusterv1.MachineAvailableCondition,
+						Status:             metav1.ConditionTrue,
+						Reason:             clusterv1.MachineAvailableReason,
+						LastTransitionTime: lastTransitionTime,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("clears rollout status if progressDeadlineSeconds is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", "")
+		d.Spec.Rollout.ProgressDeadlineSeconds = nil
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		conditions.Set(d, metav1.Condition{Type: clusterv1.MachineDeploymentRolloutFailedCondition, Status: metav1.ConditionTrue, Reason: clusterv1.MachineDeploymentRolloutProgressDeadlineExceededReason})
+
+		r := &Reconciler{}
+		rolledBack, requeueAfter, err := r.reconcileProgressDeadline(ctx, &scope{machineDeployment: d})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(requeueAfter).To(BeZero())
+		g.Expect(d.Status.Rollout).To(Equal(clusterv1.MachineDeploymentRolloutStatus{}))
+		g.Expect(conditions.Has(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(BeFalse())
+	})
+
+	t.Run("starts tracking progress of a new MachineSet", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", "")
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms1", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.30.0", 3, 3),
+				ms("ms2", "2", "v1.31.0", 1, 0),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, requeueAfter, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(requeueAfter).To(BeNumerically("~", 600*time.Second, 5*time.Second))
+		g.Expect(d.Status.Rollout.MachineSetName).To(Equal("ms2"))
+		g.Expect(d.Status.Rollout.LastProgressTime.Time).To(BeTemporally(">=", now))
+		g.Expect(conditions.IsFalse(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(BeTrue())
+	})
+
+	t.Run("tracks progress when Machines of the new MachineSet become available", func(t *testing.T) {
+		g := NewWithT(t)
+
+		newMS := ms("ms2", "2", "v1.31.0", 2, 1)
+		progressTime := metav1.NewTime(now.Add(-5 * time.Minute).Truncate(time.Second))
+		d := md("v1.31.0", "")
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.30.0", 2, 2),
+				newMS,
+			},
+			machines: collections.FromMachines(availableMachine("m1", newMS, progressTime)),
+		}
+
+		r := &Reconciler{}
+		rolledBack, requeueAfter, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(requeueAfter).To(BeNumerically("~", 5*time.Minute, 5*time.Second))
+		g.Expect(d.Status.Rollout.LastProgressTime).To(Equal(progressTime))
+		g.Expect(conditions.IsFalse(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(BeTrue())
+	})
+
+	t.Run("does not fail a completed rollout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", "")
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.30.0", 0, 0),
+				ms("ms2", "2", "v1.31.0", 3, 3),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, requeueAfter, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(requeueAfter).To(BeZero())
+		g.Expect(conditions.IsFalse(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(BeTrue())
+	})
+
+	t.Run("marks the rollout as failed when the deadline is exceeded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", clusterv1.FailMachineDeploymentProgressDeadlineExceededAction)
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.30.0", 3, 3),
+				ms("ms2", "2", "v1.31.0", 1, 0),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, _, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(d.Spec.Template.Spec.Version).To(Equal("v1.31.0"))
+		c := conditions.Get(d, clusterv1.MachineDeploymentRolloutFailedCondition)
+		g.Expect(c).ToNot(BeNil())
+		g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(c.Reason).To(Equal(clusterv1.MachineDeploymentRolloutProgressDeadlineExceededReason))
+	})
+
+	t.Run("rolls back to the previous MachineSet when the deadline is exceeded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction)
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms3", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.29.0", 0, 0),
+				ms("ms2", "2", "v1.30.0", 3, 3),
+				ms("ms3", "3", "v1.31.0", 1, 0),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, _, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeTrue())
+		g.Expect(d.Spec.Template.Spec.Version).To(Equal("v1.30.0"))
+		g.Expect(d.Status.Rollout.MachineSetName).To(Equal("ms2"))
+		c := conditions.Get(d, clusterv1.MachineDeploymentRolloutFailedCondition)
+		g.Expect(c).ToNot(BeNil())
+		g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(c.Reason).To(Equal(clusterv1.MachineDeploymentRolloutRolledBackReason))
+
+		// The next reconcile tracks progress for the MachineSet the MachineDeployment has been rolled back to,
+		// and preserves the RolledBack reason.
+		rolledBack, _, err = r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(conditions.GetReason(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(Equal(clusterv1.MachineDeploymentRolloutRolledBackReason))
+	})
+
+	t.Run("does not roll back twice", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.30.0", clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction)
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		conditions.Set(d, metav1.Condition{Type: clusterv1.MachineDeploymentRolloutFailedCondition, Status: metav1.ConditionTrue, Reason: clusterv1.MachineDeploymentRolloutRolledBackReason})
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.29.0", 0, 0),
+				ms("ms2", "2", "v1.30.0", 2, 1),
+				ms("ms3", "3", "v1.31.0", 1, 0),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, _, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(d.Spec.Template.Spec.Version).To(Equal("v1.30.0"))
+		g.Expect(conditions.GetReason(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(Equal(clusterv1.MachineDeploymentRolloutRolledBackReason))
+		g.Expect(conditions.GetMessage(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(ContainSubstring("after rollback"))
+	})
+
+	t.Run("does not roll back MachineDeployments managed by a ClusterClass", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := md("v1.31.0", clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction)
+		d.Labels = map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""}
+		d.Status.Rollout = clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "ms2", LastProgressTime: longAgo}
+		s := &scope{
+			machineDeployment: d,
+			machineSets: []*clusterv1.MachineSet{
+				ms("ms1", "1", "v1.30.0", 3, 3),
+				ms("ms2", "2", "v1.31.0", 1, 0),
+			},
+		}
+
+		r := &Reconciler{}
+		rolledBack, _, err := r.reconcileProgressDeadline(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(rolledBack).To(BeFalse())
+		g.Expect(d.Spec.Template.Spec.Version).To(Equal("v1.31.0"))
+		g.Expect(conditions.GetReason(d, clusterv1.MachineDeploymentRolloutFailedCondition)).To(Equal(clusterv1.MachineDeploymentRolloutProgressDeadlineExceededReason))
+	})
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+// rolloutBlueGreen reconcile machine sets controlled by a MachineDeployment that is using the BlueGreen strategy.
+func (r *Reconciler) rolloutBlueGreen(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, machines collections.Machines, templateExists bool) (ctrl.Result, error) {
+	planner := newRolloutPlanner(r.Client, r.RuntimeClient, r.canUpdateMachineSetCache)
+	if err := planner.init(ctx, md, msList, machines.UnsortedList(), true, templateExists); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := planner.planBlueGreen(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.createOrUpdateMachineSetsAndSyncMachineDeploymentRevision(ctx, planner); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newMS := planner.newMS
+	oldMSs := planner.oldMSs
+	allMSs := append(oldMSs, newMS)
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if mdutil.DeploymentComplete(md, &md.Status) {
+		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: planner.requeueAfter}, nil
+}
+
+// planBlueGreen determine how to proceed with the rollout when using the BlueGreen strategy if we are not yet at the desired state.
+// The blue/green rollout goes through the following phases:
+//   - the newMS is scaled up to the MachineDeployment's spec.replicas, while oldMSs keep serving.
+//   - once all the replicas of the newMS are available, the planner waits for the soak period and, if required, for a manual promotion.
+//   - the newMS is promoted by scaling down all the oldMSs at once.
+//
+// If the newMS does not become available before the rollback timeout, the rollout is rolled back by scaling down
+// the newMS to zero, while oldMSs keep serving; the rollout is retried when the BlueGreenRolledBackAnnotation is removed.
+func (p *rolloutPlanner) planBlueGreen(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	now := time.Now().UTC()
+	blueGreen := p.md.Spec.Rollout.Strategy.BlueGreen
+	mdReplicas := ptr.Deref(p.md.Spec.Replicas, 0)
+
+	// Carry over annotations tracking the progress of the blue/green rollout from the current newMS, if any.
+	p.carryOverBlueGreenAnnotations()
+
+	// If there are no old replicas, the rollout is completed (or there was nothing to roll out); just align the newMS to the MachineDeployment.
+	if mdutil.GetReplicaCountForMachineSets(p.oldMSs) == 0 {
+		delete(p.newMS.Annotations, clusterv1.BlueGreenStartedAnnotation)
+		delete(p.newMS.Annotations, clusterv1.BlueGreenAvailableAnnotation)
+		delete(p.newMS.Annotations, clusterv1.BlueGreenRolledBackAnnotation)
+		return p.reconcileNewMachineSet(ctx)
+	}
+
+	// Scale down oldMSs in case the MachineDeployment has been scaled down, so old replicas are never more than spec.replicas.
+	p.scaleDownOldMachineSets(ctx, mdReplicas)
+
+	// If the rollout has been rolled back, keep the newMS at zero replicas while oldMSs keep serving.
+	// Note: Annotations tracking the progress of the rolled back attempt are dropped, so when the user removes the
+	// BlueGreenRolledBackAnnotation the rollout is retried from scratch, with a new rollback timeout.
+	if _, ok := p.newMS.Annotations[clusterv1.BlueGreenRolledBackAnnotation]; ok {
+		delete(p.newMS.Annotations, clusterv1.BlueGreenStartedAnnotation)
+		delete(p.newMS.Annotations, clusterv1.BlueGreenAvailableAnnotation)
+		if ptr.Deref(p.newMS.Spec.Replicas, 0) > 0 {
+			p.addNotef(p.newMS, "scale down because the blue/green rollout has been rolled back")
+			log.V(5).Info(fmt.Sprintf("Setting scale down intent for MachineSet %s to 0 replicas (rollout rolled back)", klog.KObj(p.newMS)), "MachineSet", klog.KObj(p.newMS))
+			p.scaleIntents[p.newMS.Name] = 0
+		}
+		return nil
+	}
+
+	if _, ok := p.newMS.Annotations[clusterv1.BlueGreenStartedAnnotation]; !ok {
+		p.newMS.Annotations[clusterv1.BlueGreenStartedAnnotation] = now.Format(time.RFC3339)
+	}
+
+	// Bring the newMS up to the desired number of replicas, all at once.
+	if ptr.Deref(p.newMS.Spec.Replicas, 0) != mdReplicas {
+		p.addNotef(p.newMS, "scale to MachineDeployment spec.replicas to bring up the new MachineSet")
+		log.V(5).Info(fmt.Sprintf("Setting scale intent for MachineSet %s to %d replicas", klog.KObj(p.newMS), mdReplicas), "MachineSet", klog.KObj(p.newMS))
+		p.scaleIntents[p.newMS.Name] = mdReplicas
+	}
+
+	// Wait for all the replicas of the newMS to be available.
+	if ptr.Deref(p.newMS.Spec.Replicas, 0) != mdReplicas || ptr.Deref(p.newMS.Status.AvailableReplicas, 0) < mdReplicas {
+		// Availability lost during the soak period restarts the soak period.
+		delete(p.newMS.Annotations, clusterv1.BlueGreenAvailableAnnotation)
+
+		if blueGreen.RollbackTimeoutSeconds == nil {
+			return nil
+		}
+
+		startedAt := parseBlueGreenTime(p.newMS.Annotations[clusterv1.BlueGreenStartedAnnotation], now)
+		deadline := startedAt.Add(time.Duration(*blueGreen.RollbackTimeoutSeconds) * time.Second)
+		if now.Before(deadline) {
+			p.setRequeueAfter(deadline.Sub(now))
+			return nil
+		}
+
+		delete(p.newMS.Annotations, clusterv1.BlueGreenStartedAnnotation)
+		p.newMS.Annotations[clusterv1.BlueGreenRolledBackAnnotation] = now.Format(time.RFC3339)
+		p.addNotef(p.newMS, "scale down because the new MachineSet did not become available within %ds, rolling back", *blueGreen.RollbackTimeoutSeconds)
+		log.Info(fmt.Sprintf("Rolling back blue/green rollout: MachineSet %s did not become available within %ds", klog.KObj(p.newMS), *blueGreen.RollbackTimeoutSeconds), "MachineSet", klog.KObj(p.newMS))
+		p.scaleIntents[p.newMS.Name] = 0
+		return nil
+	}
+
+	if _, ok := p.newMS.Annotations[clusterv1.BlueGreenAvailableAnnotation]; !ok {
+		p.newMS.Annotations[clusterv1.BlueGreenAvailableAnnotation] = now.Format(time.RFC3339)
+	}
+
+	// Wait for the soak period to complete.
+	if soakSeconds := ptr.Deref(blueGreen.SoakSeconds, 0); soakSeconds > 0 {
+		availableAt := parseBlueGreenTime(p.newMS.Annotations[clusterv1.BlueGreenAvailableAnnotation], now)
+		soakCompletedAt := availableAt.Add(time.Duration(soakSeconds) * time.Second)
+		if now.Before(soakCompletedAt) {
+			p.addNotef(p.newMS, "waiting for the soak period to complete before promoting the new MachineSet")
+			p.setRequeueAfter(soakCompletedAt.Sub(now))
+			return nil
+		}
+	}
+
+	// Wait for manual promotion, if required.
+	if blueGreen.Promotion == clusterv1.ManualMachineDeploymentBlueGreenPromotionType &&
+		p.md.Annotations[clusterv1.BlueGreenPromoteAnnotation] != p.newMS.Name {
+		p.addNotef(p.newMS, "waiting for the new MachineSet to be promoted using the %s annotation", clusterv1.BlueGreenPromoteAnnotation)
+		return nil
+	}
+
+	// Promote the newMS by scaling down all the oldMSs at once.
+	for _, oldMS := range p.oldMSs {
+		if ptr.Deref(oldMS.Spec.Replicas, 0) <= 0 {
+			continue
+		}
+		p.addNotef(oldMS, "scale down because the new MachineSet %s has been promoted", p.newMS.Name)
+		log.V(5).Info(fmt.Sprintf("Setting scale down intent for MachineSet %s to 0 replicas (new MachineSet promoted)", klog.KObj(oldMS)), "MachineSet", klog.KObj(oldMS))
+		p.scaleIntents[oldMS.Name] = 0
+	}
+	return nil
+}
+
+// carryOverBlueGreenAnnotations carries over annotations tracking the progress of the blue/green rollout from the current newMS.
+// Note: This is required because the rollout planner computes the full intent for the newMS at every reconcile.
+func (p *rolloutPlanner) carryOverBlueGreenAnnotations() {
+	if p.newMS.Annotations == nil {
+		p.newMS.Annotations = map[string]string{}
+	}
+
+	originalMS, ok := p.originalMSs[p.newMS.Name]
+	if !ok {
+		return
+	}
+	for _, key := range []string{
+		clusterv1.BlueGreenStartedAnnotation,
+		clusterv1.BlueGreenAvailableAnnotation,
+		clusterv1.BlueGreenRolledBackAnnotation,
+	} {
+		if value, ok := originalMS.Annotations[key]; ok {
+			p.newMS.Annotations[key] = value
+		}
+	}
+}
+
+// setRequeueAfter sets requeueAfter to the given duration, if it is shorter than the current one.
+func (p *rolloutPlanner) setRequeueAfter(d time.Duration) {
+	if p.requeueAfter == 0 || d < p.requeueAfter {
+		p.requeueAfter = d
+	}
+}
+
+// parseBlueGreenTime parses a time recorded in one of the blue/green annotations, falling back to defaultTime if the value is not valid.
+func parseBlueGreenTime(value string, defaultTime time.Time) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return defaultTime
+	}
+	return t
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestPlanBlueGreen(t *testing.T) {
+	now := time.Now().UTC()
+	ago := func(d time.Duration) string {
+		return now.Add(-d).Format(time.RFC3339)
+	}
+	blueGreenMD := func(replicas int32, blueGreen clusterv1.MachineDeploymentRolloutStrategyBlueGreen, annotations map[string]string) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "md",
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Rollout: clusterv1.MachineDeploymentRolloutSpec{
+					Strategy: clusterv1.MachineDeploymentRolloutStrategy{
+						Type:      clusterv1.BlueGreenMachineDeploymentStrategyType,
+						BlueGreen: blueGreen,
+					},
+				},
+			},
+		}
+	}
+	machineSet := func(name string, replicas, availableReplicas int32, annotations map[string]string) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Annotations:       annotations,
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: ptr.To(replicas),
+			},
+			Status: clusterv1.MachineSetStatus{
+				Replicas:          ptr.To(replicas),
+				AvailableReplicas: ptr.To(availableReplicas),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                       string
+		machineDeployment          *clusterv1.MachineDeployment
+		newMachineSet              *clusterv1.MachineSet
+		oldMachineSets             []*clusterv1.MachineSet
+		expectScaleIntent          map[string]int32
+		expectNewMSAnnotations     []string
+		expectNoNewMSAnnotations   []string
+		expectRequeueAfterPositive bool
+	}{
+		{
+			name:              "scale up the new MachineSet to spec.replicas all at once, old MachineSets keep serving",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}, nil),
+			newMachineSet:     machineSet("ms2", 0, 0, nil),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms2": 3,
+			},
+			expectNewMSAnnotations:   []string{clusterv1.BlueGreenStartedAnnotation},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "wait for the new MachineSet to be available before promoting",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}, nil),
+			newMachineSet:     machineSet("ms2", 3, 2, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Minute)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:        map[string]int32{},
+			expectNewMSAnnotations:   []string{clusterv1.BlueGreenStartedAnnotation},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "scale down all the old MachineSets at once when the new MachineSet is available",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}, nil),
+			newMachineSet:     machineSet("ms3", 3, 3, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Minute)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 1, 1, nil),
+				machineSet("ms2", 2, 2, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 0,
+				"ms2": 0,
+			},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "wait for the soak period before promoting",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{SoakSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet: machineSet("ms2", 3, 3, map[string]string{
+				clusterv1.BlueGreenStartedAnnotation:   ago(time.Hour),
+				clusterv1.BlueGreenAvailableAnnotation: ago(time.Minute),
+			}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:          map[string]int32{},
+			expectNewMSAnnotations:     []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+			expectRequeueAfterPositive: true,
+		},
+		{
+			name:              "promote when the soak period is completed",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{SoakSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet: machineSet("ms2", 3, 3, map[string]string{
+				clusterv1.BlueGreenStartedAnnotation:   ago(time.Hour),
+				clusterv1.BlueGreenAvailableAnnotation: ago(11 * time.Minute),
+			}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 0,
+			},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "availability lost during the soak period restarts the soak period",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{SoakSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet: machineSet("ms2", 3, 2, map[string]string{
+				clusterv1.BlueGreenStartedAnnotation:   ago(time.Hour),
+				clusterv1.BlueGreenAvailableAnnotation: ago(time.Minute),
+			}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:        map[string]int32{},
+			expectNewMSAnnotations:   []string{clusterv1.BlueGreenStartedAnnotation},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "wait for manual promotion",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{Promotion: clusterv1.ManualMachineDeploymentBlueGreenPromotionType}, nil),
+			newMachineSet:     machineSet("ms2", 3, 3, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Hour)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:      map[string]int32{},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "manual promotion for another MachineSet does not promote the new MachineSet",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{Promotion: clusterv1.ManualMachineDeploymentBlueGreenPromotionType}, map[string]string{clusterv1.BlueGreenPromoteAnnotation: "ms0"}),
+			newMachineSet:     machineSet("ms2", 3, 3, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Hour)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:      map[string]int32{},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "promote when manually promoted",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{Promotion: clusterv1.ManualMachineDeploymentBlueGreenPromotionType}, map[string]string{clusterv1.BlueGreenPromoteAnnotation: "ms2"}),
+			newMachineSet:     machineSet("ms2", 3, 3, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Hour)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 0,
+			},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+		{
+			name:              "requeue while waiting for the rollback timeout",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{RollbackTimeoutSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet:     machineSet("ms2", 3, 1, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Minute)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:          map[string]int32{},
+			expectNewMSAnnotations:     []string{clusterv1.BlueGreenStartedAnnotation},
+			expectNoNewMSAnnotations:   []string{clusterv1.BlueGreenRolledBackAnnotation},
+			expectRequeueAfterPositive: true,
+		},
+		{
+			name:              "roll back when the new MachineSet does not become available within the rollback timeout",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{RollbackTimeoutSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet:     machineSet("ms2", 3, 1, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(11 * time.Minute)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms2": 0,
+			},
+			expectNewMSAnnotations:   []string{clusterv1.BlueGreenRolledBackAnnotation},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation},
+		},
+		{
+			name:              "keep the new MachineSet scaled down after a rollback",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{RollbackTimeoutSeconds: ptr.To[int32](600)}, nil),
+			newMachineSet: machineSet("ms2", 0, 0, map[string]string{
+				clusterv1.BlueGreenStartedAnnotation:    ago(time.Hour),
+				clusterv1.BlueGreenRolledBackAnnotation: ago(time.Minute),
+			}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent:        map[string]int32{},
+			expectNewMSAnnotations:   []string{clusterv1.BlueGreenRolledBackAnnotation},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation},
+		},
+		{
+			name:              "scale down old MachineSets when the MachineDeployment is scaled down during the rollout",
+			machineDeployment: blueGreenMD(2, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}, nil),
+			newMachineSet:     machineSet("ms2", 3, 0, map[string]string{clusterv1.BlueGreenStartedAnnotation: ago(time.Minute)}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, nil),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 2,
+				"ms2": 2,
+			},
+			expectNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation},
+		},
+		{
+			name:              "cleanup blue/green annotations when there are no old replicas",
+			machineDeployment: blueGreenMD(3, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}, nil),
+			newMachineSet: machineSet("ms2", 3, 3, map[string]string{
+				clusterv1.BlueGreenStartedAnnotation:   ago(time.Hour),
+				clusterv1.BlueGreenAvailableAnnotation: ago(time.Minute),
+			}),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 0, 0, nil),
+			},
+			expectScaleIntent:        map[string]int32{},
+			expectNoNewMSAnnotations: []string{clusterv1.BlueGreenStartedAnnotation, clusterv1.BlueGreenAvailableAnnotation},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			planner := newRolloutPlanner(nil, nil, nil)
+			planner.md = tt.machineDeployment
+			planner.originalMSs = map[string]*clusterv1.MachineSet{}
+			for _, ms := range append(tt.oldMachineSets, tt.newMachineSet) {
+				planner.originalMSs[ms.Name] = ms.DeepCopy()
+			}
+			planner.newMS = tt.newMachineSet.DeepCopy()
+			planner.newMS.Annotations = nil // Blue/green annotations must be carried over from the original MachineSet.
+			planner.oldMSs = tt.oldMachineSets
+
+			g.Expect(planner.planBlueGreen(ctx)).To(Succeed())
+			g.Expect(planner.scaleIntents).To(Equal(tt.expectScaleIntent), "unexpected scaleIntents")
+			for _, key := range tt.expectNewMSAnnotations {
+				g.Expect(planner.newMS.Annotations).To(HaveKey(key))
+			}
+			for _, key := range tt.expectNoNewMSAnnotations {
+				g.Expect(planner.newMS.Annotations).ToNot(HaveKey(key))
+			}
+			if tt.expectRequeueAfterPositive {
+				g.Expect(planner.requeueAfter).To(BeNumerically(">", 0))
+			} else {
+				g.Expect(planner.requeueAfter).To(BeZero())
+			}
+		})
+	}
+}
+
+func TestPlanBlueGreenRetryAfterRollback(t *testing.T) {
+	g := NewWithT(t)
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "md",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Rollout: clusterv1.MachineDeploymentRolloutSpec{
+				Strategy: clusterv1.MachineDeploymentRolloutStrategy{
+					Type: clusterv1.BlueGreenMachineDeploymentStrategyType,
+					BlueGreen: clusterv1.MachineDeploymentRolloutStrategyBlueGreen{
+						RollbackTimeoutSeconds: ptr.To[int32](600),
+					},
+				},
+			},
+		},
+	}
+	oldMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ms1",
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Replicas: ptr.To[int32](3),
+		},
+		Status: clusterv1.MachineSetStatus{
+			Replicas:          ptr.To[int32](3),
+			AvailableReplicas: ptr.To[int32](3),
+		},
+	}
+	newMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ms2",
+			Annotations: map[string]string{
+				clusterv1.BlueGreenStartedAnnotation: time.Now().UTC().Add(-11 * time.Minute).Format(time.RFC3339),
+			},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Replicas: ptr.To[int32](3),
+		},
+		Status: clusterv1.MachineSetStatus{
+			Replicas:          ptr.To[int32](3),
+			AvailableReplicas: ptr.To[int32](1),
+		},
+	}
+
+	// plan runs planBlueGreen and applies the resulting annotations and scale intents to the MachineSets,
+	// like createOrUpdateMachineSetsAndSyncMachineDeploymentRevision does.
+	plan := func() map[string]int32 {
+		planner := newRolloutPlanner(nil, nil, nil)
+		planner.md = md
+		planner.originalMSs = map[string]*clusterv1.MachineSet{
+			oldMS.Name: oldMS.DeepCopy(),
+			newMS.Name: newMS.DeepCopy(),
+		}
+		planner.newMS = newMS.DeepCopy()
+		planner.newMS.Annotations = nil
+		planner.oldMSs = []*clusterv1.MachineSet{oldMS}
+
+		g.Expect(planner.planBlueGreen(ctx)).To(Succeed())
+		newMS.Annotations = planner.newMS.Annotations
+		for _, ms := range []*clusterv1.MachineSet{oldMS, newMS} {
+			if replicas, ok := planner.scaleIntents[ms.Name]; ok {
+				ms.Spec.Replicas = ptr.To(replicas)
+			}
+		}
+		return planner.scaleIntents
+	}
+
+	// The new MachineSet did not become available within the rollback timeout, the rollout is rolled back.
+	g.Expect(plan()).To(Equal(map[string]int32{"ms2": 0}))
+	g.Expect(newMS.Annotations).To(HaveKey(clusterv1.BlueGreenRolledBackAnnotation))
+	newMS.Status.Replicas = ptr.To[int32](0)
+	newMS.Status.AvailableReplicas = ptr.To[int32](0)
+
+	// The user retries the rollout by removing the rolled back annotation; the rollout starts again
+	// and it is not rolled back immediately.
+	delete(newMS.Annotations, clusterv1.BlueGreenRolledBackAnnotation)
+	g.Expect(plan()).To(Equal(map[string]int32{"ms2": 3}))
+	g.Expect(newMS.Annotations).To(HaveKey(clusterv1.BlueGreenStartedAnnotation))
+	g.Expect(newMS.Annotations).ToNot(HaveKey(clusterv1.BlueGreenRolledBackAnnotation))
+
+	g.Expect(plan()).To(BeEmpty())
+	g.Expect(newMS.Annotations).ToNot(HaveKey(clusterv1.BlueGreenRolledBackAnnotation))
+
+	// The new MachineSet becomes available and it is promoted.
+	newMS.Status.Replicas = ptr.To[int32](3)
+	newMS.Status.AvailableReplicas = ptr.To[int32](3)
+	g.Expect(plan()).To(Equal(map[string]int32{"ms1": 0}))
+	g.Expect(newMS.Annotations).To(HaveKey(clusterv1.BlueGreenAvailableAnnotation))
+	g.Expect(newMS.Annotations).ToNot(HaveKey(clusterv1.BlueGreenRolledBackAnnotation))
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// canaryGatesRequeueAfter is the interval used to re-evaluate gates of a canary step that are not passing yet.
+const canaryGatesRequeueAfter = 30 * time.Second
+
+// canaryStep is a canary step with replicas resolved against the MachineDeployment's spec.replicas.
+type canaryStep struct {
+	replicas int32
+	gates    clusterv1.MachineDeploymentCanaryStepGates
+}
+
+// rolloutCanary reconcile machine sets controlled by a MachineDeployment that is using the Canary strategy.
+func (r *Reconciler) rolloutCanary(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, machines collections.Machines, templateExists bool) (ctrl.Result, error) {
+	planner := newRolloutPlanner(r.Client, r.RuntimeClient, r.canUpdateMachineSetCache)
+	planner.ClusterCache = r.ClusterCache
+	if err := planner.init(ctx, md, msList, machines.UnsortedList(), true, templateExists); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := planner.planCanary(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.createOrUpdateMachineSetsAndSyncMachineDeploymentRevision(ctx, planner); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newMS := planner.newMS
+	oldMSs := planner.oldMSs
+	allMSs := append(oldMSs, newMS)
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if mdutil.DeploymentComplete(md, &md.Status) {
+		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: planner.requeueAfter}, nil
+}
+
+// planCanary determine how to proceed with the rollout when using the Canary strategy if we are not yet at the desired state.
+// The canary rollout goes through the steps defined in the MachineDeployment, and for each step:
+//   - the newMS is scaled up to the number of replicas of the step, and the planner waits for those replicas to be available.
+//   - oldMSs are scaled down, so the total number of replicas is equal to the MachineDeployment's spec.replicas.
+//   - the planner waits for the gates of the step to pass before moving to the next step.
+//
+// If the last step defined in the MachineDeployment does not scale the newMS to the MachineDeployment's spec.replicas,
+// an implicit final step without gates is added.
+func (p *rolloutPlanner) planCanary(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	mdReplicas := ptr.Deref(p.md.Spec.Replicas, 0)
+
+	// Carry over the annotation tracking the current canary step from the current newMS, if any.
+	p.carryOverCanaryAnnotations()
+
+	// If there are no old replicas, the rollout is completed (or there was nothing to roll out); just align the newMS to the MachineDeployment.
+	if mdutil.GetReplicaCountForMachineSets(p.oldMSs) == 0 {
+		delete(p.newMS.Annotations, clusterv1.CanaryStepAnnotation)
+		p.md.Status.Canary = clusterv1.MachineDeploymentCanaryStatus{}
+		return p.reconcileNewMachineSet(ctx)
+	}
+
+	// Scale down oldMSs in case the MachineDeployment has been scaled down, so old replicas are never more than spec.replicas.
+	p.scaleDownOldMachineSets(ctx, mdReplicas)
+
+	steps := resolveCanarySteps(p.md.Spec.Rollout.Strategy.Canary.Steps, mdReplicas)
+	step := parseCanaryStep(p.newMS.Annotations[clusterv1.CanaryStepAnnotation], len(steps))
+	p.newMS.Annotations[clusterv1.CanaryStepAnnotation] = strconv.Itoa(step)
+	p.md.Status.Canary = clusterv1.MachineDeploymentCanaryStatus{
+		CurrentStep: int32(step + 1),
+		TotalSteps:  int32(len(steps)),
+	}
+
+	// Bring the newMS to the number of replicas of the current step.
+	target := steps[step].replicas
+	if ptr.Deref(p.newMS.Spec.Replicas, 0) != target {
+		p.addNotef(p.newMS, "scale to %d replicas for canary step %d/%d", target, step+1, len(steps))
+		log.V(5).Info(fmt.Sprintf("Setting scale intent for MachineSet %s to %d replicas (canary step %d/%d)", klog.KObj(p.newMS), target, step+1, len(steps)), "MachineSet", klog.KObj(p.newMS))
+		p.scaleIntents[p.newMS.Name] = target
+	}
+
+	// Wait for the replicas of the current step to be available.
+	if ptr.Deref(p.newMS.Spec.Replicas, 0) != target || ptr.Deref(p.newMS.Status.AvailableReplicas, 0) < target {
+		p.md.Status.Canary.Message = fmt.Sprintf("Waiting for %d replicas of MachineSet %s to be available", target, p.newMS.Name)
+		return nil
+	}
+
+	// Scale down oldMSs now that the replicas of the current step are available.
+	maxOldReplicas := mdReplicas - target
+	p.scaleDownOldMachineSets(ctx, maxOldReplicas)
+	if mdutil.GetReplicaCountForMachineSets(p.oldMSs) > maxOldReplicas || ptr.Deref(mdutil.GetActualReplicaCountForMachineSets(p.oldMSs), 0) > maxOldReplicas {
+		p.md.Status.Canary.Message = fmt.Sprintf("Waiting for old MachineSets to be scaled down to %d replicas", maxOldReplicas)
+		return nil
+	}
+
+	// Gates of the last step are not relevant, the rollout completes as soon as old replicas are gone.
+	if step == len(steps)-1 {
+		return nil
+	}
+
+	passed, message, retryAfter, err := p.evaluateCanaryStepGates(ctx, int32(step+1), steps[step].gates)
+	if err != nil {
+		return errors.Wrapf(err, "failed to evaluate gates for canary step %d", step+1)
+	}
+	if !passed {
+		p.md.Status.Canary.Message = message
+		p.setRequeueAfter(retryAfter)
+		return nil
+	}
+
+	// Move to the next step.
+	step++
+	log.Info(fmt.Sprintf("Gates passed, moving MachineSet %s to canary step %d/%d", klog.KObj(p.newMS), step+1, len(steps)), "MachineSet", klog.KObj(p.newMS))
+	p.newMS.Annotations[clusterv1.CanaryStepAnnotation] = strconv.Itoa(step)
+	p.md.Status.Canary.CurrentStep = int32(step + 1)
+	p.addNotef(p.newMS, "scale to %d replicas for canary step %d/%d", steps[step].replicas, step+1, len(steps))
+	p.scaleIntents[p.newMS.Name] = steps[step].replicas
+	return nil
+}
+
+// evaluateCanaryStepGates checks if all the gates of a canary step are passing.
+// If gates are not passing, a message and the time after which gates should be evaluated again are returned.
+func (p *rolloutPlanner) evaluateCanaryStepGates(ctx context.Context, step int32, gates clusterv1.MachineDeploymentCanaryStepGates) (bool, string, time.Duration, error) {
+	if p.overrideEvaluateCanaryStepGates != nil {
+		return p.overrideEvaluateCanaryStepGates(ctx, step, gates)
+	}
+
+	var newMachines []*clusterv1.Machine
+	for _, m := range p.machines {
+		if util.IsControlledBy(m, p.newMS, clusterv1.GroupVersion.WithKind("MachineSet").GroupKind()) {
+			newMachines = append(newMachines, m)
+		}
+	}
+
+	for _, gate := range gates.MachineConditions {
+		status := gate.Status
+		if status == "" {
+			status = metav1.ConditionTrue
+		}
+		for _, m := range newMachines {
+			if c := conditions.Get(m, gate.Type); c == nil || c.Status != status {
+				return false, fmt.Sprintf("Waiting for Machine %s to have condition %s with status %s", m.Name, gate.Type, status), canaryGatesRequeueAfter, nil
+			}
+		}
+	}
+
+	if len(gates.NodeLabels) > 0 {
+		if p.ClusterCache == nil {
+			return false, "", 0, errors.New("ClusterCache must not be nil when using canary step node label gates")
+		}
+		remoteClient, err := p.ClusterCache.GetClient(ctx, client.ObjectKey{Namespace: p.md.Namespace, Name: p.md.Spec.ClusterName})
+		if err != nil {
+			return false, "", 0, err
+		}
+		for _, m := range newMachines {
+			if !m.Status.NodeRef.IsDefined() {
+				return false, fmt.Sprintf("Waiting for Machine %s to have a Node", m.Name), canaryGatesRequeueAfter, nil
+			}
+			node := &corev1.Node{}
+			if err := remoteClient.Get(ctx, client.ObjectKey{Name: m.Status.NodeRef.Name}, node); err != nil {
+				return false, "", 0, errors.Wrapf(err, "failed to get Node %s", m.Status.NodeRef.Name)
+			}
+			for _, gate := range gates.NodeLabels {
+				if value, ok := node.Labels[gate.Key]; !ok || (gate.Value != "" && value != gate.Value) {
+					return false, fmt.Sprintf("Waiting for Node %s to have label %s", node.Name, nodeLabelGateString(gate)), canaryGatesRequeueAfter, nil
+				}
+			}
+		}
+	}
+
+	if len(gates.ExtensionHandlers) > 0 {
+		if !feature.Gates.Enabled(feature.RuntimeSDK) {
+			return false, "", 0, errors.Errorf("canary step extension handler gates require the %q feature gate to be enabled", feature.RuntimeSDK)
+		}
+		if p.RuntimeClient == nil {
+			return false, "", 0, errors.New("RuntimeClient must not be nil when using canary step extension handler gates")
+		}
+		req := &runtimehooksv1.AnalyzeMachineDeploymentCanaryStepRequest{
+			MachineDeployment: *cleanupMachineDeployment(p.md),
+			MachineSet:        *cleanupMachineSet(p.newMS),
+			Step:              step,
+		}
+		for _, extensionHandler := range gates.ExtensionHandlers {
+			resp := &runtimehooksv1.AnalyzeMachineDeploymentCanaryStepResponse{}
+			if err := p.RuntimeClient.CallExtension(ctx, runtimehooksv1.AnalyzeMachineDeploymentCanaryStep, p.md, extensionHandler, req, resp); err != nil {
+				return false, "", 0, err
+			}
+			if resp.RetryAfterSeconds > 0 {
+				message := fmt.Sprintf("Waiting for extension %s to complete the analysis", extensionHandler)
+				if resp.Message != "" {
+					message = fmt.Sprintf("%s: %s", message, resp.Message)
+				}
+				return false, message, time.Duration(resp.RetryAfterSeconds) * time.Second, nil
+			}
+		}
+	}
+
+	return true, "", 0, nil
+}
+
+// carryOverCanaryAnnotations carries over the annotation tracking the current canary step from the current newMS.
+// Note: This is required because the rollout planner computes the full intent for the newMS at every reconcile.
+func (p *rolloutPlanner) carryOverCanaryAnnotations() {
+	if p.newMS.Annotations == nil {
+		p.newMS.Annotations = map[string]string{}
+	}
+
+	originalMS, ok := p.originalMSs[p.newMS.Name]
+	if !ok {
+		return
+	}
+	if value, ok := originalMS.Annotations[clusterv1.CanaryStepAnnotation]; ok {
+		p.newMS.Annotations[clusterv1.CanaryStepAnnotation] = value
+	}
+}
+
+// resolveCanarySteps resolves replicas of canary steps against the MachineDeployment's spec.replicas.
+// Note: replicas are never decreased from one step to the next, and an implicit final step is added if
+// the last step does not scale to spec.replicas.
+func resolveCanarySteps(steps []clusterv1.MachineDeploymentCanaryStep, mdReplicas int32) []canaryStep {
+	resolved := make([]canaryStep, 0, len(steps)+1)
+	previous := int32(0)
+	for _, s := range steps {
+		replicas := previous
+		if s.Replicas != nil {
+			// Note: Invalid values are rejected by the webhook, ignore errors here.
+			if v, err := intstr.GetScaledValueFromIntOrPercent(s.Replicas, int(mdReplicas), true); err == nil {
+				replicas = max(previous, min(int32(v), mdReplicas))
+			}
+		}
+		resolved = append(resolved, canaryStep{replicas: replicas, gates: s.Gates})
+		previous = replicas
+	}
+	if len(resolved) == 0 || resolved[len(resolved)-1].replicas != mdReplicas {
+		resolved = append(resolved, canaryStep{replicas: mdReplicas})
+	}
+	return resolved
+}
+
+// parseCanaryStep parses the canary step recorded in the canary step annotation, falling back to the first step if the value is not valid.
+func parseCanaryStep(value string, totalSteps int) int {
+	step, err := strconv.Atoi(value)
+	if err != nil || step < 0 {
+		return 0
+	}
+	return min(step, totalSteps-1)
+}
+
+func nodeLabelGateString(gate clusterv1.MachineDeploymentCanaryNodeLabelGate) string {
+	if gate.Value == "" {
+		return gate.Key
+	}
+	return fmt.Sprintf("%s=%s", gate.Key, gate.Value)
+}
+
+func cleanupMachineDeployment(md *clusterv1.MachineDeployment) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		// Set GVK because object is later marshalled with json.Marshal.
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "MachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        md.Name,
+			Namespace:   md.Namespace,
+			Labels:      md.Labels,
+			Annotations: md.Annotations,
+		},
+		Spec: *md.Spec.DeepCopy(),
+	}
+}
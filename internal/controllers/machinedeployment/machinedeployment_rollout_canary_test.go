@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+)
+
+func TestPlanCanary(t *testing.T) {
+	canaryMD := func(replicas int32, steps ...clusterv1.MachineDeploymentCanaryStep) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "md",
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Rollout: clusterv1.MachineDeploymentRolloutSpec{
+					Strategy: clusterv1.MachineDeploymentRolloutStrategy{
+						Type: clusterv1.CanaryMachineDeploymentStrategyType,
+						Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+							Steps: steps,
+						},
+					},
+				},
+			},
+		}
+	}
+	step := func(replicas intstr.IntOrString) clusterv1.MachineDeploymentCanaryStep {
+		return clusterv1.MachineDeploymentCanaryStep{Replicas: &replicas}
+	}
+	machineSet := func(name string, replicas, availableReplicas int32, currentStep string) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: ptr.To(replicas),
+			},
+			Status: clusterv1.MachineSetStatus{
+				Replicas:          ptr.To(replicas),
+				AvailableReplicas: ptr.To(availableReplicas),
+			},
+		}
+		if currentStep != "" {
+			ms.Annotations = map[string]string{clusterv1.CanaryStepAnnotation: currentStep}
+		}
+		return ms
+	}
+
+	testCases := []struct {
+		name                 string
+		machineDeployment    *clusterv1.MachineDeployment
+		newMachineSet        *clusterv1.MachineSet
+		oldMachineSets       []*clusterv1.MachineSet
+		gatesPassed          bool
+		expectScaleIntent    map[string]int32
+		expectCurrentStep    string
+		expectCanaryStatus   clusterv1.MachineDeploymentCanaryStatus
+		expectRequeueAfter   time.Duration
+		expectGatesEvaluated bool
+	}{
+		{
+			name:              "scale up the new MachineSet to the replicas of the first step",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("50%"))),
+			newMachineSet:     machineSet("ms2", 0, 0, ""),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 4, 4, ""),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms2": 1,
+			},
+			expectCurrentStep:  "0",
+			expectCanaryStatus: clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 1, TotalSteps: 3, Message: "Waiting for 1 replicas of MachineSet ms2 to be available"},
+		},
+		{
+			name:              "scale down old MachineSets when the replicas of the current step are available",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("50%"))),
+			newMachineSet:     machineSet("ms2", 1, 1, "0"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 4, 4, ""),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 3,
+			},
+			expectCurrentStep:  "0",
+			expectCanaryStatus: clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 1, TotalSteps: 3, Message: "Waiting for old MachineSets to be scaled down to 3 replicas"},
+		},
+		{
+			name:              "wait for gates of the current step to pass",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("50%"))),
+			newMachineSet:     machineSet("ms2", 1, 1, "0"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, ""),
+			},
+			gatesPassed:          false,
+			expectScaleIntent:    map[string]int32{},
+			expectCurrentStep:    "0",
+			expectCanaryStatus:   clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 1, TotalSteps: 3, Message: "gates not passed"},
+			expectRequeueAfter:   time.Minute,
+			expectGatesEvaluated: true,
+		},
+		{
+			name:              "move to the next step when gates of the current step pass",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("50%"))),
+			newMachineSet:     machineSet("ms2", 1, 1, "0"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 3, 3, ""),
+			},
+			gatesPassed: true,
+			expectScaleIntent: map[string]int32{
+				"ms2": 2,
+			},
+			expectCurrentStep:    "1",
+			expectCanaryStatus:   clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 2, TotalSteps: 3},
+			expectGatesEvaluated: true,
+		},
+		{
+			name:              "move to the implicit final step",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("50%"))),
+			newMachineSet:     machineSet("ms2", 2, 2, "1"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 2, 2, ""),
+			},
+			gatesPassed: true,
+			expectScaleIntent: map[string]int32{
+				"ms2": 4,
+			},
+			expectCurrentStep:    "2",
+			expectCanaryStatus:   clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 3, TotalSteps: 3},
+			expectGatesEvaluated: true,
+		},
+		{
+			name:              "do not evaluate gates of the last step",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1)), step(intstr.FromString("100%"))),
+			newMachineSet:     machineSet("ms2", 4, 4, "1"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 1, 1, ""),
+			},
+			expectScaleIntent: map[string]int32{
+				"ms1": 0,
+			},
+			expectCurrentStep:  "1",
+			expectCanaryStatus: clusterv1.MachineDeploymentCanaryStatus{CurrentStep: 2, TotalSteps: 2, Message: "Waiting for old MachineSets to be scaled down to 0 replicas"},
+		},
+		{
+			name:              "cleanup canary annotation and status when there are no old replicas",
+			machineDeployment: canaryMD(4, step(intstr.FromInt32(1))),
+			newMachineSet:     machineSet("ms2", 4, 4, "1"),
+			oldMachineSets: []*clusterv1.MachineSet{
+				machineSet("ms1", 0, 0, ""),
+			},
+			expectScaleIntent: map[string]int32{},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			planner := newRolloutPlanner(nil, nil, nil)
+			planner.md = tt.machineDeployment
+			planner.originalMSs = map[string]*clusterv1.MachineSet{}
+			for _, ms := range append(tt.oldMachineSets, tt.newMachineSet) {
+				planner.originalMSs[ms.Name] = ms.DeepCopy()
+			}
+			planner.newMS = tt.newMachineSet.DeepCopy()
+			planner.newMS.Annotations = nil // The canary step annotation must be carried over from the original MachineSet.
+			planner.oldMSs = tt.oldMachineSets
+			gatesEvaluated := false
+			planner.overrideEvaluateCanaryStepGates = func(_ context.Context, _ int32, _ clusterv1.MachineDeploymentCanaryStepGates) (bool, string, time.Duration, error) {
+				gatesEvaluated = true
+				if tt.gatesPassed {
+					return true, "", 0, nil
+				}
+				return false, "gates not passed", time.Minute, nil
+			}
+
+			g.Expect(planner.planCanary(ctx)).To(Succeed())
+			g.Expect(planner.scaleIntents).To(Equal(tt.expectScaleIntent), "unexpected scaleIntents")
+			if tt.expectCurrentStep != "" {
+				g.Expect(planner.newMS.Annotations).To(HaveKeyWithValue(clusterv1.CanaryStepAnnotation, tt.expectCurrentStep))
+			} else {
+				g.Expect(planner.newMS.Annotations).ToNot(HaveKey(clusterv1.CanaryStepAnnotation))
+			}
+			g.Expect(planner.md.Status.Canary).To(Equal(tt.expectCanaryStatus))
+			g.Expect(planner.requeueAfter).To(Equal(tt.expectRequeueAfter))
+			g.Expect(gatesEvaluated).To(Equal(tt.expectGatesEvaluated))
+		})
+	}
+}
+
+func TestResolveCanarySteps(t *testing.T) {
+	step := func(replicas intstr.IntOrString) clusterv1.MachineDeploymentCanaryStep {
+		return clusterv1.MachineDeploymentCanaryStep{Replicas: &replicas}
+	}
+
+	testCases := []struct {
+		name           string
+		steps          []clusterv1.MachineDeploymentCanaryStep
+		mdReplicas     int32
+		expectReplicas []int32
+	}{
+		{
+			name:           "percentages are rounded up and an implicit final step is added",
+			steps:          []clusterv1.MachineDeploymentCanaryStep{step(intstr.FromInt32(1)), step(intstr.FromString("25%"))},
+			mdReplicas:     10,
+			expectReplicas: []int32{1, 3, 10},
+		},
+		{
+			name:           "no implicit final step if the last step scales to spec.replicas",
+			steps:          []clusterv1.MachineDeploymentCanaryStep{step(intstr.FromInt32(1)), step(intstr.FromString("100%"))},
+			mdReplicas:     10,
+			expectReplicas: []int32{1, 10},
+		},
+		{
+			name:           "replicas are capped to spec.replicas and never decrease",
+			steps:          []clusterv1.MachineDeploymentCanaryStep{step(intstr.FromInt32(3)), step(intstr.FromInt32(2)), step(intstr.FromInt32(20))},
+			mdReplicas:     5,
+			expectReplicas: []int32{3, 3, 5},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var replicas []int32
+			for _, s := range resolveCanarySteps(tt.steps, tt.mdReplicas) {
+				replicas = append(replicas, s.replicas)
+			}
+			g.Expect(replicas).To(Equal(tt.expectReplicas))
+		})
+	}
+}
+
+func TestEvaluateCanaryStepGates(t *testing.T) {
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "md",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "cluster",
+		},
+	}
+	newMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "m1",
+			Namespace: metav1.NamespaceDefault,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(newMS, clusterv1.GroupVersion.WithKind("MachineSet")),
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{Name: "node1"},
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.MachineReadyCondition, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"canary/healthy": "true"},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		gates         clusterv1.MachineDeploymentCanaryStepGates
+		expectPassed  bool
+		expectMessage string
+	}{
+		{
+			name:         "no gates",
+			expectPassed: true,
+		},
+		{
+			name: "machine condition gate passing",
+			gates: clusterv1.MachineDeploymentCanaryStepGates{
+				MachineConditions: []clusterv1.MachineDeploymentCanaryMachineConditionGate{{Type: clusterv1.MachineReadyCondition}},
+			},
+			expectPassed: true,
+		},
+		{
+			name: "machine condition gate not passing",
+			gates: clusterv1.MachineDeploymentCanaryStepGates{
+				MachineConditions: []clusterv1.MachineDeploymentCanaryMachineConditionGate{{Type: "Healthy", Status: metav1.ConditionTrue}},
+			},
+			expectPassed:  false,
+			expectMessage: "Waiting for Machine m1 to have condition Healthy with status True",
+		},
+		{
+			name: "node label gate passing",
+			gates: clusterv1.MachineDeploymentCanaryStepGates{
+				NodeLabels: []clusterv1.MachineDeploymentCanaryNodeLabelGate{{Key: "canary/healthy"}},
+			},
+			expectPassed: true,
+		},
+		{
+			name: "node label gate not passing",
+			gates: clusterv1.MachineDeploymentCanaryStepGates{
+				NodeLabels: []clusterv1.MachineDeploymentCanaryNodeLabelGate{{Key: "canary/healthy", Value: "false"}},
+			},
+			expectPassed:  false,
+			expectMessage: "Waiting for Node node1 to have label canary/healthy=false",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			workloadClient := fake.NewClientBuilder().WithObjects(node).Build()
+			planner := newRolloutPlanner(nil, nil, nil)
+			planner.ClusterCache = clustercache.NewFakeClusterCache(workloadClient, client.ObjectKey{Namespace: md.Namespace, Name: md.Spec.ClusterName})
+			planner.md = md
+			planner.newMS = newMS
+			planner.machines = []*clusterv1.Machine{machine}
+
+			passed, message, requeueAfter, err := planner.evaluateCanaryStepGates(ctx, 1, tt.gates)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(passed).To(Equal(tt.expectPassed))
+			g.Expect(message).To(Equal(tt.expectMessage))
+			if tt.expectPassed {
+				g.Expect(requeueAfter).To(BeZero())
+			} else {
+				g.Expect(requeueAfter).To(Equal(canaryGatesRequeueAfter))
+			}
+		})
+	}
+}
@@ -19,16 +19,20 @@ package machinedeployment
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 	"sigs.k8s.io/cluster-api/internal/util/hash"
@@ -39,6 +43,7 @@ import (
 type rolloutPlanner struct {
 	Client                   client.Client
 	RuntimeClient            runtimeclient.Client
+	ClusterCache             clustercache.ClusterCache
 	canUpdateMachineSetCache cache.Cache[CanUpdateMachineSetCacheEntry]
 
 	md       *clusterv1.MachineDeployment
@@ -57,10 +62,12 @@ type rolloutPlanner struct {
 
 	scaleIntents map[string]int32
 	notes        map[string][]string
+	requeueAfter time.Duration
 
 	overrideComputeDesiredMS              func(ctx context.Context, deployment *clusterv1.MachineDeployment, currentMS *clusterv1.MachineSet) (*clusterv1.MachineSet, error)
 	overrideCanUpdateMachineSetInPlace    func(ctx context.Context, oldMS, newMS *clusterv1.MachineSet) (bool, error)
 	overrideCanExtensionsUpdateMachineSet func(ctx context.Context, oldMS, newMS *clusterv1.MachineSet, templateObjects *templateObjects, extensionHandlers []string) (bool, []string, error)
+	overrideEvaluateCanaryStepGates       func(ctx context.Context, step int32, gates clusterv1.MachineDeploymentCanaryStepGates) (bool, string, time.Duration, error)
 }
 
 func newRolloutPlanner(c client.Client, runtimeClient runtimeclient.Client, canUpdateMachineSetCache cache.Cache[CanUpdateMachineSetCacheEntry]) *rolloutPlanner {
@@ -340,6 +347,32 @@ func computeDesiredMS(ctx context.Context, deployment *clusterv1.MachineDeployme
 	return desiredMS, nil
 }
 
+// scaleDownOldMachineSets scales down oldMSs so the total number of old replicas is not greater than maxReplicas.
+// Note: oldMSs are never scaled up; the oldest MachineSets are scaled down first.
+func (p *rolloutPlanner) scaleDownOldMachineSets(ctx context.Context, maxReplicas int32) {
+	log := ctrl.LoggerFrom(ctx)
+	totalScaleDownCount := max(mdutil.GetReplicaCountForMachineSets(p.oldMSs)-maxReplicas, 0)
+
+	sort.Sort(mdutil.MachineSetsByCreationTimestamp(p.oldMSs))
+	for _, oldMS := range p.oldMSs {
+		if totalScaleDownCount <= 0 {
+			break
+		}
+
+		replicas := ptr.Deref(oldMS.Spec.Replicas, 0)
+		if replicas <= 0 {
+			continue
+		}
+
+		scaleDownCount := min(replicas, totalScaleDownCount)
+		newScaleIntent := replicas - scaleDownCount
+		p.addNotef(oldMS, "scale down to align old MachineSets spec.replicas to MachineDeployment spec.replicas")
+		log.V(5).Info(fmt.Sprintf("Setting scale down intent for MachineSet %s to %d replicas (-%d)", klog.KObj(oldMS), newScaleIntent, scaleDownCount), "MachineSet", klog.KObj(oldMS))
+		p.scaleIntents[oldMS.Name] = newScaleIntent
+		totalScaleDownCount -= scaleDownCount
+	}
+}
+
 func (p *rolloutPlanner) addNotef(ms *clusterv1.MachineSet, format string, a ...any) {
 	msg := fmt.Sprintf(format, a...)
 	for _, note := range p.notes[ms.Name] {
@@ -431,6 +464,22 @@ func (p *rolloutPlanner) getMachineSetDiff(ms *clusterv1.MachineSet) machineSetD
 			}
 		}
 
+		if originalMS.Annotations[clusterv1.BlueGreenRolledBackAnnotation] != ms.Annotations[clusterv1.BlueGreenRolledBackAnnotation] {
+			if value, ok := ms.Annotations[clusterv1.BlueGreenRolledBackAnnotation]; ok {
+				changes = append(changes, fmt.Sprintf("%s: %s", clusterv1.BlueGreenRolledBackAnnotation, value))
+			} else {
+				changes = append(changes, fmt.Sprintf("%s removed", clusterv1.BlueGreenRolledBackAnnotation))
+			}
+		}
+
+		if originalMS.Annotations[clusterv1.CanaryStepAnnotation] != ms.Annotations[clusterv1.CanaryStepAnnotation] {
+			if value, ok := ms.Annotations[clusterv1.CanaryStepAnnotation]; ok {
+				changes = append(changes, fmt.Sprintf("%s: %s", clusterv1.CanaryStepAnnotation, value))
+			} else {
+				changes = append(changes, fmt.Sprintf("%s removed", clusterv1.CanaryStepAnnotation))
+			}
+		}
+
 		if originalMS.Annotations[clusterv1.DisableMachineCreateAnnotation] != ms.Annotations[clusterv1.DisableMachineCreateAnnotation] {
 			if value, ok := ms.Annotations[clusterv1.DisableMachineCreateAnnotation]; ok {
 				changes = append(changes, fmt.Sprintf("%s: %s", clusterv1.DisableMachineCreateAnnotation, value))
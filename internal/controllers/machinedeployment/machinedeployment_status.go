@@ -96,6 +96,12 @@ func setPhase(_ context.Context, machineDeployment *clusterv1.MachineDeployment,
 		return
 	}
 
+	// Surface rollouts that did not make progress within spec.rollout.progressDeadlineSeconds.
+	if conditions.IsTrue(machineDeployment, clusterv1.MachineDeploymentRolloutFailedCondition) {
+		machineDeployment.Status.Phase = string(clusterv1.MachineDeploymentPhaseFailed)
+		return
+	}
+
 	desiredReplicas := *machineDeployment.Spec.Replicas
 	currentReplicas := ptr.Deref(mdutil.GetActualReplicaCountForMachineSets(machineSets), 0)
 
@@ -132,11 +132,12 @@ func Revision(obj runtime.Object) (int64, error) {
 }
 
 var annotationsToSkip = map[string]bool{
-	corev1.LastAppliedConfigAnnotation:  true,
-	clusterv1.RevisionAnnotation:        true,
-	revisionHistoryAnnotation:           true,
-	clusterv1.DesiredReplicasAnnotation: true,
-	clusterv1.MaxReplicasAnnotation:     true,
+	corev1.LastAppliedConfigAnnotation:   true,
+	clusterv1.RevisionAnnotation:         true,
+	revisionHistoryAnnotation:            true,
+	clusterv1.DesiredReplicasAnnotation:  true,
+	clusterv1.MaxReplicasAnnotation:      true,
+	clusterv1.BlueGreenPromoteAnnotation: true,
 
 	// Exclude the conversion annotation, to avoid infinite loops between the conversion webhook
 	// and the MachineDeployment controller syncing the annotations between a MachineDeployment
@@ -279,6 +280,33 @@ func FindOneActiveOrLatest(newMS *clusterv1.MachineSet, oldMSs []*clusterv1.Mach
 	}
 }
 
+// FindPreviousRevisionMachineSet returns the old machine set with the highest revision lower than the revision of
+// the new machine set, or nil if there is no such machine set.
+// Note: Machine sets being deleted and machine sets with a revision that cannot be parsed are ignored.
+func FindPreviousRevisionMachineSet(newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) *clusterv1.MachineSet {
+	newRevision, err := Revision(newMS)
+	if err != nil {
+		return nil
+	}
+
+	var previousMS *clusterv1.MachineSet
+	previousRevision := int64(0)
+	for _, ms := range oldMSs {
+		if !ms.DeletionTimestamp.IsZero() {
+			continue
+		}
+		v, err := Revision(ms)
+		if err != nil {
+			continue
+		}
+		if v < newRevision && v > previousRevision {
+			previousMS = ms
+			previousRevision = v
+		}
+	}
+	return previousMS
+}
+
 // MaxUnavailable returns the maximum unavailable machines a rolling deployment can take.
 func MaxUnavailable(deployment clusterv1.MachineDeployment) int32 {
 	if !IsRollingUpdate(&deployment) || *(deployment.Spec.Replicas) == 0 {
@@ -1242,6 +1242,73 @@ func TestGetRevisionAnnotations(t *testing.T) {
 	})
 }
 
+func TestFindPreviousRevisionMachineSet(t *testing.T) {
+	withName := func(ms *clusterv1.MachineSet, name string) *clusterv1.MachineSet {
+		ms.Name = name
+		return ms
+	}
+	deleting := func(ms *clusterv1.MachineSet) *clusterv1.MachineSet {
+		ms.DeletionTimestamp = ptr.To(metav1.Now())
+		return ms
+	}
+
+	tests := []struct {
+		name     string
+		newMS    *clusterv1.MachineSet
+		oldMSs   []*clusterv1.MachineSet
+		expected string
+	}{
+		{
+			name:  "returns the old machine set with the highest revision lower than the new machine set",
+			newMS: withName(machineSetWithRevisionAndHistory("4", ""), "ms4"),
+			oldMSs: []*clusterv1.MachineSet{
+				withName(machineSetWithRevisionAndHistory("1", ""), "ms1"),
+				withName(machineSetWithRevisionAndHistory("3", ""), "ms3"),
+				withName(machineSetWithRevisionAndHistory("2", ""), "ms2"),
+			},
+			expected: "ms3",
+		},
+		{
+			name:  "ignores old machine sets with a revision higher than the new machine set",
+			newMS: withName(machineSetWithRevisionAndHistory("2", ""), "ms2"),
+			oldMSs: []*clusterv1.MachineSet{
+				withName(machineSetWithRevisionAndHistory("1", ""), "ms1"),
+				withName(machineSetWithRevisionAndHistory("3", ""), "ms3"),
+			},
+			expected: "ms1",
+		},
+		{
+			name:  "ignores old machine sets being deleted or with an invalid revision",
+			newMS: withName(machineSetWithRevisionAndHistory("4", ""), "ms4"),
+			oldMSs: []*clusterv1.MachineSet{
+				withName(machineSetWithRevisionAndHistory("1", ""), "ms1"),
+				deleting(withName(machineSetWithRevisionAndHistory("3", ""), "ms3")),
+				withName(machineSetWithRevisionAndHistory("foo", ""), "ms-foo"),
+			},
+			expected: "ms1",
+		},
+		{
+			name:     "returns nil if there are no old machine sets",
+			newMS:    withName(machineSetWithRevisionAndHistory("1", ""), "ms1"),
+			oldMSs:   nil,
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := FindPreviousRevisionMachineSet(tt.newMS, tt.oldMSs)
+			if tt.expected == "" {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Name).To(Equal(tt.expected))
+		})
+	}
+}
+
 func machineSetWithRevisionAndHistory(revision string, revisionHistory string) *clusterv1.MachineSet {
 	ms := &clusterv1.MachineSet{
 		ObjectMeta: metav1.ObjectMeta{
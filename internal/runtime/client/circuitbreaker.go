@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+)
+
+// defaultHalfOpenAfter is the default time after which an open circuit breaker lets a probe call through.
+const defaultHalfOpenAfter = 30 * time.Second
+
+// extensionGuard guards calls to the ExtensionHandlers of an extension by implementing
+// a circuit breaker and by limiting the number of in-flight requests.
+//
+// The circuit breaker opens after failureThreshold consecutive failed calls. While the circuit breaker is
+// open, calls fail immediately; after halfOpenAfter the circuit breaker becomes half-open and lets a single
+// probe call through; if the probe call succeeds the circuit breaker closes, otherwise it opens again.
+type extensionGuard struct {
+	lock sync.Mutex
+
+	// failureThreshold is the number of consecutive failed calls after which the circuit breaker opens;
+	// 0 means that the circuit breaker is disabled.
+	failureThreshold int32
+	halfOpenAfter    time.Duration
+
+	state               runtimeclient.CircuitBreakerState
+	consecutiveFailures int32
+	openedAt            time.Time
+	probeInFlight       bool
+
+	// inFlight is a semaphore used to limit the number of in-flight requests; nil means no limit.
+	inFlight chan struct{}
+
+	now           func() time.Time
+	onStateChange func()
+}
+
+func newExtensionGuard(onStateChange func()) *extensionGuard {
+	return &extensionGuard{
+		state:         runtimeclient.CircuitBreakerClosed,
+		now:           time.Now,
+		onStateChange: onStateChange,
+	}
+}
+
+// configure updates the configuration of the extensionGuard.
+// Note: configure must be called only when the ExtensionConfig is registered, not on every call.
+func (g *extensionGuard) configure(circuitBreaker runtimev1.CircuitBreaker, maxInFlightRequests int32) {
+	g.lock.Lock()
+
+	halfOpenAfter := defaultHalfOpenAfter
+	if circuitBreaker.HalfOpenAfterSeconds != 0 {
+		halfOpenAfter = time.Duration(circuitBreaker.HalfOpenAfterSeconds) * time.Second
+	}
+	g.halfOpenAfter = halfOpenAfter
+
+	changed := false
+	if g.failureThreshold != circuitBreaker.FailureThreshold {
+		// Reset the circuit breaker when it is enabled, disabled or the threshold is changed.
+		changed = g.state != runtimeclient.CircuitBreakerClosed || (g.failureThreshold == 0) != (circuitBreaker.FailureThreshold == 0)
+		g.failureThreshold = circuitBreaker.FailureThreshold
+		g.state = runtimeclient.CircuitBreakerClosed
+		g.consecutiveFailures = 0
+	}
+
+	// Note: in-flight requests release the semaphore they acquired, so it is safe to replace it.
+	if int(maxInFlightRequests) != cap(g.inFlight) {
+		g.inFlight = nil
+		if maxInFlightRequests > 0 {
+			g.inFlight = make(chan struct{}, maxInFlightRequests)
+		}
+	}
+	g.lock.Unlock()
+
+	if changed {
+		g.onStateChange()
+	}
+}
+
+// getState returns the state of the circuit breaker, or false if the circuit breaker is disabled.
+func (g *extensionGuard) getState() (runtimeclient.CircuitBreakerState, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.failureThreshold == 0 {
+		return "", false
+	}
+	if g.state == runtimeclient.CircuitBreakerOpen && g.now().Sub(g.openedAt) >= g.halfOpenAfter {
+		// The next call will be let through as a probe call.
+		return runtimeclient.CircuitBreakerHalfOpen, true
+	}
+	return g.state, true
+}
+
+// acquire must be called before calling an ExtensionHandler. If the call is allowed, acquire returns a
+// func which must be called with the outcome of the call once it completes.
+// Calls exceeding the maximum number of in-flight requests wait until deadline for a previous call to complete;
+// the same deadline applies to the call itself, so that waiting does not extend the time a call can take.
+func (g *extensionGuard) acquire(ctx context.Context, deadline time.Time) (func(failed bool), error) {
+	g.lock.Lock()
+	probe := false
+	changed := false
+	if g.failureThreshold != 0 {
+		switch g.state {
+		case runtimeclient.CircuitBreakerOpen:
+			if g.now().Sub(g.openedAt) < g.halfOpenAfter {
+				g.lock.Unlock()
+				return nil, errCallingExtensionHandler(errors.New("circuit breaker is open"))
+			}
+			g.state = runtimeclient.CircuitBreakerHalfOpen
+			changed = true
+			probe = true
+		case runtimeclient.CircuitBreakerHalfOpen:
+			if g.probeInFlight {
+				g.lock.Unlock()
+				return nil, errCallingExtensionHandler(errors.New("circuit breaker is half-open and a probe call is in flight"))
+			}
+			probe = true
+		}
+		g.probeInFlight = probe
+	}
+	inFlight := g.inFlight
+	g.lock.Unlock()
+
+	if changed {
+		g.onStateChange()
+	}
+
+	if inFlight != nil {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case inFlight <- struct{}{}:
+		case <-timer.C:
+			g.abortProbe(probe)
+			return nil, errCallingExtensionHandler(errors.Errorf("timed out waiting for one of %d in-flight requests to complete", cap(inFlight)))
+		case <-ctx.Done():
+			g.abortProbe(probe)
+			return nil, errCallingExtensionHandler(errors.Wrap(ctx.Err(), "context cancelled while waiting for in-flight requests to complete"))
+		}
+		// Note: select picks randomly if both the semaphore and the timer are ready.
+		if !time.Now().Before(deadline) {
+			<-inFlight
+			g.abortProbe(probe)
+			return nil, errCallingExtensionHandler(errors.Errorf("timed out waiting for one of %d in-flight requests to complete", cap(inFlight)))
+		}
+	}
+
+	return func(failed bool) {
+		if inFlight != nil {
+			<-inFlight
+		}
+		g.done(probe, failed)
+	}, nil
+}
+
+// abortProbe allows another call to be let through as a probe call if the probe call has not been sent.
+func (g *extensionGuard) abortProbe(probe bool) {
+	if !probe {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.probeInFlight = false
+}
+
+// done updates the state of the circuit breaker with the outcome of a call.
+func (g *extensionGuard) done(probe, failed bool) {
+	g.lock.Lock()
+	if g.failureThreshold == 0 {
+		g.lock.Unlock()
+		return
+	}
+
+	changed := false
+	switch {
+	case probe:
+		g.probeInFlight = false
+		// Note: the circuit breaker might have been reset while the probe call was in flight.
+		if g.state != runtimeclient.CircuitBreakerHalfOpen {
+			break
+		}
+		changed = true
+		if failed {
+			g.state = runtimeclient.CircuitBreakerOpen
+			g.openedAt = g.now()
+			break
+		}
+		g.state = runtimeclient.CircuitBreakerClosed
+		g.consecutiveFailures = 0
+	case g.state == runtimeclient.CircuitBreakerClosed:
+		if !failed {
+			g.consecutiveFailures = 0
+			break
+		}
+		g.consecutiveFailures++
+		if g.consecutiveFailures >= g.failureThreshold {
+			changed = true
+			g.state = runtimeclient.CircuitBreakerOpen
+			g.openedAt = g.now()
+		}
+	}
+	g.lock.Unlock()
+
+	if changed {
+		g.onStateChange()
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+)
+
+func TestExtensionGuard_CircuitBreaker(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	stateChanges := 0
+	guard := newExtensionGuard(func() { stateChanges++ })
+	guard.now = func() time.Time { return now }
+
+	// The circuit breaker is disabled by default.
+	_, ok := guard.getState()
+	g.Expect(ok).To(BeFalse())
+
+	guard.configure(runtimev1.CircuitBreaker{FailureThreshold: 2, HalfOpenAfterSeconds: 10}, 0)
+	g.Expect(stateChanges).To(Equal(1))
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerClosed))
+
+	call := func(failed bool) error {
+		release, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+		if err != nil {
+			return err
+		}
+		release(failed)
+		return nil
+	}
+
+	// A successful call resets the consecutive failures.
+	g.Expect(call(true)).To(Succeed())
+	g.Expect(call(false)).To(Succeed())
+	g.Expect(call(true)).To(Succeed())
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerClosed))
+
+	// The circuit breaker opens after 2 consecutive failures.
+	g.Expect(call(true)).To(Succeed())
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerOpen))
+	g.Expect(stateChanges).To(Equal(2))
+
+	// Calls are rejected while the circuit breaker is open.
+	err := call(false)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(Equal("circuit breaker is open"))
+
+	// After halfOpenAfter the circuit breaker becomes half-open and lets a single probe call through.
+	now = now.Add(10 * time.Second)
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerHalfOpen))
+	release, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stateChanges).To(Equal(3))
+	err = call(false)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(Equal("circuit breaker is half-open and a probe call is in flight"))
+
+	// A failed probe call opens the circuit breaker again.
+	release(true)
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerOpen))
+	g.Expect(stateChanges).To(Equal(4))
+	g.Expect(call(false)).ToNot(Succeed())
+
+	// A successful probe call closes the circuit breaker.
+	now = now.Add(10 * time.Second)
+	g.Expect(call(false)).To(Succeed())
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerClosed))
+	g.Expect(stateChanges).To(Equal(6))
+
+	// Changing the configuration resets the circuit breaker.
+	g.Expect(call(true)).To(Succeed())
+	g.Expect(call(true)).To(Succeed())
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerOpen))
+	guard.configure(runtimev1.CircuitBreaker{FailureThreshold: 3}, 0)
+	g.Expect(getState(guard)).To(Equal(runtimeclient.CircuitBreakerClosed))
+	g.Expect(guard.halfOpenAfter).To(Equal(defaultHalfOpenAfter))
+
+	// Disabling the circuit breaker lets all calls through.
+	guard.configure(runtimev1.CircuitBreaker{}, 0)
+	_, ok = guard.getState()
+	g.Expect(ok).To(BeFalse())
+	for range 5 {
+		g.Expect(call(true)).To(Succeed())
+	}
+}
+
+func TestExtensionGuard_MaxInFlightRequests(t *testing.T) {
+	g := NewWithT(t)
+
+	guard := newExtensionGuard(func() {})
+	guard.configure(runtimev1.CircuitBreaker{}, 2)
+
+	release1, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+	release2, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// A third call fails after waiting for the timeout.
+	_, err = guard.acquire(context.Background(), time.Now().Add(10*time.Millisecond))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(Equal("timed out waiting for one of 2 in-flight requests to complete"))
+
+	// A call fails if the deadline has already passed, even if a slot is free.
+	release2(false)
+	_, err = guard.acquire(context.Background(), time.Now().Add(-time.Second))
+	g.Expect(err).To(HaveOccurred())
+	release2, err = guard.acquire(context.Background(), time.Now().Add(time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// A third call fails if the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = guard.acquire(ctx, time.Now().Add(time.Second))
+	g.Expect(err).To(HaveOccurred())
+
+	// A third call proceeds as soon as a previous call completes.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release1(false)
+	}()
+	release3, err := guard.acquire(context.Background(), time.Now().Add(5*time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Calls in flight release the semaphore they acquired when the limit is changed.
+	guard.configure(runtimev1.CircuitBreaker{}, 1)
+	release2(false)
+	release3(false)
+	release4, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = guard.acquire(context.Background(), time.Now().Add(10*time.Millisecond))
+	g.Expect(err).To(HaveOccurred())
+	release4(false)
+
+	// Removing the limit lets all calls through.
+	guard.configure(runtimev1.CircuitBreaker{}, 0)
+	for range 5 {
+		_, err := guard.acquire(context.Background(), time.Now().Add(time.Second))
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+}
+
+func getState(guard *extensionGuard) runtimeclient.CircuitBreakerState {
+	state, _ := guard.getState()
+	return state
+}
@@ -31,10 +31,18 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,12 +57,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
+	runtimegrpcv1 "sigs.k8s.io/cluster-api/internal/runtime/grpc/v1alpha1"
 	runtimemetrics "sigs.k8s.io/cluster-api/internal/runtime/metrics"
+	runtimerecorder "sigs.k8s.io/cluster-api/internal/runtime/recorder"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/cache"
@@ -71,11 +83,42 @@ type Options struct {
 	Catalog  *runtimecatalog.Catalog
 	Registry runtimeregistry.ExtensionRegistry
 	Client   ctrlclient.Client
+
+	// InProcessExtensions are Runtime Extensions called in-process instead of via HTTPS.
+	// InProcessExtensions are added to the registry on WarmUp.
+	InProcessExtensions []*runtimeserver.InProcessExtension
+
+	// Recorder, if set, records requests and responses of calls to extension handlers.
+	Recorder *runtimerecorder.Recorder
+
+	// TracerProvider is used to create spans for calls to extension handlers.
+	// Defaults to the global TracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// CircuitBreakerEvents, if set, receives an event for the ExtensionConfig whenever the state
+	// of its circuit breaker changes. Events are dropped if the channel is full.
+	CircuitBreakerEvents chan<- event.GenericEvent
 }
 
+const tracerName = "sigs.k8s.io/cluster-api/internal/runtime/client"
+
 // New returns a new Client.
 func New(options Options) (runtimeclient.Client, *certwatcher.CertWatcher, error) {
 	httpClientCache := cache.New[httpClientEntry](24 * time.Hour)
+	grpcConnsCache := newGRPCConnCache(24 * time.Hour)
+
+	inProcessExtensions := map[string]*runtimeserver.InProcessExtension{}
+	for _, extension := range options.InProcessExtensions {
+		if _, ok := inProcessExtensions[extension.Name()]; ok {
+			return nil, nil, errors.Errorf("failed to create RuntimeSDK client: in-process extension %q is defined more than once", extension.Name())
+		}
+		inProcessExtensions[extension.Name()] = extension
+	}
+
+	tracerProvider := options.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
 
 	var certWatcher *certwatcher.CertWatcher
 	if options.CertFile != "" && options.KeyFile != "" {
@@ -86,19 +129,27 @@ func New(options Options) (runtimeclient.Client, *certwatcher.CertWatcher, error
 		}
 		certWatcher.RegisterCallback(func(_ tls.Certificate) {
 			httpClientCache.DeleteAll()
+			grpcConnsCache.deleteAll()
 		})
 	}
 	return &client{
-		certFile:         options.CertFile,
-		keyFile:          options.KeyFile,
-		catalog:          options.Catalog,
-		registry:         options.Registry,
-		client:           options.Client,
-		httpClientsCache: httpClientCache,
+		certFile:             options.CertFile,
+		keyFile:              options.KeyFile,
+		catalog:              options.Catalog,
+		registry:             options.Registry,
+		client:               options.Client,
+		httpClientsCache:     httpClientCache,
+		grpcConnsCache:       grpcConnsCache,
+		inProcessExtensions:  inProcessExtensions,
+		recorder:             options.Recorder,
+		tracer:               tracerProvider.Tracer(tracerName),
+		extensionGuards:      map[string]*extensionGuard{},
+		circuitBreakerEvents: options.CircuitBreakerEvents,
 	}, certWatcher, nil
 }
 
 var _ runtimeclient.Client = &client{}
+var _ runtimeclient.CircuitBreakerStateGetter = &client{}
 
 type client struct {
 	certFile         string
@@ -107,6 +158,18 @@ type client struct {
 	registry         runtimeregistry.ExtensionRegistry
 	client           ctrlclient.Client
 	httpClientsCache cache.Cache[httpClientEntry]
+	grpcConnsCache   *grpcConnCache
+
+	// inProcessExtensions are the Runtime Extensions called in-process, by name.
+	inProcessExtensions map[string]*runtimeserver.InProcessExtension
+
+	recorder *runtimerecorder.Recorder
+	tracer   trace.Tracer
+
+	// extensionGuards implement the circuit breaker and the limit of in-flight requests, by ExtensionConfig name.
+	extensionGuardsLock  sync.Mutex
+	extensionGuards      map[string]*extensionGuard
+	circuitBreakerEvents chan<- event.GenericEvent
 }
 
 type httpClientEntry struct {
@@ -138,7 +201,29 @@ func (r httpClientEntry) Key() string {
 }
 
 func (c *client) WarmUp(extensionConfigList *runtimev1.ExtensionConfigList) error {
-	return c.registry.WarmUp(extensionConfigList)
+	if err := c.registry.WarmUp(extensionConfigList); err != nil {
+		return err
+	}
+	for i := range extensionConfigList.Items {
+		extensionConfig := &extensionConfigList.Items[i]
+		if _, ok := c.inProcessExtensions[extensionConfig.Name]; ok {
+			continue
+		}
+		c.configureExtensionGuard(extensionConfig)
+	}
+
+	// Add in-process extensions to the registry.
+	// Note: Registrations from ExtensionConfigs with the same name as an in-process extension are replaced.
+	for _, extension := range c.inProcessExtensions {
+		extensionConfig, err := c.discoverInProcessExtension(context.Background(), extension)
+		if err != nil {
+			return err
+		}
+		if err := c.registry.Add(extensionConfig); err != nil {
+			return errors.Wrapf(err, "failed to register in-process extension %q", extension.Name())
+		}
+	}
+	return nil
 }
 
 func (c *client) IsReady() bool {
@@ -183,6 +268,73 @@ func (c *client) Discover(ctx context.Context, extensionConfig *runtimev1.Extens
 		return nil, errors.Wrapf(err, "failed to discover extension %q", extensionConfig.Name)
 	}
 
+	modifiedExtensionConfig, err := setDiscoveredHandlers(extensionConfig, response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover extension %q", extensionConfig.Name)
+	}
+	modifiedExtensionConfig.Status.Transport = negotiateTransport(log, extensionConfig.Spec.ClientConfig.Transport, response.Transports)
+	return modifiedExtensionConfig, nil
+}
+
+// negotiateTransport returns the transport to be used to call the ExtensionHandlers of an Extension,
+// given the preferred transport from the ExtensionConfig and the transports supported by the Extension.
+func negotiateTransport(log logr.Logger, preferred runtimev1.Transport, supported []runtimehooksv1.Transport) runtimev1.Transport {
+	if preferred != runtimev1.TransportGRPC {
+		return runtimev1.TransportHTTPS
+	}
+	for _, t := range supported {
+		if t == runtimehooksv1.TransportGRPC {
+			return runtimev1.TransportGRPC
+		}
+	}
+	log.Info(fmt.Sprintf("Extension does not support transport %s, falling back to %s", runtimev1.TransportGRPC, runtimev1.TransportHTTPS))
+	return runtimev1.TransportHTTPS
+}
+
+// discoverInProcessExtension makes the discovery call on the in-process extension and returns a corresponding
+// ExtensionConfig with extension handlers information in the ExtensionConfig status.
+func (c *client) discoverInProcessExtension(ctx context.Context, extension *runtimeserver.InProcessExtension) (*runtimev1.ExtensionConfig, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hookGVH, err := c.catalog.GroupVersionHook(runtimehooksv1.Discovery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover in-process extension %q: failed to compute GVH of hook", extension.Name())
+	}
+
+	request := &runtimehooksv1.DiscoveryRequest{}
+	response := &runtimehooksv1.DiscoveryResponse{}
+	if err := extension.Call(ctx, hookGVH, "", request, response); err != nil {
+		return nil, errors.Wrapf(err, "failed to discover in-process extension %q", extension.Name())
+	}
+
+	// Check to see if the response is not a success and handle the failure accordingly.
+	if err := validateResponseStatus(log, response, "discover in-process extension", extension.Name()); err != nil {
+		return nil, err
+	}
+
+	// Check to see if the response is valid.
+	if err = defaultAndValidateDiscoveryResponse(c.catalog, response); err != nil {
+		return nil, errors.Wrapf(err, "failed to discover in-process extension %q", extension.Name())
+	}
+
+	extensionConfig := &runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: extension.Name(),
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			NamespaceSelector: extension.NamespaceSelector(),
+			Settings:          extension.Settings(),
+		},
+	}
+	extensionConfig, err = setDiscoveredHandlers(extensionConfig, response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover in-process extension %q", extension.Name())
+	}
+	return extensionConfig, nil
+}
+
+// setDiscoveredHandlers returns a copy of the ExtensionConfig with the handlers from the discovery response in the status.
+func setDiscoveredHandlers(extensionConfig *runtimev1.ExtensionConfig, response *runtimehooksv1.DiscoveryResponse) (*runtimev1.ExtensionConfig, error) {
 	modifiedExtensionConfig := extensionConfig.DeepCopy()
 	// Reset the handlers that were previously registered with the ExtensionConfig.
 	modifiedExtensionConfig.Status.Handlers = []runtimev1.ExtensionHandler{}
@@ -190,7 +342,7 @@ func (c *client) Discover(ctx context.Context, extensionConfig *runtimev1.Extens
 	for _, handler := range response.Handlers {
 		handlerName, err := NameForHandler(handler, extensionConfig)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to discover extension %q", extensionConfig.Name)
+			return nil, err
 		}
 		modifiedExtensionConfig.Status.Handlers = append(
 			modifiedExtensionConfig.Status.Handlers,
@@ -210,19 +362,72 @@ func (c *client) Discover(ctx context.Context, extensionConfig *runtimev1.Extens
 }
 
 func (c *client) Register(extensionConfig *runtimev1.ExtensionConfig) error {
+	if _, ok := c.inProcessExtensions[extensionConfig.Name]; ok {
+		return errors.Errorf("failed to register ExtensionConfig %q: name is already used by an in-process extension", extensionConfig.Name)
+	}
 	if err := c.registry.Add(extensionConfig); err != nil {
 		return errors.Wrapf(err, "failed to register ExtensionConfig %q", extensionConfig.Name)
 	}
+	c.configureExtensionGuard(extensionConfig)
 	return nil
 }
 
 func (c *client) Unregister(extensionConfig *runtimev1.ExtensionConfig) error {
+	// ExtensionConfigs with the same name as an in-process extension are never registered,
+	// so there is nothing to unregister (and in-process extensions must not be unregistered).
+	if _, ok := c.inProcessExtensions[extensionConfig.Name]; ok {
+		return nil
+	}
 	if err := c.registry.Remove(extensionConfig); err != nil {
 		return errors.Wrapf(err, "failed to unregister ExtensionConfig %q", extensionConfig.Name)
 	}
+
+	c.extensionGuardsLock.Lock()
+	defer c.extensionGuardsLock.Unlock()
+	delete(c.extensionGuards, extensionConfig.Name)
 	return nil
 }
 
+func (c *client) GetCircuitBreakerState(extensionConfigName string) (runtimeclient.CircuitBreakerState, bool) {
+	c.extensionGuardsLock.Lock()
+	guard, ok := c.extensionGuards[extensionConfigName]
+	c.extensionGuardsLock.Unlock()
+	if !ok {
+		return "", false
+	}
+	return guard.getState()
+}
+
+// getExtensionGuard returns the extensionGuard for the ExtensionConfig with the given name.
+// Note: extensionGuards are configured when ExtensionConfigs are registered; extensionGuards for in-process
+// extensions have neither a circuit breaker nor a limit of in-flight requests.
+func (c *client) getExtensionGuard(extensionConfigName string) *extensionGuard {
+	c.extensionGuardsLock.Lock()
+	defer c.extensionGuardsLock.Unlock()
+	guard, ok := c.extensionGuards[extensionConfigName]
+	if !ok {
+		guard = newExtensionGuard(func() { c.notifyCircuitBreakerStateChange(extensionConfigName) })
+		c.extensionGuards[extensionConfigName] = guard
+	}
+	return guard
+}
+
+// configureExtensionGuard creates or updates the extensionGuard for the ExtensionConfig.
+func (c *client) configureExtensionGuard(extensionConfig *runtimev1.ExtensionConfig) {
+	c.getExtensionGuard(extensionConfig.Name).configure(extensionConfig.Spec.CircuitBreaker, extensionConfig.Spec.MaxInFlightRequests)
+}
+
+// notifyCircuitBreakerStateChange sends an event for the ExtensionConfig with the given name to circuitBreakerEvents.
+func (c *client) notifyCircuitBreakerStateChange(extensionConfigName string) {
+	if c.circuitBreakerEvents == nil {
+		return
+	}
+	select {
+	case c.circuitBreakerEvents <- event.GenericEvent{Object: &runtimev1.ExtensionConfig{ObjectMeta: metav1.ObjectMeta{Name: extensionConfigName}}}:
+	default:
+	}
+}
+
 func (c *client) GetAllExtensions(ctx context.Context, hook runtimecatalog.Hook, forObject ctrlclient.Object) ([]string, error) {
 	hookName := runtimecatalog.HookName(hook)
 	log := ctrl.LoggerFrom(ctx).WithValues("hook", hookName)
@@ -348,7 +553,21 @@ func aggregateSuccessfulResponses(aggregatedResponse runtimehooksv1.ResponseObje
 // Nb. FailurePolicy does not affect the following kinds of errors:
 // - Internal errors. Examples: hooks is incompatible with ExtensionHandler, ExtensionHandler information is missing.
 // - Error when ExtensionHandler returns a response with `Status` set to `Failure`.
-func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject ctrlclient.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...runtimeclient.CallExtensionOption) error {
+func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject ctrlclient.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...runtimeclient.CallExtensionOption) (retErr error) {
+	ctx, span := c.tracer.Start(ctx, "CallExtension", trace.WithAttributes(
+		attribute.String("extensionHandler", name),
+		attribute.String("hook", runtimecatalog.HookName(hook)),
+	))
+	defer func() {
+		if retErr != nil {
+			span.RecordError(retErr)
+			span.SetStatus(otelcodes.Error, retErr.Error())
+		} else {
+			span.SetAttributes(attribute.String("responseStatus", string(response.GetStatus())))
+		}
+		span.End()
+	}()
+
 	// Calculate the options.
 	options := &runtimeclient.CallExtensionOptions{}
 	for _, opt := range opts {
@@ -409,25 +628,92 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 				return fmt.Errorf("failed to call extension handler %q: cached response of type %s instead of type %s", name, cacheVal.Type(), outVal.Type())
 			}
 			reflect.Indirect(outVal).Set(reflect.Indirect(cacheVal))
+			span.SetAttributes(attribute.Bool("cached", true))
 			return nil
 		}
 	}
 
-	httpClient, err := c.getHTTPClient(registration.ClientConfig)
-	if err != nil {
-		return errors.Wrapf(err, "failed to call extension handler %q: failed to get http client", name)
+	transport := registration.Transport
+	if transport == "" {
+		transport = runtimev1.TransportHTTPS
 	}
+	if _, ok := c.inProcessExtensions[registration.ExtensionConfigName]; ok {
+		transport = runtimev1.TransportInProcess
+	}
+	span.SetAttributes(
+		attribute.String("extensionConfig", registration.ExtensionConfigName),
+		attribute.String("transport", string(transport)),
+	)
 
-	httpOpts := &httpCallOptions{
-		catalog:         c.catalog,
-		config:          registration.ClientConfig,
-		registrationGVH: registration.GroupVersionHook,
-		hookGVH:         hookGVH,
-		name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
-		timeout:         timeoutDuration,
-		httpClient:      httpClient,
+	start := time.Now()
+	// Calls are rejected while the circuit breaker for the extension is open, or if the maximum number
+	// of in-flight requests is not freed up within the timeout; in both cases the FailurePolicy applies.
+	// Note: The deadline covers both waiting for in-flight requests and the call itself.
+	deadline := start.Add(timeoutDuration)
+	release, err := c.getExtensionGuard(registration.ExtensionConfigName).acquire(ctx, deadline)
+	callCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	extension, inProcess := c.inProcessExtensions[registration.ExtensionConfigName]
+	switch {
+	case err != nil:
+		// The call has been rejected.
+	case inProcess:
+		inProcessOpts := &inProcessCallOptions{
+			catalog:         c.catalog,
+			extension:       extension,
+			registrationGVH: registration.GroupVersionHook,
+			hookGVH:         hookGVH,
+			name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
+			timeout:         timeoutDuration,
+		}
+		err = inProcessCall(callCtx, request, response, inProcessOpts)
+	case registration.Transport == runtimev1.TransportGRPC && runtimegrpcv1.IsSupported(registration.GroupVersionHook):
+		// Note: Runtime Hooks without a protobuf definition are called via HTTPS also when using the gRPC transport.
+		var conn *grpc.ClientConn
+		var releaseConn func()
+		conn, releaseConn, err = c.getGRPCConn(registration.ClientConfig)
+		if err != nil {
+			release(false)
+			return errors.Wrapf(err, "failed to call extension handler %q: failed to get gRPC connection", name)
+		}
+
+		grpcOpts := &grpcCallOptions{
+			catalog:         c.catalog,
+			config:          registration.ClientConfig,
+			registrationGVH: registration.GroupVersionHook,
+			hookGVH:         hookGVH,
+			name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
+			timeout:         timeoutDuration,
+			conn:            conn,
+		}
+		err = grpcCall(callCtx, request, response, grpcOpts)
+		releaseConn()
+	default:
+		var httpClient *http.Client
+		httpClient, err = c.getHTTPClient(registration.ClientConfig)
+		if err != nil {
+			release(false)
+			return errors.Wrapf(err, "failed to call extension handler %q: failed to get http client", name)
+		}
+
+		httpOpts := &httpCallOptions{
+			catalog:         c.catalog,
+			config:          registration.ClientConfig,
+			registrationGVH: registration.GroupVersionHook,
+			hookGVH:         hookGVH,
+			name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
+			timeout:         timeoutDuration,
+			httpClient:      httpClient,
+		}
+		err = httpCall(callCtx, request, response, httpOpts)
+	}
+	if release != nil {
+		_, failed := err.(errCallingExtensionHandler)
+		release(failed)
+	}
+	if c.recorder != nil {
+		c.record(ctx, registration, hookGVH, transport, start, request, response, err)
 	}
-	err = httpCall(ctx, request, response, httpOpts)
 	if err != nil {
 		// If the error is errCallingExtensionHandler then apply failure policy to calculate
 		// the effective result of the operation.
@@ -467,6 +753,105 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 	return nil
 }
 
+// record records a call to an extension handler.
+func (c *client) record(ctx context.Context, registration *runtimeregistry.ExtensionRegistration, hookGVH runtimecatalog.GroupVersionHook, transport runtimev1.Transport, start time.Time, request, response runtime.Object, callErr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	record := runtimerecorder.Record{
+		Time:              metav1.NewTime(start),
+		Duration:          metav1.Duration{Duration: time.Since(start)},
+		ExtensionConfig:   registration.ExtensionConfigName,
+		ExtensionHandler:  registration.Name,
+		APIVersion:        hookGVH.GroupVersion().String(),
+		Hook:              hookGVH.Hook,
+		HandlerAPIVersion: registration.GroupVersionHook.GroupVersion().String(),
+		Transport:         string(transport),
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		log.Error(err, "Failed to record call to extension handler: failed to marshal request")
+		return
+	}
+	record.Request = requestData
+
+	if callErr != nil {
+		record.Error = callErr.Error()
+	} else {
+		responseData, err := json.Marshal(response)
+		if err != nil {
+			log.Error(err, "Failed to record call to extension handler: failed to marshal response")
+			return
+		}
+		record.Response = responseData
+	}
+
+	c.recorder.Record(record)
+}
+
+// Replay sends the request of a recorded call to an extension handler via HTTPS, using the given
+// ClientConfig, and returns the response.
+func Replay(ctx context.Context, catalog *runtimecatalog.Catalog, config runtimev1.ClientConfig, record runtimerecorder.Record, timeout time.Duration) (runtimehooksv1.ResponseObject, error) {
+	hookGV, err := schema.ParseGroupVersion(record.APIVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d: invalid apiVersion", record.ID)
+	}
+	hookGVH := runtimecatalog.GroupVersionHook{Group: hookGV.Group, Version: hookGV.Version, Hook: record.Hook}
+
+	registrationGVH := hookGVH
+	if record.HandlerAPIVersion != "" {
+		registrationGV, err := schema.ParseGroupVersion(record.HandlerAPIVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to replay record %d: invalid handlerAPIVersion", record.ID)
+		}
+		registrationGVH = runtimecatalog.GroupVersionHook{Group: registrationGV.Group, Version: registrationGV.Version, Hook: record.Hook}
+	}
+
+	request, err := catalog.NewRequest(hookGVH)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d", record.ID)
+	}
+	if err := json.Unmarshal(record.Request, request); err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d: failed to unmarshal request", record.ID)
+	}
+	response, err := catalog.NewResponse(hookGVH)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d", record.ID)
+	}
+	responseObject, ok := response.(runtimehooksv1.ResponseObject)
+	if !ok {
+		return nil, errors.Errorf("failed to replay record %d: response of type %T is not a ResponseObject", record.ID, response)
+	}
+
+	// Note: we are passing an empty gvh and "" as name because the only relevant part of the url
+	// for this function is the Host, which derives from config (ghv and name are appended to the path).
+	extensionURL, err := urlForExtension(config, runtimecatalog.GroupVersionHook{}, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d", record.ID)
+	}
+	httpClient, err := createHTTPClient("", "", config.CABundle, extensionURL.Hostname())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d", record.ID)
+	}
+
+	name := record.ExtensionHandler
+	if record.ExtensionConfig != "" {
+		name = strings.TrimSuffix(record.ExtensionHandler, "."+record.ExtensionConfig)
+	}
+	if err := httpCall(ctx, request, response, &httpCallOptions{
+		catalog:         catalog,
+		config:          config,
+		registrationGVH: registrationGVH,
+		hookGVH:         hookGVH,
+		name:            name,
+		timeout:         timeout,
+		httpClient:      httpClient,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to replay record %d", record.ID)
+	}
+	return responseObject, nil
+}
+
 func (c *client) getHTTPClient(config runtimev1.ClientConfig) (*http.Client, error) {
 	// Note: we are passing an empty gvh and "" as name because the only relevant part of the url
 	// for this function is the Hostname, which derives from config (ghv and name are appended to the path).
@@ -490,6 +875,49 @@ func (c *client) getHTTPClient(config runtimev1.ClientConfig) (*http.Client, err
 
 func createHTTPClient(certFile, keyFile string, caData []byte, hostName string) (*http.Client, error) {
 	httpClient := &http.Client{}
+	tlsConfig, err := createTLSConfig(certFile, keyFile, caData, hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	// This also adds http2
+	httpClient.Transport = utilnet.SetTransportDefaults(&http.Transport{
+		TLSClientConfig: tlsConfig,
+	})
+	return httpClient, nil
+}
+
+// getGRPCConn returns the grpc.ClientConn to call a Runtime Extension; the returned func must be called to release
+// the grpc.ClientConn when the call is completed.
+func (c *client) getGRPCConn(config runtimev1.ClientConfig) (*grpc.ClientConn, func(), error) {
+	// Note: we are passing an empty gvh and "" as name because the only relevant part of the url
+	// for this function is the Host, which derives from config (ghv and name are appended to the path).
+	extensionURL, err := urlForExtension(config, runtimecatalog.GroupVersionHook{}, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	target := extensionURL.Host
+	if extensionURL.Port() == "" {
+		target = net.JoinHostPort(extensionURL.Hostname(), "443")
+	}
+
+	return c.grpcConnsCache.getOrCreate(grpcConnKey(target, config.CABundle), func() (*grpc.ClientConn, error) {
+		tlsConfig, err := createTLSConfig(c.certFile, c.keyFile, config.CABundle, extensionURL.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		conn, err := grpc.NewClient(target,
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+			grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gRPC client")
+		}
+		return conn, nil
+	})
+}
+
+func createTLSConfig(certFile, keyFile string, caData []byte, hostName string) (*tls.Config, error) {
 	tlsConfig, err := transport.TLSConfigFor(&transport.Config{
 		TLS: transport.TLSConfig{
 			CertFile:   certFile,
@@ -501,12 +929,7 @@ func createHTTPClient(certFile, keyFile string, caData []byte, hostName string)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create tls config")
 	}
-
-	// This also adds http2
-	httpClient.Transport = utilnet.SetTransportDefaults(&http.Transport{
-		TLSClientConfig: tlsConfig,
-	})
-	return httpClient, nil
+	return tlsConfig, nil
 }
 
 // cloneAndAddSettings creates a new request object and adds settings to it.
@@ -557,39 +980,10 @@ func httpCall(ctx context.Context, request, response runtime.Object, opts *httpC
 	}()
 	requireConversion := opts.registrationGVH.Version != opts.hookGVH.Version
 
-	requestLocal := request
-	responseLocal := response
-
-	if requireConversion {
-		log.V(5).Info(fmt.Sprintf("Hook version of supported request is %s. Converting request from %s", opts.registrationGVH, opts.hookGVH))
-		// The request and response objects need to be converted to match the version supported by
-		// the ExtensionHandler.
-		var err error
-
-		// Create a new hook request object that is compatible with the version of ExtensionHandler.
-		requestLocal, err = opts.catalog.NewRequest(opts.registrationGVH)
-		if err != nil {
-			return errors.Wrap(err, "http call failed")
-		}
-
-		// Convert the request to the version supported by the ExtensionHandler.
-		if err := opts.catalog.Convert(request, requestLocal, ctx); err != nil {
-			return errors.Wrapf(err, "http call failed: failed to convert request from %T to %T", request, requestLocal)
-		}
-
-		// Create a new hook response object that is compatible with the version of the ExtensionHandler.
-		responseLocal, err = opts.catalog.NewResponse(opts.registrationGVH)
-		if err != nil {
-			return errors.Wrap(err, "http call failed")
-		}
-	}
-
-	// Ensure the GroupVersionKind is set to the request.
-	requestGVH, err := opts.catalog.Request(opts.registrationGVH)
+	requestLocal, responseLocal, err := convertRequest(ctx, opts.catalog, request, response, opts.registrationGVH, opts.hookGVH)
 	if err != nil {
 		return errors.Wrap(err, "http call failed")
 	}
-	requestLocal.GetObjectKind().SetGroupVersionKind(requestGVH)
 
 	postBody, err := json.Marshal(requestLocal)
 	if err != nil {
@@ -657,6 +1051,188 @@ func httpCall(ctx context.Context, request, response runtime.Object, opts *httpC
 	return nil
 }
 
+// convertRequest returns the request and the response objects to be used for calling an ExtensionHandler
+// which supports the registrationGVH version of the hook.
+// If the version of the hook is different, the request is converted and a new response object is created;
+// the response must then be converted back by the caller.
+func convertRequest(ctx context.Context, catalog *runtimecatalog.Catalog, request, response runtime.Object, registrationGVH, hookGVH runtimecatalog.GroupVersionHook) (runtime.Object, runtime.Object, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	requestLocal := request
+	responseLocal := response
+
+	if registrationGVH.Version != hookGVH.Version {
+		log.V(5).Info(fmt.Sprintf("Hook version of supported request is %s. Converting request from %s", registrationGVH, hookGVH))
+		// The request and response objects need to be converted to match the version supported by
+		// the ExtensionHandler.
+		var err error
+
+		// Create a new hook request object that is compatible with the version of ExtensionHandler.
+		requestLocal, err = catalog.NewRequest(registrationGVH)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Convert the request to the version supported by the ExtensionHandler.
+		if err := catalog.Convert(request, requestLocal, ctx); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to convert request from %T to %T", request, requestLocal)
+		}
+
+		// Create a new hook response object that is compatible with the version of the ExtensionHandler.
+		responseLocal, err = catalog.NewResponse(registrationGVH)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Ensure the GroupVersionKind is set to the request.
+	requestGVH, err := catalog.Request(registrationGVH)
+	if err != nil {
+		return nil, nil, err
+	}
+	requestLocal.GetObjectKind().SetGroupVersionKind(requestGVH)
+
+	return requestLocal, responseLocal, nil
+}
+
+type grpcCallOptions struct {
+	catalog         *runtimecatalog.Catalog
+	config          runtimev1.ClientConfig
+	registrationGVH runtimecatalog.GroupVersionHook
+	hookGVH         runtimecatalog.GroupVersionHook
+	name            string
+	timeout         time.Duration
+	conn            *grpc.ClientConn
+}
+
+// grpcCall calls an ExtensionHandler via gRPC.
+// The gRPC method is the path of the ExtensionHandler, i.e. the same path used by httpCall, and the
+// request and the response are encoded as JSON like for httpCall.
+func grpcCall(ctx context.Context, request, response runtime.Object, opts *grpcCallOptions) error {
+	log := ctrl.LoggerFrom(ctx)
+	if opts == nil || request == nil || response == nil {
+		return errors.New("gRPC call failed: opts, request and response cannot be nil")
+	}
+	if opts.catalog == nil {
+		return errors.New("gRPC call failed: opts.Catalog cannot be nil")
+	}
+
+	extensionURL, err := urlForExtension(opts.config, opts.registrationGVH, opts.name)
+	if err != nil {
+		return errors.Wrap(err, "gRPC call failed")
+	}
+
+	// Observe request duration metric.
+	start := time.Now()
+	defer func() {
+		runtimemetrics.RequestDuration.Observe(opts.hookGVH, *extensionURL, time.Since(start))
+	}()
+	requireConversion := opts.registrationGVH.Version != opts.hookGVH.Version
+
+	requestLocal, responseLocal, err := convertRequest(ctx, opts.catalog, request, response, opts.registrationGVH, opts.hookGVH)
+	if err != nil {
+		return errors.Wrap(err, "gRPC call failed")
+	}
+
+	if opts.timeout != 0 {
+		// Make the call time-bound if timeout is non-zero value.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.timeout, errors.New("gRPC request timeout expired"))
+		defer cancel()
+	}
+
+	requestMessage, err := runtimegrpcv1.ConvertRequestToProto(requestLocal)
+	if err != nil {
+		return errors.Wrap(err, "gRPC call failed")
+	}
+	responseMessage, err := runtimegrpcv1.NewResponse(responseLocal)
+	if err != nil {
+		return errors.Wrap(err, "gRPC call failed")
+	}
+
+	// Call the extension.
+	err = opts.conn.Invoke(ctx, extensionURL.Path, requestMessage, responseMessage)
+
+	// Create request metric.
+	defer func() {
+		runtimemetrics.RequestsTotal.ObserveGRPC(*extensionURL, opts.hookGVH, err, response)
+	}()
+
+	if err != nil {
+		return errCallingExtensionHandler(
+			errors.Wrapf(err, "gRPC call failed"),
+		)
+	}
+
+	if err := runtimegrpcv1.ConvertResponseFromProto(responseMessage, responseLocal); err != nil {
+		return errors.Wrap(err, "gRPC call failed")
+	}
+
+	if requireConversion {
+		log.V(5).Info(fmt.Sprintf("Hook version of received response is %s. Converting response to %s", opts.registrationGVH, opts.hookGVH))
+		// Convert the received response to the original version of the response object.
+		if err := opts.catalog.Convert(responseLocal, response, ctx); err != nil {
+			return errors.Wrapf(err, "gRPC call failed: failed to convert response from %T to %T", responseLocal, response)
+		}
+	}
+
+	return nil
+}
+
+type inProcessCallOptions struct {
+	catalog         *runtimecatalog.Catalog
+	extension       *runtimeserver.InProcessExtension
+	registrationGVH runtimecatalog.GroupVersionHook
+	hookGVH         runtimecatalog.GroupVersionHook
+	name            string
+	timeout         time.Duration
+}
+
+// inProcessCall calls an ExtensionHandler of an in-process extension.
+// The request and the response are converted like for httpCall, but instead of being sent as JSON over HTTPS
+// they are passed to the ExtensionHandler directly.
+func inProcessCall(ctx context.Context, request, response runtime.Object, opts *inProcessCallOptions) error {
+	log := ctrl.LoggerFrom(ctx)
+	if opts == nil || request == nil || response == nil {
+		return errors.New("in-process call failed: opts, request and response cannot be nil")
+	}
+	if opts.catalog == nil {
+		return errors.New("in-process call failed: opts.Catalog cannot be nil")
+	}
+
+	requireConversion := opts.registrationGVH.Version != opts.hookGVH.Version
+
+	requestLocal, responseLocal, err := convertRequest(ctx, opts.catalog, request, response, opts.registrationGVH, opts.hookGVH)
+	if err != nil {
+		return errors.Wrap(err, "in-process call failed")
+	}
+
+	if opts.timeout != 0 {
+		// Make the context of the call time-bound if timeout is non-zero value.
+		// Note: ExtensionHandlers are called synchronously, so they have to respect the context.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.timeout, errors.New("in-process call timeout expired"))
+		defer cancel()
+	}
+
+	// Call the extension.
+	if err := opts.extension.Call(ctx, opts.registrationGVH, opts.name, requestLocal, responseLocal); err != nil {
+		return errCallingExtensionHandler(
+			errors.Wrap(err, "in-process call failed"),
+		)
+	}
+
+	if requireConversion {
+		log.V(5).Info(fmt.Sprintf("Hook version of received response is %s. Converting response to %s", opts.registrationGVH, opts.hookGVH))
+		// Convert the received response to the original version of the response object.
+		if err := opts.catalog.Convert(responseLocal, response, ctx); err != nil {
+			return errors.Wrapf(err, "in-process call failed: failed to convert response from %T to %T", responseLocal, response)
+		}
+	}
+
+	return nil
+}
+
 func urlForExtension(config runtimev1.ClientConfig, gvh runtimecatalog.GroupVersionHook, name string) (*url.URL, error) {
 	var u *url.URL
 	if config.Service.IsDefined() {
@@ -29,12 +29,23 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -42,12 +53,16 @@ import (
 	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	grpchooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/grpc/v1alpha1"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
+	runtimerecorder "sigs.k8s.io/cluster-api/internal/runtime/recorder"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	fakev1alpha1 "sigs.k8s.io/cluster-api/internal/runtime/test/v1alpha1"
 	fakev1alpha2 "sigs.k8s.io/cluster-api/internal/runtime/test/v1alpha2"
@@ -855,6 +870,225 @@ func TestClient_CallExtension(t *testing.T) {
 	}
 }
 
+func TestClient_CallExtensionWithGRPCTransport(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	request := &runtimehooksv1.GeneratePatchesRequest{
+		Variables: []runtimehooksv1.Variable{
+			{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte(`3`)}},
+		},
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{
+			{
+				UID:    "uid1",
+				Object: runtime.RawExtension{Raw: []byte(`{"kind":"DockerMachineTemplate"}`)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		failurePolicy runtimev1.FailurePolicy
+		handler       func(method string, request *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error)
+		wantResponse  *runtimehooksv1.GeneratePatchesResponse
+		wantErr       bool
+	}{
+		{
+			name:          "should call the extension handler via gRPC using protobuf messages",
+			failurePolicy: runtimev1.FailurePolicyFail,
+			handler: func(method string, request *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error) {
+				return &grpchooksv1.GeneratePatchesResponse{
+					Status:  string(runtimehooksv1.ResponseStatusSuccess),
+					Message: method,
+					Items: []*grpchooksv1.GeneratePatchesResponseItem{
+						{
+							Uid:       request.GetItems()[0].GetUid(),
+							PatchType: string(runtimehooksv1.JSONMergePatchType),
+							Patch:     request.GetVariables()[0].GetValue(),
+						},
+					},
+				}, nil
+			},
+			wantResponse: &runtimehooksv1.GeneratePatchesResponse{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: runtimehooksv1.GroupVersion.String(),
+					Kind:       "GeneratePatchesResponse",
+				},
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusSuccess,
+					Message: "/hooks.runtime.cluster.x-k8s.io/v1alpha1/generatepatches/generate-patches",
+				},
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID:       "uid1",
+						PatchType: runtimehooksv1.JSONMergePatchType,
+						Patch:     []byte(`3`),
+					},
+				},
+			},
+		},
+		{
+			name:          "should fail if the extension handler returns a failure",
+			failurePolicy: runtimev1.FailurePolicyFail,
+			handler: func(string, *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error) {
+				return &grpchooksv1.GeneratePatchesResponse{
+					Status:  string(runtimehooksv1.ResponseStatusFailure),
+					Message: "failed",
+				}, nil
+			},
+			wantErr: true,
+		},
+		{
+			name:          "should fail if the gRPC call fails and FailurePolicy is Fail",
+			failurePolicy: runtimev1.FailurePolicyFail,
+			handler: func(string, *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error) {
+				return nil, status.Error(codes.Unavailable, "unavailable")
+			},
+			wantErr: true,
+		},
+		{
+			name:          "should succeed if the gRPC call fails and FailurePolicy is Ignore",
+			failurePolicy: runtimev1.FailurePolicyIgnore,
+			handler: func(string, *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error) {
+				return nil, status.Error(codes.Unavailable, "unavailable")
+			},
+			wantResponse: &runtimehooksv1.GeneratePatchesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status: runtimehooksv1.ResponseStatusSuccess,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			srv := createSecureGRPCTestServer(tt.handler)
+			srv.StartTLS()
+			defer srv.Close()
+
+			c := newGRPCTestClient(g, srv, ns, tt.failurePolicy)
+
+			response := &runtimehooksv1.GeneratePatchesResponse{}
+			err := c.CallExtension(context.Background(), runtimehooksv1.GeneratePatches, ns, "generate-patches", request, response)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(response).To(Equal(tt.wantResponse))
+		})
+	}
+
+	t.Run("should call extension handlers of Runtime Hooks without a protobuf definition via HTTPS", func(t *testing.T) {
+		g := NewWithT(t)
+
+		srv := createSecureGRPCTestServer(func(string, *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error) {
+			return nil, status.Error(codes.Internal, "unexpected gRPC call")
+		})
+		srv.StartTLS()
+		defer srv.Close()
+
+		c := newGRPCTestClient(g, srv, ns, runtimev1.FailurePolicyFail)
+
+		response := &runtimehooksv1.BeforeClusterCreateResponse{}
+		err := c.CallExtension(context.Background(), runtimehooksv1.BeforeClusterCreate, ns, "before-cluster-create", &runtimehooksv1.BeforeClusterCreateRequest{}, response)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(response.Message).To(Equal("served via HTTPS"))
+	})
+}
+
+// newGRPCTestClient returns a client for an Extension server using the gRPC transport, with the GeneratePatches and
+// BeforeClusterCreate extension handlers.
+func newGRPCTestClient(g *WithT, srv *httptest.Server, ns *corev1.Namespace, failurePolicy runtimev1.FailurePolicy) runtimeclient.Client {
+	extensionConfig := runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			ResourceVersion: "15",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				URL:       fmt.Sprintf("https://%s/", srv.Listener.Addr().String()),
+				CABundle:  testcerts.CACert,
+				Transport: runtimev1.TransportGRPC,
+			},
+			NamespaceSelector: &metav1.LabelSelector{},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			Transport: runtimev1.TransportGRPC,
+		},
+	}
+	for name, hook := range map[string]string{"generate-patches": "GeneratePatches", "before-cluster-create": "BeforeClusterCreate"} {
+		extensionConfig.Status.Handlers = append(extensionConfig.Status.Handlers, runtimev1.ExtensionHandler{
+			Name: name,
+			RequestHook: runtimev1.GroupVersionHook{
+				APIVersion: runtimehooksv1.GroupVersion.String(),
+				Hook:       hook,
+			},
+			TimeoutSeconds: 1,
+			FailurePolicy:  failurePolicy,
+		})
+	}
+
+	cat := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(cat)
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(ns).
+		Build()
+
+	c, _, err := New(Options{
+		Catalog:  cat,
+		Registry: registry([]runtimev1.ExtensionConfig{extensionConfig}),
+		Client:   fakeClient,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	return c
+}
+
+func Test_negotiateTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred runtimev1.Transport
+		supported []runtimehooksv1.Transport
+		want      runtimev1.Transport
+	}{
+		{
+			name:      "should default to HTTPS",
+			preferred: "",
+			supported: []runtimehooksv1.Transport{runtimehooksv1.TransportHTTPS, runtimehooksv1.TransportGRPC},
+			want:      runtimev1.TransportHTTPS,
+		},
+		{
+			name:      "should use HTTPS if preferred",
+			preferred: runtimev1.TransportHTTPS,
+			supported: []runtimehooksv1.Transport{runtimehooksv1.TransportHTTPS, runtimehooksv1.TransportGRPC},
+			want:      runtimev1.TransportHTTPS,
+		},
+		{
+			name:      "should use GRPC if preferred and supported",
+			preferred: runtimev1.TransportGRPC,
+			supported: []runtimehooksv1.Transport{runtimehooksv1.TransportHTTPS, runtimehooksv1.TransportGRPC},
+			want:      runtimev1.TransportGRPC,
+		},
+		{
+			name:      "should fall back to HTTPS if GRPC is preferred but not supported",
+			preferred: runtimev1.TransportGRPC,
+			supported: nil,
+			want:      runtimev1.TransportHTTPS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(negotiateTransport(logr.Discard(), tt.preferred, tt.supported)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestClient_CallExtensionWithClientAuthentication(t *testing.T) {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1555,6 +1789,443 @@ func TestClient_CallAllExtensions(t *testing.T) {
 	}
 }
 
+func TestClient_InProcessExtension(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": "foo",
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		namespaceSelector *metav1.LabelSelector
+		failurePolicy     *runtimehooksv1.FailurePolicy
+		handlerFunc       func(context.Context, *fakev1alpha1.FakeRequest, *fakev1alpha1.FakeResponse)
+		wantCalled        bool
+		wantResponse      *fakev1alpha2.FakeResponse
+		wantErr           bool
+	}{
+		{
+			name: "should call the extension handler in-process and convert request and response",
+			handlerFunc: func(_ context.Context, request *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+				response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+				response.SetMessage(fmt.Sprintf("%s/%s", request.Settings["key"], request.Second))
+			},
+			wantCalled: true,
+			wantResponse: &fakev1alpha2.FakeResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusSuccess,
+					Message: "value/second",
+				},
+			},
+		},
+		{
+			name: "should fail if the extension handler returns a failure",
+			handlerFunc: func(_ context.Context, _ *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+				response.SetStatus(runtimehooksv1.ResponseStatusFailure)
+				response.SetMessage("failed")
+			},
+			wantCalled: true,
+			wantErr:    true,
+		},
+		{
+			name:          "should fail if the extension handler panics and FailurePolicy is Fail",
+			failurePolicy: ptr.To(runtimehooksv1.FailurePolicyFail),
+			handlerFunc: func(context.Context, *fakev1alpha1.FakeRequest, *fakev1alpha1.FakeResponse) {
+				panic("boom")
+			},
+			wantCalled: true,
+			wantErr:    true,
+		},
+		{
+			name:          "should succeed if the extension handler panics and FailurePolicy is Ignore",
+			failurePolicy: ptr.To(runtimehooksv1.FailurePolicyIgnore),
+			handlerFunc: func(context.Context, *fakev1alpha1.FakeRequest, *fakev1alpha1.FakeResponse) {
+				panic("boom")
+			},
+			wantCalled: true,
+			wantResponse: &fakev1alpha2.FakeResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status: runtimehooksv1.ResponseStatusSuccess,
+				},
+			},
+		},
+		{
+			name: "should not call the extension handler if the namespaceSelector does not match",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "bar"},
+			},
+			handlerFunc: func(_ context.Context, _ *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+				response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+			},
+			wantCalled: false,
+			wantResponse: &fakev1alpha2.FakeResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status: runtimehooksv1.ResponseStatusSuccess,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+			cat := runtimecatalog.New()
+			g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+			g.Expect(fakev1alpha1.AddToCatalog(cat)).To(Succeed())
+			g.Expect(fakev1alpha2.AddToCatalog(cat)).To(Succeed())
+
+			extension, err := runtimeserver.NewInProcessExtension(runtimeserver.InProcessExtensionOptions{
+				Name:              "in-process",
+				Catalog:           cat,
+				NamespaceSelector: tt.namespaceSelector,
+				Settings:          map[string]string{"key": "value"},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			called := false
+			g.Expect(extension.AddExtensionHandler(runtimeserver.ExtensionHandler{
+				Hook: fakev1alpha1.FakeHook,
+				Name: "fake",
+				HandlerFunc: func(ctx context.Context, request *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+					called = true
+					tt.handlerFunc(ctx, request, response)
+				},
+				FailurePolicy: tt.failurePolicy,
+			})).To(Succeed())
+
+			c, _, err := New(Options{
+				Catalog:             cat,
+				Registry:            runtimeregistry.New(),
+				Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build(),
+				InProcessExtensions: []*runtimeserver.InProcessExtension{extension},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(c.WarmUp(&runtimev1.ExtensionConfigList{})).To(Succeed())
+
+			obj := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: "foo",
+				},
+			}
+			request := &fakev1alpha2.FakeRequest{Second: "second"}
+			response := &fakev1alpha2.FakeResponse{}
+			err = c.CallAllExtensions(context.Background(), fakev1alpha2.FakeHook, obj, request, response)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(response).To(Equal(tt.wantResponse))
+			}
+			g.Expect(called).To(Equal(tt.wantCalled))
+		})
+	}
+}
+
+func TestClient_InProcessExtensionNameConflicts(t *testing.T) {
+	g := NewWithT(t)
+
+	cat := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+
+	extension, err := runtimeserver.NewInProcessExtension(runtimeserver.InProcessExtensionOptions{
+		Name:    "in-process",
+		Catalog: cat,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Creating a client with two in-process extensions with the same name fails.
+	_, _, err = New(Options{
+		Catalog:             cat,
+		Registry:            runtimeregistry.New(),
+		InProcessExtensions: []*runtimeserver.InProcessExtension{extension, extension},
+	})
+	g.Expect(err).To(HaveOccurred())
+
+	c, _, err := New(Options{
+		Catalog:             cat,
+		Registry:            runtimeregistry.New(),
+		InProcessExtensions: []*runtimeserver.InProcessExtension{extension},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.WarmUp(&runtimev1.ExtensionConfigList{})).To(Succeed())
+
+	// Registering an ExtensionConfig with the same name as an in-process extension fails,
+	// while unregistering it is a no-op.
+	extensionConfig := &runtimev1.ExtensionConfig{ObjectMeta: metav1.ObjectMeta{Name: "in-process"}}
+	g.Expect(c.Register(extensionConfig)).ToNot(Succeed())
+	g.Expect(c.Unregister(extensionConfig)).To(Succeed())
+}
+
+func TestClient_CallExtensionWithRecorderAndTracing(t *testing.T) {
+	g := NewWithT(t)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": "foo",
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	cat := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+	g.Expect(fakev1alpha1.AddToCatalog(cat)).To(Succeed())
+	g.Expect(fakev1alpha2.AddToCatalog(cat)).To(Succeed())
+
+	extension, err := runtimeserver.NewInProcessExtension(runtimeserver.InProcessExtensionOptions{
+		Name:    "in-process",
+		Catalog: cat,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(extension.AddExtensionHandler(runtimeserver.ExtensionHandler{
+		Hook: fakev1alpha1.FakeHook,
+		Name: "fake",
+		HandlerFunc: func(_ context.Context, request *fakev1alpha1.FakeRequest, response *fakev1alpha1.FakeResponse) {
+			if request.Second == "fail" {
+				response.SetStatus(runtimehooksv1.ResponseStatusFailure)
+				response.SetMessage("failed")
+				return
+			}
+			response.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+			response.SetMessage(request.Second)
+		},
+	})).To(Succeed())
+
+	recorder, err := runtimerecorder.New(runtimerecorder.Options{
+		Sink: runtimerecorder.NewFileSink(filepath.Join(t.TempDir(), "records.json")),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c, _, err := New(Options{
+		Catalog:             cat,
+		Registry:            runtimeregistry.New(),
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build(),
+		InProcessExtensions: []*runtimeserver.InProcessExtension{extension},
+		Recorder:            recorder,
+		TracerProvider:      tracerProvider,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.WarmUp(&runtimev1.ExtensionConfigList{})).To(Succeed())
+
+	obj := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "foo",
+		},
+	}
+	g.Expect(c.CallExtension(context.Background(), fakev1alpha2.FakeHook, obj, "fake.in-process",
+		&fakev1alpha2.FakeRequest{Second: "second"}, &fakev1alpha2.FakeResponse{})).To(Succeed())
+	g.Expect(c.CallExtension(context.Background(), fakev1alpha2.FakeHook, obj, "fake.in-process",
+		&fakev1alpha2.FakeRequest{Second: "fail"}, &fakev1alpha2.FakeResponse{})).ToNot(Succeed())
+
+	// Both calls are recorded, with the request and the response of the hook version used by the caller.
+	records := recorder.Records()
+	g.Expect(records).To(HaveLen(2))
+	g.Expect(records[0].ID).To(Equal(int64(1)))
+	g.Expect(records[0].ExtensionConfig).To(Equal("in-process"))
+	g.Expect(records[0].ExtensionHandler).To(Equal("fake.in-process"))
+	g.Expect(records[0].APIVersion).To(Equal(fakev1alpha2.GroupVersion.String()))
+	g.Expect(records[0].HandlerAPIVersion).To(Equal(fakev1alpha1.GroupVersion.String()))
+	g.Expect(records[0].Hook).To(Equal("FakeHook"))
+	g.Expect(records[0].Transport).To(Equal(string(runtimev1.TransportInProcess)))
+	g.Expect(records[0].Error).To(BeEmpty())
+	recordedRequest := &fakev1alpha2.FakeRequest{}
+	g.Expect(json.Unmarshal(records[0].Request, recordedRequest)).To(Succeed())
+	g.Expect(recordedRequest.Second).To(Equal("second"))
+	recordedResponse := &fakev1alpha2.FakeResponse{}
+	g.Expect(json.Unmarshal(records[0].Response, recordedResponse)).To(Succeed())
+	g.Expect(recordedResponse.Message).To(Equal("second"))
+	// Note: a failure response is recorded as well, the error is returned only afterwards when validating the response.
+	recordedResponse = &fakev1alpha2.FakeResponse{}
+	g.Expect(json.Unmarshal(records[1].Response, recordedResponse)).To(Succeed())
+	g.Expect(recordedResponse.Status).To(Equal(runtimehooksv1.ResponseStatusFailure))
+
+	// A span is created for each call.
+	spans := exporter.GetSpans()
+	g.Expect(spans).To(HaveLen(2))
+	g.Expect(spans[0].Name).To(Equal("CallExtension"))
+	g.Expect(spans[0].Attributes).To(ContainElements(
+		attribute.String("extensionHandler", "fake.in-process"),
+		attribute.String("hook", "FakeHook"),
+		attribute.String("extensionConfig", "in-process"),
+		attribute.String("transport", string(runtimev1.TransportInProcess)),
+		attribute.String("responseStatus", "Success"),
+	))
+	g.Expect(spans[0].Status.Code).To(Equal(otelcodes.Unset))
+	g.Expect(spans[1].Status.Code).To(Equal(otelcodes.Error))
+}
+
+func TestClient_CallExtensionWithCircuitBreaker(t *testing.T) {
+	g := NewWithT(t)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	var serverCallCount atomic.Int32
+	srv := newUnstartedTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		serverCallCount.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	srv.StartTLS()
+	defer srv.Close()
+
+	extensionConfig := runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-extension",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				URL:      fmt.Sprintf("https://%s/", srv.Listener.Addr().String()),
+				CABundle: testcerts.CACert,
+			},
+			NamespaceSelector: &metav1.LabelSelector{},
+			CircuitBreaker: runtimev1.CircuitBreaker{
+				FailureThreshold:     2,
+				HalfOpenAfterSeconds: 60,
+			},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{
+				{
+					Name: "fake.my-extension",
+					RequestHook: runtimev1.GroupVersionHook{
+						APIVersion: fakev1alpha1.GroupVersion.String(),
+						Hook:       "FakeHook",
+					},
+					TimeoutSeconds: 1,
+					FailurePolicy:  runtimev1.FailurePolicyFail,
+				},
+			},
+		},
+	}
+
+	cat := runtimecatalog.New()
+	g.Expect(fakev1alpha1.AddToCatalog(cat)).To(Succeed())
+	circuitBreakerEvents := make(chan event.GenericEvent, 10)
+	c, _, err := New(Options{
+		Catalog:              cat,
+		Registry:             runtimeregistry.New(),
+		Client:               fake.NewClientBuilder().WithObjects(ns).Build(),
+		CircuitBreakerEvents: circuitBreakerEvents,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.WarmUp(&runtimev1.ExtensionConfigList{Items: []runtimev1.ExtensionConfig{extensionConfig}})).To(Succeed())
+	stateGetter, ok := c.(runtimeclient.CircuitBreakerStateGetter)
+	g.Expect(ok).To(BeTrue())
+
+	state, ok := stateGetter.GetCircuitBreakerState("my-extension")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(state).To(Equal(runtimeclient.CircuitBreakerClosed))
+
+	obj := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "foo",
+		},
+	}
+	callExtension := func() error {
+		return c.CallExtension(context.Background(), fakev1alpha1.FakeHook, obj, "fake.my-extension", &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{})
+	}
+
+	// The circuit breaker opens after 2 consecutive failures.
+	g.Expect(callExtension()).ToNot(Succeed())
+	g.Expect(callExtension()).ToNot(Succeed())
+	g.Expect(serverCallCount.Load()).To(Equal(int32(2)))
+	state, _ = stateGetter.GetCircuitBreakerState("my-extension")
+	g.Expect(state).To(Equal(runtimeclient.CircuitBreakerOpen))
+	g.Expect(circuitBreakerEvents).To(Receive(WithTransform(func(e event.GenericEvent) string {
+		return e.Object.GetName()
+	}, Equal("my-extension"))))
+
+	// While the circuit breaker is open calls fail without calling the extension.
+	err = callExtension()
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("circuit breaker is open"))
+	g.Expect(serverCallCount.Load()).To(Equal(int32(2)))
+
+	// With FailurePolicy Ignore calls rejected by the circuit breaker succeed.
+	extensionConfig.Status.Handlers[0].FailurePolicy = runtimev1.FailurePolicyIgnore
+	g.Expect(c.Register(&extensionConfig)).To(Succeed())
+	g.Expect(callExtension()).To(Succeed())
+	g.Expect(serverCallCount.Load()).To(Equal(int32(2)))
+
+	// Unregistering the ExtensionConfig removes the circuit breaker.
+	g.Expect(c.Unregister(&extensionConfig)).To(Succeed())
+	_, ok = stateGetter.GetCircuitBreakerState("my-extension")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestReplay(t *testing.T) {
+	g := NewWithT(t)
+
+	cat := runtimecatalog.New()
+	g.Expect(fakev1alpha1.AddToCatalog(cat)).To(Succeed())
+	g.Expect(fakev1alpha2.AddToCatalog(cat)).To(Succeed())
+
+	var gotPath string
+	var gotRequest *fakev1alpha1.FakeRequest
+	srv := newUnstartedTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRequest = &fakev1alpha1.FakeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(gotRequest); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		respBody, err := json.Marshal(fakeSuccessResponse(gotRequest.Second))
+		if err != nil {
+			panic(err)
+		}
+		_, _ = w.Write(respBody)
+	}))
+	srv.StartTLS()
+	defer srv.Close()
+
+	record := runtimerecorder.Record{
+		ID:                1,
+		ExtensionConfig:   "my-extension",
+		ExtensionHandler:  "fake.my-extension",
+		APIVersion:        fakev1alpha2.GroupVersion.String(),
+		HandlerAPIVersion: fakev1alpha1.GroupVersion.String(),
+		Hook:              "FakeHook",
+		Request:           json.RawMessage(`{"second":"replayed"}`),
+	}
+	config := runtimev1.ClientConfig{
+		URL:      fmt.Sprintf("https://%s/", srv.Listener.Addr().String()),
+		CABundle: testcerts.CACert,
+	}
+
+	response, err := Replay(context.Background(), cat, config, record, 10*time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gotPath).To(Equal("/test.runtime.cluster.x-k8s.io/v1alpha1/fakehook/fake"))
+	g.Expect(gotRequest.Second).To(Equal("replayed"))
+	g.Expect(response).To(BeAssignableToTypeOf(&fakev1alpha2.FakeResponse{}))
+	g.Expect(response.GetStatus()).To(Equal(runtimehooksv1.ResponseStatusSuccess))
+	g.Expect(response.GetMessage()).To(Equal("replayed"))
+
+	// Replay fails for an unknown hook.
+	record.Hook = "UnknownHook"
+	_, err = Replay(context.Background(), cat, config, record, 10*time.Second)
+	g.Expect(err).To(HaveOccurred())
+}
+
 func Test_client_matchNamespace(t *testing.T) {
 	g := NewWithT(t)
 	foo := &corev1.Namespace{
@@ -1760,6 +2431,49 @@ func createSecureTestServer(server testServerConfig, callbacks ...func()) *httpt
 	return srv
 }
 
+// createSecureGRPCTestServer creates a test server serving GeneratePatches via gRPC; like the Runtime SDK server,
+// all the other calls are served via HTTPS on the same port.
+func createSecureGRPCTestServer(handler func(method string, request *grpchooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesResponse, error)) *httptest.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			request := &grpchooksv1.GeneratePatchesRequest{}
+			if err := stream.RecvMsg(request); err != nil {
+				return err
+			}
+			response, err := handler(method, request)
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(response)
+		}),
+	)
+
+	srv := newUnstartedTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		respBody, err := json.Marshal(&runtimehooksv1.BeforeClusterCreateResponse{
+			CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusSuccess,
+					Message: "served via HTTPS",
+				},
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(respBody)
+	}))
+	// gRPC requires HTTP/2.
+	srv.EnableHTTP2 = true
+
+	return srv
+}
+
 func registry(configs []runtimev1.ExtensionConfig) runtimeregistry.ExtensionRegistry {
 	registry := runtimeregistry.New()
 	err := registry.WarmUp(&runtimev1.ExtensionConfigList{
@@ -39,6 +39,7 @@ type RuntimeClientBuilder struct {
 	callAllValidations func(object runtimehooksv1.RequestObject) error
 	callResponses      map[string]runtimehooksv1.ResponseObject
 	callValidations    func(name string, object runtimehooksv1.RequestObject) error
+	circuitBreakers    map[string]runtimeclient.CircuitBreakerState
 }
 
 // NewRuntimeClientBuilder returns a new builder for the fake runtime client.
@@ -82,6 +83,12 @@ func (f *RuntimeClientBuilder) WithCallExtensionValidations(callValidations func
 	return f
 }
 
+// WithCircuitBreakerStates can be used to dictate the responses for GetCircuitBreakerState.
+func (f *RuntimeClientBuilder) WithCircuitBreakerStates(states map[string]runtimeclient.CircuitBreakerState) *RuntimeClientBuilder {
+	f.circuitBreakers = states
+	return f
+}
+
 // MarkReady can be used to mark the fake runtime client as either ready or not ready.
 func (f *RuntimeClientBuilder) MarkReady(ready bool) *RuntimeClientBuilder {
 	f.ready = ready
@@ -97,6 +104,7 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 		callAllValidations: f.callAllValidations,
 		callResponses:      f.callResponses,
 		callValidations:    f.callValidations,
+		circuitBreakers:    f.circuitBreakers,
 		catalog:            f.catalog,
 		callAllTracker:     map[string]int{},
 		callTracker:        map[string]int{},
@@ -104,6 +112,7 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 }
 
 var _ runtimeclient.Client = &RuntimeClient{}
+var _ runtimeclient.CircuitBreakerStateGetter = &RuntimeClient{}
 
 // RuntimeClient is a fake implementation of runtimeclient.Client.
 type RuntimeClient struct {
@@ -114,6 +123,7 @@ type RuntimeClient struct {
 	callAllValidations func(object runtimehooksv1.RequestObject) error
 	callResponses      map[string]runtimehooksv1.ResponseObject
 	callValidations    func(name string, object runtimehooksv1.RequestObject) error
+	circuitBreakers    map[string]runtimeclient.CircuitBreakerState
 
 	callTracker    map[string]int
 	callAllTracker map[string]int
@@ -218,6 +228,12 @@ func (fc *RuntimeClient) Unregister(_ *runtimev1.ExtensionConfig) error {
 	panic("unimplemented")
 }
 
+// GetCircuitBreakerState implements CircuitBreakerStateGetter.
+func (fc *RuntimeClient) GetCircuitBreakerState(extensionConfigName string) (runtimeclient.CircuitBreakerState, bool) {
+	state, ok := fc.circuitBreakers[extensionConfigName]
+	return state, ok
+}
+
 // WarmUp implements Client.
 func (fc *RuntimeClient) WarmUp(_ *runtimev1.ExtensionConfigList) error {
 	panic("unimplemented")
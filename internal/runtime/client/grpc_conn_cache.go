@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// grpcConnCache caches the grpc.ClientConn used to call Runtime Extensions.
+// Note: Differently from an http.Client, a grpc.ClientConn must be closed to release its resources; connections are
+// closed when they expire or when all the entries are deleted, e.g. when the client certificate is rotated, as soon
+// as there are no more in-flight calls using them.
+type grpcConnCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[string]*grpcConnEntry
+	now     func() time.Time
+}
+
+type grpcConnEntry struct {
+	conn      *grpc.ClientConn
+	createdAt time.Time
+
+	// inFlight is the number of calls using conn.
+	inFlight int
+	// evicted is true when the entry has been removed from the cache; conn is closed when there are no in-flight calls.
+	evicted bool
+}
+
+func newGRPCConnCache(ttl time.Duration) *grpcConnCache {
+	return &grpcConnCache{
+		ttl:     ttl,
+		entries: map[string]*grpcConnEntry{},
+		now:     time.Now,
+	}
+}
+
+// grpcConnKey returns the cache key for a grpc.ClientConn.
+// Note: caData and target are the variable parts in the TLSConfig and in the
+// target of a grpc.ClientConn that is used to call runtime extensions.
+func grpcConnKey(target string, caData []byte) string {
+	return fmt.Sprintf("%s/%s", target, string(caData))
+}
+
+// getOrCreate returns the grpc.ClientConn for key, creating it if it does not exist or if it is expired.
+// The returned release func must be called when the call using the grpc.ClientConn is completed.
+func (c *grpcConnCache) getOrCreate(key string, create func() (*grpc.ClientConn, error)) (*grpc.ClientConn, func(), error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if now.Sub(entry.createdAt) >= c.ttl {
+			c.evictLocked(k, entry)
+		}
+	}
+
+	entry, ok := c.entries[key]
+	if !ok {
+		conn, err := create()
+		if err != nil {
+			return nil, nil, err
+		}
+		entry = &grpcConnEntry{conn: conn, createdAt: now}
+		c.entries[key] = entry
+	}
+
+	entry.inFlight++
+	var once sync.Once
+	return entry.conn, func() { once.Do(func() { c.release(entry) }) }, nil
+}
+
+// deleteAll deletes all the entries from the cache.
+func (c *grpcConnCache) deleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for k, entry := range c.entries {
+		c.evictLocked(k, entry)
+	}
+}
+
+// len returns the number of entries in the cache.
+func (c *grpcConnCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *grpcConnCache) release(entry *grpcConnEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry.inFlight--
+	if entry.evicted && entry.inFlight == 0 {
+		_ = entry.conn.Close()
+	}
+}
+
+func (c *grpcConnCache) evictLocked(key string, entry *grpcConnEntry) {
+	delete(c.entries, key)
+	entry.evicted = true
+	if entry.inFlight == 0 {
+		_ = entry.conn.Close()
+	}
+}
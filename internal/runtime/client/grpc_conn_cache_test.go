@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestGRPCConnCache(t *testing.T) {
+	newConn := func() (*grpc.ClientConn, error) {
+		return grpc.NewClient("passthrough:///localhost:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	isClosed := func(conn *grpc.ClientConn) bool {
+		return conn.GetState() == connectivity.Shutdown
+	}
+
+	t.Run("reuses connections", func(t *testing.T) {
+		g := NewWithT(t)
+		c := newGRPCConnCache(time.Hour)
+
+		conn1, release1, err := c.getOrCreate("a", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		release1()
+		conn2, release2, err := c.getOrCreate("a", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		release2()
+		g.Expect(conn2).To(BeIdenticalTo(conn1))
+		g.Expect(isClosed(conn1)).To(BeFalse())
+
+		conn3, release3, err := c.getOrCreate("b", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		release3()
+		g.Expect(conn3).ToNot(BeIdenticalTo(conn1))
+		g.Expect(c.len()).To(Equal(2))
+	})
+	t.Run("closes expired connections", func(t *testing.T) {
+		g := NewWithT(t)
+		c := newGRPCConnCache(time.Hour)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		conn1, release1, err := c.getOrCreate("a", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		release1()
+		idleConn, releaseIdle, err := c.getOrCreate("b", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		releaseIdle()
+
+		now = now.Add(2 * time.Hour)
+		conn2, release2, err := c.getOrCreate("a", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		defer release2()
+		g.Expect(conn2).ToNot(BeIdenticalTo(conn1))
+		g.Expect(isClosed(conn1)).To(BeTrue())
+		g.Expect(isClosed(idleConn)).To(BeTrue())
+		g.Expect(isClosed(conn2)).To(BeFalse())
+		g.Expect(c.len()).To(Equal(1))
+	})
+	t.Run("closes connections when deleting all the entries, after in-flight calls are completed", func(t *testing.T) {
+		g := NewWithT(t)
+		c := newGRPCConnCache(time.Hour)
+
+		idleConn, releaseIdle, err := c.getOrCreate("a", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+		releaseIdle()
+		inFlightConn, releaseInFlight, err := c.getOrCreate("b", newConn)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		c.deleteAll()
+		g.Expect(c.len()).To(Equal(0))
+		g.Expect(isClosed(idleConn)).To(BeTrue())
+		g.Expect(isClosed(inFlightConn)).To(BeFalse())
+
+		releaseInFlight()
+		g.Expect(isClosed(inFlightConn)).To(BeTrue())
+		// Calling release more than once is a no-op.
+		releaseInFlight()
+	})
+}
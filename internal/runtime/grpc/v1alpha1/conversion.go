@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	grpchooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/grpc/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// IsSupported returns true if the Runtime Hook has a protobuf definition, and thus it can be called via gRPC.
+func IsSupported(gvh runtimecatalog.GroupVersionHook) bool {
+	if gvh.GroupVersion() != runtimehooksv1.GroupVersion {
+		return false
+	}
+	switch gvh.Hook {
+	case runtimecatalog.HookName(runtimehooksv1.GeneratePatches), runtimecatalog.HookName(runtimehooksv1.ValidateTopology):
+		return true
+	default:
+		return false
+	}
+}
+
+// NewRequest returns an empty protobuf message for the given Runtime Hook request.
+func NewRequest(request runtime.Object) (proto.Message, error) {
+	switch request.(type) {
+	case *runtimehooksv1.GeneratePatchesRequest:
+		return &grpchooksv1.GeneratePatchesRequest{}, nil
+	case *runtimehooksv1.ValidateTopologyRequest:
+		return &grpchooksv1.ValidateTopologyRequest{}, nil
+	default:
+		return nil, errors.Errorf("request type %T does not have a protobuf definition", request)
+	}
+}
+
+// NewResponse returns an empty protobuf message for the given Runtime Hook response.
+func NewResponse(response runtime.Object) (proto.Message, error) {
+	switch response.(type) {
+	case *runtimehooksv1.GeneratePatchesResponse:
+		return &grpchooksv1.GeneratePatchesResponse{}, nil
+	case *runtimehooksv1.ValidateTopologyResponse:
+		return &grpchooksv1.ValidateTopologyResponse{}, nil
+	default:
+		return nil, errors.Errorf("response type %T does not have a protobuf definition", response)
+	}
+}
+
+// ConvertRequestToProto converts a Runtime Hook request to the corresponding protobuf message.
+func ConvertRequestToProto(request runtime.Object) (proto.Message, error) {
+	switch in := request.(type) {
+	case *runtimehooksv1.GeneratePatchesRequest:
+		return convertGeneratePatchesRequestToProto(in)
+	case *runtimehooksv1.ValidateTopologyRequest:
+		return convertValidateTopologyRequestToProto(in)
+	default:
+		return nil, errors.Errorf("request type %T does not have a protobuf definition", request)
+	}
+}
+
+// ConvertRequestFromProto converts a protobuf message to the corresponding Runtime Hook request.
+// Note: apiVersion and kind of the request are set like in requests received via HTTPS.
+func ConvertRequestFromProto(message proto.Message, request runtime.Object) error {
+	switch out := request.(type) {
+	case *runtimehooksv1.GeneratePatchesRequest:
+		in, ok := message.(*grpchooksv1.GeneratePatchesRequest)
+		if !ok {
+			return errors.Errorf("cannot convert %T to %T", message, request)
+		}
+		convertGeneratePatchesRequestFromProto(in, out)
+		return nil
+	case *runtimehooksv1.ValidateTopologyRequest:
+		in, ok := message.(*grpchooksv1.ValidateTopologyRequest)
+		if !ok {
+			return errors.Errorf("cannot convert %T to %T", message, request)
+		}
+		convertValidateTopologyRequestFromProto(in, out)
+		return nil
+	default:
+		return errors.Errorf("request type %T does not have a protobuf definition", request)
+	}
+}
+
+// ConvertResponseToProto converts a Runtime Hook response to the corresponding protobuf message.
+func ConvertResponseToProto(response runtime.Object) (proto.Message, error) {
+	switch in := response.(type) {
+	case *runtimehooksv1.GeneratePatchesResponse:
+		return convertGeneratePatchesResponseToProto(in), nil
+	case *runtimehooksv1.ValidateTopologyResponse:
+		return &grpchooksv1.ValidateTopologyResponse{
+			Status:  string(in.Status),
+			Message: in.Message,
+		}, nil
+	default:
+		return nil, errors.Errorf("response type %T does not have a protobuf definition", response)
+	}
+}
+
+// ConvertResponseFromProto converts a protobuf message to the corresponding Runtime Hook response.
+// Note: apiVersion and kind of the response are set like in responses received via HTTPS.
+func ConvertResponseFromProto(message proto.Message, response runtime.Object) error {
+	switch out := response.(type) {
+	case *runtimehooksv1.GeneratePatchesResponse:
+		in, ok := message.(*grpchooksv1.GeneratePatchesResponse)
+		if !ok {
+			return errors.Errorf("cannot convert %T to %T", message, response)
+		}
+		convertGeneratePatchesResponseFromProto(in, out)
+		return nil
+	case *runtimehooksv1.ValidateTopologyResponse:
+		in, ok := message.(*grpchooksv1.ValidateTopologyResponse)
+		if !ok {
+			return errors.Errorf("cannot convert %T to %T", message, response)
+		}
+		out.SetGroupVersionKind(runtimehooksv1.GroupVersion.WithKind("ValidateTopologyResponse"))
+		out.Status = runtimehooksv1.ResponseStatus(in.GetStatus())
+		out.Message = in.GetMessage()
+		return nil
+	default:
+		return errors.Errorf("response type %T does not have a protobuf definition", response)
+	}
+}
+
+func convertGeneratePatchesRequestToProto(in *runtimehooksv1.GeneratePatchesRequest) (*grpchooksv1.GeneratePatchesRequest, error) {
+	out := &grpchooksv1.GeneratePatchesRequest{
+		Settings:  in.Settings,
+		Variables: convertVariablesToProto(in.Variables),
+	}
+	for i := range in.Items {
+		item := &in.Items[i]
+		object, err := convertRawExtensionToProto(item.Object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert object of item %s", item.UID)
+		}
+		out.Items = append(out.Items, &grpchooksv1.GeneratePatchesRequestItem{
+			Uid:             string(item.UID),
+			HolderReference: convertHolderReferenceToProto(item.HolderReference),
+			Object:          object,
+			Variables:       convertVariablesToProto(item.Variables),
+		})
+	}
+	return out, nil
+}
+
+func convertGeneratePatchesRequestFromProto(in *grpchooksv1.GeneratePatchesRequest, out *runtimehooksv1.GeneratePatchesRequest) {
+	out.SetGroupVersionKind(runtimehooksv1.GroupVersion.WithKind("GeneratePatchesRequest"))
+	out.Settings = in.GetSettings()
+	out.Variables = convertVariablesFromProto(in.GetVariables())
+	out.Items = nil
+	for _, item := range in.GetItems() {
+		out.Items = append(out.Items, runtimehooksv1.GeneratePatchesRequestItem{
+			UID:             types.UID(item.GetUid()),
+			HolderReference: convertHolderReferenceFromProto(item.GetHolderReference()),
+			Object:          runtime.RawExtension{Raw: item.GetObject()},
+			Variables:       convertVariablesFromProto(item.GetVariables()),
+		})
+	}
+}
+
+func convertGeneratePatchesResponseToProto(in *runtimehooksv1.GeneratePatchesResponse) *grpchooksv1.GeneratePatchesResponse {
+	out := &grpchooksv1.GeneratePatchesResponse{
+		Status:  string(in.Status),
+		Message: in.Message,
+	}
+	for _, item := range in.Items {
+		out.Items = append(out.Items, &grpchooksv1.GeneratePatchesResponseItem{
+			Uid:       string(item.UID),
+			PatchType: string(item.PatchType),
+			Patch:     item.Patch,
+		})
+	}
+	return out
+}
+
+func convertGeneratePatchesResponseFromProto(in *grpchooksv1.GeneratePatchesResponse, out *runtimehooksv1.GeneratePatchesResponse) {
+	out.SetGroupVersionKind(runtimehooksv1.GroupVersion.WithKind("GeneratePatchesResponse"))
+	out.Status = runtimehooksv1.ResponseStatus(in.GetStatus())
+	out.Message = in.GetMessage()
+	out.Items = nil
+	for _, item := range in.GetItems() {
+		out.Items = append(out.Items, runtimehooksv1.GeneratePatchesResponseItem{
+			UID:       types.UID(item.GetUid()),
+			PatchType: runtimehooksv1.PatchType(item.GetPatchType()),
+			Patch:     item.GetPatch(),
+		})
+	}
+}
+
+func convertValidateTopologyRequestToProto(in *runtimehooksv1.ValidateTopologyRequest) (*grpchooksv1.ValidateTopologyRequest, error) {
+	out := &grpchooksv1.ValidateTopologyRequest{
+		Settings:  in.Settings,
+		Variables: convertVariablesToProto(in.Variables),
+	}
+	for i, item := range in.Items {
+		if item == nil {
+			continue
+		}
+		object, err := convertRawExtensionToProto(item.Object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert object of item %d", i)
+		}
+		out.Items = append(out.Items, &grpchooksv1.ValidateTopologyRequestItem{
+			HolderReference: convertHolderReferenceToProto(item.HolderReference),
+			Object:          object,
+			Variables:       convertVariablesToProto(item.Variables),
+		})
+	}
+	return out, nil
+}
+
+func convertValidateTopologyRequestFromProto(in *grpchooksv1.ValidateTopologyRequest, out *runtimehooksv1.ValidateTopologyRequest) {
+	out.SetGroupVersionKind(runtimehooksv1.GroupVersion.WithKind("ValidateTopologyRequest"))
+	out.Settings = in.GetSettings()
+	out.Variables = convertVariablesFromProto(in.GetVariables())
+	out.Items = nil
+	for _, item := range in.GetItems() {
+		out.Items = append(out.Items, &runtimehooksv1.ValidateTopologyRequestItem{
+			HolderReference: convertHolderReferenceFromProto(item.GetHolderReference()),
+			Object:          runtime.RawExtension{Raw: item.GetObject()},
+			Variables:       convertVariablesFromProto(item.GetVariables()),
+		})
+	}
+}
+
+func convertVariablesToProto(in []runtimehooksv1.Variable) []*grpchooksv1.Variable {
+	var out []*grpchooksv1.Variable
+	for _, variable := range in {
+		out = append(out, &grpchooksv1.Variable{
+			Name:  variable.Name,
+			Value: variable.Value.Raw,
+		})
+	}
+	return out
+}
+
+func convertVariablesFromProto(in []*grpchooksv1.Variable) []runtimehooksv1.Variable {
+	var out []runtimehooksv1.Variable
+	for _, variable := range in {
+		out = append(out, runtimehooksv1.Variable{
+			Name:  variable.GetName(),
+			Value: apiextensionsv1.JSON{Raw: variable.GetValue()},
+		})
+	}
+	return out
+}
+
+func convertHolderReferenceToProto(in runtimehooksv1.HolderReference) *grpchooksv1.HolderReference {
+	return &grpchooksv1.HolderReference{
+		ApiVersion: in.APIVersion,
+		Kind:       in.Kind,
+		Namespace:  in.Namespace,
+		Name:       in.Name,
+		FieldPath:  in.FieldPath,
+	}
+}
+
+func convertHolderReferenceFromProto(in *grpchooksv1.HolderReference) runtimehooksv1.HolderReference {
+	return runtimehooksv1.HolderReference{
+		APIVersion: in.GetApiVersion(),
+		Kind:       in.GetKind(),
+		Namespace:  in.GetNamespace(),
+		Name:       in.GetName(),
+		FieldPath:  in.GetFieldPath(),
+	}
+}
+
+// convertRawExtensionToProto returns the JSON encoding of a RawExtension, like the JSON encoding used by the HTTPS transport.
+func convertRawExtensionToProto(in runtime.RawExtension) ([]byte, error) {
+	if in.Raw != nil || in.Object == nil {
+		return in.Raw, nil
+	}
+	return json.Marshal(in.Object)
+}
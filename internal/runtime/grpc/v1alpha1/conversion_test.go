@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	grpchooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/grpc/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+func TestIsSupported(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IsSupported(runtimecatalog.GroupVersionHook{Group: runtimehooksv1.GroupVersion.Group, Version: runtimehooksv1.GroupVersion.Version, Hook: "GeneratePatches"})).To(BeTrue())
+	g.Expect(IsSupported(runtimecatalog.GroupVersionHook{Group: runtimehooksv1.GroupVersion.Group, Version: runtimehooksv1.GroupVersion.Version, Hook: "ValidateTopology"})).To(BeTrue())
+	g.Expect(IsSupported(runtimecatalog.GroupVersionHook{Group: runtimehooksv1.GroupVersion.Group, Version: runtimehooksv1.GroupVersion.Version, Hook: "BeforeClusterCreate"})).To(BeFalse())
+	g.Expect(IsSupported(runtimecatalog.GroupVersionHook{Group: runtimehooksv1.GroupVersion.Group, Version: "v1alpha2", Hook: "GeneratePatches"})).To(BeFalse())
+}
+
+func TestConvertRequest(t *testing.T) {
+	variables := []runtimehooksv1.Variable{
+		{Name: "builtin", Value: apiextensionsv1.JSON{Raw: []byte(`{"cluster":{"name":"cluster1"}}`)}},
+		{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte(`3`)}},
+	}
+	holderReference := runtimehooksv1.HolderReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "MachineDeployment",
+		Namespace:  metav1.NamespaceDefault,
+		Name:       "md1",
+		FieldPath:  "spec.template.spec.infrastructureRef",
+	}
+	object := runtime.RawExtension{Raw: []byte(`{"apiVersion":"infrastructure.cluster.x-k8s.io/v1beta2","kind":"DockerMachineTemplate"}`)}
+
+	tests := []struct {
+		name  string
+		in    runtime.Object
+		empty runtime.Object
+	}{
+		{
+			name: "GeneratePatchesRequest",
+			in: &runtimehooksv1.GeneratePatchesRequest{
+				TypeMeta:      metav1.TypeMeta{APIVersion: runtimehooksv1.GroupVersion.String(), Kind: "GeneratePatchesRequest"},
+				CommonRequest: runtimehooksv1.CommonRequest{Settings: map[string]string{"key": "value"}},
+				Variables:     variables,
+				Items: []runtimehooksv1.GeneratePatchesRequestItem{
+					{UID: "uid1", HolderReference: holderReference, Object: object, Variables: variables[1:]},
+					{UID: "uid2", HolderReference: holderReference, Object: object},
+				},
+			},
+			empty: &runtimehooksv1.GeneratePatchesRequest{},
+		},
+		{
+			name: "ValidateTopologyRequest",
+			in: &runtimehooksv1.ValidateTopologyRequest{
+				TypeMeta:      metav1.TypeMeta{APIVersion: runtimehooksv1.GroupVersion.String(), Kind: "ValidateTopologyRequest"},
+				CommonRequest: runtimehooksv1.CommonRequest{Settings: map[string]string{"key": "value"}},
+				Variables:     variables,
+				Items: []*runtimehooksv1.ValidateTopologyRequestItem{
+					{HolderReference: holderReference, Object: object, Variables: variables[1:]},
+				},
+			},
+			empty: &runtimehooksv1.ValidateTopologyRequest{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			message, err := ConvertRequestToProto(tt.in)
+			g.Expect(err).ToNot(HaveOccurred())
+			data, err := proto.Marshal(message)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			received, err := NewRequest(tt.empty)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(proto.Unmarshal(data, received)).To(Succeed())
+
+			out := tt.empty.DeepCopyObject()
+			g.Expect(ConvertRequestFromProto(received, out)).To(Succeed())
+			g.Expect(out).To(Equal(tt.in))
+		})
+	}
+}
+
+func TestConvertResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    runtime.Object
+		empty runtime.Object
+	}{
+		{
+			name: "GeneratePatchesResponse",
+			in: &runtimehooksv1.GeneratePatchesResponse{
+				TypeMeta:       metav1.TypeMeta{APIVersion: runtimehooksv1.GroupVersion.String(), Kind: "GeneratePatchesResponse"},
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess, Message: "message"},
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{UID: "uid1", PatchType: runtimehooksv1.JSONPatchType, Patch: []byte(`[{"op":"add","path":"/spec/foo","value":"bar"}]`)},
+					{UID: "uid2", PatchType: runtimehooksv1.JSONMergePatchType, Patch: []byte(`{"spec":{"foo":"bar"}}`)},
+				},
+			},
+			empty: &runtimehooksv1.GeneratePatchesResponse{},
+		},
+		{
+			name: "ValidateTopologyResponse",
+			in: &runtimehooksv1.ValidateTopologyResponse{
+				TypeMeta:       metav1.TypeMeta{APIVersion: runtimehooksv1.GroupVersion.String(), Kind: "ValidateTopologyResponse"},
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "invalid topology"},
+			},
+			empty: &runtimehooksv1.ValidateTopologyResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			message, err := ConvertResponseToProto(tt.in)
+			g.Expect(err).ToNot(HaveOccurred())
+			data, err := proto.Marshal(message)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			received, err := NewResponse(tt.empty)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(proto.Unmarshal(data, received)).To(Succeed())
+
+			out := tt.empty.DeepCopyObject()
+			g.Expect(ConvertResponseFromProto(received, out)).To(Succeed())
+			g.Expect(out).To(Equal(tt.in))
+		})
+	}
+}
+
+func TestConvertRawExtensionToProto(t *testing.T) {
+	g := NewWithT(t)
+
+	object := &clusterv1.MachineDeployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "MachineDeployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "md1"},
+	}
+	data, err := convertRawExtensionToProto(runtime.RawExtension{Object: object})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"kind":"MachineDeployment"`))
+	g.Expect(string(data)).To(ContainSubstring(`"name":"md1"`))
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := ConvertRequestToProto(&runtimehooksv1.BeforeClusterCreateRequest{})
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewRequest(&runtimehooksv1.BeforeClusterCreateRequest{})
+	g.Expect(err).To(HaveOccurred())
+	_, err = ConvertResponseToProto(&runtimehooksv1.BeforeClusterCreateResponse{})
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewResponse(&runtimehooksv1.BeforeClusterCreateResponse{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ConvertResponseFromProto(&grpchooksv1.ValidateTopologyResponse{}, &runtimehooksv1.GeneratePatchesResponse{})).ToNot(Succeed())
+}
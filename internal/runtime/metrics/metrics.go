@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	grpcstatus "google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -49,7 +50,7 @@ var (
 		prometheus.NewCounterVec(prometheus.CounterOpts{
 			Subsystem: runtimeSDKSubsystem,
 			Name:      "requests_total",
-			Help:      "Number of HTTP requests, partitioned by status code, host, hook and response status.",
+			Help:      "Number of HTTP and gRPC requests, partitioned by status code, host, hook and response status.",
 		}, []string{"code", "host", "group", "version", "hook", "status"}),
 	}
 	// RequestDuration reports the request latency in seconds.
@@ -91,6 +92,19 @@ func (m *requestsTotalObserver) Observe(req *http.Request, resp *http.Response,
 	m.metric.WithLabelValues(code, host, gvh.Group, gvh.Version, gvh.Hook, status).Inc()
 }
 
+// ObserveGRPC observes a gRPC request result and increments the metric for the given
+// gRPC status code, host, gvh and response.
+func (m *requestsTotalObserver) ObserveGRPC(u url.URL, gvh runtimecatalog.GroupVersionHook, err error, response runtime.Object) {
+	code := grpcstatus.Code(err).String()
+
+	status := unknownResponseStatus
+	if responseObject, ok := response.(runtimehooksv1.ResponseObject); ok && responseObject.GetStatus() != "" {
+		status = string(responseObject.GetStatus())
+	}
+
+	m.metric.WithLabelValues(code, u.Host, gvh.Group, gvh.Version, gvh.Hook, status).Inc()
+}
+
 type requestDurationObserver struct {
 	metric *prometheus.HistogramVec
 }
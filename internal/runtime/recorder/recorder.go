@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recorder implements a recorder for calls to Runtime Extension handlers.
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// DefaultMaxRecords is the default maximum number of records kept by a Recorder.
+	DefaultMaxRecords = 100
+
+	// DefaultFlushInterval is the default interval at which a Recorder writes records to its Sink.
+	DefaultFlushInterval = 10 * time.Second
+)
+
+// Record is a recorded call to an extension handler.
+type Record struct {
+	// ID identifies the record; IDs are increasing over time.
+	ID int64 `json:"id"`
+
+	// Time is the time when the extension handler has been called.
+	Time metav1.Time `json:"time"`
+
+	// Duration is the duration of the call.
+	Duration metav1.Duration `json:"duration"`
+
+	// ExtensionConfig is the name of the ExtensionConfig (or of the in-process extension)
+	// which defines the extension handler.
+	ExtensionConfig string `json:"extensionConfig"`
+
+	// ExtensionHandler is the name of the extension handler, e.g. "my-handler.my-extension".
+	ExtensionHandler string `json:"extensionHandler"`
+
+	// APIVersion is the apiVersion of the hook used to encode Request and Response.
+	APIVersion string `json:"apiVersion"`
+
+	// Hook is the name of the hook, e.g. "GeneratePatches".
+	Hook string `json:"hook"`
+
+	// HandlerAPIVersion is the apiVersion of the hook the extension handler has been registered for.
+	// If it is different from APIVersion, the request was converted to HandlerAPIVersion before calling
+	// the extension handler.
+	HandlerAPIVersion string `json:"handlerAPIVersion"`
+
+	// Transport is the transport used to call the extension handler.
+	Transport string `json:"transport,omitempty"`
+
+	// Request is the request sent to the extension handler.
+	Request json.RawMessage `json:"request"`
+
+	// Response is the response received from the extension handler.
+	// Response is not set if the extension handler could not be called.
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Error is the error returned by the call, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Options are creation options for a Recorder.
+type Options struct {
+	// Sink is the Sink records are written to.
+	Sink Sink
+
+	// MaxRecords is the maximum number of records kept by the Recorder; when the maximum
+	// is reached the oldest records are dropped.
+	// Defaults to DefaultMaxRecords.
+	MaxRecords int
+
+	// FlushInterval is the interval at which the Recorder writes records to the Sink.
+	// Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+}
+
+// Recorder keeps a bounded list of calls to extension handlers and periodically writes them to a Sink.
+type Recorder struct {
+	sink          Sink
+	maxRecords    int
+	flushInterval time.Duration
+
+	lock    sync.Mutex
+	records []Record
+	nextID  int64
+	dirty   bool
+}
+
+// New creates a new Recorder.
+func New(options Options) (*Recorder, error) {
+	if options.Sink == nil {
+		return nil, errors.New("failed to create recorder: sink must be set")
+	}
+	if options.MaxRecords < 0 {
+		return nil, errors.New("failed to create recorder: maxRecords must be greater or equal to 0")
+	}
+	if options.MaxRecords == 0 {
+		options.MaxRecords = DefaultMaxRecords
+	}
+	if options.FlushInterval == 0 {
+		options.FlushInterval = DefaultFlushInterval
+	}
+
+	return &Recorder{
+		sink:          options.Sink,
+		maxRecords:    options.MaxRecords,
+		flushInterval: options.FlushInterval,
+		nextID:        1,
+	}, nil
+}
+
+// Record adds a record to the Recorder, dropping the oldest record if the Recorder is full.
+// The ID of the record is set by the Recorder.
+func (r *Recorder) Record(record Record) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	record.ID = r.nextID
+	r.nextID++
+	r.add(record)
+	r.dirty = true
+}
+
+func (r *Recorder) add(records ...Record) {
+	r.records = append(r.records, records...)
+	if len(r.records) > r.maxRecords {
+		r.records = append([]Record{}, r.records[len(r.records)-r.maxRecords:]...)
+	}
+}
+
+// Records returns a copy of the records kept by the Recorder, from the oldest to the newest.
+func (r *Recorder) Records() []Record {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]Record{}, r.records...)
+}
+
+// Flush writes the records to the Sink, if there are records which have not been written yet.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.lock.Lock()
+	if !r.dirty {
+		r.lock.Unlock()
+		return nil
+	}
+	records := append([]Record{}, r.records...)
+	r.dirty = false
+	r.lock.Unlock()
+
+	if err := r.sink.Write(ctx, records); err != nil {
+		r.lock.Lock()
+		r.dirty = true
+		r.lock.Unlock()
+		return errors.Wrap(err, "failed to write records")
+	}
+	return nil
+}
+
+// Start loads the records from the Sink, so records survive restarts, and then writes
+// records to the Sink every FlushInterval until the context is done.
+// Start implements manager.Runnable.
+func (r *Recorder) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("runtime-extension-recorder")
+
+	existing, err := r.sink.Read(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read records")
+	}
+	r.lock.Lock()
+	// Existing records are older than records recorded before Start, so they are added first
+	// and IDs of the latter are shifted to preserve ordering.
+	var maxID int64
+	for _, record := range existing {
+		if record.ID > maxID {
+			maxID = record.ID
+		}
+	}
+	recorded := r.records
+	r.records = nil
+	r.add(existing...)
+	for i := range recorded {
+		recorded[i].ID += maxID
+	}
+	r.add(recorded...)
+	r.nextID += maxID
+	r.lock.Unlock()
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Use a new context to write the records not written yet, given that ctx is already done.
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := r.Flush(flushCtx); err != nil {
+				log.Error(err, "Failed to write Runtime Extension call records")
+			}
+			return nil
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				log.Error(err, "Failed to write Runtime Extension call records")
+			}
+		}
+	}
+}
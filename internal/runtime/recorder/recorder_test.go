@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecorder(t *testing.T) {
+	g := NewWithT(t)
+
+	sink := NewFileSink(filepath.Join(t.TempDir(), "records.json"))
+	r, err := New(Options{Sink: sink, MaxRecords: 3})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		r.Record(Record{ExtensionHandler: name})
+	}
+
+	// Only the newest MaxRecords records are kept.
+	g.Expect(recordIDs(r.Records())).To(Equal([]int64{2, 3, 4}))
+	g.Expect(r.Records()[0].ExtensionHandler).To(Equal("b"))
+
+	// Records are written to the sink on Flush.
+	g.Expect(r.Flush(context.Background())).To(Succeed())
+	records, err := sink.Read(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recordIDs(records)).To(Equal([]int64{2, 3, 4}))
+
+	// A new Recorder using the same sink continues from the existing records.
+	r, err = New(Options{Sink: sink, MaxRecords: 3, FlushInterval: time.Hour})
+	g.Expect(err).ToNot(HaveOccurred())
+	r.Record(Record{ExtensionHandler: "e"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(r.Start(ctx)).To(Succeed())
+
+	g.Expect(recordIDs(r.Records())).To(Equal([]int64{3, 4, 5}))
+	g.Expect(r.Records()[2].ExtensionHandler).To(Equal("e"))
+	r.Record(Record{ExtensionHandler: "f"})
+	g.Expect(recordIDs(r.Records())).To(Equal([]int64{4, 5, 6}))
+
+	// Records not written yet are written when the Recorder is stopped.
+	records, err = sink.Read(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recordIDs(records)).To(Equal([]int64{3, 4, 5}))
+}
+
+func TestNew(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := New(Options{})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = New(Options{Sink: NewFileSink("records.json"), MaxRecords: -1})
+	g.Expect(err).To(HaveOccurred())
+
+	r, err := New(Options{Sink: NewFileSink("records.json")})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.maxRecords).To(Equal(DefaultMaxRecords))
+	g.Expect(r.flushInterval).To(Equal(DefaultFlushInterval))
+}
+
+func TestFileSink(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	sink := NewFileSink(filepath.Join(t.TempDir(), "records.json"))
+
+	// Reading a file which does not exist returns no records.
+	records, err := sink.Read(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(records).To(BeEmpty())
+
+	want := []Record{
+		{
+			ID:               1,
+			Time:             metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)),
+			ExtensionConfig:  "my-extension",
+			ExtensionHandler: "my-handler.my-extension",
+			APIVersion:       "hooks.runtime.cluster.x-k8s.io/v1alpha1",
+			Hook:             "BeforeClusterCreate",
+			Request:          json.RawMessage(`{"settings":{"key":"value"}}`),
+			Response:         json.RawMessage(`{"status":"Success"}`),
+		},
+	}
+	g.Expect(sink.Write(ctx, want)).To(Succeed())
+	records, err = sink.Read(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(records).To(Equal(want))
+}
+
+func TestConfigMapSink(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	sink := NewConfigMapSink(c, "capi-system", "runtime-extension-records")
+
+	// Reading a ConfigMap which does not exist returns no records.
+	records, err := sink.Read(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(records).To(BeEmpty())
+
+	// Write creates the ConfigMap.
+	want := []Record{{ID: 1, Hook: "BeforeClusterCreate", Request: json.RawMessage(`{}`)}}
+	g.Expect(sink.Write(ctx, want)).To(Succeed())
+	records, err = sink.Read(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(records).To(Equal(want))
+
+	// Write updates the ConfigMap, dropping the oldest records if they do not fit into the ConfigMap.
+	request := json.RawMessage(`"` + strings.Repeat("a", 250*1024) + `"`)
+	want = []Record{
+		{ID: 1, Request: request},
+		{ID: 2, Request: request},
+		{ID: 3, Request: request},
+		{ID: 4, Request: request},
+	}
+	g.Expect(sink.Write(ctx, want)).To(Succeed())
+	records, err = sink.Read(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recordIDs(records)).To(Equal([]int64{2, 3, 4}))
+
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "capi-system", Name: "runtime-extension-records"}, configMap)).To(Succeed())
+	g.Expect(len(configMap.Data[ConfigMapDataKey])).To(BeNumerically("<=", configMapMaxDataSize))
+}
+
+func recordIDs(records []Record) []int64 {
+	ids := []int64{}
+	for _, r := range records {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
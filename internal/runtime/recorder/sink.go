@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapDataKey is the key of the ConfigMap data used to store records.
+	ConfigMapDataKey = "records.json"
+
+	// configMapMaxDataSize is the maximum size of the records stored in a ConfigMap.
+	// Note: ConfigMaps are limited to 1 MiB, some space is left for the other fields of the ConfigMap.
+	configMapMaxDataSize = 900 * 1024
+)
+
+// Sink stores records.
+type Sink interface {
+	// Write stores records, replacing the records previously stored.
+	Write(ctx context.Context, records []Record) error
+
+	// Read returns the stored records.
+	Read(ctx context.Context) ([]Record, error)
+}
+
+// Encode encodes records as JSON.
+func Encode(records []Record) ([]byte, error) {
+	if records == nil {
+		records = []Record{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode records")
+	}
+	return data, nil
+}
+
+// Decode decodes records from JSON.
+func Decode(data []byte) ([]Record, error) {
+	records := []Record{}
+	if len(data) == 0 {
+		return records, nil
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrap(err, "failed to decode records")
+	}
+	return records, nil
+}
+
+// NewFileSink returns a Sink which stores records in a file.
+func NewFileSink(path string) Sink {
+	return &fileSink{path: path}
+}
+
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) Write(_ context.Context, records []Record) error {
+	data, err := Encode(records)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it, so readers never get a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to write records to %s", s.path)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // the file does not exist anymore after a successful rename.
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write records to %s", s.path)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write records to %s", s.path)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrapf(err, "failed to write records to %s", s.path)
+	}
+	return nil
+}
+
+func (s *fileSink) Read(_ context.Context) ([]Record, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Record{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read records from %s", s.path)
+	}
+	return Decode(data)
+}
+
+// NewConfigMapSink returns a Sink which stores records in a ConfigMap.
+// Given that the size of a ConfigMap is limited, the oldest records are dropped if required.
+func NewConfigMapSink(c client.Client, namespace, name string) Sink {
+	return &configMapSink{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+type configMapSink struct {
+	client    client.Client
+	namespace string
+	name      string
+}
+
+func (s *configMapSink) Write(ctx context.Context, records []Record) error {
+	var data []byte
+	for {
+		var err error
+		data, err = Encode(records)
+		if err != nil {
+			return err
+		}
+		if len(data) <= configMapMaxDataSize {
+			break
+		}
+		if len(records) == 0 {
+			return errors.Errorf("failed to write records to ConfigMap %s/%s: records are too big", s.namespace, s.name)
+		}
+		records = records[1:]
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to write records to ConfigMap %s/%s", s.namespace, s.name)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      s.name,
+			},
+			Data: map[string]string{
+				ConfigMapDataKey: string(data),
+			},
+		}
+		if err := s.client.Create(ctx, configMap); err != nil {
+			return errors.Wrapf(err, "failed to write records to ConfigMap %s/%s", s.namespace, s.name)
+		}
+		return nil
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[ConfigMapDataKey] = string(data)
+	if err := s.client.Update(ctx, configMap); err != nil {
+		return errors.Wrapf(err, "failed to write records to ConfigMap %s/%s", s.namespace, s.name)
+	}
+	return nil
+}
+
+func (s *configMapSink) Read(ctx context.Context) ([]Record, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Record{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read records from ConfigMap %s/%s", s.namespace, s.name)
+	}
+	return Decode([]byte(configMap.Data[ConfigMapDataKey]))
+}
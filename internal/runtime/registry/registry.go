@@ -76,6 +76,9 @@ type ExtensionRegistration struct {
 	// ClientConfig is the ClientConfig to communicate with the RuntimeExtension.
 	ClientConfig runtimev1.ClientConfig
 
+	// Transport is the transport used to call the RuntimeExtension, as negotiated during discovery.
+	Transport runtimev1.Transport
+
 	// TimeoutSeconds is the timeout duration used for calls to the RuntimeExtension.
 	TimeoutSeconds int32
 
@@ -260,6 +263,7 @@ func (r *extensionRegistry) add(extensionConfig *runtimev1.ExtensionConfig) erro
 			},
 			NamespaceSelector: selector,
 			ClientConfig:      extensionConfig.Spec.ClientConfig,
+			Transport:         extensionConfig.Status.Transport,
 			TimeoutSeconds:    e.TimeoutSeconds,
 			FailurePolicy:     e.FailurePolicy,
 			Settings:          extensionConfig.Spec.Settings,
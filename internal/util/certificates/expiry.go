@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certificates implements helper functions to inventory the expiry dates of the certificates of a Cluster.
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	clog "sigs.k8s.io/cluster-api/util/log"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// ExpiringThreshold is how long before the expiry date a certificate is considered expiring.
+const ExpiringThreshold = 30 * 24 * time.Hour
+
+// Expiry is the expiry date of a certificate of a Cluster.
+type Expiry struct {
+	// Source describes where the certificate is stored, e.g. "Secret foo-ca".
+	Source string
+
+	// ExpiryDate is the expiry date of the certificate.
+	ExpiryDate time.Time
+}
+
+// GetExpiries returns the expiry dates of the certificates of a Cluster, i.e. of the certificates stored in
+// the cluster certificate Secrets, of the client certificates in the kubeconfig Secret, and of the Machine certificates
+// reported in Machine's status.certificatesExpiryDate.
+// Secrets with certificates that cannot be parsed do not fail GetExpiries; they are instead returned in the list of
+// invalid sources, so they can be surfaced to the users without blocking the reconcile.
+// Note: Secrets are read with the secretCachingClient first, falling back to the regular client if they are not found.
+func GetExpiries(ctx context.Context, secretCachingClient, c client.Client, cluster client.ObjectKey, machines collections.Machines) ([]Expiry, []string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	expiries := []Expiry{}
+	invalidSources := []string{}
+
+	// Note: The service account key pair is not included, because it doesn't have an expiry date.
+	purposes := []secret.Purpose{secret.ClusterCA, secret.EtcdCA, secret.FrontProxyCA, secret.APIServerEtcdClient}
+	for _, purpose := range purposes {
+		certificateSecret, err := getSecret(ctx, secretCachingClient, c, client.ObjectKey{Namespace: cluster.Namespace, Name: secret.Name(cluster.Name, purpose)})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "failed to get %s certificate Secret", purpose)
+		}
+		source := fmt.Sprintf("Secret %s", certificateSecret.Name)
+		certs, err := certutil.ParseCertsPEM(certificateSecret.Data[secret.TLSCrtDataName])
+		if err != nil {
+			log.Error(err, "Failed to parse certificates", "Secret", klog.KObj(certificateSecret))
+			invalidSources = append(invalidSources, source)
+			continue
+		}
+		// Note: While certificate authorities are rotated, the Secret contains a bundle of certificates.
+		for _, cert := range certs {
+			expiries = append(expiries, Expiry{Source: source, ExpiryDate: cert.NotAfter})
+		}
+	}
+
+	configSecret, err := getSecret(ctx, secretCachingClient, c, client.ObjectKey{Namespace: cluster.Namespace, Name: secret.Name(cluster.Name, secret.Kubeconfig)})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, errors.Wrap(err, "failed to get kubeconfig Secret")
+	}
+	if err == nil {
+		source := fmt.Sprintf("Secret %s", configSecret.Name)
+		expiryDate, err := kubeconfig.ClientCertificatesExpiryDate(configSecret)
+		switch {
+		case err != nil:
+			log.Error(err, "Failed to get client certificates expiry date", "Secret", klog.KObj(configSecret))
+			invalidSources = append(invalidSources, source)
+		case expiryDate != nil:
+			expiries = append(expiries, Expiry{Source: source, ExpiryDate: *expiryDate})
+		}
+	}
+
+	for _, machine := range machines {
+		if machine.Status.CertificatesExpiryDate.IsZero() {
+			continue
+		}
+		expiries = append(expiries, Expiry{Source: fmt.Sprintf("Machine %s", machine.Name), ExpiryDate: machine.Status.CertificatesExpiryDate.Time})
+	}
+
+	sort.SliceStable(expiries, func(i, j int) bool {
+		return expiries[i].ExpiryDate.Before(expiries[j].ExpiryDate)
+	})
+	return expiries, invalidSources, nil
+}
+
+// EarliestExpiryDate returns the earliest expiry date from a list of expiries sorted by GetExpiries;
+// a zero value is returned if the list is empty.
+func EarliestExpiryDate(expiries []Expiry) metav1.Time {
+	if len(expiries) == 0 {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(expiries[0].ExpiryDate)
+}
+
+// ExpiringCondition returns a CertificatesExpiring condition from a list of expiries sorted by GetExpiries
+// and from the list of sources with certificates that cannot be parsed.
+func ExpiringCondition(expiries []Expiry, invalidSources []string, now time.Time) metav1.Condition {
+	invalidMessage := ""
+	if len(invalidSources) > 0 {
+		invalidMessage = fmt.Sprintf("Certificates in %s cannot be parsed", clog.StringListToString(invalidSources))
+	}
+
+	if len(expiries) == 0 {
+		message := "No certificates found"
+		if invalidMessage != "" {
+			message = invalidMessage
+		}
+		return metav1.Condition{
+			Type:    clusterv1.CertificatesExpiringCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.CertificatesExpiryUnknownReason,
+			Message: message,
+		}
+	}
+
+	expired := false
+	expiring := []Expiry{}
+	for _, expiry := range expiries {
+		if !expiry.ExpiryDate.Before(now.Add(ExpiringThreshold)) {
+			break
+		}
+		if expiry.ExpiryDate.Before(now) {
+			expired = true
+		}
+		expiring = append(expiring, expiry)
+	}
+
+	if len(expiring) == 0 {
+		// If some certificates cannot be parsed, it is not possible to tell if they are expiring.
+		if invalidMessage != "" {
+			return metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.CertificatesExpiryUnknownReason,
+				Message: invalidMessage,
+			}
+		}
+		return metav1.Condition{
+			Type:   clusterv1.CertificatesExpiringCondition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.CertificatesNotExpiringReason,
+		}
+	}
+
+	reason := clusterv1.CertificatesExpiringReason
+	if expired {
+		reason = clusterv1.CertificatesExpiredReason
+	}
+	message := clog.ListToString(expiring, func(expiry Expiry) string {
+		if expiry.ExpiryDate.Before(now) {
+			return fmt.Sprintf("%s expired at %s", expiry.Source, expiry.ExpiryDate.UTC().Format(time.RFC3339))
+		}
+		return fmt.Sprintf("%s expires at %s", expiry.Source, expiry.ExpiryDate.UTC().Format(time.RFC3339))
+	}, 3)
+	if invalidMessage != "" {
+		message += "; " + invalidMessage
+	}
+	return metav1.Condition{
+		Type:    clusterv1.CertificatesExpiringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func getSecret(ctx context.Context, secretCachingClient, c client.Client, key client.ObjectKey) (*corev1.Secret, error) {
+	s := &corev1.Secret{}
+	if secretCachingClient != nil {
+		err := secretCachingClient.Get(ctx, key, s)
+		if err == nil {
+			return s, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if err := c.Get(ctx, key, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
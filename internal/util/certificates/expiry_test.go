@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+var ctx = ctrl.SetupSignalHandler()
+
+func TestGetExpiries(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cluster := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}
+
+	// No certificates.
+	expiries, invalidSources, err := GetExpiries(ctx, nil, c, cluster, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(expiries).To(BeEmpty())
+	g.Expect(invalidSources).To(BeEmpty())
+
+	// Certificates, kubeconfig and Machines.
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.Generate()).To(Succeed())
+	g.Expect(certificates.SaveGenerated(ctx, c, cluster, metav1.OwnerReference{})).To(Succeed())
+	g.Expect(kubeconfig.CreateSecretWithOwner(ctx, c, cluster, "1.2.3.4:6443", metav1.OwnerReference{})).To(Succeed())
+
+	machineExpiryDate := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	machines := collections.FromMachines(
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "m1"},
+			Status:     clusterv1.MachineStatus{CertificatesExpiryDate: metav1.NewTime(machineExpiryDate)},
+		},
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "m2"},
+		},
+	)
+
+	expiries, invalidSources, err = GetExpiries(ctx, nil, c, cluster, machines)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(invalidSources).To(BeEmpty())
+	sources := []string{}
+	for _, expiry := range expiries {
+		sources = append(sources, expiry.Source)
+	}
+	g.Expect(sources).To(HaveLen(5))
+	g.Expect(sources[0]).To(Equal("Machine m1"))
+	g.Expect(sources).To(ContainElements("Secret foo-ca", "Secret foo-etcd", "Secret foo-proxy", "Secret foo-kubeconfig"))
+	g.Expect(EarliestExpiryDate(expiries)).To(Equal(metav1.NewTime(machineExpiryDate)))
+
+	// Malformed certificate Secrets are reported as invalid sources.
+	etcdSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: "foo-etcd"}, etcdSecret)).To(Succeed())
+	etcdSecret.Data[secret.TLSCrtDataName] = []byte("not a certificate")
+	g.Expect(c.Update(ctx, etcdSecret)).To(Succeed())
+	proxySecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: "foo-proxy"}, proxySecret)).To(Succeed())
+	delete(proxySecret.Data, secret.TLSCrtDataName)
+	g.Expect(c.Update(ctx, proxySecret)).To(Succeed())
+
+	expiries, invalidSources, err = GetExpiries(ctx, nil, c, cluster, machines)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(invalidSources).To(ConsistOf("Secret foo-etcd", "Secret foo-proxy"))
+	sources = []string{}
+	for _, expiry := range expiries {
+		sources = append(sources, expiry.Source)
+	}
+	g.Expect(sources).To(ConsistOf("Machine m1", "Secret foo-ca", "Secret foo-kubeconfig"))
+}
+
+func TestExpiringCondition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		expiries          []Expiry
+		invalidSources    []string
+		expectedCondition metav1.Condition
+	}{
+		{
+			name: "no certificates",
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.CertificatesExpiryUnknownReason,
+				Message: "No certificates found",
+			},
+		},
+		{
+			name:           "no valid certificates",
+			invalidSources: []string{"Secret foo-ca"},
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.CertificatesExpiryUnknownReason,
+				Message: "Certificates in Secret foo-ca cannot be parsed",
+			},
+		},
+		{
+			name: "certificates not expiring",
+			expiries: []Expiry{
+				{Source: "Secret foo-ca", ExpiryDate: now.Add(365 * 24 * time.Hour)},
+			},
+			expectedCondition: metav1.Condition{
+				Type:   clusterv1.CertificatesExpiringCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.CertificatesNotExpiringReason,
+			},
+		},
+		{
+			name: "certificates expiring",
+			expiries: []Expiry{
+				{Source: "Machine m1", ExpiryDate: now.Add(24 * time.Hour)},
+				{Source: "Secret foo-kubeconfig", ExpiryDate: now.Add(48 * time.Hour)},
+				{Source: "Secret foo-ca", ExpiryDate: now.Add(365 * 24 * time.Hour)},
+			},
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.CertificatesExpiringReason,
+				Message: "Machine m1 expires at 2026-01-02T00:00:00Z, Secret foo-kubeconfig expires at 2026-01-03T00:00:00Z",
+			},
+		},
+		{
+			name: "certificates expired",
+			expiries: []Expiry{
+				{Source: "Secret foo-ca", ExpiryDate: now.Add(-24 * time.Hour)},
+				{Source: "Machine m1", ExpiryDate: now.Add(24 * time.Hour)},
+			},
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.CertificatesExpiredReason,
+				Message: "Secret foo-ca expired at 2025-12-31T00:00:00Z, Machine m1 expires at 2026-01-02T00:00:00Z",
+			},
+		},
+		{
+			name: "certificates not expiring and invalid certificates",
+			expiries: []Expiry{
+				{Source: "Secret foo-ca", ExpiryDate: now.Add(365 * 24 * time.Hour)},
+			},
+			invalidSources: []string{"Secret foo-etcd", "Secret foo-kubeconfig"},
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.CertificatesExpiryUnknownReason,
+				Message: "Certificates in Secret foo-etcd, Secret foo-kubeconfig cannot be parsed",
+			},
+		},
+		{
+			name: "certificates expiring and invalid certificates",
+			expiries: []Expiry{
+				{Source: "Machine m1", ExpiryDate: now.Add(24 * time.Hour)},
+			},
+			invalidSources: []string{"Secret foo-etcd"},
+			expectedCondition: metav1.Condition{
+				Type:    clusterv1.CertificatesExpiringCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.CertificatesExpiringReason,
+				Message: "Machine m1 expires at 2026-01-02T00:00:00Z; Certificates in Secret foo-etcd cannot be parsed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(ExpiringCondition(tt.expiries, tt.invalidSources, now)).To(Equal(tt.expectedCondition))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+// ObjectTreeNode is a machine-readable representation of an object in an ObjectTree, e.g. to be printed in JSON or YAML.
+type ObjectTreeNode struct {
+	// APIVersion of the object; empty for virtual objects.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the object, e.g. Machine, or MachineGroup for a group of Machines.
+	Kind string `json:"kind"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object; empty for group objects.
+	Name string `json:"name,omitempty"`
+
+	// MetaName used for the object in the presentation layer, e.g. ControlPlane, Workers.
+	MetaName string `json:"metaName,omitempty"`
+
+	// Virtual is true if the object does not correspond to any real object, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// Deleting is true if the object is being deleted.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// GroupItems is the list of names for the objects included in a group object.
+	GroupItems []string `json:"groupItems,omitempty"`
+
+	// Replicas is the current number of replicas, e.g. Machines, for the object.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// DesiredReplicas is the desired number of replicas for the object.
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+
+	// AvailableReplicas is the number of available replicas for the object.
+	AvailableReplicas *int32 `json:"availableReplicas,omitempty"`
+
+	// ReadyReplicas is the number of ready replicas for the object.
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+
+	// UpToDateReplicas is the number of up-to-date replicas for the object.
+	UpToDateReplicas *int32 `json:"upToDateReplicas,omitempty"`
+
+	// Available is the Available condition of the object, if any.
+	Available *metav1.Condition `json:"available,omitempty"`
+
+	// Ready is the Ready condition of the object, if any.
+	Ready *metav1.Condition `json:"ready,omitempty"`
+
+	// UpToDate is the UpToDate condition of the object, if any.
+	UpToDate *metav1.Condition `json:"upToDate,omitempty"`
+
+	// Conditions is the list of all the conditions of the object.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Children of the object in the ObjectTree, in the same order they are printed in the tree view.
+	Children []*ObjectTreeNode `json:"children,omitempty"`
+}
+
+// ConvertObjectTree returns the machine-readable representation of an ObjectTree.
+// Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
+func ConvertObjectTree(objectTree *tree.ObjectTree) *ObjectTreeNode {
+	return convertObject(objectTree, objectTree.GetRoot())
+}
+
+func convertObject(objectTree *tree.ObjectTree, obj ctrlclient.Object) *ObjectTreeNode {
+	node := &ObjectTreeNode{
+		APIVersion: obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+		Kind:       obj.GetObjectKind().GroupVersionKind().Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		MetaName:   tree.GetMetaName(obj),
+		Virtual:    tree.IsVirtualObject(obj),
+		Deleting:   !obj.GetDeletionTimestamp().IsZero(),
+		Available:  tree.GetAvailableCondition(obj),
+		Ready:      tree.GetReadyCondition(obj),
+		UpToDate:   tree.GetMachineUpToDateCondition(obj),
+		Conditions: tree.GetConditions(obj),
+	}
+
+	if node.Virtual {
+		node.APIVersion = ""
+	}
+
+	// NB. Group objects get a random name to avoid conflicts, so the name is replaced by the list of items in the group.
+	if tree.IsGroupObject(obj) {
+		node.Name = ""
+		node.GroupItems = strings.Split(tree.GetGroupItems(obj), tree.GroupItemsSeparator)
+	}
+
+	counters := newReplicaCounters(obj)
+	node.Replicas = counters.replicas
+	node.DesiredReplicas = counters.desiredReplicas
+	node.AvailableReplicas = counters.availableReplicas
+	node.ReadyReplicas = counters.readyReplicas
+	node.UpToDateReplicas = counters.upToDateReplicas
+
+	for _, child := range orderChildrenObjects(objectTree.GetObjectsByParent(obj.GetUID())) {
+		node.Children = append(node.Children, convertObject(objectTree, child))
+	}
+	return node
+}
+
+// PrintObjectTreeJSON prints the machine-readable representation of an ObjectTree in JSON format.
+// Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
+func PrintObjectTreeJSON(tree *tree.ObjectTree, w io.Writer) error {
+	b, err := json.MarshalIndent(ConvertObjectTree(tree), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal object tree to JSON")
+	}
+	if _, err := w.Write(append(b, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write object tree")
+	}
+	return nil
+}
+
+// PrintObjectTreeYAML prints the machine-readable representation of an ObjectTree in YAML format.
+// Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
+func PrintObjectTreeYAML(tree *tree.ObjectTree, w io.Writer) error {
+	b, err := yaml.Marshal(ConvertObjectTree(tree))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal object tree to YAML")
+	}
+	if _, err := w.Write(b); err != nil {
+		return errors.Wrap(err, "failed to write object tree")
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+func newTestObjectTree() *tree.ObjectTree {
+	cluster := &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster1", UID: "cluster1"},
+		Status: clusterv1.ClusterStatus{
+			Workers: &clusterv1.WorkersStatus{
+				DesiredReplicas:   ptr.To[int32](3),
+				Replicas:          ptr.To[int32](2),
+				AvailableReplicas: ptr.To[int32](2),
+				ReadyReplicas:     ptr.To[int32](2),
+				UpToDateReplicas:  ptr.To[int32](1),
+			},
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.AvailableCondition, Status: metav1.ConditionFalse, Reason: "NotAvailable"},
+				{Type: clusterv1.PausedCondition, Status: metav1.ConditionFalse, Reason: "NotPaused"},
+			},
+		},
+	}
+	objectTree := tree.NewObjectTree(cluster, tree.ObjectTreeOptions{Grouping: true})
+
+	workers := tree.VirtualObject("ns", "WorkerGroup", "Workers")
+	objectTree.Add(cluster, workers, tree.GroupingObject(true))
+
+	for _, name := range []string{"m2", "m1"} {
+		machine := &clusterv1.Machine{
+			TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)},
+			Status: clusterv1.MachineStatus{
+				Conditions: []metav1.Condition{
+					{Type: clusterv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: "Ready"},
+				},
+			},
+		}
+		objectTree.Add(workers, machine)
+	}
+	return objectTree
+}
+
+func Test_ConvertObjectTree(t *testing.T) {
+	g := NewWithT(t)
+
+	got := ConvertObjectTree(newTestObjectTree())
+
+	// The root object surfaces the replica counters and the conditions of the Cluster.
+	g.Expect(got.APIVersion).To(Equal(clusterv1.GroupVersion.String()))
+	g.Expect(got.Kind).To(Equal("Cluster"))
+	g.Expect(got.Namespace).To(Equal("ns"))
+	g.Expect(got.Name).To(Equal("cluster1"))
+	g.Expect(got.Virtual).To(BeFalse())
+	g.Expect(got.Replicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(got.DesiredReplicas).To(Equal(ptr.To[int32](3)))
+	g.Expect(got.AvailableReplicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(got.ReadyReplicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(got.UpToDateReplicas).To(Equal(ptr.To[int32](1)))
+	g.Expect(got.Available).ToNot(BeNil())
+	g.Expect(got.Available.Reason).To(Equal("NotAvailable"))
+	g.Expect(got.Ready).To(BeNil())
+	g.Expect(got.Conditions).To(HaveLen(2))
+
+	// The virtual Workers object.
+	g.Expect(got.Children).To(HaveLen(1))
+	workers := got.Children[0]
+	g.Expect(workers.Kind).To(Equal("WorkerGroup"))
+	g.Expect(workers.Name).To(Equal("Workers"))
+	g.Expect(workers.APIVersion).To(BeEmpty())
+	g.Expect(workers.Virtual).To(BeTrue())
+
+	// The Machines with the same Ready condition are grouped.
+	g.Expect(workers.Children).To(HaveLen(1))
+	group := workers.Children[0]
+	g.Expect(group.Kind).To(Equal("MachineGroup"))
+	g.Expect(group.Name).To(BeEmpty())
+	g.Expect(group.GroupItems).To(Equal([]string{"m1", "m2"}))
+	g.Expect(group.Replicas).To(BeNil())
+	g.Expect(group.ReadyReplicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(group.AvailableReplicas).To(Equal(ptr.To[int32](0)))
+	g.Expect(group.Ready).ToNot(BeNil())
+	g.Expect(group.Ready.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(group.Children).To(BeEmpty())
+}
+
+func Test_PrintObjectTreeJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	var out bytes.Buffer
+	g.Expect(PrintObjectTreeJSON(newTestObjectTree(), &out)).To(Succeed())
+
+	got := &ObjectTreeNode{}
+	g.Expect(json.Unmarshal(out.Bytes(), got)).To(Succeed())
+	g.Expect(got.Name).To(Equal("cluster1"))
+	g.Expect(got.Children).To(HaveLen(1))
+	g.Expect(got.Children[0].Children[0].GroupItems).To(Equal([]string{"m1", "m2"}))
+}
+
+func Test_PrintObjectTreeYAML(t *testing.T) {
+	g := NewWithT(t)
+
+	var out bytes.Buffer
+	g.Expect(PrintObjectTreeYAML(newTestObjectTree(), &out)).To(Succeed())
+
+	got := &ObjectTreeNode{}
+	g.Expect(yaml.Unmarshal(out.Bytes(), got)).To(Succeed())
+	g.Expect(got.Name).To(Equal("cluster1"))
+	g.Expect(got.Children).To(HaveLen(1))
+	g.Expect(got.Children[0].Children[0].GroupItems).To(Equal([]string{"m1", "m2"}))
+}
@@ -606,14 +606,22 @@ type rowDescriptor struct {
 	message           string
 }
 
-// newRowDescriptor returns a v1beta2ConditionDescriptor for the given condition.
+// replicaCounters contains the replica counters for an object.
+type replicaCounters struct {
+	replicas          *int32
+	desiredReplicas   *int32
+	availableReplicas *int32
+	readyReplicas     *int32
+	upToDateReplicas  *int32
+}
+
+// newReplicaCounters returns the replica counters for the given object.
 // Note: the return value of this func adapt to the object represented in the line.
-func newRowDescriptor(obj ctrlclient.Object) rowDescriptor {
-	v := rowDescriptor{}
+func newReplicaCounters(obj ctrlclient.Object) replicaCounters {
+	v := replicaCounters{}
 	switch obj := obj.(type) {
 	case *clusterv1.Cluster:
-		// If the object is a cluster, returns all the replica counters (CP and worker replicas are summed for sake of simplicity);
-		// also, pick the Available condition as the condition to show for this object in case not all the conditions are visualized.
+		// If the object is a cluster, returns all the replica counters (CP and worker replicas are summed for sake of simplicity).
 		cp := obj.Status.ControlPlane
 		if cp == nil {
 			cp = &clusterv1.ClusterControlPlaneStatus{}
@@ -623,101 +631,123 @@ func newRowDescriptor(obj ctrlclient.Object) rowDescriptor {
 			w = &clusterv1.WorkersStatus{}
 		}
 		if cp.DesiredReplicas != nil || w.DesiredReplicas != nil || cp.Replicas != nil || w.Replicas != nil {
-			v.replicas = fmt.Sprintf("%d/%d", ptr.Deref(cp.Replicas, 0)+ptr.Deref(w.Replicas, 0), ptr.Deref(cp.DesiredReplicas, 0)+ptr.Deref(w.DesiredReplicas, 0))
+			v.replicas = ptr.To(ptr.Deref(cp.Replicas, 0) + ptr.Deref(w.Replicas, 0))
+			v.desiredReplicas = ptr.To(ptr.Deref(cp.DesiredReplicas, 0) + ptr.Deref(w.DesiredReplicas, 0))
 		}
 		if cp.AvailableReplicas != nil || w.AvailableReplicas != nil {
-			v.availableCounters = fmt.Sprintf("%d", ptr.Deref(cp.AvailableReplicas, 0)+ptr.Deref(w.AvailableReplicas, 0))
+			v.availableReplicas = ptr.To(ptr.Deref(cp.AvailableReplicas, 0) + ptr.Deref(w.AvailableReplicas, 0))
 		}
 		if cp.ReadyReplicas != nil || w.ReadyReplicas != nil {
-			v.readyCounters = fmt.Sprintf("%d", ptr.Deref(cp.ReadyReplicas, 0)+ptr.Deref(w.ReadyReplicas, 0))
+			v.readyReplicas = ptr.To(ptr.Deref(cp.ReadyReplicas, 0) + ptr.Deref(w.ReadyReplicas, 0))
 		}
 		if cp.UpToDateReplicas != nil || w.UpToDateReplicas != nil {
-			v.upToDateCounters = fmt.Sprintf("%d", ptr.Deref(cp.UpToDateReplicas, 0)+ptr.Deref(w.UpToDateReplicas, 0))
-		}
-
-		if available := tree.GetAvailableCondition(obj); available != nil {
-			availableColor, availableStatus, availableAge, availableReason, availableMessage := conditionInfo(*available, true)
-			v.status = availableColor.Sprintf("%s", availableStatus)
-			v.reason = availableReason
-			v.age = availableAge
-			v.message = availableMessage
+			v.upToDateReplicas = ptr.To(ptr.Deref(cp.UpToDateReplicas, 0) + ptr.Deref(w.UpToDateReplicas, 0))
 		}
 	case *clusterv1.MachineDeployment:
-		// If the object is a MachineDeployment, returns all the replica counters and pick the Available condition
-		// as the condition to show for this object in case not all the conditions are visualized.
 		if obj.Spec.Replicas != nil {
-			v.replicas = fmt.Sprintf("%d/%d", ptr.Deref(obj.Status.Replicas, 0), ptr.Deref(obj.Spec.Replicas, 0))
-		}
-		if obj.Status.AvailableReplicas != nil {
-			v.availableCounters = fmt.Sprintf("%d", *obj.Status.AvailableReplicas)
+			v.replicas = ptr.To(ptr.Deref(obj.Status.Replicas, 0))
+			v.desiredReplicas = obj.Spec.Replicas
 		}
-		if obj.Status.ReadyReplicas != nil {
-			v.readyCounters = fmt.Sprintf("%d", *obj.Status.ReadyReplicas)
-		}
-		if obj.Status.UpToDateReplicas != nil {
-			v.upToDateCounters = fmt.Sprintf("%d", *obj.Status.UpToDateReplicas)
-		}
-
-		if available := tree.GetAvailableCondition(obj); available != nil {
-			availableColor, availableStatus, availableAge, availableReason, availableMessage := conditionInfo(*available, true)
-			v.status = availableColor.Sprintf("%s", availableStatus)
-			v.reason = availableReason
-			v.age = availableAge
-			v.message = availableMessage
-		}
-
+		v.availableReplicas = obj.Status.AvailableReplicas
+		v.readyReplicas = obj.Status.ReadyReplicas
+		v.upToDateReplicas = obj.Status.UpToDateReplicas
 	case *clusterv1.MachineSet:
-		// If the object is a MachineSet, returns all the replica counters; no condition
-		// is shown for this object in case not all the conditions are visualized.
 		if obj.Spec.Replicas != nil {
-			v.replicas = fmt.Sprintf("%d/%d", ptr.Deref(obj.Status.Replicas, 0), ptr.Deref(obj.Spec.Replicas, 0))
+			v.replicas = ptr.To(ptr.Deref(obj.Status.Replicas, 0))
+			v.desiredReplicas = obj.Spec.Replicas
 		}
-		if obj.Status.AvailableReplicas != nil {
-			v.availableCounters = fmt.Sprintf("%d", *obj.Status.AvailableReplicas)
+		v.availableReplicas = obj.Status.AvailableReplicas
+		v.readyReplicas = obj.Status.ReadyReplicas
+		v.upToDateReplicas = obj.Status.UpToDateReplicas
+	case *clusterv1.Machine:
+		// If the object is a Machine, use Available, Ready and UpToDate conditions to infer replica counters.
+		v.replicas = ptr.To[int32](1)
+		v.availableReplicas = ptr.To[int32](0)
+		if available := tree.GetAvailableCondition(obj); available != nil && available.Status == metav1.ConditionTrue {
+			v.availableReplicas = ptr.To[int32](1)
 		}
-		if obj.Status.ReadyReplicas != nil {
-			v.readyCounters = fmt.Sprintf("%d", *obj.Status.ReadyReplicas)
+		v.readyReplicas = ptr.To[int32](0)
+		if ready := tree.GetReadyCondition(obj); ready != nil && ready.Status == metav1.ConditionTrue {
+			v.readyReplicas = ptr.To[int32](1)
 		}
-		if obj.Status.UpToDateReplicas != nil {
-			v.upToDateCounters = fmt.Sprintf("%d", *obj.Status.UpToDateReplicas)
+		v.upToDateReplicas = ptr.To[int32](0)
+		if upToDate := tree.GetMachineUpToDateCondition(obj); upToDate != nil && upToDate.Status == metav1.ConditionTrue {
+			v.upToDateReplicas = ptr.To[int32](1)
 		}
+	case *unstructured.Unstructured:
+		// If the Unstructured object implements the Cluster API control plane contract, surface corresponding replica counters.
+		if tree.GetObjectContract(obj) == "ControlPlane" {
+			contractVersion := tree.GetObjectContractVersion(obj)
 
-	case *clusterv1.Machine:
-		// If the object is a Machine, use Available, Ready and UpToDate conditions to infer replica counters;
-		// additionally pick the Ready condition as the condition to show for this object in case not all the conditions are visualized.
-		v.replicas = "1"
+			if current, err := contract.ControlPlane().StatusReplicas().Get(obj); err == nil && current != nil {
+				if desired, err := contract.ControlPlane().Replicas().Get(obj); err == nil && desired != nil {
+					v.replicas = ptr.To(int32(*current))
+					v.desiredReplicas = ptr.To(int32(*desired))
+				}
+			}
 
-		v.availableCounters = "0"
-		if available := tree.GetAvailableCondition(obj); available != nil {
-			if available.Status == metav1.ConditionTrue {
-				v.availableCounters = "1"
+			if c, err := contract.ControlPlane().AvailableReplicas().Get(obj); err == nil && c != nil {
+				v.availableReplicas = ptr.To(int32(*c))
+			}
+			if c, err := contract.ControlPlane().ReadyReplicas().Get(obj); err == nil && c != nil {
+				v.readyReplicas = ptr.To(int32(*c))
+			}
+			if c, err := contract.ControlPlane().UpToDateReplicas(contractVersion).Get(obj); err == nil && c != nil {
+				v.upToDateReplicas = ptr.To(int32(*c))
 			}
 		}
+	case *tree.NodeObject:
+		// If the object represent a group of objects, surface the corresponding replica counters.
+		if tree.IsGroupObject(obj) {
+			v.availableReplicas = ptr.To(int32(tree.GetGroupItemsAvailableCounter(obj)))
+			v.readyReplicas = ptr.To(int32(tree.GetGroupItemsReadyCounter(obj)))
+			v.upToDateReplicas = ptr.To(int32(tree.GetGroupItemsUpToDateCounter(obj)))
+		}
+	}
+	return v
+}
 
-		v.readyCounters = "0"
-		if ready := tree.GetReadyCondition(obj); ready != nil {
-			readyColor, readyStatus, readyAge, readyReason, readyMessage := conditionInfo(*ready, true)
-			v.status = readyColor.Sprintf("%s", readyStatus)
-			v.reason = readyReason
-			v.age = readyAge
-			v.message = readyMessage
-			if ready.Status == metav1.ConditionTrue {
-				v.readyCounters = "1"
-			}
+// newRowDescriptor returns a v1beta2ConditionDescriptor for the given condition.
+// Note: the return value of this func adapt to the object represented in the line.
+func newRowDescriptor(obj ctrlclient.Object) rowDescriptor {
+	v := rowDescriptor{}
+
+	// Surface the replica counters for the object.
+	counters := newReplicaCounters(obj)
+	if counters.replicas != nil {
+		v.replicas = fmt.Sprintf("%d", *counters.replicas)
+		if counters.desiredReplicas != nil {
+			v.replicas = fmt.Sprintf("%d/%d", *counters.replicas, *counters.desiredReplicas)
 		}
+	}
+	if counters.availableReplicas != nil {
+		v.availableCounters = fmt.Sprintf("%d", *counters.availableReplicas)
+	}
+	if counters.readyReplicas != nil {
+		v.readyCounters = fmt.Sprintf("%d", *counters.readyReplicas)
+	}
+	if counters.upToDateReplicas != nil {
+		v.upToDateCounters = fmt.Sprintf("%d", *counters.upToDateReplicas)
+	}
 
-		v.upToDateCounters = "0"
-		if upToDate := tree.GetMachineUpToDateCondition(obj); upToDate != nil {
-			if upToDate.Status == metav1.ConditionTrue {
-				v.upToDateCounters = "1"
-			}
+	switch obj := obj.(type) {
+	case *clusterv1.Cluster, *clusterv1.MachineDeployment:
+		// If the object is a Cluster or a MachineDeployment, pick the Available condition as the condition to show
+		// for this object in case not all the conditions are visualized.
+		if available := tree.GetAvailableCondition(obj); available != nil {
+			availableColor, availableStatus, availableAge, availableReason, availableMessage := conditionInfo(*available, true)
+			v.status = availableColor.Sprintf("%s", availableStatus)
+			v.reason = availableReason
+			v.age = availableAge
+			v.message = availableMessage
 		}
 
+	case *clusterv1.MachineSet:
+		// If the object is a MachineSet, no condition is shown for this object in case not all the conditions are visualized.
+
 	case *unstructured.Unstructured:
 		// If the object is a Unstructured, pick the Ready condition as the condition to show for this object
 		// in case not all the conditions are visualized.
-		// Also, if the Unstructured object implements the Cluster API control plane contract, surface
-		// corresponding replica counters.
 		if ready := tree.GetReadyCondition(obj); ready != nil {
 			readyColor, readyStatus, readyAge, readyReason, readyMessage := conditionInfo(*ready, true)
 			v.status = readyColor.Sprintf("%s", readyStatus)
@@ -726,8 +756,7 @@ func newRowDescriptor(obj ctrlclient.Object) rowDescriptor {
 			v.message = readyMessage
 		}
 
-		// if the unstructured object is a ControlPlane, pick the condition with type Available if it exists (use it instead of ready)
-		// and also get replica counters if defined.
+		// if the unstructured object is a ControlPlane, pick the condition with type Available if it exists (use it instead of ready).
 		if tree.GetObjectContract(obj) == "ControlPlane" {
 			if available := tree.GetAvailableCondition(obj); available != nil {
 				availableColor, availableStatus, availableAge, availableReason, availableMessage := conditionInfo(*available, true)
@@ -736,35 +765,11 @@ func newRowDescriptor(obj ctrlclient.Object) rowDescriptor {
 				v.age = availableAge
 				v.message = availableMessage
 			}
-
-			contractVersion := tree.GetObjectContractVersion(obj)
-
-			if current, err := contract.ControlPlane().StatusReplicas().Get(obj); err == nil && current != nil {
-				if desired, err := contract.ControlPlane().Replicas().Get(obj); err == nil && desired != nil {
-					v.replicas = fmt.Sprintf("%d/%d", *current, *desired)
-				}
-			}
-
-			if c, err := contract.ControlPlane().AvailableReplicas().Get(obj); err == nil && c != nil {
-				v.availableCounters = fmt.Sprintf("%d", *c)
-			}
-			if c, err := contract.ControlPlane().ReadyReplicas().Get(obj); err == nil && c != nil {
-				v.readyCounters = fmt.Sprintf("%d", *c)
-			}
-			if c, err := contract.ControlPlane().UpToDateReplicas(contractVersion).Get(obj); err == nil && c != nil {
-				v.upToDateCounters = fmt.Sprintf("%d", *c)
-			}
-		}
-
-	case *tree.NodeObject:
-		// If the object represent a group of objects, surface the corresponding replica counters.
-		// Also, pick the Ready condition as the condition to show for this group.
-		if tree.IsGroupObject(obj) {
-			v.readyCounters = fmt.Sprintf("%d", tree.GetGroupItemsReadyCounter(obj))
-			v.availableCounters = fmt.Sprintf("%d", tree.GetGroupItemsAvailableCounter(obj))
-			v.upToDateCounters = fmt.Sprintf("%d", tree.GetGroupItemsUpToDateCounter(obj))
 		}
 
+	case *clusterv1.Machine, *tree.NodeObject:
+		// If the object is a Machine or a NodeObject (e.g. a group of objects), pick the Ready condition
+		// as the condition to show for this object in case not all the conditions are visualized.
 		if ready := tree.GetReadyCondition(obj); ready != nil {
 			readyColor, readyStatus, readyAge, readyReason, readyMessage := conditionInfo(*ready, true)
 			v.status = readyColor.Sprintf("%s", readyStatus)
@@ -636,12 +636,30 @@ func validateTopologyRollout(topology clusterv1.Topology, fldPath *field.Path) f
 
 	for _, md := range topology.Workers.MachineDeployments {
 		fldPath := fldPath.Child("workers", "machineDeployments").Key(md.Name).Child("rollout")
+		allErrs = append(allErrs, validateTopologyRolloutStrategyType(fldPath.Child("strategy", "type"), md.Rollout.Strategy.Type)...)
 		allErrs = append(allErrs, validateRolloutStrategy(fldPath.Child("strategy"), md.Rollout.Strategy.RollingUpdate.MaxUnavailable, md.Rollout.Strategy.RollingUpdate.MaxSurge)...)
 	}
 
 	return allErrs
 }
 
+// validateTopologyRolloutStrategyType validates the rollout strategy type of a MachineDeployment in a Cluster topology or in a ClusterClass.
+// Note: The BlueGreen and Canary rollout strategies require additional configuration of the MachineDeployment,
+// which cannot be set in the topology.
+func validateTopologyRolloutStrategyType(fldPath *field.Path, strategyType clusterv1.MachineDeploymentTopologyRolloutStrategyType) field.ErrorList {
+	switch strategyType {
+	case "", clusterv1.RollingUpdateMachineDeploymentTopologyStrategyType, clusterv1.OnDeleteMachineDeploymentTopologyStrategyType:
+		return nil
+	default:
+		return field.ErrorList{
+			field.NotSupported(fldPath, strategyType, []string{
+				string(clusterv1.RollingUpdateMachineDeploymentTopologyStrategyType),
+				string(clusterv1.OnDeleteMachineDeploymentTopologyStrategyType),
+			}),
+		}
+	}
+}
+
 func validateTopologyTaints(topology clusterv1.Topology, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -2020,6 +2020,44 @@ func TestClusterTopologyValidation(t *testing.T) {
 					Build()).
 				Build(),
 		},
+		{
+			name:      "should return error when a MachineDeployment in a Topology uses an unsupported rollout strategy",
+			expectErr: true,
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(clusterv1.MachineDeploymentTopology{
+						Name:  "workers1",
+						Class: "aa",
+						Rollout: clusterv1.MachineDeploymentTopologyRolloutSpec{
+							Strategy: clusterv1.MachineDeploymentTopologyRolloutStrategy{
+								Type: clusterv1.MachineDeploymentTopologyRolloutStrategyType(clusterv1.CanaryMachineDeploymentStrategyType),
+							},
+						},
+					}).
+					Build()).
+				Build(),
+		},
+		{
+			name:      "should pass when a MachineDeployment in a Topology uses the OnDelete rollout strategy",
+			expectErr: false,
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(clusterv1.MachineDeploymentTopology{
+						Name:  "workers1",
+						Class: "aa",
+						Rollout: clusterv1.MachineDeploymentTopologyRolloutSpec{
+							Strategy: clusterv1.MachineDeploymentTopologyRolloutStrategy{
+								Type: clusterv1.OnDeleteMachineDeploymentTopologyStrategyType,
+							},
+						},
+					}).
+					Build()).
+				Build(),
+		},
 		{
 			name:      "should update",
 			expectErr: false,
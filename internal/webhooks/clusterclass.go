@@ -380,6 +380,7 @@ func validateClusterClassRollout(clusterClass *clusterv1.ClusterClass) field.Err
 
 	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
 		fldPath := field.NewPath("spec", "workers", "machineDeployments").Key(md.Class).Child("rollout")
+		allErrs = append(allErrs, validateTopologyRolloutStrategyType(fldPath.Child("strategy", "type"), md.Rollout.Strategy.Type)...)
 		allErrs = append(allErrs, validateRolloutStrategy(fldPath.Child("strategy"), md.Rollout.Strategy.RollingUpdate.MaxUnavailable, md.Rollout.Strategy.RollingUpdate.MaxSurge)...)
 	}
 
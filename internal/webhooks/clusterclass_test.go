@@ -681,6 +681,54 @@ func TestClusterClassValidation(t *testing.T) {
 				Build(),
 			expectErr: true,
 		},
+		{
+			name: "create pass if machineDeploymentClass uses the OnDelete rollout strategy",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cp-infra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithStrategy(clusterv1.MachineDeploymentClassRolloutStrategy{
+							Type: clusterv1.OnDeleteMachineDeploymentTopologyStrategyType,
+						}).
+						Build()).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "create fail if machineDeploymentClass uses an unsupported rollout strategy",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cp-infra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithStrategy(clusterv1.MachineDeploymentClassRolloutStrategy{
+							Type: clusterv1.MachineDeploymentTopologyRolloutStrategyType(clusterv1.BlueGreenMachineDeploymentStrategyType),
+						}).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
 		{
 			name: "create pass if valid machineHealthCheck defined for ControlPlane with MachineInfrastructure set",
 			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
@@ -19,6 +19,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -222,6 +223,20 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	}
 
 	allErrs = append(allErrs, validateRolloutStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable, newMD.Spec.Rollout.Strategy.RollingUpdate.MaxSurge)...)
+	if newMD.Spec.Rollout.Strategy.Type != clusterv1.BlueGreenMachineDeploymentStrategyType &&
+		!reflect.DeepEqual(newMD.Spec.Rollout.Strategy.BlueGreen, clusterv1.MachineDeploymentRolloutStrategyBlueGreen{}) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("rollout", "strategy", "blueGreen"), "can only be set when rollout strategy type is BlueGreen"),
+		)
+	}
+	allErrs = append(allErrs, validateCanaryStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy)...)
+	if newMD.Spec.Rollout.OnProgressDeadlineExceeded != "" && newMD.Spec.Rollout.ProgressDeadlineSeconds == nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("rollout", "onProgressDeadlineExceeded"), "can only be set when rollout progressDeadlineSeconds is set"),
+		)
+	}
 	allErrs = append(allErrs, validateRemediationMaxInFlight(specPath.Child("remediation"), newMD.Spec.Remediation.MaxInFlight)...)
 
 	if newMD.Spec.Template.Spec.Version != "" {
@@ -245,6 +260,34 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind(), newMD.Name, allErrs)
 }
 
+func validateCanaryStrategy(fldPath *field.Path, strategy clusterv1.MachineDeploymentRolloutStrategy) field.ErrorList {
+	var allErrs field.ErrorList
+	canaryPath := fldPath.Child("canary")
+	if strategy.Type != clusterv1.CanaryMachineDeploymentStrategyType {
+		if !reflect.DeepEqual(strategy.Canary, clusterv1.MachineDeploymentRolloutStrategyCanary{}) {
+			allErrs = append(allErrs, field.Forbidden(canaryPath, "can only be set when rollout strategy type is Canary"))
+		}
+		return allErrs
+	}
+
+	if len(strategy.Canary.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(canaryPath.Child("steps"), "must be set when rollout strategy type is Canary"))
+	}
+	for i, step := range strategy.Canary.Steps {
+		stepPath := canaryPath.Child("steps").Index(i)
+		if step.Replicas == nil {
+			allErrs = append(allErrs, field.Required(stepPath.Child("replicas"), "must be set"))
+		} else if _, err := intstr.GetScaledValueFromIntOrPercent(step.Replicas, 0, true); err != nil {
+			// Note: total and roundUp parameters don't matter for validation.
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("replicas"), step.Replicas.String(), fmt.Sprintf("must be either an int or a percentage: %v", err.Error())))
+		}
+		if len(step.Gates.ExtensionHandlers) > 0 && !feature.Gates.Enabled(feature.RuntimeSDK) {
+			allErrs = append(allErrs, field.Forbidden(stepPath.Child("gates", "extensionHandlers"), "can only be set if the RuntimeSDK feature flag is enabled"))
+		}
+	}
+	return allErrs
+}
+
 func validateRolloutStrategy(fldPath *field.Path, maxUnavailable, maxSurge *intstr.IntOrString) field.ErrorList {
 	var allErrs field.ErrorList
 	if maxUnavailable != nil {
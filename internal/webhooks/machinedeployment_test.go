@@ -368,6 +368,7 @@ func TestMachineDeploymentValidation(t *testing.T) {
 		selectors     map[string]string
 		labels        map[string]string
 		strategy      clusterv1.MachineDeploymentRolloutStrategy
+		rollout       clusterv1.MachineDeploymentRolloutSpec
 		remediation   clusterv1.MachineDeploymentRemediationSpec
 		expectErr     bool
 		machineNaming clusterv1.MachineNamingSpec
@@ -518,6 +519,102 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "should not return error when blueGreen is set with BlueGreen strategy",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.BlueGreenMachineDeploymentStrategyType,
+				BlueGreen: clusterv1.MachineDeploymentRolloutStrategyBlueGreen{
+					SoakSeconds: ptr.To[int32](60),
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error when blueGreen is set with RollingUpdate strategy",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				BlueGreen: clusterv1.MachineDeploymentRolloutStrategyBlueGreen{
+					SoakSeconds: ptr.To[int32](60),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should not return error when canary is set with Canary strategy",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Steps: []clusterv1.MachineDeploymentCanaryStep{
+						{Replicas: ptr.To(intstr.FromInt32(1))},
+						{Replicas: ptr.To(intstr.FromString("25%"))},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error when canary is set with RollingUpdate strategy",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Steps: []clusterv1.MachineDeploymentCanaryStep{
+						{Replicas: ptr.To(intstr.FromInt32(1))},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when canary steps are not set with Canary strategy",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when canary step replicas is an invalid percentage",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Steps: []clusterv1.MachineDeploymentCanaryStep{
+						{Replicas: ptr.To(intstr.FromString("25"))},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when canary step extension handlers are set with RuntimeSDK disabled",
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Steps: []clusterv1.MachineDeploymentCanaryStep{
+						{
+							Replicas: ptr.To(intstr.FromInt32(1)),
+							Gates: clusterv1.MachineDeploymentCanaryStepGates{
+								ExtensionHandlers: []string{"analyze.extension"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should not return error when onProgressDeadlineExceeded is set with progressDeadlineSeconds",
+			rollout: clusterv1.MachineDeploymentRolloutSpec{
+				ProgressDeadlineSeconds:    ptr.To[int32](600),
+				OnProgressDeadlineExceeded: clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction,
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error when onProgressDeadlineExceeded is set without progressDeadlineSeconds",
+			rollout: clusterv1.MachineDeploymentRolloutSpec{
+				OnProgressDeadlineExceeded: clusterv1.RollbackMachineDeploymentProgressDeadlineExceededAction,
+			},
+			expectErr: true,
+		},
 		{
 			name: "should return error when MachineNamingSpec does not have {{ .random }}",
 			machineNaming: clusterv1.MachineNamingSpec{
@@ -543,7 +640,9 @@ func TestMachineDeploymentValidation(t *testing.T) {
 				},
 				Spec: clusterv1.MachineDeploymentSpec{
 					Rollout: clusterv1.MachineDeploymentRolloutSpec{
-						Strategy: tt.strategy,
+						Strategy:                   tt.strategy,
+						ProgressDeadlineSeconds:    tt.rollout.ProgressDeadlineSeconds,
+						OnProgressDeadlineExceeded: tt.rollout.OnProgressDeadlineExceeded,
 					},
 					Selector: metav1.LabelSelector{
 						MatchLabels: tt.selectors,
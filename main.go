@@ -24,6 +24,7 @@ import (
 	"os"
 	"regexp"
 	goruntime "runtime"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -50,6 +51,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	addonsv1beta1 "sigs.k8s.io/cluster-api/api/addons/v1beta1"
@@ -72,6 +74,7 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimerecorder "sigs.k8s.io/cluster-api/internal/runtime/recorder"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	"sigs.k8s.io/cluster-api/internal/setup"
 	"sigs.k8s.io/cluster-api/util/apiwarnings"
@@ -106,6 +109,9 @@ var (
 	webhookKeyName              string
 	runtimeExtensionCertFile    string
 	runtimeExtensionKeyFile     string
+	runtimeExtensionRecordFile  string
+	runtimeExtensionRecordCM    string
+	runtimeExtensionRecordMax   int
 	healthAddr                  string
 	managerOptions              = flags.ManagerOptions{}
 	logOptions                  = logs.NewOptions()
@@ -263,6 +269,15 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&runtimeExtensionKeyFile, "runtime-extension-client-key-file", "",
 		"Path of the PEM-encoded client key to be used when calling runtime extensions.")
 
+	fs.StringVar(&runtimeExtensionRecordFile, "runtime-extension-record-file", "",
+		"Path of a file where requests and responses of calls to runtime extensions are recorded. Recording is disabled if neither this flag nor --runtime-extension-record-configmap are set.")
+
+	fs.StringVar(&runtimeExtensionRecordCM, "runtime-extension-record-configmap", "",
+		"Namespace and name of a ConfigMap, in the format <namespace>/<name>, where requests and responses of calls to runtime extensions are recorded. The controller is only allowed to create ConfigMaps in its own namespace. Recording is disabled if neither this flag nor --runtime-extension-record-file are set.")
+
+	fs.IntVar(&runtimeExtensionRecordMax, "runtime-extension-record-max", runtimerecorder.DefaultMaxRecords,
+		"Maximum number of calls to runtime extensions to be recorded; when the maximum is reached, the oldest calls are dropped.")
+
 	fs.StringVar(&healthAddr, "health-addr", ":9440",
 		"The address the health endpoint binds to.")
 
@@ -404,6 +419,38 @@ func main() {
 	}
 }
 
+// setupRuntimeExtensionRecorder returns the recorder for calls to runtime extensions, or nil if recording is disabled.
+func setupRuntimeExtensionRecorder(mgr ctrl.Manager) (*runtimerecorder.Recorder, error) {
+	var sink runtimerecorder.Sink
+	switch {
+	case runtimeExtensionRecordFile != "" && runtimeExtensionRecordCM != "":
+		return nil, errors.New("--runtime-extension-record-file and --runtime-extension-record-configmap are mutually exclusive")
+	case runtimeExtensionRecordFile != "":
+		sink = runtimerecorder.NewFileSink(runtimeExtensionRecordFile)
+	case runtimeExtensionRecordCM != "":
+		namespace, name, ok := strings.Cut(runtimeExtensionRecordCM, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, errors.Errorf("invalid --runtime-extension-record-configmap %q: must be in the format <namespace>/<name>", runtimeExtensionRecordCM)
+		}
+		sink = runtimerecorder.NewConfigMapSink(mgr.GetClient(), namespace, name)
+	default:
+		return nil, nil
+	}
+
+	recorder, err := runtimerecorder.New(runtimerecorder.Options{
+		Sink:       sink,
+		MaxRecords: runtimeExtensionRecordMax,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Note: the recorder is managed by the manager, which periodically writes records to the sink.
+	if err := mgr.Add(recorder); err != nil {
+		return nil, errors.Wrap(err, "failed to add recorder to the manager")
+	}
+	return recorder, nil
+}
+
 func setupChecks(mgr ctrl.Manager) {
 	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
 		setupLog.Error(err, "Unable to create ready check")
@@ -481,15 +528,25 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 	}
 
 	var runtimeClient runtimeclient.Client
+	// circuitBreakerEvents is used by the runtimeClient to trigger reconciles of ExtensionConfigs whose circuit breaker changed state.
+	circuitBreakerEvents := make(chan event.GenericEvent, 100)
 	if feature.Gates.Enabled(feature.RuntimeSDK) {
 		// This is the creation of the runtimeClient for the controllers, embedding a shared catalog and registry instance.
 		var certWatcher *certwatcher.CertWatcher
+		var recorder *runtimerecorder.Recorder
+		recorder, err = setupRuntimeExtensionRecorder(mgr)
+		if err != nil {
+			setupLog.Error(err, "Unable to create RuntimeSDK recorder")
+			os.Exit(1)
+		}
 		runtimeClient, certWatcher, err = internalruntimeclient.New(internalruntimeclient.Options{
-			CertFile: runtimeExtensionCertFile,
-			KeyFile:  runtimeExtensionKeyFile,
-			Catalog:  catalog,
-			Registry: runtimeregistry.New(),
-			Client:   mgr.GetClient(),
+			CertFile:             runtimeExtensionCertFile,
+			KeyFile:              runtimeExtensionKeyFile,
+			Catalog:              catalog,
+			Registry:             runtimeregistry.New(),
+			Client:               mgr.GetClient(),
+			Recorder:             recorder,
+			CircuitBreakerEvents: circuitBreakerEvents,
 		})
 		if err != nil {
 			setupLog.Error(err, "Unable to create RuntimeSDK client")
@@ -582,11 +639,12 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 
 	if feature.Gates.Enabled(feature.RuntimeSDK) {
 		if err = (&controllers.ExtensionConfigReconciler{
-			Client:             mgr.GetClient(),
-			APIReader:          mgr.GetAPIReader(),
-			RuntimeClient:      runtimeClient,
-			PartialSecretCache: partialSecretCache,
-			WatchFilterValue:   watchFilterValue,
+			Client:               mgr.GetClient(),
+			APIReader:            mgr.GetAPIReader(),
+			RuntimeClient:        runtimeClient,
+			PartialSecretCache:   partialSecretCache,
+			WatchFilterValue:     watchFilterValue,
+			CircuitBreakerEvents: circuitBreakerEvents,
 		}).SetupWithManager(ctx, mgr, concurrency(extensionConfigConcurrency)); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "ExtensionConfig")
 			os.Exit(1)
@@ -597,6 +655,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 		Client:                      mgr.GetClient(),
 		APIReader:                   mgr.GetAPIReader(),
 		ClusterCache:                clusterCache,
+		SecretCachingClient:         secretCachingClient,
 		WatchFilterValue:            watchFilterValue,
 		RemoteConnectionGracePeriod: remoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
@@ -676,6 +735,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 		Client:           mgr.GetClient(),
 		APIReader:        mgr.GetAPIReader(),
 		RuntimeClient:    runtimeClient,
+		ClusterCache:     clusterCache,
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineDeployment")
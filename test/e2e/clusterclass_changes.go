@@ -708,7 +708,7 @@ func assertMachineDeploymentTopologyFields(g Gomega, md clusterv1.MachineDeploym
 	}
 
 	if !reflect.DeepEqual(mdTopology.Rollout.Strategy, clusterv1.MachineDeploymentTopologyRolloutStrategy{}) {
-		g.Expect(md.Spec.Rollout.Strategy.Type).To(BeComparableTo(mdTopology.Rollout.Strategy.Type))
+		g.Expect(md.Spec.Rollout.Strategy.Type).To(BeComparableTo(clusterv1.MachineDeploymentRolloutStrategyType(mdTopology.Rollout.Strategy.Type)))
 		g.Expect(md.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable).To(BeComparableTo(mdTopology.Rollout.Strategy.RollingUpdate.MaxUnavailable))
 		g.Expect(md.Spec.Rollout.Strategy.RollingUpdate.MaxSurge).To(BeComparableTo(mdTopology.Rollout.Strategy.RollingUpdate.MaxSurge))
 	}
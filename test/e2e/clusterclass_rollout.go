@@ -196,7 +196,7 @@ func ClusterClassRolloutSpec(ctx context.Context, inputGetter func() ClusterClas
 				topology.MinReadySeconds = ptr.To[int32](rand.Int31n(20))                  //nolint:gosec
 				topology.Rollout.After = metav1.NewTime(time.Now().Add(24 * time.Hour))
 				topology.Rollout.Strategy = clusterv1.MachineDeploymentTopologyRolloutStrategy{
-					Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					Type: clusterv1.RollingUpdateMachineDeploymentTopologyStrategyType,
 					RollingUpdate: clusterv1.MachineDeploymentTopologyRolloutStrategyRollingUpdate{
 						MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
 						MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 5 + rand.Int31n(20)}, //nolint:gosec
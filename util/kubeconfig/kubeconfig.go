@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -188,6 +189,38 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 	return false, nil
 }
 
+// ClientCertificatesExpiryDate returns the earliest expiry date of the Kubeconfig secret's client certificates;
+// nil is returned if the Kubeconfig does not contain client certificates, e.g. when using token authentication.
+func ClientCertificatesExpiryDate(configSecret *corev1.Secret) (*time.Time, error) {
+	data, err := toKubeconfigBytes(configSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+
+	var expiryDate *time.Time
+	for _, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		cert, err := certs.DecodeCertPEM(authInfo.ClientCertificateData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode kubeconfig client certificate")
+		}
+		if cert == nil {
+			continue
+		}
+		if expiryDate == nil || cert.NotAfter.Before(*expiryDate) {
+			expiryDate = &cert.NotAfter
+		}
+	}
+	return expiryDate, nil
+}
+
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
 func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret, options ...KubeConfigOption) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
@@ -241,6 +274,21 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
 
+	// The CA Secret can contain a bundle of certificates, e.g. while certificate authorities are being rotated;
+	// in this case the kubeconfig must trust all of them, because the API server serving certificate can be
+	// signed by any of them.
+	caCerts, err := certutil.ParseCertsPEM(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode CA Cert")
+	}
+	if len(caCerts) > 1 {
+		var caData []byte
+		for _, c := range caCerts {
+			caData = append(caData, certs.EncodeCertPEM(c)...)
+		}
+		cfg.Clusters[clusterName.Name].CertificateAuthorityData = caData
+	}
+
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to serialize config to yaml")
@@ -435,6 +435,31 @@ func TestNeedsClientCertRotation(t *testing.T) {
 	g.Expect(NeedsClientCertRotation(kubeconfigSecret, certs.DefaultCertDuration-time.Hour)).To(BeFalse())
 }
 
+func TestClientCertificatesExpiryDate(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config, err := New("foo", "https://127:0.0.1:4003", caCert, caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	kubeconfigSecret := GenerateSecretWithOwner(client.ObjectKey{Name: "test1", Namespace: "test"}, out, metav1.OwnerReference{})
+
+	clientCert, err := certs.DecodeCertPEM(config.AuthInfos["foo-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	expiryDate, err := ClientCertificatesExpiryDate(kubeconfigSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(expiryDate).ToNot(BeNil())
+	g.Expect(*expiryDate).To(Equal(clientCert.NotAfter))
+}
+
 func TestRegenerateClientCerts(t *testing.T) {
 	g := NewWithT(t)
 	caKey, err := certs.NewPrivateKey()
@@ -472,3 +497,44 @@ func TestRegenerateClientCerts(t *testing.T) {
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
 }
+
+func TestRegenerateSecretWithCertificateAuthorityBundle(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	otherCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	otherCACert, err := getTestCACert(otherCAKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caBundle := append(certs.EncodeCertPEM(caCert), certs.EncodeCertPEM(otherCACert)...)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: caBundle,
+		},
+	}
+	kubeconfigSecret := validSecret.DeepCopy()
+
+	c := fake.NewClientBuilder().WithObjects(kubeconfigSecret, caSecret).Build()
+
+	g.Expect(RegenerateSecret(ctx, c, kubeconfigSecret)).To(Succeed())
+
+	newSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, util.ObjectKey(kubeconfigSecret), newSecret)).To(Succeed())
+	newConfig, err := clientcmd.Load(newSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The kubeconfig trusts all the certificate authorities in the bundle, the client certificate is signed by the first one.
+	g.Expect(newConfig.Clusters["test1"].CertificateAuthorityData).To(Equal(caBundle))
+	newCert, err := certs.DecodeCertPEM(newConfig.AuthInfos["test1-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newCert.CheckSignatureFrom(caCert)).To(Succeed())
+}
@@ -424,12 +424,7 @@ func (c *Certificate) Generate() error {
 		return nil
 	}
 
-	generator := generateCACert
-	if c.Purpose == ServiceAccount {
-		generator = generateServiceAccountKeys
-	}
-
-	kp, err := generator(c.ValidityPeriodDays, c.KeyEncryptionAlgorithm)
+	kp, err := c.generateKeyPair()
 	if err != nil {
 		return err
 	}
@@ -439,6 +434,15 @@ func (c *Certificate) Generate() error {
 	return nil
 }
 
+// generateKeyPair generates a new key pair for the certificate.
+func (c *Certificate) generateKeyPair() (*certs.KeyPair, error) {
+	generator := generateCACert
+	if c.Purpose == ServiceAccount {
+		generator = generateServiceAccountKeys
+	}
+	return generator(c.ValidityPeriodDays, c.KeyEncryptionAlgorithm)
+}
+
 // AsFiles converts a slice of certificates into bootstrap files.
 func (c Certificates) AsFiles() []bootstrapv1.File {
 	certFiles := make([]bootstrapv1.File, 0)
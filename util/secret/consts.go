@@ -27,6 +27,14 @@ const (
 
 	// TLSCrtDataName is the key used to store a TLS certificate in the secret's data field.
 	TLSCrtDataName = "tls.crt"
+
+	// TLSNextKeyDataName is the key used to store the private key of a new certificate authority in the secret's
+	// data field while the certificate authority is being rotated.
+	TLSNextKeyDataName = "next.tls.key"
+
+	// TLSNextCrtDataName is the key used to store the certificate of a new certificate authority in the secret's
+	// data field while the certificate authority is being rotated.
+	TLSNextCrtDataName = "next.tls.crt"
 )
 
 // Purpose is the name to append to the secret generated for a cluster.
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/util/certs"
+)
+
+// A certificate authority (or the service account key pair) is rotated in three steps:
+//   - TrustNext generates a new certificate authority and adds it to the trust bundle stored in tls.crt,
+//     after the current one; the current certificate authority is still used for signing.
+//   - SignWithNext moves the new certificate authority at the top of the trust bundle and stores its key in tls.key,
+//     so it is used for signing; the previous certificate authority is still trusted.
+//   - DropPrevious removes the previous certificate authority from the trust bundle.
+//
+// While the rotation is in progress the new certificate authority is also stored in next.tls.crt and next.tls.key.
+// Note: The certificate authority used for signing is always the first one in the trust bundle, because
+// this is what kubeadm and the Kubernetes components expect.
+
+// IsRotating returns true if a new certificate authority has been added to the certificate,
+// and the rotation is not completed yet.
+func (c *Certificate) IsRotating() bool {
+	if c.Secret == nil {
+		return false
+	}
+	_, ok := c.Secret.Data[TLSNextKeyDataName]
+	return ok
+}
+
+// TrustNext generates a new certificate authority and adds it to the trust bundle of the certificate,
+// after the current one. The certificate Secret is updated in memory only.
+func (c *Certificate) TrustNext() error {
+	if err := c.ensureRotatable(); err != nil {
+		return err
+	}
+	if c.IsRotating() {
+		return nil
+	}
+
+	next, err := c.generateKeyPair()
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate new certificate authority for certificate: %s", c.Purpose)
+	}
+
+	c.setKeyPair(&certs.KeyPair{
+		Cert: append(append([]byte{}, c.KeyPair.Cert...), next.Cert...),
+		Key:  c.KeyPair.Key,
+	})
+	c.Secret.Data[TLSNextCrtDataName] = next.Cert
+	c.Secret.Data[TLSNextKeyDataName] = next.Key
+	return nil
+}
+
+// SignWithNext makes the certificate authority added by TrustNext the one used for signing; previous
+// certificate authorities are still part of the trust bundle. The certificate Secret is updated in memory only.
+func (c *Certificate) SignWithNext() error {
+	if err := c.ensureRotatable(); err != nil {
+		return err
+	}
+	if !c.IsRotating() {
+		return errors.Errorf("cannot sign with new certificate authority for certificate %s: rotation not started", c.Purpose)
+	}
+
+	nextCert := c.Secret.Data[TLSNextCrtDataName]
+	nextBlocks, err := decodePEMBlocks(nextCert)
+	if err != nil || len(nextBlocks) != 1 {
+		return errors.Errorf("invalid %s data for certificate %s", TLSNextCrtDataName, c.Purpose)
+	}
+	blocks, err := decodePEMBlocks(c.KeyPair.Cert)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s data for certificate %s", TLSCrtDataName, c.Purpose)
+	}
+
+	bundle := append([]byte{}, nextCert...)
+	for _, b := range blocks {
+		if bytes.Equal(b.Bytes, nextBlocks[0].Bytes) {
+			continue
+		}
+		bundle = append(bundle, pem.EncodeToMemory(b)...)
+	}
+
+	c.setKeyPair(&certs.KeyPair{
+		Cert: bundle,
+		Key:  c.Secret.Data[TLSNextKeyDataName],
+	})
+	return nil
+}
+
+// DropPrevious removes all the certificate authorities except the one added by TrustNext from the trust bundle,
+// completing the rotation. The certificate Secret is updated in memory only.
+func (c *Certificate) DropPrevious() error {
+	if err := c.ensureRotatable(); err != nil {
+		return err
+	}
+	if !c.IsRotating() {
+		return nil
+	}
+	if !bytes.Equal(c.KeyPair.Key, c.Secret.Data[TLSNextKeyDataName]) {
+		return errors.Errorf("cannot drop previous certificate authorities for certificate %s: new certificate authority is not used for signing yet", c.Purpose)
+	}
+
+	c.setKeyPair(&certs.KeyPair{
+		Cert: c.Secret.Data[TLSNextCrtDataName],
+		Key:  c.Secret.Data[TLSNextKeyDataName],
+	})
+	delete(c.Secret.Data, TLSNextCrtDataName)
+	delete(c.Secret.Data, TLSNextKeyDataName)
+	return nil
+}
+
+func (c *Certificate) ensureRotatable() error {
+	if c.External {
+		return errors.Errorf("cannot rotate external certificate %s", c.Purpose)
+	}
+	if c.Secret == nil || c.KeyPair == nil {
+		return errors.Wrapf(ErrMissingCertificate, "for certificate: %s", c.Purpose)
+	}
+	if c.Secret.Data == nil {
+		c.Secret.Data = map[string][]byte{}
+	}
+	return nil
+}
+
+func (c *Certificate) setKeyPair(kp *certs.KeyPair) {
+	c.KeyPair = kp
+	c.Secret.Data[TLSCrtDataName] = kp.Cert
+	c.Secret.Data[TLSKeyDataName] = kp.Key
+}
+
+func decodePEMBlocks(data []byte) ([]*pem.Block, error) {
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("no PEM data found")
+	}
+	return blocks, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestCertificateRotation(t *testing.T) {
+	t.Run("rotates a certificate authority", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCertificateWithSecret(g, secret.ClusterCA)
+		oldCert := c.KeyPair.Cert
+		oldKey := c.KeyPair.Key
+		g.Expect(c.IsRotating()).To(BeFalse())
+
+		// Trust the new certificate authority, continue to sign with the old one.
+		g.Expect(c.TrustNext()).To(Succeed())
+		g.Expect(c.IsRotating()).To(BeTrue())
+		nextCert := c.Secret.Data[secret.TLSNextCrtDataName]
+		nextKey := c.Secret.Data[secret.TLSNextKeyDataName]
+		g.Expect(nextCert).ToNot(Equal(oldCert))
+		g.Expect(c.KeyPair.Key).To(Equal(oldKey))
+		g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(append(append([]byte{}, oldCert...), nextCert...)))
+		g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(oldKey))
+		g.Expect(cert.ParseCertsPEM(c.KeyPair.Cert)).To(HaveLen(2))
+		hashes, err := c.Hashes()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(hashes).To(HaveLen(2))
+
+		// Calling TrustNext again is a no-op.
+		g.Expect(c.TrustNext()).To(Succeed())
+		g.Expect(c.Secret.Data[secret.TLSNextCrtDataName]).To(Equal(nextCert))
+		g.Expect(cert.ParseCertsPEM(c.KeyPair.Cert)).To(HaveLen(2))
+
+		// Sign with the new certificate authority, continue to trust the old one.
+		g.Expect(c.SignWithNext()).To(Succeed())
+		g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(append(append([]byte{}, nextCert...), oldCert...)))
+		g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+
+		// Calling SignWithNext again is a no-op.
+		g.Expect(c.SignWithNext()).To(Succeed())
+		g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(append(append([]byte{}, nextCert...), oldCert...)))
+
+		// Drop the old certificate authority.
+		g.Expect(c.DropPrevious()).To(Succeed())
+		g.Expect(c.IsRotating()).To(BeFalse())
+		g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(nextCert))
+		g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+		g.Expect(c.Secret.Data).ToNot(HaveKey(secret.TLSNextCrtDataName))
+		g.Expect(c.Secret.Data).ToNot(HaveKey(secret.TLSNextKeyDataName))
+	})
+	t.Run("rotates the service account key pair", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCertificateWithSecret(g, secret.ServiceAccount)
+
+		g.Expect(c.TrustNext()).To(Succeed())
+		g.Expect(keyutil.ParsePublicKeysPEM(c.KeyPair.Cert)).To(HaveLen(2))
+		g.Expect(c.SignWithNext()).To(Succeed())
+		g.Expect(keyutil.ParsePublicKeysPEM(c.KeyPair.Cert)).To(HaveLen(2))
+		g.Expect(c.DropPrevious()).To(Succeed())
+		g.Expect(keyutil.ParsePublicKeysPEM(c.KeyPair.Cert)).To(HaveLen(1))
+	})
+	t.Run("fails to drop the old certificate authority before signing with the new one", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCertificateWithSecret(g, secret.FrontProxyCA)
+
+		g.Expect(c.SignWithNext()).ToNot(Succeed())
+		g.Expect(c.TrustNext()).To(Succeed())
+		g.Expect(c.DropPrevious()).ToNot(Succeed())
+	})
+	t.Run("fails to rotate external certificates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCertificateWithSecret(g, secret.EtcdCA)
+		c.External = true
+
+		g.Expect(c.TrustNext()).ToNot(Succeed())
+	})
+}
+
+func newCertificateWithSecret(g *WithT, purpose secret.Purpose) *secret.Certificate {
+	c := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{}).GetByPurpose(purpose)
+	g.Expect(c.Generate()).To(Succeed())
+	c.Secret = c.AsSecret(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}, metav1.OwnerReference{})
+	return c
+}